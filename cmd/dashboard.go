@@ -0,0 +1,57 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/d0ugal/github-exporter/internal/dashboard"
+	"github.com/d0ugal/github-exporter/internal/metrics"
+	promexporter_metrics "github.com/d0ugal/promexporter/metrics"
+)
+
+// runDashboard implements the "dashboard" subcommand which prints a Grafana dashboard
+// generated from the exporter's metric registry
+func runDashboard(args []string) int {
+	fs := flag.NewFlagSet("dashboard", flag.ExitOnError)
+
+	var (
+		namespace string
+		title     string
+		uid       string
+		output    string
+	)
+
+	fs.StringVar(&namespace, "namespace", metrics.DefaultNamespace, "Metric namespace configured for the exporter")
+	fs.StringVar(&title, "title", "GitHub Exporter", "Dashboard title")
+	fs.StringVar(&uid, "uid", "", "Dashboard UID (default: <namespace>-exporter)")
+	fs.StringVar(&output, "output", "", "Write the dashboard to a file instead of stdout")
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	registry := metrics.NewGitHubRegistryWithNamespace(promexporter_metrics.NewRegistry(namespace+"_exporter_info"), namespace)
+
+	data, err := dashboard.Generate(registry.GetMetricsInfo(), registry.MetricTypes(), dashboard.Options{
+		Title:     title,
+		UID:       uid,
+		Namespace: namespace,
+	}).JSON()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to render dashboard: %v\n", err)
+		return 1
+	}
+
+	if output == "" {
+		fmt.Println(string(data))
+		return 0
+	}
+
+	if err := os.WriteFile(output, append(data, '\n'), 0o644); err != nil { //nolint:gosec // Dashboards are not sensitive
+		fmt.Fprintf(os.Stderr, "Failed to write dashboard: %v\n", err)
+		return 1
+	}
+
+	return 0
+}
@@ -1,15 +1,25 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"log/slog"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/d0ugal/github-exporter/internal/collectors"
 	"github.com/d0ugal/github-exporter/internal/config"
+	"github.com/d0ugal/github-exporter/internal/githubapp"
+	"github.com/d0ugal/github-exporter/internal/kubernetes"
 	"github.com/d0ugal/github-exporter/internal/metrics"
+	"github.com/d0ugal/github-exporter/internal/redact"
+	"github.com/d0ugal/github-exporter/internal/server"
+	"github.com/d0ugal/github-exporter/internal/tokenpool"
 	"github.com/d0ugal/github-exporter/internal/version"
+	"github.com/d0ugal/github-exporter/internal/webhook"
 	"github.com/d0ugal/promexporter/app"
 	"github.com/d0ugal/promexporter/logging"
 	promexporter_metrics "github.com/d0ugal/promexporter/metrics"
@@ -24,6 +34,10 @@ func hasEnvironmentVariables() bool {
 		"GITHUB_EXPORTER_LOG_FORMAT",
 		"GITHUB_EXPORTER_METRICS_DEFAULT_INTERVAL",
 		"GITHUB_EXPORTER_GITHUB_TOKEN",
+		"GITHUB_EXPORTER_GITHUB_TOKENS",
+		"GITHUB_EXPORTER_GITHUB_APP_ID",
+		"GITHUB_EXPORTER_GITHUB_APP_INSTALLATION_ID",
+		"GITHUB_EXPORTER_GITHUB_APP_PRIVATE_KEY_PATH",
 		"GITHUB_EXPORTER_GITHUB_ORGS",
 		"GITHUB_EXPORTER_GITHUB_REPOS",
 		"GITHUB_EXPORTER_GITHUB_TIMEOUT",
@@ -40,6 +54,18 @@ func hasEnvironmentVariables() bool {
 }
 
 func main() {
+	// Dispatch subcommands before parsing the server flags
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "dashboard":
+			os.Exit(runDashboard(os.Args[2:]))
+		case "rules":
+			os.Exit(runRules(os.Args[2:]))
+		case "selftest":
+			os.Exit(runSelfTest(os.Args[2:]))
+		}
+	}
+
 	// Parse command line flags
 	var showVersion bool
 	flag.BoolVar(&showVersion, "version", false, "Show version information")
@@ -47,10 +73,12 @@ func main() {
 
 	var (
 		configPath    string
+		configDir     string
 		configFromEnv bool
 	)
 
 	flag.StringVar(&configPath, "config", "config.yaml", "Path to configuration file")
+	flag.StringVar(&configDir, "config-dir", "", "Directory of YAML config fragments merged over the configuration file")
 	flag.BoolVar(&configFromEnv, "config-from-env", false, "Load configuration from environment variables only")
 	flag.Parse()
 
@@ -69,19 +97,23 @@ func main() {
 		}
 	}
 
+	if configDir == "" && !configFromEnv {
+		configDir = os.Getenv("CONFIG_DIR")
+	}
+
 	// Check if we should use environment-only configuration
 	if !configFromEnv {
 		// Check explicit flag first
 		if os.Getenv("GITHUB_EXPORTER_CONFIG_FROM_ENV") == "true" {
 			configFromEnv = true
-		} else if hasEnvironmentVariables() {
+		} else if configDir == "" && hasEnvironmentVariables() {
 			// Auto-detect environment variables and use them
 			configFromEnv = true
 		}
 	}
 
 	// Load configuration
-	cfg, err := config.LoadConfig(configPath, configFromEnv)
+	cfg, err := config.LoadConfig(configPath, configDir, configFromEnv)
 	if err != nil {
 		slog.Error("Failed to load configuration", "error", err)
 		os.Exit(1)
@@ -94,10 +126,17 @@ func main() {
 	})
 
 	// Initialize metrics registry using promexporter
-	metricsRegistry := promexporter_metrics.NewRegistry("github_exporter_info")
+	metricsRegistry := promexporter_metrics.NewRegistry(cfg.MetricsOptions.Namespace + "_exporter_info")
 
 	// Add custom metrics to the registry
-	githubRegistry := metrics.NewGitHubRegistry(metricsRegistry)
+	githubRegistry := metrics.NewGitHubRegistryWithNamespace(metricsRegistry, cfg.MetricsOptions.Namespace)
+	if cfg.MetricsOptions.CompatibilityMode {
+		githubRegistry.EnableCompatibilityAliases()
+	}
+
+	if len(cfg.MetricsOptions.RepoLabels) > 0 {
+		githubRegistry.EnableRepoLabels()
+	}
 
 	// Create and build application using promexporter
 	application := app.New("github-exporter").
@@ -106,12 +145,118 @@ func main() {
 		WithVersionInfo(version.Version, version.Commit, version.BuildDate).
 		Build()
 
+	// Building the application configures logging, so redaction is added afterwards
+	redactLogs(cfg)
+
 	// Create collector with app reference for tracing
 	githubCollector := collectors.NewGitHubCollector(cfg, githubRegistry, application)
-	application.WithCollector(githubCollector)
 
-	if err := application.Run(); err != nil {
+	// Rotate between a pool of tokens if configured
+	if len(cfg.GitHub.Tokens) > 0 {
+		githubCollector.WithAuthTransport(tokenpool.NewTransport(cfg.GitHub.Tokens, nil, githubRegistry, cfg.GitHub.RateLimitBuffer))
+	}
+
+	// Authenticate as a GitHub App and discover its installations if configured
+	var appDiscoverer *githubapp.Discoverer
+	if cfg.GitHub.App.Enabled() {
+		githubApp, err := githubapp.New(cfg.GitHub.App, nil)
+		if err != nil {
+			slog.Error("Failed to set up GitHub App authentication", "error", err)
+			os.Exit(1)
+		}
+
+		transport := githubapp.NewTransport(githubApp, githubRegistry, cfg.GitHub.RateLimitBuffer)
+		githubCollector.WithAuthTransport(transport)
+		appDiscoverer = githubapp.NewDiscoverer(githubApp, transport, githubRegistry, cfg.GitHub.App.PollInterval.Duration)
+	}
+
+	// Create the exporter's own HTTP server so it can control metric exposition
+	httpServer := server.New(cfg, githubRegistry, "github-exporter", server.VersionInfo{
+		Version:   version.Version,
+		Commit:    version.Commit,
+		BuildDate: version.BuildDate,
+	}).WithStatusProvider(githubCollector).
+		WithServiceDiscovery(githubCollector).
+		WithPauseControl(githubCollector, cfg.Admin.Token).
+		WithResync(githubCollector, cfg.Admin.Token)
+
+	// Accept webhook deliveries if the receiver is enabled, applying them to the
+	// polled metrics right away
+	if cfg.Webhook.Enabled {
+		httpServer.WithWebhookReceiver(cfg.Webhook.Path, webhook.NewReceiver(cfg.Webhook, githubRegistry).WithUpdater(githubCollector))
+	}
+
+	// Create Kubernetes target discovery if enabled
+	var discoverer *kubernetes.Discoverer
+	if cfg.Kubernetes.Enabled {
+		discoverer, err = kubernetes.NewDiscoverer(cfg.Kubernetes)
+		if err != nil {
+			slog.Error("Failed to set up Kubernetes discovery", "error", err)
+			os.Exit(1)
+		}
+	}
+
+	if err := run(application, githubCollector, discoverer, appDiscoverer, httpServer); err != nil {
 		slog.Error("Application failed", "error", err)
 		os.Exit(1)
 	}
 }
+
+// redactLogs keeps the configured credentials, and anything shaped like a token,
+// out of all log output
+func redactLogs(cfg *config.Config) {
+	redact.Register(cfg.GitHub.Token, cfg.Webhook.Secret, cfg.Admin.Token,
+		cfg.ServerOptions.Auth.Password, cfg.ServerOptions.Auth.BearerToken, cfg.Notifications.WebhookURL)
+	redact.Register(cfg.GitHub.Tokens...)
+	slog.SetDefault(slog.New(redact.NewHandler(slog.Default().Handler())))
+}
+
+// run starts the collector and HTTP server and handles graceful shutdown
+func run(application *app.App, githubCollector *collectors.GitHubCollector, discoverer *kubernetes.Discoverer, appDiscoverer *githubapp.Discoverer, httpServer *server.Server) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Discover Kubernetes targets before the first collection
+	if discoverer != nil {
+		discoverer.Start(ctx, func(targets kubernetes.Targets) {
+			githubCollector.SetDynamicTargets(collectors.TargetSourceKubernetes, targets.Orgs, targets.Repos)
+		})
+	}
+
+	// Discover the GitHub App's installations before the first collection
+	if appDiscoverer != nil {
+		appDiscoverer.Start(ctx, func(targets githubapp.Targets) {
+			githubCollector.SetDynamicTargets(collectors.TargetSourceApp, targets.Orgs, targets.Repos)
+		})
+	}
+
+	githubCollector.Start(ctx)
+
+	// Handle graceful shutdown
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+
+	go func() {
+		<-sigChan
+		slog.Info("Shutting down gracefully...")
+		cancel()
+
+		githubCollector.Stop()
+
+		// Shutdown tracing
+		if tracer := application.GetTracer(); tracer != nil {
+			shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer shutdownCancel()
+
+			if err := tracer.Shutdown(shutdownCtx); err != nil {
+				slog.Error("Failed to shutdown tracing gracefully", "error", err)
+			}
+		}
+
+		if err := httpServer.Shutdown(); err != nil {
+			slog.Error("Failed to shutdown server gracefully", "error", err)
+		}
+	}()
+
+	return httpServer.Start()
+}
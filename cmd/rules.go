@@ -0,0 +1,56 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/d0ugal/github-exporter/internal/config"
+	"github.com/d0ugal/github-exporter/internal/rules"
+)
+
+// runRules implements the "rules" subcommand which prints Prometheus alerting rules
+// derived from the current configuration
+func runRules(args []string) int {
+	fs := flag.NewFlagSet("rules", flag.ExitOnError)
+
+	var (
+		configPath    string
+		configDir     string
+		configFromEnv bool
+		output        string
+	)
+
+	fs.StringVar(&configPath, "config", "config.yaml", "Path to configuration file")
+	fs.StringVar(&configDir, "config-dir", "", "Directory of YAML config fragments merged over the configuration file")
+	fs.BoolVar(&configFromEnv, "config-from-env", false, "Load configuration from environment variables only")
+	fs.StringVar(&output, "output", "", "Write the rules to a file instead of stdout")
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	cfg, err := config.LoadConfig(configPath, configDir, configFromEnv)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load configuration: %v\n", err)
+		return 1
+	}
+
+	data, err := rules.Generate(cfg).YAML()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to render rules: %v\n", err)
+		return 1
+	}
+
+	if output == "" {
+		fmt.Print(string(data))
+		return 0
+	}
+
+	if err := os.WriteFile(output, data, 0o644); err != nil { //nolint:gosec // Rule files are not sensitive
+		fmt.Fprintf(os.Stderr, "Failed to write rules: %v\n", err)
+		return 1
+	}
+
+	return 0
+}
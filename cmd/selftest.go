@@ -0,0 +1,194 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/d0ugal/github-exporter/internal/collectors"
+	"github.com/d0ugal/github-exporter/internal/config"
+	"github.com/d0ugal/github-exporter/internal/githubapp"
+	"github.com/d0ugal/github-exporter/internal/metrics"
+	"github.com/d0ugal/github-exporter/internal/tokenpool"
+	"github.com/d0ugal/promexporter/app"
+	"github.com/d0ugal/promexporter/logging"
+	promexporter_metrics "github.com/d0ugal/promexporter/metrics"
+)
+
+// runSelfTest implements the "selftest" subcommand which runs a single collection
+// of one repository with debug logging and prints the calls made, the metrics
+// produced and the quota consumed. It exits non-zero when the token lacks
+// permissions for an enabled collector or the repository could not be collected.
+func runSelfTest(args []string) int {
+	fs := flag.NewFlagSet("selftest", flag.ExitOnError)
+
+	var (
+		configPath    string
+		configDir     string
+		configFromEnv bool
+		repo          string
+	)
+
+	fs.StringVar(&configPath, "config", "config.yaml", "Path to configuration file")
+	fs.StringVar(&configDir, "config-dir", "", "Directory of YAML config fragments merged over the configuration file")
+	fs.BoolVar(&configFromEnv, "config-from-env", false, "Load configuration from environment variables only")
+	fs.StringVar(&repo, "repo", "", "Repository (owner/name) to collect (default: the first configured repository)")
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	cfg, err := config.LoadConfig(configPath, configDir, configFromEnv)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load configuration: %v\n", err)
+		return 1
+	}
+
+	if repo == "" {
+		repo = firstRepo(cfg.GitHub.Repos)
+	}
+
+	if owner, name, ok := strings.Cut(repo, "/"); !ok || owner == "" || name == "" || strings.Contains(name, "*") {
+		fmt.Fprintln(os.Stderr, "No repository to test: set -repo to owner/name or configure one in github.repos")
+		return 1
+	}
+
+	restrictToRepo(cfg, repo)
+
+	// Log every API response
+	logging.Configure(&logging.Config{Level: "debug", Format: cfg.Logging.Format})
+	redactLogs(cfg)
+
+	githubRegistry := metrics.NewGitHubRegistryWithNamespace(promexporter_metrics.NewRegistry(cfg.MetricsOptions.Namespace+"_exporter_info"), cfg.MetricsOptions.Namespace)
+
+	// The application is not built, so no server routes or tracing are set up
+	application := app.New("github-exporter")
+
+	githubCollector := collectors.NewGitHubCollector(cfg, githubRegistry, application)
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	// Rotate between the pool of tokens if configured
+	if len(cfg.GitHub.Tokens) > 0 {
+		githubCollector.WithAuthTransport(tokenpool.NewTransport(cfg.GitHub.Tokens, nil, githubRegistry, cfg.GitHub.RateLimitBuffer))
+	}
+
+	// Register the GitHub App's installations so requests are authenticated
+	if cfg.GitHub.App.Enabled() {
+		githubApp, err := githubapp.New(cfg.GitHub.App, nil)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to set up GitHub App authentication: %v\n", err)
+			return 1
+		}
+
+		transport := githubapp.NewTransport(githubApp, githubRegistry, cfg.GitHub.RateLimitBuffer)
+		if _, err := githubapp.NewDiscoverer(githubApp, transport, githubRegistry, cfg.GitHub.App.PollInterval.Duration).Discover(ctx); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to discover GitHub App installations: %v\n", err)
+			return 1
+		}
+
+		githubCollector.WithAuthTransport(transport)
+	}
+
+	report := githubCollector.SelfTest(ctx)
+	printSelfTestReport(repo, report)
+
+	if report.Failed() {
+		return 1
+	}
+
+	return 0
+}
+
+// firstRepo returns the first configured repository that is not a wildcard
+func firstRepo(repos []string) string {
+	for _, repo := range repos {
+		if !strings.Contains(repo, "*") {
+			return repo
+		}
+	}
+
+	return ""
+}
+
+// restrictToRepo limits the configuration to a single repository and disables
+// everything that collects other targets or persists state
+func restrictToRepo(cfg *config.Config, repo string) {
+	cfg.GitHub.Orgs = nil
+	cfg.GitHub.Repos = []string{repo}
+	cfg.GitHub.Teams = nil
+	cfg.GitHub.Starred = false
+	cfg.GitHub.Projects = nil
+	cfg.GitHub.ForkActivity = nil
+	cfg.GitHub.CustomSearches = nil
+	cfg.GitHub.CustomQueries = nil
+	cfg.Kubernetes.Enabled = false
+	cfg.State.Enabled = false
+}
+
+// printSelfTestReport prints the result of a self-test to stdout
+func printSelfTestReport(repo string, report collectors.SelfTestReport) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	defer func() { _ = w.Flush() }()
+
+	_, _ = fmt.Fprintf(w, "\nSelf-test of %s took %s\n", repo, report.Duration.Round(time.Millisecond))
+
+	_, _ = fmt.Fprintln(w, "\nPermissions:")
+	for _, collector := range sortedKeys(report.Permissions) {
+		result := "ok"
+		if !report.Permissions[collector] {
+			result = "MISSING"
+		}
+
+		_, _ = fmt.Fprintf(w, "  %s\t%s\n", collector, result)
+	}
+
+	_, _ = fmt.Fprintln(w, "\nAPI calls (endpoint, status):")
+	total := 0
+	for _, call := range sortedKeys(report.Calls) {
+		total += report.Calls[call]
+		_, _ = fmt.Fprintf(w, "  %s\t%d\n", call, report.Calls[call])
+	}
+	_, _ = fmt.Fprintf(w, "  total\t%d\n", total)
+
+	_, _ = fmt.Fprintln(w, "\nMetrics (series):")
+	for _, family := range sortedKeys(report.Series) {
+		_, _ = fmt.Fprintf(w, "  %s\t%d\n", family, report.Series[family])
+	}
+
+	_, _ = fmt.Fprintln(w, "\nTargets:")
+	for _, target := range report.Targets {
+		result := "ok"
+		if !target.Healthy() {
+			result = target.LastError
+		}
+
+		_, _ = fmt.Fprintf(w, "  %s %s\t%s\n", target.Type, target.Target, result)
+	}
+
+	if report.QuotaUsed >= 0 {
+		_, _ = fmt.Fprintf(w, "\nQuota used: %d (%d remaining)\n", report.QuotaUsed, report.QuotaRemaining)
+	} else {
+		_, _ = fmt.Fprintln(w, "\nQuota used: unknown")
+	}
+}
+
+// sortedKeys returns the keys of a map in order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
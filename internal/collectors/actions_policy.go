@@ -0,0 +1,114 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-github/v76/github"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// setActionsPolicyMetrics exports an organization's GitHub Actions settings. Each
+// setting is read separately, so one that can't be read (e.g. fork PR settings for
+// private repositories on plans without private forks) doesn't hide the others.
+func (gc *GitHubCollector) setActionsPolicyMetrics(ctx context.Context, org string) {
+	orgLabels := prometheus.Labels{"org": org}
+
+	var permissions *github.ActionsPermissions
+	if err := gc.fetchOrgSetting(ctx, org, "actions_permissions", func(reqCtx context.Context) (resp *github.Response, err error) {
+		permissions, resp, err = gc.api.GetOrgActionsPermissions(reqCtx, org)
+		return resp, err
+	}); err != nil {
+		logError("Failed to get Actions permissions", err)
+	} else {
+		gc.metrics.GitHubOrgActionsPolicy.DeletePartialMatch(orgLabels)
+		gc.metrics.GitHubOrgActionsPolicy.With(prometheus.Labels{
+			"org":                  org,
+			"enabled_repositories": permissions.GetEnabledRepositories(),
+			"allowed_actions":      permissions.GetAllowedActions(),
+		}).Set(1)
+	}
+
+	var workflowPermissions *github.DefaultWorkflowPermissionOrganization
+	if err := gc.fetchOrgSetting(ctx, org, "actions_workflow_permissions", func(reqCtx context.Context) (resp *github.Response, err error) {
+		workflowPermissions, resp, err = gc.api.GetOrgDefaultWorkflowPermissions(reqCtx, org)
+		return resp, err
+	}); err != nil {
+		logError("Failed to get default workflow permissions", err)
+	} else {
+		gc.metrics.GitHubOrgDefaultWorkflowPermission.DeletePartialMatch(orgLabels)
+		gc.metrics.GitHubOrgDefaultWorkflowPermission.With(prometheus.Labels{
+			"org":         org,
+			"permissions": workflowPermissions.GetDefaultWorkflowPermissions(),
+		}).Set(1)
+		gc.metrics.GitHubOrgWorkflowsCanApprovePRs.With(orgLabels).Set(boolToFloat(workflowPermissions.GetCanApprovePullRequestReviews()))
+	}
+
+	var runners *github.SelfHostedRunnersSettingsOrganization
+	if err := gc.fetchOrgSetting(ctx, org, "actions_runner_permissions", func(reqCtx context.Context) (resp *github.Response, err error) {
+		runners, resp, err = gc.api.GetOrgSelfHostedRunnersSettings(reqCtx, org)
+		return resp, err
+	}); err != nil {
+		logError("Failed to get self-hosted runner settings", err)
+	} else {
+		gc.metrics.GitHubOrgSelfHostedRunnersPolicy.DeletePartialMatch(orgLabels)
+		gc.metrics.GitHubOrgSelfHostedRunnersPolicy.With(prometheus.Labels{
+			"org":                  org,
+			"enabled_repositories": runners.GetEnabledRepositories(),
+		}).Set(1)
+	}
+
+	var approval *github.ContributorApprovalPermissions
+	if err := gc.fetchOrgSetting(ctx, org, "actions_fork_pr_approval", func(reqCtx context.Context) (resp *github.Response, err error) {
+		approval, resp, err = gc.api.GetOrgForkPRApprovalPolicy(reqCtx, org)
+		return resp, err
+	}); err != nil {
+		logError("Failed to get fork pull request approval policy", err)
+	} else {
+		gc.metrics.GitHubOrgForkPRApprovalPolicy.DeletePartialMatch(orgLabels)
+		gc.metrics.GitHubOrgForkPRApprovalPolicy.With(prometheus.Labels{
+			"org":    org,
+			"policy": approval.ApprovalPolicy,
+		}).Set(1)
+	}
+
+	var privateForks *github.WorkflowsPermissions
+	if err := gc.fetchOrgSetting(ctx, org, "actions_private_fork_pr", func(reqCtx context.Context) (resp *github.Response, err error) {
+		privateForks, resp, err = gc.api.GetOrgPrivateForkPRWorkflowSettings(reqCtx, org)
+		return resp, err
+	}); err != nil {
+		logError("Failed to get private repository fork pull request settings", err)
+	} else {
+		gc.metrics.GitHubOrgPrivateForkPRApproval.With(orgLabels).Set(boolToFloat(privateForks.GetRequireApprovalForForkPRWorkflows()))
+	}
+}
+
+// fetchOrgSetting makes a single rate limited call for an organization setting and
+// records it in the API metrics. The call gets a context bounded by the endpoint's timeout.
+func (gc *GitHubCollector) fetchOrgSetting(ctx context.Context, org, endpoint string, call func(context.Context) (*github.Response, error)) error {
+	t := target{Org: org}
+
+	// Wait for rate limiter
+	if err := gc.limiter.Wait(ctx); err != nil {
+		return wrapAPIError(endpoint, t, fmt.Errorf("rate limiter error: %w", err))
+	}
+
+	reqCtx, cancel := gc.requestContext(ctx, endpoint)
+	_, err := call(reqCtx)
+	cancel()
+	if err != nil {
+		gc.recordAPIError(endpoint, err)
+		return wrapAPIError(endpoint, t, err)
+	}
+
+	return nil
+}
+
+// boolToFloat returns 1 for true and 0 for false
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,61 @@
+package collectors
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// TestSetActionsPolicyMetrics tests organization Actions settings
+func TestSetActionsPolicyMetrics(t *testing.T) {
+	responses := map[string]string{
+		"/orgs/d0ugal/actions/permissions":                              `{"enabled_repositories": "all", "allowed_actions": "selected"}`,
+		"/orgs/d0ugal/actions/permissions/workflow":                     `{"default_workflow_permissions": "read", "can_approve_pull_request_reviews": false}`,
+		"/orgs/d0ugal/actions/permissions/self-hosted-runners":          `{"enabled_repositories": "selected"}`,
+		"/orgs/d0ugal/actions/permissions/fork-pr-contributor-approval": `{"approval_policy": "all_external_contributors"}`,
+	}
+
+	collector := newAPITestCollector(t, func(w http.ResponseWriter, r *http.Request) {
+		body, ok := responses[r.URL.Path]
+		if !ok {
+			// Fork PR settings for private repositories are unavailable on some plans
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"message": "Forbidden"}`))
+
+			return
+		}
+
+		_, _ = w.Write([]byte(body))
+	})
+
+	collector.setActionsPolicyMetrics(t.Context(), "d0ugal")
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubOrgActionsPolicy.WithLabelValues("d0ugal", "all", "selected")); got != 1 {
+		t.Errorf("Expected Actions policy info, got %v", got)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubOrgDefaultWorkflowPermission.WithLabelValues("d0ugal", "read")); got != 1 {
+		t.Errorf("Expected read default workflow permissions, got %v", got)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubOrgWorkflowsCanApprovePRs.WithLabelValues("d0ugal")); got != 0 {
+		t.Errorf("Expected workflows not to approve pull requests, got %v", got)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubOrgSelfHostedRunnersPolicy.WithLabelValues("d0ugal", "selected")); got != 1 {
+		t.Errorf("Expected selected self-hosted runner policy, got %v", got)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubOrgForkPRApprovalPolicy.WithLabelValues("d0ugal", "all_external_contributors")); got != 1 {
+		t.Errorf("Expected fork PR approval policy info, got %v", got)
+	}
+
+	if got := testutil.CollectAndCount(collector.metrics.GitHubOrgPrivateForkPRApproval); got != 0 {
+		t.Errorf("Expected no private fork PR series after a failed call, got %d", got)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubAPIErrorsTotal.WithLabelValues("actions_private_fork_pr", "forbidden", "403")); got != 1 {
+		t.Errorf("Expected 1 forbidden error, got %v", got)
+	}
+}
@@ -0,0 +1,66 @@
+package collectors
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// maxFutureSkew is how far in the future a timestamp read from an API response
+// may lie, allowing for clock skew between GitHub and the exporter
+const maxFutureSkew = time.Hour
+
+// earliestTimestamp predates any GitHub resource. Earlier timestamps, such as
+// the zero time of a missing field, are not plausible.
+var earliestTimestamp = time.Date(2007, time.October, 1, 0, 0, 0, 0, time.UTC)
+
+// plausibleCount reports whether a count read from an API response can be
+// exported. Negative counts, e.g. from partial responses, are counted as
+// anomalies instead, keeping the last plausible value.
+func (gc *GitHubCollector) plausibleCount(metric string, t target, value int) bool {
+	if value >= 0 {
+		return true
+	}
+
+	gc.recordAnomaly(metric, "negative", t, "value", value)
+
+	return false
+}
+
+// plausibleTimestamp reports whether a timestamp read from an API response can
+// be exported, counting timestamps far in the future or before GitHub existed
+// as anomalies instead
+func (gc *GitHubCollector) plausibleTimestamp(metric string, t target, timestamp time.Time) bool {
+	switch {
+	case timestamp.After(time.Now().Add(maxFutureSkew)):
+		gc.recordAnomaly(metric, "future_timestamp", t, "timestamp", timestamp)
+	case timestamp.Before(earliestTimestamp):
+		gc.recordAnomaly(metric, "past_timestamp", t, "timestamp", timestamp)
+	default:
+		return true
+	}
+
+	return false
+}
+
+// adjustCount adds delta to a count, unless that would make it negative, e.g.
+// when a delivery reports a change the last poll already counted
+func (gc *GitHubCollector) adjustCount(gauge prometheus.Gauge, metric string, t target, delta float64) {
+	var current dto.Metric
+	if err := gauge.Write(&current); err == nil && current.GetGauge().GetValue()+delta < 0 {
+		gc.recordAnomaly(metric, "negative", t, "delta", delta)
+		return
+	}
+
+	gauge.Add(delta)
+}
+
+// recordAnomaly counts and logs a value rejected before it was exported
+func (gc *GitHubCollector) recordAnomaly(metric, reason string, t target, kv ...any) {
+	gc.metrics.GitHubExporterAnomalies.With(prometheus.Labels{"metric": metric, "reason": reason}).Inc()
+
+	slog.Warn("Rejected implausible value from the GitHub API",
+		append([]any{"metric", metric, "reason", reason, "target", t.String()}, kv...)...)
+}
@@ -0,0 +1,74 @@
+package collectors
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v76/github"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// TestPlausibleValues tests that negative counts and out of range timestamps
+// are rejected and counted as anomalies
+func TestPlausibleValues(t *testing.T) {
+	collector := createTestCollector()
+	repo := target{Org: "d0ugal", Repo: "private"}
+
+	if !collector.plausibleCount("repo_stars", repo, 0) {
+		t.Error("Expected a zero count to be plausible")
+	}
+
+	if collector.plausibleCount("repo_stars", repo, -1) {
+		t.Error("Expected a negative count to be rejected")
+	}
+
+	tests := []struct {
+		name      string
+		timestamp time.Time
+		reason    string
+	}{
+		{name: "now", timestamp: time.Now()},
+		{name: "within skew", timestamp: time.Now().Add(maxFutureSkew / 2)},
+		{name: "future", timestamp: time.Now().Add(2 * maxFutureSkew), reason: "future_timestamp"},
+		{name: "zero", timestamp: time.Time{}, reason: "past_timestamp"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := collector.plausibleTimestamp("repo_last_updated_timestamp", repo, tt.timestamp); got != (tt.reason == "") {
+				t.Errorf("Expected plausible to be %v, got %v", tt.reason == "", got)
+			}
+		})
+	}
+
+	anomalies := collector.metrics.GitHubExporterAnomalies
+
+	if got := testutil.ToFloat64(anomalies.WithLabelValues("repo_stars", "negative")); got != 1 {
+		t.Errorf("Expected 1 negative count anomaly, got %v", got)
+	}
+
+	for _, reason := range []string{"future_timestamp", "past_timestamp"} {
+		if got := testutil.ToFloat64(anomalies.WithLabelValues("repo_last_updated_timestamp", reason)); got != 1 {
+			t.Errorf("Expected 1 %s anomaly, got %v", reason, got)
+		}
+	}
+}
+
+// TestApplyOpenCountsNegative tests that deliveries never make open counts
+// negative, e.g. when the last poll already counted a closed issue
+func TestApplyOpenCountsNegative(t *testing.T) {
+	collector := newWebhookTestCollector()
+	collector.metrics.GitHubReposOpenIssues.WithLabelValues("d0ugal", "private", "private").Set(1)
+
+	for range 2 {
+		collector.ApplyIssues(&github.IssuesEvent{Action: github.Ptr("closed"), Repo: testWebhookRepo("private")})
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubReposOpenIssues.WithLabelValues("d0ugal", "private", "private")); got != 0 {
+		t.Errorf("Expected 0 open issues, got %v", got)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubExporterAnomalies.WithLabelValues("repo_open_issues", "negative")); got != 1 {
+		t.Errorf("Expected 1 anomaly, got %v", got)
+	}
+}
@@ -0,0 +1,290 @@
+package collectors
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/v76/github"
+)
+
+// GitHubAPI is the part of the GitHub API used by the collector. Methods mirror the
+// go-github client they are backed by, so responses and errors are handled the same
+// way regardless of the implementation.
+type GitHubAPI interface {
+	// Users
+	GetAuthenticatedUser(ctx context.Context) (*github.User, *github.Response, error)
+	GetUser(ctx context.Context, user string) (*github.User, *github.Response, error)
+
+	// Organizations
+	GetOrganization(ctx context.Context, org string) (*github.Organization, *github.Response, error)
+	ListOrgPackages(ctx context.Context, org string, opts *github.PackageListOptions) ([]*github.Package, *github.Response, error)
+	ListPackageVersions(ctx context.Context, org, packageType, packageName string, opts *github.PackageListOptions) ([]*github.PackageVersion, *github.Response, error)
+	GetOrgActionsPermissions(ctx context.Context, org string) (*github.ActionsPermissions, *github.Response, error)
+	GetOrgDefaultWorkflowPermissions(ctx context.Context, org string) (*github.DefaultWorkflowPermissionOrganization, *github.Response, error)
+	GetOrgSelfHostedRunnersSettings(ctx context.Context, org string) (*github.SelfHostedRunnersSettingsOrganization, *github.Response, error)
+	GetOrgForkPRApprovalPolicy(ctx context.Context, org string) (*github.ContributorApprovalPermissions, *github.Response, error)
+	GetOrgPrivateForkPRWorkflowSettings(ctx context.Context, org string) (*github.WorkflowsPermissions, *github.Response, error)
+	ListOrgSecrets(ctx context.Context, org string, opts *github.ListOptions) (*github.Secrets, *github.Response, error)
+	ListOrgVariables(ctx context.Context, org string, opts *github.ListOptions) (*github.ActionsVariables, *github.Response, error)
+
+	// Repositories
+	GetRepository(ctx context.Context, owner, repo string) (*github.Repository, *github.Response, error)
+	ListOrgRepositories(ctx context.Context, org string, opts *github.RepositoryListByOrgOptions) ([]*github.Repository, *github.Response, error)
+	ListUserRepositories(ctx context.Context, opts *github.RepositoryListByAuthenticatedUserOptions) ([]*github.Repository, *github.Response, error)
+	ListTeamRepositories(ctx context.Context, org, slug string, opts *github.ListOptions) ([]*github.Repository, *github.Response, error)
+	ListStarredRepositories(ctx context.Context, opts *github.ActivityListStarredOptions) ([]*github.StarredRepository, *github.Response, error)
+	ListCommits(ctx context.Context, owner, repo string, opts *github.CommitsListOptions) ([]*github.RepositoryCommit, *github.Response, error)
+	ListReleases(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.RepositoryRelease, *github.Response, error)
+	CompareCommits(ctx context.Context, owner, repo, base, head string, opts *github.ListOptions) (*github.CommitsComparison, *github.Response, error)
+	ListForks(ctx context.Context, owner, repo string, opts *github.RepositoryListForksOptions) ([]*github.Repository, *github.Response, error)
+	GetContents(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentGetOptions) (*github.RepositoryContent, []*github.RepositoryContent, *github.Response, error)
+	GetCodeownersErrors(ctx context.Context, owner, repo string, opts *github.GetCodeownersErrorsOptions) (*github.CodeownersErrors, *github.Response, error)
+	IsPrivateReportingEnabled(ctx context.Context, owner, repo string) (bool, *github.Response, error)
+	GetAllRulesets(ctx context.Context, owner, repo string, opts *github.RepositoryListRulesetsOptions) ([]*github.RepositoryRuleset, *github.Response, error)
+	GetRuleset(ctx context.Context, owner, repo string, rulesetID int64, includesParents bool) (*github.RepositoryRuleset, *github.Response, error)
+	ListHooks(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.Hook, *github.Response, error)
+	ListHookDeliveries(ctx context.Context, owner, repo string, id int64, opts *github.ListCursorOptions) ([]*github.HookDelivery, *github.Response, error)
+
+	// Issues, pull requests and search
+	ListIssues(ctx context.Context, owner, repo string, opts *github.IssueListByRepoOptions) ([]*github.Issue, *github.Response, error)
+	ListIssueComments(ctx context.Context, owner, repo string, opts *github.IssueListCommentsOptions) ([]*github.IssueComment, *github.Response, error)
+	ListReviewComments(ctx context.Context, owner, repo string, opts *github.PullRequestListCommentsOptions) ([]*github.PullRequestComment, *github.Response, error)
+	SearchIssues(ctx context.Context, query string, opts *github.SearchOptions) (*github.IssuesSearchResult, *github.Response, error)
+
+	// Actions and checks
+	ListWorkflows(ctx context.Context, owner, repo string, opts *github.ListOptions) (*github.Workflows, *github.Response, error)
+	ListWorkflowRuns(ctx context.Context, owner, repo string, opts *github.ListWorkflowRunsOptions) (*github.WorkflowRuns, *github.Response, error)
+	ListWorkflowRunsByID(ctx context.Context, owner, repo string, workflowID int64, opts *github.ListWorkflowRunsOptions) (*github.WorkflowRuns, *github.Response, error)
+	ListWorkflowRunsByFileName(ctx context.Context, owner, repo, workflowFileName string, opts *github.ListWorkflowRunsOptions) (*github.WorkflowRuns, *github.Response, error)
+	CreateWorkflowDispatchEventByFileName(ctx context.Context, owner, repo, workflowFileName string, event github.CreateWorkflowDispatchEventRequest) (*github.Response, error)
+	GetWorkflowRunUsage(ctx context.Context, owner, repo string, runID int64) (*github.WorkflowRunUsage, *github.Response, error)
+	ListCheckRunsForRef(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error)
+	GetRequiredStatusChecks(ctx context.Context, owner, repo, branch string) (*github.RequiredStatusChecks, *github.Response, error)
+	GetBranchProtection(ctx context.Context, owner, repo, branch string) (*github.Protection, *github.Response, error)
+	ListRepoSecrets(ctx context.Context, owner, repo string, opts *github.ListOptions) (*github.Secrets, *github.Response, error)
+	ListEnvSecrets(ctx context.Context, repoID int, env string, opts *github.ListOptions) (*github.Secrets, *github.Response, error)
+	ListEnvironments(ctx context.Context, owner, repo string, opts *github.EnvironmentListOptions) (*github.EnvResponse, *github.Response, error)
+	GetRepoOIDCSubjectClaimCustomTemplate(ctx context.Context, owner, repo string) (*github.OIDCSubjectClaimCustomTemplate, *github.Response, error)
+	ListRepoVariables(ctx context.Context, owner, repo string, opts *github.ListOptions) (*github.ActionsVariables, *github.Response, error)
+
+	// GraphQL sends body to the GraphQL API and decodes the response into out
+	GraphQL(ctx context.Context, body, out interface{}) (*github.Response, error)
+}
+
+// githubAPI implements GitHubAPI with the go-github client
+type githubAPI struct {
+	client *github.Client
+}
+
+// NewGitHubAPI returns a GitHubAPI backed by a go-github client
+func NewGitHubAPI(client *github.Client) GitHubAPI {
+	return &githubAPI{client: client}
+}
+
+func (a *githubAPI) GetAuthenticatedUser(ctx context.Context) (*github.User, *github.Response, error) {
+	return a.client.Users.Get(ctx, "")
+}
+
+func (a *githubAPI) GetUser(ctx context.Context, user string) (*github.User, *github.Response, error) {
+	return a.client.Users.Get(ctx, user)
+}
+
+func (a *githubAPI) GetOrganization(ctx context.Context, org string) (*github.Organization, *github.Response, error) {
+	return a.client.Organizations.Get(ctx, org)
+}
+
+func (a *githubAPI) ListOrgPackages(ctx context.Context, org string, opts *github.PackageListOptions) ([]*github.Package, *github.Response, error) {
+	return a.client.Organizations.ListPackages(ctx, org, opts)
+}
+
+func (a *githubAPI) ListPackageVersions(ctx context.Context, org, packageType, packageName string, opts *github.PackageListOptions) ([]*github.PackageVersion, *github.Response, error) {
+	return a.client.Organizations.PackageGetAllVersions(ctx, org, packageType, packageName, opts)
+}
+
+func (a *githubAPI) GetOrgActionsPermissions(ctx context.Context, org string) (*github.ActionsPermissions, *github.Response, error) {
+	return a.client.Actions.GetActionsPermissions(ctx, org)
+}
+
+func (a *githubAPI) GetOrgDefaultWorkflowPermissions(ctx context.Context, org string) (*github.DefaultWorkflowPermissionOrganization, *github.Response, error) {
+	return a.client.Actions.GetDefaultWorkflowPermissionsInOrganization(ctx, org)
+}
+
+func (a *githubAPI) GetOrgSelfHostedRunnersSettings(ctx context.Context, org string) (*github.SelfHostedRunnersSettingsOrganization, *github.Response, error) {
+	return a.client.Actions.GetSelfHostedRunnersSettingsInOrganization(ctx, org)
+}
+
+func (a *githubAPI) GetOrgForkPRApprovalPolicy(ctx context.Context, org string) (*github.ContributorApprovalPermissions, *github.Response, error) {
+	return a.client.Actions.GetOrganizationForkPRContributorApprovalPermissions(ctx, org)
+}
+
+func (a *githubAPI) GetOrgPrivateForkPRWorkflowSettings(ctx context.Context, org string) (*github.WorkflowsPermissions, *github.Response, error) {
+	return a.client.Actions.GetPrivateRepoForkPRWorkflowSettingsInOrganization(ctx, org)
+}
+
+func (a *githubAPI) ListOrgSecrets(ctx context.Context, org string, opts *github.ListOptions) (*github.Secrets, *github.Response, error) {
+	return a.client.Actions.ListOrgSecrets(ctx, org, opts)
+}
+
+func (a *githubAPI) ListOrgVariables(ctx context.Context, org string, opts *github.ListOptions) (*github.ActionsVariables, *github.Response, error) {
+	return a.client.Actions.ListOrgVariables(ctx, org, opts)
+}
+
+func (a *githubAPI) GetRepository(ctx context.Context, owner, repo string) (*github.Repository, *github.Response, error) {
+	return a.client.Repositories.Get(ctx, owner, repo)
+}
+
+func (a *githubAPI) ListOrgRepositories(ctx context.Context, org string, opts *github.RepositoryListByOrgOptions) ([]*github.Repository, *github.Response, error) {
+	return a.client.Repositories.ListByOrg(ctx, org, opts)
+}
+
+func (a *githubAPI) ListUserRepositories(ctx context.Context, opts *github.RepositoryListByAuthenticatedUserOptions) ([]*github.Repository, *github.Response, error) {
+	return a.client.Repositories.ListByAuthenticatedUser(ctx, opts)
+}
+
+func (a *githubAPI) ListTeamRepositories(ctx context.Context, org, slug string, opts *github.ListOptions) ([]*github.Repository, *github.Response, error) {
+	return a.client.Teams.ListTeamReposBySlug(ctx, org, slug, opts)
+}
+
+func (a *githubAPI) ListStarredRepositories(ctx context.Context, opts *github.ActivityListStarredOptions) ([]*github.StarredRepository, *github.Response, error) {
+	return a.client.Activity.ListStarred(ctx, "", opts)
+}
+
+func (a *githubAPI) ListCommits(ctx context.Context, owner, repo string, opts *github.CommitsListOptions) ([]*github.RepositoryCommit, *github.Response, error) {
+	return a.client.Repositories.ListCommits(ctx, owner, repo, opts)
+}
+
+func (a *githubAPI) ListReleases(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.RepositoryRelease, *github.Response, error) {
+	return a.client.Repositories.ListReleases(ctx, owner, repo, opts)
+}
+
+func (a *githubAPI) CompareCommits(ctx context.Context, owner, repo, base, head string, opts *github.ListOptions) (*github.CommitsComparison, *github.Response, error) {
+	return a.client.Repositories.CompareCommits(ctx, owner, repo, base, head, opts)
+}
+
+func (a *githubAPI) ListForks(ctx context.Context, owner, repo string, opts *github.RepositoryListForksOptions) ([]*github.Repository, *github.Response, error) {
+	return a.client.Repositories.ListForks(ctx, owner, repo, opts)
+}
+
+func (a *githubAPI) GetContents(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentGetOptions) (*github.RepositoryContent, []*github.RepositoryContent, *github.Response, error) {
+	return a.client.Repositories.GetContents(ctx, owner, repo, path, opts)
+}
+
+func (a *githubAPI) GetCodeownersErrors(ctx context.Context, owner, repo string, opts *github.GetCodeownersErrorsOptions) (*github.CodeownersErrors, *github.Response, error) {
+	return a.client.Repositories.GetCodeownersErrors(ctx, owner, repo, opts)
+}
+
+func (a *githubAPI) IsPrivateReportingEnabled(ctx context.Context, owner, repo string) (bool, *github.Response, error) {
+	return a.client.Repositories.IsPrivateReportingEnabled(ctx, owner, repo)
+}
+
+func (a *githubAPI) GetAllRulesets(ctx context.Context, owner, repo string, opts *github.RepositoryListRulesetsOptions) ([]*github.RepositoryRuleset, *github.Response, error) {
+	return a.client.Repositories.GetAllRulesets(ctx, owner, repo, opts)
+}
+
+func (a *githubAPI) GetRuleset(ctx context.Context, owner, repo string, rulesetID int64, includesParents bool) (*github.RepositoryRuleset, *github.Response, error) {
+	return a.client.Repositories.GetRuleset(ctx, owner, repo, rulesetID, includesParents)
+}
+
+func (a *githubAPI) ListHooks(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.Hook, *github.Response, error) {
+	return a.client.Repositories.ListHooks(ctx, owner, repo, opts)
+}
+
+func (a *githubAPI) ListHookDeliveries(ctx context.Context, owner, repo string, id int64, opts *github.ListCursorOptions) ([]*github.HookDelivery, *github.Response, error) {
+	return a.client.Repositories.ListHookDeliveries(ctx, owner, repo, id, opts)
+}
+
+func (a *githubAPI) ListIssues(ctx context.Context, owner, repo string, opts *github.IssueListByRepoOptions) ([]*github.Issue, *github.Response, error) {
+	return a.client.Issues.ListByRepo(ctx, owner, repo, opts)
+}
+
+// ListIssueComments lists comments across all issues and pull requests of a repository
+func (a *githubAPI) ListIssueComments(ctx context.Context, owner, repo string, opts *github.IssueListCommentsOptions) ([]*github.IssueComment, *github.Response, error) {
+	return a.client.Issues.ListComments(ctx, owner, repo, 0, opts)
+}
+
+// ListReviewComments lists review comments across all pull requests of a repository
+func (a *githubAPI) ListReviewComments(ctx context.Context, owner, repo string, opts *github.PullRequestListCommentsOptions) ([]*github.PullRequestComment, *github.Response, error) {
+	return a.client.PullRequests.ListComments(ctx, owner, repo, 0, opts)
+}
+
+func (a *githubAPI) SearchIssues(ctx context.Context, query string, opts *github.SearchOptions) (*github.IssuesSearchResult, *github.Response, error) {
+	return a.client.Search.Issues(ctx, query, opts)
+}
+
+func (a *githubAPI) ListWorkflows(ctx context.Context, owner, repo string, opts *github.ListOptions) (*github.Workflows, *github.Response, error) {
+	return a.client.Actions.ListWorkflows(ctx, owner, repo, opts)
+}
+
+func (a *githubAPI) ListWorkflowRuns(ctx context.Context, owner, repo string, opts *github.ListWorkflowRunsOptions) (*github.WorkflowRuns, *github.Response, error) {
+	return a.client.Actions.ListRepositoryWorkflowRuns(ctx, owner, repo, opts)
+}
+
+func (a *githubAPI) ListWorkflowRunsByID(ctx context.Context, owner, repo string, workflowID int64, opts *github.ListWorkflowRunsOptions) (*github.WorkflowRuns, *github.Response, error) {
+	return a.client.Actions.ListWorkflowRunsByID(ctx, owner, repo, workflowID, opts)
+}
+
+func (a *githubAPI) ListWorkflowRunsByFileName(ctx context.Context, owner, repo, workflowFileName string, opts *github.ListWorkflowRunsOptions) (*github.WorkflowRuns, *github.Response, error) {
+	return a.client.Actions.ListWorkflowRunsByFileName(ctx, owner, repo, workflowFileName, opts)
+}
+
+func (a *githubAPI) CreateWorkflowDispatchEventByFileName(ctx context.Context, owner, repo, workflowFileName string, event github.CreateWorkflowDispatchEventRequest) (*github.Response, error) {
+	return a.client.Actions.CreateWorkflowDispatchEventByFileName(ctx, owner, repo, workflowFileName, event)
+}
+
+func (a *githubAPI) GetWorkflowRunUsage(ctx context.Context, owner, repo string, runID int64) (*github.WorkflowRunUsage, *github.Response, error) {
+	return a.client.Actions.GetWorkflowRunUsageByID(ctx, owner, repo, runID)
+}
+
+func (a *githubAPI) ListCheckRunsForRef(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+	return a.client.Checks.ListCheckRunsForRef(ctx, owner, repo, ref, opts)
+}
+
+func (a *githubAPI) GetRequiredStatusChecks(ctx context.Context, owner, repo, branch string) (*github.RequiredStatusChecks, *github.Response, error) {
+	return a.client.Repositories.GetRequiredStatusChecks(ctx, owner, repo, branch)
+}
+
+func (a *githubAPI) GetBranchProtection(ctx context.Context, owner, repo, branch string) (*github.Protection, *github.Response, error) {
+	return a.client.Repositories.GetBranchProtection(ctx, owner, repo, branch)
+}
+
+func (a *githubAPI) ListRepoSecrets(ctx context.Context, owner, repo string, opts *github.ListOptions) (*github.Secrets, *github.Response, error) {
+	return a.client.Actions.ListRepoSecrets(ctx, owner, repo, opts)
+}
+
+func (a *githubAPI) ListEnvSecrets(ctx context.Context, repoID int, env string, opts *github.ListOptions) (*github.Secrets, *github.Response, error) {
+	return a.client.Actions.ListEnvSecrets(ctx, repoID, env, opts)
+}
+
+func (a *githubAPI) ListEnvironments(ctx context.Context, owner, repo string, opts *github.EnvironmentListOptions) (*github.EnvResponse, *github.Response, error) {
+	return a.client.Repositories.ListEnvironments(ctx, owner, repo, opts)
+}
+
+func (a *githubAPI) GetRepoOIDCSubjectClaimCustomTemplate(ctx context.Context, owner, repo string) (*github.OIDCSubjectClaimCustomTemplate, *github.Response, error) {
+	return a.client.Actions.GetRepoOIDCSubjectClaimCustomTemplate(ctx, owner, repo)
+}
+
+func (a *githubAPI) ListRepoVariables(ctx context.Context, owner, repo string, opts *github.ListOptions) (*github.ActionsVariables, *github.Response, error) {
+	return a.client.Actions.ListRepoVariables(ctx, owner, repo, opts)
+}
+
+// GraphQL executes a GraphQL request through the REST client, so it shares its
+// authentication, transport and error handling
+func (a *githubAPI) GraphQL(ctx context.Context, body, out interface{}) (*github.Response, error) {
+	req, err := a.client.NewRequest(http.MethodPost, a.graphqlPath(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.client.Do(ctx, req, out)
+}
+
+// graphqlPath returns the GraphQL endpoint relative to the REST base URL. On
+// github.com this is /graphql, on GitHub Enterprise Server /api/graphql next to /api/v3/.
+func (a *githubAPI) graphqlPath() string {
+	if strings.HasSuffix(a.client.BaseURL.Path, "/v3/") {
+		return "../graphql"
+	}
+
+	return "graphql"
+}
@@ -0,0 +1,88 @@
+package collectors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-github/v76/github"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// apiErrorStatusCode extracts the HTTP status code from a GitHub API error,
+// returning 0 when the error did not come from an HTTP response
+func apiErrorStatusCode(err error) int {
+	if resp := apiErrorResponse(err); resp != nil {
+		return resp.StatusCode
+	}
+
+	return 0
+}
+
+// classifyAPIError returns the status code label and error type for a GitHub API error
+func classifyAPIError(err error) (string, string) {
+	statusCode := apiErrorStatusCode(err)
+
+	statusLabel := "none"
+	if statusCode != 0 {
+		statusLabel = fmt.Sprintf("%d", statusCode)
+	}
+
+	var rateLimitErr *github.RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		return statusLabel, "rate_limited"
+	}
+
+	var abuseErr *github.AbuseRateLimitError
+	if errors.As(err, &abuseErr) {
+		return statusLabel, "secondary_rate_limited"
+	}
+
+	switch {
+	case statusCode == http.StatusUnauthorized:
+		return statusLabel, "unauthorized"
+	case statusCode == http.StatusForbidden:
+		return statusLabel, "forbidden"
+	case statusCode == http.StatusNotFound:
+		return statusLabel, "not_found"
+	case statusCode == http.StatusUnprocessableEntity:
+		return statusLabel, "validation_failed"
+	case statusCode == http.StatusTooManyRequests:
+		return statusLabel, "rate_limited"
+	case statusCode >= 500:
+		return statusLabel, "server_error"
+	case statusCode != 0:
+		return statusLabel, "api_error"
+	case errors.Is(err, context.DeadlineExceeded):
+		return statusLabel, "timeout"
+	case errors.Is(err, context.Canceled):
+		return statusLabel, "cancelled"
+	default:
+		return statusLabel, "network_error"
+	}
+}
+
+// recordAPIError increments the API error counter for a failed GitHub API call,
+// deriving the error type from the response status code
+func (gc *GitHubCollector) recordAPIError(endpoint string, err error) {
+	statusCode, errorType := classifyAPIError(err)
+
+	gc.metrics.GitHubAPIErrorsTotal.With(prometheus.Labels{
+		"endpoint":    endpoint,
+		"error_type":  errorType,
+		"status_code": statusCode,
+	}).Inc()
+}
+
+// recordError increments the API error counter with an explicit error type, such as
+// failures of a whole collection phase
+func (gc *GitHubCollector) recordError(endpoint, errorType string, err error) {
+	statusCode, _ := classifyAPIError(err)
+
+	gc.metrics.GitHubAPIErrorsTotal.With(prometheus.Labels{
+		"endpoint":    endpoint,
+		"error_type":  errorType,
+		"status_code": statusCode,
+	}).Inc()
+}
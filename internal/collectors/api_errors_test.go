@@ -0,0 +1,46 @@
+package collectors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-github/v76/github"
+)
+
+// TestClassifyAPIError tests mapping of GitHub API errors to status codes and error types
+func TestClassifyAPIError(t *testing.T) {
+	responseError := func(code int) error {
+		return &github.ErrorResponse{Response: &http.Response{StatusCode: code}}
+	}
+
+	tests := []struct {
+		name       string
+		err        error
+		statusCode string
+		errorType  string
+	}{
+		{"unauthorized", responseError(401), "401", "unauthorized"},
+		{"forbidden", responseError(403), "403", "forbidden"},
+		{"not found", responseError(404), "404", "not_found"},
+		{"validation", responseError(422), "422", "validation_failed"},
+		{"too many requests", responseError(429), "429", "rate_limited"},
+		{"server error", responseError(502), "502", "server_error"},
+		{"wrapped", fmt.Errorf("failed: %w", responseError(404)), "404", "not_found"},
+		{"primary rate limit", &github.RateLimitError{Response: &http.Response{StatusCode: 403}}, "403", "rate_limited"},
+		{"secondary rate limit", &github.AbuseRateLimitError{Response: &http.Response{StatusCode: 403}}, "403", "secondary_rate_limited"},
+		{"timeout", context.DeadlineExceeded, "none", "timeout"},
+		{"network", errors.New("connection refused"), "none", "network_error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statusCode, errorType := classifyAPIError(tt.err)
+			if statusCode != tt.statusCode || errorType != tt.errorType {
+				t.Errorf("Expected (%s, %s), got (%s, %s)", tt.statusCode, tt.errorType, statusCode, errorType)
+			}
+		})
+	}
+}
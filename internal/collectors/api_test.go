@@ -0,0 +1,83 @@
+package collectors
+
+import (
+	"testing"
+
+	"github.com/d0ugal/promexporter/app"
+	"github.com/google/go-github/v76/github"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"golang.org/x/time/rate"
+)
+
+// newFakeCollector creates a collector backed by an in-memory GitHub API
+func newFakeCollector() (*GitHubCollector, *fakeGitHubAPI) {
+	api := newFakeGitHubAPI()
+
+	collector := createTestCollector()
+	collector.app = app.New("github-exporter")
+	collector.api = api
+	collector.limiter = newSharedLimiter(rate.Inf, 1)
+
+	return collector, api
+}
+
+// TestCollectRepoMetricsWithFakeAPI tests repository metrics collected through the GitHubAPI interface
+func TestCollectRepoMetricsWithFakeAPI(t *testing.T) {
+	collector, api := newFakeCollector()
+	collector.config.GitHub.Repos = []string{"d0ugal/github-exporter", "d0ugal/missing"}
+
+	repo := api.addRepo("d0ugal", "github-exporter", false)
+	repo.StargazersCount = github.Ptr(42)
+	api.searchTotals["repo:d0ugal/github-exporter type:pr state:open"] = 3
+
+	result, err := collector.collectRepoMetrics(t.Context())
+	if err == nil {
+		t.Error("Expected an error for the missing repository")
+	}
+
+	if result.Succeeded != 1 || result.Failed != 1 {
+		t.Errorf("Expected 1 succeeded and 1 failed repository, got %+v", result)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubReposStars.WithLabelValues("d0ugal", "github-exporter", "public")); got != 42 {
+		t.Errorf("Expected 42 stars, got %v", got)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubReposOpenPRs.WithLabelValues("d0ugal", "github-exporter", "public")); got != 3 {
+		t.Errorf("Expected 3 open PRs, got %v", got)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubAPIErrorsTotal.WithLabelValues("repos", "not_found", "404")); got != 1 {
+		t.Errorf("Expected 1 not_found error, got %v", got)
+	}
+}
+
+// TestCollectBranchBuildStatusWithFakeAPI tests that the worst workflow run decides the branch status
+func TestCollectBranchBuildStatusWithFakeAPI(t *testing.T) {
+	collector, api := newFakeCollector()
+
+	api.workflowRuns["d0ugal/github-exporter"] = []*github.WorkflowRun{
+		{WorkflowID: github.Ptr(int64(1)), Name: github.Ptr("CI"), HeadBranch: github.Ptr("main"), Conclusion: github.Ptr("success")},
+		{WorkflowID: github.Ptr(int64(2)), Name: github.Ptr("Lint"), HeadBranch: github.Ptr("main"), Conclusion: github.Ptr("failure")},
+		{WorkflowID: github.Ptr(int64(1)), Name: github.Ptr("CI"), HeadBranch: github.Ptr("feature"), Conclusion: github.Ptr("failure")},
+	}
+	api.checkRuns["d0ugal/github-exporter@main"] = []*github.CheckRun{
+		{Name: github.Ptr("build"), Status: github.Ptr("completed"), Conclusion: github.Ptr("success")},
+	}
+
+	if err := collector.collectBranchBuildStatus(t.Context(), "d0ugal", "github-exporter", "main"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubBranchBuildStatus.WithLabelValues("d0ugal", "github-exporter", "main")); got != 0 {
+		t.Errorf("Expected failed branch status, got %v", got)
+	}
+
+	if got := testutil.CollectAndCount(collector.metrics.GitHubWorkflowRunStatus); got != 2 {
+		t.Errorf("Expected 2 workflow run series for main, got %d", got)
+	}
+
+	if api.calls["ListCheckRunsForRef"] != 1 {
+		t.Errorf("Expected check runs to be listed once, got %d", api.calls["ListCheckRunsForRef"])
+	}
+}
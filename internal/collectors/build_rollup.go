@@ -0,0 +1,47 @@
+package collectors
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// setBuildStatusRollups exports the worst build status of each repository across
+// its monitored branches, and the number of repositories with a failed build in
+// each organization. Both are derived from the branch build status series, so
+// they also reflect branches refreshed in degraded mode.
+func (gc *GitHubCollector) setBuildStatusRollups() {
+	worst := make(map[target]float64)
+
+	for _, s := range matchingSeries(gc.metrics.GitHubBranchBuildStatus, nil) {
+		repo := target{Org: s.labels["org"], Repo: s.labels["repo"]}
+
+		// Worst status wins, as for branches
+		if current, ok := worst[repo]; !ok || s.value < current {
+			worst[repo] = s.value
+		}
+	}
+
+	failing := make(map[string]float64)
+
+	gc.metrics.GitHubRepoBuildStatus.Reset()
+
+	for repo, status := range worst {
+		gc.metrics.GitHubRepoBuildStatus.With(prometheus.Labels{
+			"org":  repo.Org,
+			"repo": repo.Repo,
+		}).Set(status)
+
+		// Organizations without failures are exported with a count of 0
+		failed := 0.0
+		if status == 0 {
+			failed = 1
+		}
+
+		failing[repo.Org] += failed
+	}
+
+	gc.metrics.GitHubOrgFailingBuilds.Reset()
+
+	for org, count := range failing {
+		gc.metrics.GitHubOrgFailingBuilds.With(prometheus.Labels{"org": org}).Set(count)
+	}
+}
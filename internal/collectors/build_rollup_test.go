@@ -0,0 +1,56 @@
+package collectors
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// TestSetBuildStatusRollups tests that repositories report their worst branch
+// status and organizations count their repositories with a failed build
+func TestSetBuildStatusRollups(t *testing.T) {
+	collector := createTestCollector()
+
+	branches := []struct {
+		org, repo, branch string
+		status            float64
+	}{
+		{"acme", "api", "main", 1},
+		{"acme", "api", "develop", 0},
+		{"acme", "web", "main", 1},
+		{"acme", "web", "develop", 2},
+		{"d0ugal", "exporter", "main", 1},
+	}
+
+	for _, b := range branches {
+		collector.metrics.GitHubBranchBuildStatus.With(prometheus.Labels{
+			"org":    b.org,
+			"repo":   b.repo,
+			"branch": b.branch,
+		}).Set(b.status)
+	}
+
+	// A repository that is no longer monitored
+	collector.metrics.GitHubRepoBuildStatus.With(prometheus.Labels{"org": "acme", "repo": "gone"}).Set(0)
+
+	collector.setBuildStatusRollups()
+
+	repos := map[string]float64{"api": 0, "web": 1}
+	for repo, expected := range repos {
+		if got := testutil.ToFloat64(collector.metrics.GitHubRepoBuildStatus.With(prometheus.Labels{"org": "acme", "repo": repo})); got != expected {
+			t.Errorf("Expected acme/%s to have status %v, got %v", repo, expected, got)
+		}
+	}
+
+	if got := testutil.CollectAndCount(collector.metrics.GitHubRepoBuildStatus); got != 3 {
+		t.Errorf("Expected 3 repositories, got %d", got)
+	}
+
+	orgs := map[string]float64{"acme": 1, "d0ugal": 0}
+	for org, expected := range orgs {
+		if got := testutil.ToFloat64(collector.metrics.GitHubOrgFailingBuilds.With(prometheus.Labels{"org": org})); got != expected {
+			t.Errorf("Expected %v failing repositories in %s, got %v", expected, org, got)
+		}
+	}
+}
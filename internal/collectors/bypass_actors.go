@@ -0,0 +1,140 @@
+package collectors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/go-github/v76/github"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// setBypassActorMetrics exports the actors allowed to bypass the active rulesets
+// of a repository and the pull request requirements of its configured branches'
+// protection. GitHub only returns bypass lists to tokens with admin access, so
+// other tokens see none.
+func (gc *GitHubCollector) setBypassActorMetrics(ctx context.Context, owner, repo string) error {
+	errs := []error{gc.setRulesetBypassActorMetrics(ctx, owner, repo)}
+
+	for _, branch := range gc.config.GitHub.Branches {
+		errs = append(errs, gc.setBranchBypassActorMetrics(ctx, owner, repo, branch))
+	}
+
+	return errors.Join(errs...)
+}
+
+// setRulesetBypassActorMetrics counts the distinct actors allowed to bypass any
+// active ruleset of a repository, by actor type
+func (gc *GitHubCollector) setRulesetBypassActorMetrics(ctx context.Context, owner, repo string) error {
+	rulesets, err := gc.listRulesets(ctx, owner, repo)
+	if err != nil {
+		logError("Failed to list rulesets", err)
+		return err
+	}
+
+	type actor struct {
+		kind github.BypassActorType
+		id   int64
+	}
+
+	actors := make(map[actor]bool)
+
+	for _, ruleset := range rulesets {
+		if ruleset.Enforcement != github.RulesetEnforcementActive {
+			continue
+		}
+
+		// The list endpoint omits bypass actors, so they are read per ruleset
+		full, err := gc.getRuleset(ctx, owner, repo, ruleset.GetID())
+		if err != nil {
+			logError("Failed to get ruleset", err, "ruleset", ruleset.Name)
+			return err
+		}
+
+		for _, bypass := range full.BypassActors {
+			if bypass.ActorType != nil {
+				actors[actor{kind: *bypass.ActorType, id: bypass.GetActorID()}] = true
+			}
+		}
+	}
+
+	counts := make(map[github.BypassActorType]int)
+	for a := range actors {
+		counts[a.kind]++
+	}
+
+	labels := prometheus.Labels{"org": owner, "repo": repo}
+
+	// Counts are rebuilt every cycle so actor types no longer allowed disappear
+	gc.metrics.GitHubRulesetBypassActors.DeletePartialMatch(labels)
+
+	for kind, count := range counts {
+		gc.metrics.GitHubRulesetBypassActors.With(prometheus.Labels{
+			"org":        owner,
+			"repo":       repo,
+			"actor_type": string(kind),
+		}).Set(float64(count))
+	}
+
+	return nil
+}
+
+// setBranchBypassActorMetrics counts the users, teams and apps allowed to bypass
+// the pull request requirements of a branch's protection. Branches that are not
+// protected or don't require pull requests have no series.
+func (gc *GitHubCollector) setBranchBypassActorMetrics(ctx context.Context, owner, repo, branch string) error {
+	protection, err := gc.branchProtection(ctx, owner, repo, branch)
+	if err != nil {
+		logError("Failed to get branch protection", err, "branch", branch)
+		return err
+	}
+
+	labels := prometheus.Labels{"org": owner, "repo": repo, "branch": branch}
+	gc.metrics.GitHubBranchBypassActors.DeletePartialMatch(labels)
+
+	allowances := protection.GetRequiredPullRequestReviews().GetBypassPullRequestAllowances()
+	if allowances == nil {
+		return nil
+	}
+
+	for kind, count := range map[string]int{
+		"User":        len(allowances.Users),
+		"Team":        len(allowances.Teams),
+		"Integration": len(allowances.Apps),
+	} {
+		gc.metrics.GitHubBranchBypassActors.With(prometheus.Labels{
+			"org":        owner,
+			"repo":       repo,
+			"branch":     branch,
+			"actor_type": kind,
+		}).Set(float64(count))
+	}
+
+	return nil
+}
+
+// branchProtection returns the protection of a branch, or nil if the branch is
+// not protected or doesn't exist
+func (gc *GitHubCollector) branchProtection(ctx context.Context, owner, repo, branch string) (*github.Protection, error) {
+	t := target{Org: owner, Repo: repo, Branch: branch}
+
+	// Wait for rate limiter
+	if err := gc.limiter.Wait(ctx); err != nil {
+		return nil, wrapAPIError("branch_protection", t, fmt.Errorf("rate limiter error: %w", err))
+	}
+
+	reqCtx, cancel := gc.requestContext(ctx, "branch_protection")
+	protection, _, err := gc.api.GetBranchProtection(reqCtx, owner, repo, branch)
+	cancel()
+	if err != nil {
+		// Also returned when the token may not read the branch protection
+		if _, errorType := classifyAPIError(err); errorType == "not_found" {
+			return nil, nil
+		}
+
+		gc.recordAPIError("branch_protection", err)
+		return nil, wrapAPIError("branch_protection", t, err)
+	}
+
+	return protection, nil
+}
@@ -0,0 +1,65 @@
+package collectors
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// TestSetBypassActorMetrics tests counting the distinct bypass actors of active
+// rulesets and the bypass allowances of branch protection
+func TestSetBypassActorMetrics(t *testing.T) {
+	collector := newAPITestCollector(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/d0ugal/private/rulesets":
+			_, _ = w.Write([]byte(`[
+				{"id": 1, "name": "main", "target": "branch", "source_type": "Repository", "enforcement": "active"},
+				{"id": 2, "name": "org tags", "target": "tag", "source_type": "Organization", "enforcement": "active"},
+				{"id": 3, "name": "trial", "target": "branch", "source_type": "Repository", "enforcement": "evaluate"}
+			]`))
+		case "/repos/d0ugal/private/rulesets/1":
+			_, _ = w.Write([]byte(`{"id": 1, "name": "main", "enforcement": "active", "bypass_actors": [
+				{"actor_id": 1, "actor_type": "OrganizationAdmin", "bypass_mode": "always"},
+				{"actor_id": 42, "actor_type": "Team", "bypass_mode": "always"},
+				{"actor_id": 7, "actor_type": "Integration", "bypass_mode": "pull_request"}
+			]}`))
+		case "/repos/d0ugal/private/rulesets/2":
+			_, _ = w.Write([]byte(`{"id": 2, "name": "org tags", "enforcement": "active", "bypass_actors": [
+				{"actor_id": 42, "actor_type": "Team", "bypass_mode": "always"},
+				{"actor_id": 43, "actor_type": "Team", "bypass_mode": "always"}
+			]}`))
+		case "/repos/d0ugal/private/branches/main/protection":
+			_, _ = w.Write([]byte(`{"required_pull_request_reviews": {"bypass_pull_request_allowances": {
+				"users": [{"login": "d0ugal"}, {"login": "octocat"}],
+				"teams": [{"slug": "admins"}],
+				"apps": []
+			}}}`))
+		case "/repos/d0ugal/private/branches/develop/protection":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "Branch not protected"}`))
+		default:
+			t.Errorf("Unexpected request path %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	collector.config.GitHub.Branches = []string{"main", "develop"}
+
+	collector.setBypassActorMetrics(t.Context(), "d0ugal", "private")
+
+	for actorType, want := range map[string]float64{"OrganizationAdmin": 1, "Team": 2, "Integration": 1} {
+		if got := testutil.ToFloat64(collector.metrics.GitHubRulesetBypassActors.WithLabelValues("d0ugal", "private", actorType)); got != want {
+			t.Errorf("Expected %v %s ruleset bypass actors, got %v", want, actorType, got)
+		}
+	}
+
+	for actorType, want := range map[string]float64{"User": 2, "Team": 1, "Integration": 0} {
+		if got := testutil.ToFloat64(collector.metrics.GitHubBranchBypassActors.WithLabelValues("d0ugal", "private", "main", actorType)); got != want {
+			t.Errorf("Expected %v %s branch bypass actors, got %v", want, actorType, got)
+		}
+	}
+
+	if got := testutil.CollectAndCount(collector.metrics.GitHubBranchBypassActors); got != 3 {
+		t.Errorf("Expected no series for the unprotected branch, got %d series", got)
+	}
+}
@@ -0,0 +1,34 @@
+package collectors
+
+import (
+	"github.com/google/go-github/v76/github"
+)
+
+// latestCheckRuns returns the most recent run of each check. A check is re-run
+// in a new check suite, and check run IDs increase over time, so the run with
+// the highest ID is the latest one. Checks are told apart by name rather than
+// by app, as GitHub Actions creates a check suite per workflow under one app.
+func latestCheckRuns(runs []*github.CheckRun) []*github.CheckRun {
+	latest := make(map[string]*github.CheckRun)
+	order := make([]string, 0, len(runs))
+
+	for _, run := range runs {
+		name := run.GetName()
+
+		current, ok := latest[name]
+		if !ok {
+			order = append(order, name)
+		}
+
+		if !ok || run.GetID() > current.GetID() {
+			latest[name] = run
+		}
+	}
+
+	filtered := make([]*github.CheckRun, 0, len(order))
+	for _, name := range order {
+		filtered = append(filtered, latest[name])
+	}
+
+	return filtered
+}
@@ -0,0 +1,76 @@
+package collectors
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/google/go-github/v76/github"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// TestCollectCheckRunsLatestCheckSuite tests that only the latest run of each
+// check is reported when enabled, and superseded series are deleted
+func TestCollectCheckRunsLatestCheckSuite(t *testing.T) {
+	checkRun := func(name, conclusion string, id, suite int64) *github.CheckRun {
+		return &github.CheckRun{
+			ID:         github.Ptr(id),
+			Name:       github.Ptr(name),
+			Status:     github.Ptr("completed"),
+			Conclusion: github.Ptr(conclusion),
+			App:        &github.App{ID: github.Ptr(int64(1))}, // GitHub Actions, one suite per workflow
+			CheckSuite: &github.CheckSuite{ID: github.Ptr(suite)},
+		}
+	}
+
+	runs := []*github.CheckRun{
+		checkRun("build", "success", 300, 30),
+		checkRun("build", "failure", 100, 10), // Superseded by the re-run in suite 30
+		checkRun("lint", "failure", 200, 20),
+	}
+
+	tests := []struct {
+		name     string
+		latest   bool
+		expected []string
+	}{
+		{"all suites", false, []string{"build/success", "build/failure", "lint/failure"}},
+		{"latest suite", true, []string{"build/success", "lint/failure"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector, api := newFakeCollector()
+			collector.config.GitHub.BuildStatus.LatestCheckSuite = tt.latest
+			api.checkRuns["d0ugal/github-exporter@main"] = runs
+
+			// Reported by an earlier cycle, before the check was renamed
+			collector.metrics.GitHubCheckRunStatus.WithLabelValues("d0ugal", "github-exporter", "test", "main", "success").Set(1)
+
+			if err := collector.collectCheckRuns(t.Context(), "d0ugal", "github-exporter", "main"); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			var names []string
+			for _, s := range matchingSeries(collector.metrics.GitHubCheckRunStatus, prometheus.Labels{"branch": "main"}) {
+				if s.labels["check_name"] != "test" {
+					names = append(names, s.labels["check_name"]+"/"+s.labels["conclusion"])
+				}
+			}
+
+			if len(names) != len(tt.expected) {
+				t.Fatalf("Expected check runs %v, got %v", tt.expected, names)
+			}
+
+			for _, name := range tt.expected {
+				if !slices.Contains(names, name) {
+					t.Errorf("Expected check run %s to be reported, got %v", name, names)
+				}
+			}
+
+			stale := len(matchingSeries(collector.metrics.GitHubCheckRunStatus, prometheus.Labels{"check_name": "test"}))
+			if tt.latest == (stale != 0) {
+				t.Errorf("Expected the series of the renamed check to be deleted only for the latest suite, got %d", stale)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/v76/github"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// codeownersPaths are the locations GitHub reads a CODEOWNERS file from, in order of precedence
+var codeownersPaths = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+// countCodeownersRules counts the rules in a CODEOWNERS file, skipping blank
+// lines and comments
+func countCodeownersRules(content string) int {
+	rules := 0
+
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		rules++
+	}
+
+	return rules
+}
+
+// setCodeownersMetrics exports whether a repository has a CODEOWNERS file, how
+// many rules it contains and, if enabled, how many errors GitHub found in it
+func (gc *GitHubCollector) setCodeownersMetrics(ctx context.Context, owner, repo string) error {
+	labels := prometheus.Labels{"org": owner, "repo": repo}
+
+	content, found, err := gc.getFirstFile(ctx, owner, repo, codeownersPaths)
+	if err != nil {
+		logError("Failed to get CODEOWNERS file", err)
+		return err
+	}
+
+	if !found {
+		gc.metrics.GitHubCodeownersExists.With(labels).Set(0)
+		gc.metrics.GitHubCodeownersRules.With(labels).Set(0)
+
+		return nil
+	}
+
+	gc.metrics.GitHubCodeownersExists.With(labels).Set(1)
+	gc.metrics.GitHubCodeownersRules.With(labels).Set(float64(countCodeownersRules(content)))
+
+	if !gc.config.Collectors.CodeownersErrorsEnabled() {
+		return nil
+	}
+
+	errorCount, err := gc.countCodeownersErrors(ctx, owner, repo)
+	if err != nil {
+		logError("Failed to get CODEOWNERS errors", err)
+		return err
+	}
+
+	gc.metrics.GitHubCodeownersErrors.With(labels).Set(float64(errorCount))
+
+	return nil
+}
+
+// countCodeownersErrors returns the number of errors GitHub reports for the
+// repository's CODEOWNERS file
+func (gc *GitHubCollector) countCodeownersErrors(ctx context.Context, owner, repo string) (int, error) {
+	t := target{Org: owner, Repo: repo}
+
+	// Wait for rate limiter
+	if err := gc.limiter.Wait(ctx); err != nil {
+		return 0, wrapAPIError("codeowners_errors", t, fmt.Errorf("rate limiter error: %w", err))
+	}
+
+	reqCtx, cancel := gc.requestContext(ctx, "codeowners_errors")
+	codeownersErrors, _, err := gc.api.GetCodeownersErrors(reqCtx, owner, repo, &github.GetCodeownersErrorsOptions{})
+	cancel()
+	if err != nil {
+		gc.recordAPIError("codeowners_errors", err)
+		return 0, wrapAPIError("codeowners_errors", t, err)
+	}
+
+	return len(codeownersErrors.Errors), nil
+}
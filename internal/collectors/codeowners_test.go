@@ -0,0 +1,75 @@
+package collectors
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// TestCountCodeownersRules tests counting rules while skipping comments and blank lines
+func TestCountCodeownersRules(t *testing.T) {
+	content := "# Owners\n\n*       @d0ugal\n/docs/ @d0ugal/docs # docs team\n  \n*.go @d0ugal/go\n"
+
+	if got := countCodeownersRules(content); got != 3 {
+		t.Errorf("Expected 3 rules, got %d", got)
+	}
+}
+
+// TestSetCodeownersMetrics tests finding CODEOWNERS in a fallback location and reading its errors
+func TestSetCodeownersMetrics(t *testing.T) {
+	content := base64.StdEncoding.EncodeToString([]byte("* @d0ugal\n/docs/ @unknown\n"))
+
+	collector := newAPITestCollector(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/d0ugal/private/contents/.github/CODEOWNERS":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+		case "/repos/d0ugal/private/contents/CODEOWNERS":
+			_, _ = fmt.Fprintf(w, `{"type": "file", "encoding": "base64", "content": %q}`, content)
+		case "/repos/d0ugal/private/codeowners/errors":
+			_, _ = w.Write([]byte(`{"errors": [{"line": 2, "kind": "Unknown owner", "path": "CODEOWNERS"}]}`))
+		default:
+			t.Errorf("Unexpected request path %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	enabled := true
+	collector.config.Collectors.Codeowners = &enabled
+	collector.config.Collectors.CodeownersErrors = &enabled
+
+	collector.setCodeownersMetrics(t.Context(), "d0ugal", "private")
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubCodeownersExists.WithLabelValues("d0ugal", "private")); got != 1 {
+		t.Errorf("Expected CODEOWNERS to exist, got %v", got)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubCodeownersRules.WithLabelValues("d0ugal", "private")); got != 2 {
+		t.Errorf("Expected 2 rules, got %v", got)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubCodeownersErrors.WithLabelValues("d0ugal", "private")); got != 1 {
+		t.Errorf("Expected 1 error, got %v", got)
+	}
+}
+
+// TestSetCodeownersMetricsMissing tests repositories without a CODEOWNERS file
+func TestSetCodeownersMetricsMissing(t *testing.T) {
+	collector := newAPITestCollector(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+	})
+
+	collector.setCodeownersMetrics(t.Context(), "d0ugal", "private")
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubCodeownersExists.WithLabelValues("d0ugal", "private")); got != 0 {
+		t.Errorf("Expected CODEOWNERS to be missing, got %v", got)
+	}
+
+	if got := testutil.CollectAndCount(collector.metrics.GitHubAPIErrorsTotal); got != 0 {
+		t.Errorf("Expected missing files not to be recorded as API errors, got %d", got)
+	}
+}
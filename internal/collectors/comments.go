@@ -0,0 +1,124 @@
+package collectors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/go-github/v76/github"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// maxActivityPages bounds the pages of incremental activity (comments, issues)
+// listed per repository and cycle
+const maxActivityPages = 10
+
+// inWindow reports whether ts lies in [since, now)
+func inWindow(ts *github.Timestamp, since, now time.Time) bool {
+	if ts == nil {
+		return false
+	}
+
+	return !ts.Before(since) && ts.Before(now)
+}
+
+// setCommentMetrics counts issue and pull request review comments created since
+// the previous cycle
+func (gc *GitHubCollector) setCommentMetrics(ctx context.Context, owner, repo string) error {
+	t := target{Org: owner, Repo: repo}
+	now := time.Now()
+
+	labels := prometheus.Labels{"org": owner, "repo": repo}
+
+	var errs []error
+
+	key := "issue_comments:" + t.String()
+	if since, ok := gc.activity.advance(key, now); ok {
+		count, err := gc.countIssueComments(ctx, owner, repo, since, now)
+		if err != nil {
+			gc.activity.rewind(key, since)
+			logError("Failed to list issue comments", err)
+			errs = append(errs, err)
+		} else {
+			gc.metrics.GitHubIssueCommentsTotal.With(labels).Add(float64(count))
+		}
+	} else {
+		gc.metrics.GitHubIssueCommentsTotal.With(labels).Add(0)
+	}
+
+	key = "review_comments:" + t.String()
+	if since, ok := gc.activity.advance(key, now); ok {
+		count, err := gc.countReviewComments(ctx, owner, repo, since, now)
+		if err != nil {
+			gc.activity.rewind(key, since)
+			logError("Failed to list pull request review comments", err)
+			errs = append(errs, err)
+		} else {
+			gc.metrics.GitHubPRReviewCommentsTotal.With(labels).Add(float64(count))
+		}
+	} else {
+		gc.metrics.GitHubPRReviewCommentsTotal.With(labels).Add(0)
+	}
+
+	return errors.Join(errs...)
+}
+
+// countIssueComments counts the issue and pull request conversation comments created in [since, now)
+func (gc *GitHubCollector) countIssueComments(ctx context.Context, owner, repo string, since, now time.Time) (int, error) {
+	comments, err := gc.listIssueCommentsSince(ctx, owner, repo, since)
+	if err != nil {
+		return 0, err
+	}
+
+	// since filters on the update time, so edited older comments are skipped here
+	count := 0
+
+	for _, comment := range comments {
+		if inWindow(comment.CreatedAt, since, now) {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// countReviewComments counts the pull request review comments created in [since, now)
+func (gc *GitHubCollector) countReviewComments(ctx context.Context, owner, repo string, since, now time.Time) (int, error) {
+	t := target{Org: owner, Repo: repo}
+	opts := &github.PullRequestListCommentsOptions{
+		Since:       since,
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+
+	count := 0
+
+	for page := 0; page < maxActivityPages; page++ {
+		// Wait for rate limiter
+		if err := gc.limiter.Wait(ctx); err != nil {
+			return 0, wrapAPIError("pr_review_comments", t, fmt.Errorf("rate limiter error: %w", err))
+		}
+
+		reqCtx, cancel := gc.requestContext(ctx, "pr_review_comments")
+		comments, resp, err := gc.api.ListReviewComments(reqCtx, owner, repo, opts)
+		cancel()
+		if err != nil {
+			gc.recordAPIError("pr_review_comments", err)
+			return 0, wrapAPIError("pr_review_comments", t, err)
+		}
+
+		for _, comment := range comments {
+			if inWindow(comment.CreatedAt, since, now) {
+				count++
+			}
+		}
+
+		if resp == nil || resp.NextPage == 0 {
+			break
+		}
+
+		opts.Page = resp.NextPage
+	}
+
+	return count, nil
+}
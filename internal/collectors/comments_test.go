@@ -0,0 +1,60 @@
+package collectors
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// TestSetCommentMetrics tests counting comments created since the previous cycle
+func TestSetCommentMetrics(t *testing.T) {
+	var created string
+
+	collector := newAPITestCollector(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("since") == "" {
+			t.Errorf("Expected since parameter on %s", r.URL)
+		}
+
+		switch r.URL.Path {
+		case "/repos/d0ugal/private/issues/comments":
+			// The second comment was created before the window and only edited since
+			_, _ = fmt.Fprintf(w, `[{"id": 1, "created_at": %q}, {"id": 2, "created_at": "2020-01-01T00:00:00Z"}]`, created)
+		case "/repos/d0ugal/private/pulls/comments":
+			_, _ = fmt.Fprintf(w, `[{"id": 3, "created_at": %q}]`, created)
+		default:
+			t.Errorf("Unexpected request path %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	// Seed the previous cycle
+	collector.activity.advance("issue_comments:d0ugal/private", time.Now().Add(-time.Hour))
+	collector.activity.advance("review_comments:d0ugal/private", time.Now().Add(-time.Hour))
+
+	created = time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)
+	collector.setCommentMetrics(t.Context(), "d0ugal", "private")
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubIssueCommentsTotal.WithLabelValues("d0ugal", "private")); got != 1 {
+		t.Errorf("Expected 1 issue comment, got %v", got)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubPRReviewCommentsTotal.WithLabelValues("d0ugal", "private")); got != 1 {
+		t.Errorf("Expected 1 review comment, got %v", got)
+	}
+}
+
+// TestSetCommentMetricsFirstCycle tests that the first cycle only seeds the tracker
+func TestSetCommentMetricsFirstCycle(t *testing.T) {
+	collector := newAPITestCollector(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Unexpected request %s", r.URL)
+	})
+
+	collector.setCommentMetrics(t.Context(), "d0ugal", "private")
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubIssueCommentsTotal.WithLabelValues("d0ugal", "private")); got != 0 {
+		t.Errorf("Expected no issue comments on the first cycle, got %v", got)
+	}
+}
@@ -0,0 +1,100 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/google/go-github/v76/github"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// commitsPerCycle bounds the number of commits listed per branch and cycle. When
+// more commits were pushed since the last cycle, only this many are counted.
+const commitsPerCycle = 100
+
+// commitTracker remembers the head commit seen on each branch in the previous cycle
+type commitTracker struct {
+	mu    sync.Mutex
+	heads map[string]string // "org/repo@branch" -> head SHA
+}
+
+func newCommitTracker() *commitTracker {
+	return &commitTracker{
+		heads: make(map[string]string),
+	}
+}
+
+// observe records the commits currently on a branch (newest first) and returns how
+// many are new since the previous observation. The first observation of a branch
+// only seeds the tracker.
+func (ct *commitTracker) observe(branch string, commits []*github.RepositoryCommit) int {
+	if len(commits) == 0 {
+		return 0
+	}
+
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+
+	previous, seen := ct.heads[branch]
+	ct.heads[branch] = commits[0].GetSHA()
+
+	if !seen {
+		return 0
+	}
+
+	for i, commit := range commits {
+		if commit.GetSHA() == previous {
+			return i
+		}
+	}
+
+	// The previous head is further back than one page, or was force-pushed away
+	return len(commits)
+}
+
+// rename moves the branch heads of a repository that was renamed or transferred
+func (ct *commitTracker) rename(from, to string) {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+
+	renameRepoKeys(ct.heads, from, to)
+}
+
+// collectBranchCommits counts the commits pushed to a branch since the last cycle
+func (gc *GitHubCollector) collectBranchCommits(ctx context.Context, owner, repo, branch string) error {
+	t := target{Org: owner, Repo: repo, Branch: branch}
+
+	// Wait for rate limiter
+	if err := gc.limiter.Wait(ctx); err != nil {
+		return wrapAPIError("commits", t, fmt.Errorf("rate limiter error: %w", err))
+	}
+
+	reqCtx, cancel := gc.requestContext(ctx, "commits")
+	commits, _, err := gc.api.ListCommits(reqCtx, owner, repo, &github.CommitsListOptions{
+		SHA: branch,
+		ListOptions: github.ListOptions{
+			PerPage: commitsPerCycle,
+		},
+	})
+	cancel()
+	if err != nil {
+		gc.recordAPIError("commits", err)
+		return wrapAPIError("commits", t, fmt.Errorf("failed to list commits: %w", err))
+	}
+
+	newCommits := gc.commits.observe(t.String(), commits)
+
+	gc.metrics.GitHubBranchCommitsTotal.With(prometheus.Labels{
+		"org":    owner,
+		"repo":   repo,
+		"branch": branch,
+	}).Add(float64(newCommits))
+
+	if newCommits > 0 {
+		slog.Debug("New commits on branch", "org", owner, "repo", repo, "branch", branch, "count", newCommits)
+	}
+
+	return nil
+}
@@ -0,0 +1,40 @@
+package collectors
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// TestCollectBranchCommits tests counting new commits on a branch between cycles
+func TestCollectBranchCommits(t *testing.T) {
+	pages := []string{
+		`[{"sha": "b"}, {"sha": "a"}]`,
+		`[{"sha": "d"}, {"sha": "c"}, {"sha": "b"}, {"sha": "a"}]`,
+		`[{"sha": "e"}]`,
+	}
+
+	var requests int
+
+	collector := newAPITestCollector(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/d0ugal/private/commits" || r.URL.Query().Get("sha") != "main" {
+			t.Errorf("Unexpected request %s", r.URL)
+		}
+
+		_, _ = w.Write([]byte(pages[requests]))
+		requests++
+	})
+
+	counter := collector.metrics.GitHubBranchCommitsTotal.WithLabelValues("d0ugal", "private", "main")
+
+	for i, expected := range []float64{0, 2, 3} {
+		if err := collector.collectBranchCommits(t.Context(), "d0ugal", "private", "main"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if got := testutil.ToFloat64(counter); got != expected {
+			t.Errorf("Cycle %d: expected %v commits, got %v", i, expected, got)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package collectors
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// communityFileDirs are the directories GitHub reads community health files from
+var communityFileDirs = []string{"", ".github", "docs"}
+
+// communityFiles are the exported community health files, by file label
+var communityFiles = []string{"issue_template", "pull_request_template", "contributing"}
+
+// setCommunityFileMetrics exports whether a repository has issue templates, a
+// pull request template and a contributing guide
+func (gc *GitHubCollector) setCommunityFileMetrics(ctx context.Context, owner, repo string, pushedAt time.Time) error {
+	present, err := gc.findCommunityFiles(ctx, owner, repo, pushedAt)
+	if err != nil {
+		logError("Failed to list community health files", err)
+		return err
+	}
+
+	for _, file := range communityFiles {
+		gc.metrics.GitHubCommunityFilePresent.With(prometheus.Labels{
+			"org":  owner,
+			"repo": repo,
+			"file": file,
+		}).Set(boolToFloat(present[file]))
+	}
+
+	return nil
+}
+
+// findCommunityFiles reports which community health files are present in the
+// directories they are read from. Names are matched case-insensitively with
+// any extension, like GitHub does.
+func (gc *GitHubCollector) findCommunityFiles(ctx context.Context, owner, repo string, pushedAt time.Time) (map[string]bool, error) {
+	present := make(map[string]bool, len(communityFiles))
+
+	for _, dir := range communityFileDirs {
+		files, err := gc.cachedDirectory(ctx, owner, repo, dir, pushedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, file := range files {
+			name := strings.ToLower(file.name)
+			base, _, _ := strings.Cut(name, ".")
+
+			switch {
+			case file.kind == "dir":
+				// Multiple templates are kept in a directory of their own
+				if dir == ".github" && (name == "issue_template" || name == "pull_request_template") {
+					present[name] = true
+				}
+			case base == "issue_template", base == "pull_request_template", base == "contributing":
+				present[base] = true
+			}
+		}
+	}
+
+	return present, nil
+}
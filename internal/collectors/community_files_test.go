@@ -0,0 +1,91 @@
+package collectors
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// TestSetCommunityFileMetrics tests detecting community health files in the
+// root, .github and docs directories, and that listings are cached until a push
+func TestSetCommunityFileMetrics(t *testing.T) {
+	calls := 0
+
+	collector := newAPITestCollector(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+
+		switch r.URL.Path {
+		case "/repos/d0ugal/private/contents/", "/repos/d0ugal/private/contents":
+			_, _ = w.Write([]byte(`[
+				{"name": "README.md", "type": "file"},
+				{"name": "Contributing.rst", "type": "file"},
+				{"name": "docs", "type": "dir"}
+			]`))
+		case "/repos/d0ugal/private/contents/.github":
+			_, _ = w.Write([]byte(`[
+				{"name": "ISSUE_TEMPLATE", "type": "dir"},
+				{"name": "workflows", "type": "dir"}
+			]`))
+		case "/repos/d0ugal/private/contents/docs":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+		default:
+			t.Errorf("Unexpected request path %s", r.URL.Path)
+		}
+	})
+
+	pushedAt := time.Now().Add(-time.Hour)
+
+	collector.setCommunityFileMetrics(t.Context(), "d0ugal", "private", pushedAt)
+
+	for file, expected := range map[string]float64{
+		"issue_template":        1,
+		"pull_request_template": 0,
+		"contributing":          1,
+	} {
+		if got := testutil.ToFloat64(collector.metrics.GitHubCommunityFilePresent.WithLabelValues("d0ugal", "private", file)); got != expected {
+			t.Errorf("Expected %s present=%v, got %v", file, expected, got)
+		}
+	}
+
+	if calls != 3 {
+		t.Errorf("Expected 3 directory listings, got %d", calls)
+	}
+
+	collector.setCommunityFileMetrics(t.Context(), "d0ugal", "private", pushedAt)
+
+	if calls != 3 {
+		t.Errorf("Expected cached listings without a push, got %d calls", calls)
+	}
+
+	collector.setCommunityFileMetrics(t.Context(), "d0ugal", "private", time.Now())
+
+	if calls != 6 {
+		t.Errorf("Expected the directories to be listed again after a push, got %d calls", calls)
+	}
+}
+
+// TestFindCommunityFilesTemplates tests single-file templates in any supported directory
+func TestFindCommunityFilesTemplates(t *testing.T) {
+	collector := newAPITestCollector(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/d0ugal/private/contents/docs":
+			_, _ = w.Write([]byte(`[{"name": "issue_template.md", "type": "file"}]`))
+		case "/repos/d0ugal/private/contents/.github":
+			_, _ = w.Write([]byte(`[{"name": "pull_request_template.md", "type": "file"}]`))
+		default:
+			_, _ = w.Write([]byte(`[]`))
+		}
+	})
+
+	present, err := collector.findCommunityFiles(t.Context(), "d0ugal", "private", time.Now())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !present["issue_template"] || !present["pull_request_template"] || present["contributing"] {
+		t.Errorf("Unexpected community files %v", present)
+	}
+}
@@ -0,0 +1,143 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v76/github"
+)
+
+// repoFile is an entry of a repository directory listing
+type repoFile struct {
+	name string
+	kind string // "file", "dir", "symlink" or "submodule"
+}
+
+// directoryCache holds directory listings of each repository, so directories
+// are only listed again after the repository was pushed to
+type directoryCache struct {
+	mu      sync.Mutex
+	entries map[string]directoryEntry // "owner/repo/dir"
+}
+
+type directoryEntry struct {
+	pushedAt time.Time
+	files    []repoFile
+}
+
+func (c *directoryCache) get(key string, pushedAt time.Time) ([]repoFile, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || !entry.pushedAt.Equal(pushedAt) {
+		return nil, false
+	}
+
+	return entry.files, true
+}
+
+func (c *directoryCache) set(key string, pushedAt time.Time, files []repoFile) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.entries == nil {
+		c.entries = make(map[string]directoryEntry)
+	}
+
+	c.entries[key] = directoryEntry{pushedAt: pushedAt, files: files}
+}
+
+// getFirstFile returns the content of the first of the given paths that exists in
+// a repository, e.g. the locations GitHub reads a CODEOWNERS file from
+func (gc *GitHubCollector) getFirstFile(ctx context.Context, owner, repo string, paths []string) (string, bool, error) {
+	t := target{Org: owner, Repo: repo}
+
+	for _, path := range paths {
+		// Wait for rate limiter
+		if err := gc.limiter.Wait(ctx); err != nil {
+			return "", false, wrapAPIError("contents", t, fmt.Errorf("rate limiter error: %w", err))
+		}
+
+		reqCtx, cancel := gc.requestContext(ctx, "contents")
+		file, _, _, err := gc.api.GetContents(reqCtx, owner, repo, path, nil)
+		cancel()
+
+		if err != nil {
+			if _, errorType := classifyAPIError(err); errorType == "not_found" {
+				continue
+			}
+
+			gc.recordAPIError("contents", err)
+
+			return "", false, wrapAPIError("contents", t, err)
+		}
+
+		if file == nil {
+			continue
+		}
+
+		content, err := file.GetContent()
+		if err != nil {
+			return "", false, wrapAPIError("contents", t, fmt.Errorf("failed to decode %s: %w", path, err))
+		}
+
+		return content, true, nil
+	}
+
+	return "", false, nil
+}
+
+// cachedDirectory returns the entries of a directory of a repository's default
+// branch, "" being the root, listing it only when the repository was pushed to
+// since it was last listed. Missing directories have no entries.
+func (gc *GitHubCollector) cachedDirectory(ctx context.Context, owner, repo, dir string, pushedAt time.Time) ([]repoFile, error) {
+	key := owner + "/" + repo + "/" + dir
+
+	if files, ok := gc.directories.get(key, pushedAt); ok {
+		return files, nil
+	}
+
+	entries, err := gc.listDirectory(ctx, owner, repo, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]repoFile, 0, len(entries))
+	for _, entry := range entries {
+		files = append(files, repoFile{name: entry.GetName(), kind: entry.GetType()})
+	}
+
+	gc.directories.set(key, pushedAt, files)
+
+	return files, nil
+}
+
+// listDirectory lists the entries of a directory of a repository's default
+// branch, "" being the root. Missing directories have no entries.
+func (gc *GitHubCollector) listDirectory(ctx context.Context, owner, repo, path string) ([]*github.RepositoryContent, error) {
+	t := target{Org: owner, Repo: repo}
+
+	// Wait for rate limiter
+	if err := gc.limiter.Wait(ctx); err != nil {
+		return nil, wrapAPIError("contents", t, fmt.Errorf("rate limiter error: %w", err))
+	}
+
+	reqCtx, cancel := gc.requestContext(ctx, "contents")
+	_, entries, _, err := gc.api.GetContents(reqCtx, owner, repo, path, nil)
+	cancel()
+
+	if err != nil {
+		if _, errorType := classifyAPIError(err); errorType == "not_found" {
+			return nil, nil
+		}
+
+		gc.recordAPIError("contents", err)
+
+		return nil, wrapAPIError("contents", t, err)
+	}
+
+	return entries, nil
+}
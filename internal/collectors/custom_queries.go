@@ -0,0 +1,209 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/d0ugal/github-exporter/internal/config"
+	"github.com/d0ugal/github-exporter/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// newCustomQueryGauges registers a gauge for each user-defined GraphQL query,
+// labelled by the query's label names in sorted order
+func newCustomQueryGauges(queries []config.CustomQuery, registry *metrics.GitHubRegistry) map[string]*prometheus.GaugeVec {
+	gauges := make(map[string]*prometheus.GaugeVec, len(queries))
+
+	for _, query := range queries {
+		labels := make([]string, 0, len(query.Labels))
+		for label := range query.Labels {
+			labels = append(labels, label)
+		}
+
+		sort.Strings(labels)
+
+		gauges[query.Name] = registry.NewCustomQueryGaugeVec(query.Name, query.Help, labels)
+	}
+
+	return gauges
+}
+
+// collectCustomQueryMetrics runs each user-defined GraphQL query and exports the
+// values extracted from its response. A failed query keeps its previous values.
+func (gc *GitHubCollector) collectCustomQueryMetrics(ctx context.Context) error {
+	queries := gc.config.GitHub.CustomQueries
+
+	var failed int
+
+	for _, query := range queries {
+		if err := gc.collectCustomQuery(ctx, query); err != nil {
+			logError("Failed to run custom query", err, "name", query.Name)
+			failed++
+		}
+	}
+
+	if failed > 0 && failed == len(queries) {
+		return fmt.Errorf("failed to run all %d custom queries", len(queries))
+	}
+
+	return nil
+}
+
+// collectCustomQuery runs one user-defined GraphQL query and replaces the series
+// of its gauge with the extracted values
+func (gc *GitHubCollector) collectCustomQuery(ctx context.Context, query config.CustomQuery) error {
+	gauge, ok := gc.customQueries[query.Name]
+	if !ok {
+		return fmt.Errorf("no gauge registered for custom query %s", query.Name)
+	}
+
+	var data interface{}
+	if err := gc.graphql(ctx, "graphql_custom", query.Query, query.Variables, &data); err != nil {
+		return err
+	}
+
+	items := []interface{}{data}
+
+	if query.Items != "" {
+		list, err := lookupPath(data, query.Items)
+		if err != nil {
+			return fmt.Errorf("items: %w", err)
+		}
+
+		if list != nil {
+			if items, ok = list.([]interface{}); !ok {
+				return fmt.Errorf("items: %s is not a list", query.Items)
+			}
+		} else {
+			items = nil
+		}
+	}
+
+	type sample struct {
+		labels prometheus.Labels
+		value  float64
+	}
+
+	samples := make([]sample, 0, len(items))
+
+	for i, item := range items {
+		raw, err := lookupPath(item, query.Value)
+		if err != nil {
+			return fmt.Errorf("item %d: value: %w", i, err)
+		}
+
+		// Missing values, e.g. of inaccessible repositories, produce no series
+		value, ok := toGaugeValue(raw)
+		if !ok {
+			continue
+		}
+
+		labels := make(prometheus.Labels, len(query.Labels))
+		for label, path := range query.Labels {
+			raw, err := lookupPath(item, path)
+			if err != nil {
+				return fmt.Errorf("item %d: label %s: %w", i, label, err)
+			}
+
+			labels[label] = toLabelValue(raw)
+		}
+
+		samples = append(samples, sample{labels: labels, value: value})
+	}
+
+	// Series are rebuilt on every successful run so removed items disappear
+	gauge.Reset()
+
+	for _, s := range samples {
+		gauge.With(s.labels).Set(s.value)
+	}
+
+	return nil
+}
+
+// lookupPath resolves a JSONPath-style path such as "$.repository.issues.nodes[0].title"
+// against decoded JSON. Missing object keys and null values resolve to nil; indexing
+// past the end of a list or into a value of the wrong type is an error.
+func lookupPath(data interface{}, path string) (interface{}, error) {
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "$"), ".")
+	if path == "" {
+		return data, nil
+	}
+
+	current := data
+
+	for _, segment := range strings.Split(path, ".") {
+		key, rest, _ := strings.Cut(segment, "[")
+
+		if key != "" {
+			if current == nil {
+				return nil, nil
+			}
+
+			object, ok := current.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("%s: %q is not an object", path, key)
+			}
+
+			current = object[key]
+		}
+
+		for rest != "" {
+			index, remainder, ok := strings.Cut(rest, "]")
+			if !ok {
+				return nil, fmt.Errorf("%s: unterminated index", path)
+			}
+
+			n, err := strconv.Atoi(index)
+			if err != nil || n < 0 {
+				return nil, fmt.Errorf("%s: invalid index %q", path, index)
+			}
+
+			if current == nil {
+				return nil, nil
+			}
+
+			list, ok := current.([]interface{})
+			if !ok || n >= len(list) {
+				return nil, fmt.Errorf("%s: index %d out of range", path, n)
+			}
+
+			current = list[n]
+			rest = strings.TrimPrefix(remainder, "[")
+		}
+	}
+
+	return current, nil
+}
+
+// toGaugeValue converts a JSON number, boolean or numeric string to a gauge value
+func toGaugeValue(raw interface{}) (float64, bool) {
+	switch v := raw.(type) {
+	case float64:
+		return v, true
+	case bool:
+		return boolToFloat(v), true
+	case string:
+		value, err := strconv.ParseFloat(v, 64)
+		return value, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// toLabelValue converts a JSON value to a label value
+func toLabelValue(raw interface{}) string {
+	switch v := raw.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,138 @@
+package collectors
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/d0ugal/github-exporter/internal/config"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// TestLookupPath tests resolving JSONPath-style paths against decoded JSON
+func TestLookupPath(t *testing.T) {
+	data := map[string]interface{}{
+		"repository": map[string]interface{}{
+			"discussions": map[string]interface{}{"totalCount": float64(12)},
+			"labels": []interface{}{
+				map[string]interface{}{"name": "bug"},
+				map[string]interface{}{"name": "security"},
+			},
+			"license": nil,
+		},
+	}
+
+	tests := []struct {
+		path    string
+		want    interface{}
+		wantErr bool
+	}{
+		{path: "$.repository.discussions.totalCount", want: float64(12)},
+		{path: "repository.discussions.totalCount", want: float64(12)},
+		{path: "$.repository.labels[1].name", want: "security"},
+		{path: "$.repository.license.name", want: nil},
+		{path: "$.repository.missing", want: nil},
+		{path: "$.repository.labels[2].name", wantErr: true},
+		{path: "$.repository.discussions.totalCount.value", wantErr: true},
+		{path: "$.repository.labels[x]", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := lookupPath(data, tt.path)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("lookupPath(%q) error = %v, wantErr %v", tt.path, err, tt.wantErr)
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf("lookupPath(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+// TestCollectCustomQuery tests exporting one series per item with labels and that
+// items missing from a later response disappear
+func TestCollectCustomQuery(t *testing.T) {
+	response := `{"data": {"repository": {"discussionCategories": {"nodes": [
+		{"name": "Q&A", "discussions": {"totalCount": 7}},
+		{"name": "Ideas", "discussions": {"totalCount": 3}}
+	]}}}}`
+
+	collector := newAPITestCollector(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if !strings.Contains(string(body), `"owner":"d0ugal"`) {
+			t.Errorf("Expected query variables to be sent, got %s", body)
+		}
+
+		_, _ = w.Write([]byte(response))
+	})
+
+	query := config.CustomQuery{
+		Name:      "discussions",
+		Query:     "query($owner: String!) { repository(owner: $owner, name: \"github-exporter\") { discussionCategories(first: 25) { nodes { name discussions { totalCount } } } } }",
+		Variables: map[string]interface{}{"owner": "d0ugal"},
+		Items:     "$.repository.discussionCategories.nodes",
+		Value:     "$.discussions.totalCount",
+		Labels:    map[string]string{"category": "$.name"},
+	}
+
+	collector.config.GitHub.CustomQueries = []config.CustomQuery{query}
+	collector.customQueries = newCustomQueryGauges(collector.config.GitHub.CustomQueries, collector.metrics)
+	gauge := collector.customQueries["discussions"]
+
+	if err := collector.collectCustomQueryMetrics(t.Context()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if got := testutil.ToFloat64(gauge.WithLabelValues("Q&A")); got != 7 {
+		t.Errorf("Expected 7 Q&A discussions, got %v", got)
+	}
+
+	if got := testutil.ToFloat64(gauge.WithLabelValues("Ideas")); got != 3 {
+		t.Errorf("Expected 3 Ideas discussions, got %v", got)
+	}
+
+	response = `{"data": {"repository": {"discussionCategories": {"nodes": [
+		{"name": "Q&A", "discussions": {"totalCount": 8}}
+	]}}}}`
+
+	if err := collector.collectCustomQueryMetrics(t.Context()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if got := testutil.CollectAndCount(gauge); got != 1 {
+		t.Errorf("Expected removed categories to disappear, got %d series", got)
+	}
+}
+
+// TestCollectCustomQueryErrors tests that a failed query keeps its previous values
+func TestCollectCustomQueryErrors(t *testing.T) {
+	response := `{"data": {"organization": {"repositories": {"totalCount": 42}}}}`
+
+	collector := newAPITestCollector(t, func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(response))
+	})
+
+	collector.config.GitHub.CustomQueries = []config.CustomQuery{{
+		Name:  "org_repositories",
+		Query: "{ organization(login: \"d0ugal\") { repositories { totalCount } } }",
+		Value: "$.organization.repositories.totalCount",
+	}}
+	collector.customQueries = newCustomQueryGauges(collector.config.GitHub.CustomQueries, collector.metrics)
+	gauge := collector.customQueries["org_repositories"]
+
+	if err := collector.collectCustomQueryMetrics(t.Context()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	response = `{"data": null, "errors": [{"type": "NOT_FOUND", "message": "Could not resolve to an Organization"}]}`
+
+	if err := collector.collectCustomQueryMetrics(t.Context()); err == nil {
+		t.Fatal("Expected an error when every query fails")
+	}
+
+	if got := testutil.ToFloat64(gauge); got != 42 {
+		t.Errorf("Expected the previous value to be kept, got %v", got)
+	}
+}
@@ -0,0 +1,34 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// collectCustomSearchMetrics exports the result count of each user-defined search
+// query. A failed search keeps its previous value.
+func (gc *GitHubCollector) collectCustomSearchMetrics(ctx context.Context) error {
+	searches := gc.config.GitHub.CustomSearches
+
+	var failed int
+
+	for _, search := range searches {
+		total, err := gc.searchTotal(ctx, target{}, search.Query)
+		if err != nil {
+			logError("Failed to run custom search", err, "name", search.Name)
+			failed++
+
+			continue
+		}
+
+		gc.metrics.GitHubCustomSearchResults.With(prometheus.Labels{"name": search.Name}).Set(float64(total))
+	}
+
+	if failed > 0 && failed == len(searches) {
+		return fmt.Errorf("failed to run all %d custom searches", len(searches))
+	}
+
+	return nil
+}
@@ -0,0 +1,35 @@
+package collectors
+
+import (
+	"testing"
+
+	"github.com/d0ugal/github-exporter/internal/config"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// TestCollectCustomSearchMetrics tests exporting the result count of each custom search by name
+func TestCollectCustomSearchMetrics(t *testing.T) {
+	collector, api := newFakeCollector()
+	collector.config.GitHub.CustomSearches = []config.CustomSearch{
+		{Name: "security_issues", Query: "org:d0ugal label:security state:open"},
+		{Name: "stale_prs", Query: "org:d0ugal type:pr state:open updated:<2026-01-01"},
+	}
+
+	api.searchTotals["org:d0ugal label:security state:open"] = 4
+
+	if err := collector.collectCustomSearchMetrics(t.Context()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubCustomSearchResults.WithLabelValues("security_issues")); got != 4 {
+		t.Errorf("Expected 4 security issues, got %v", got)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubCustomSearchResults.WithLabelValues("stale_prs")); got != 0 {
+		t.Errorf("Expected 0 stale PRs, got %v", got)
+	}
+
+	if api.calls["SearchIssues"] != 2 {
+		t.Errorf("Expected one search per query, got %d", api.calls["SearchIssues"])
+	}
+}
@@ -0,0 +1,104 @@
+package collectors
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/google/go-github/v76/github"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// skipTracker remembers the targets that were not reached before the collection
+// deadline so they can be collected first in the next cycle
+type skipTracker struct {
+	mu       sync.Mutex
+	previous map[string]bool // "type:name" -> skipped in the previous cycle
+	current  map[string]bool // "type:name" -> skipped in the current cycle
+}
+
+func newSkipTracker() *skipTracker {
+	return &skipTracker{
+		previous: make(map[string]bool),
+		current:  make(map[string]bool),
+	}
+}
+
+// begin starts a new collection cycle
+func (st *skipTracker) begin() {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	st.previous = st.current
+	st.current = make(map[string]bool)
+}
+
+// skip records that a target was not reached in the current cycle
+func (st *skipTracker) skip(targetType, name string) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	st.current[targetType+":"+name] = true
+}
+
+// skipped returns the number of targets skipped in the current cycle
+func (st *skipTracker) skipped() int {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	return len(st.current)
+}
+
+// wasSkipped reports whether a target was skipped in the previous cycle
+func (st *skipTracker) wasSkipped(targetType, name string) bool {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	return st.previous[targetType+":"+name]
+}
+
+// prioritize returns a copy of items with the targets skipped in the previous cycle
+// moved to the front, keeping the configured order otherwise
+func prioritize[T any](st *skipTracker, targetType string, items []T, name func(T) string) []T {
+	result := append([]T(nil), items...)
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return st.wasSkipped(targetType, name(result[i])) && !st.wasSkipped(targetType, name(result[j]))
+	})
+
+	return result
+}
+
+// pastDeadline reports whether the collection deadline has passed, in which case the
+// target is recorded as skipped so it is prioritized in the next cycle
+func (gc *GitHubCollector) pastDeadline(ctx context.Context, targetType, name string) bool {
+	if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return false
+	}
+
+	gc.skipped.skip(targetType, name)
+
+	// Skipped targets are still monitored
+	switch targetType {
+	case "org":
+		gc.inventory.keep(name, "")
+	case "repo":
+		owner, repo, _ := strings.Cut(name, "/")
+		gc.inventory.keep(owner, repo)
+	}
+	gc.metrics.GitHubCollectionSkipped.With(prometheus.Labels{"reason": "deadline"}).Inc()
+
+	return true
+}
+
+// identity returns a target name unchanged, for prioritizing lists of names
+func identity(name string) string {
+	return name
+}
+
+// repoFullName returns the "owner/repo" name of a discovered repository
+func repoFullName(repo *github.Repository) string {
+	return repo.GetOwner().GetLogin() + "/" + repo.GetName()
+}
@@ -0,0 +1,123 @@
+package collectors
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/d0ugal/promexporter/app"
+	"github.com/google/go-github/v76/github"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// TestPrioritize tests that targets skipped in the previous cycle are moved to the front
+func TestPrioritize(t *testing.T) {
+	tracker := newSkipTracker()
+	tracker.skip("repo", "d0ugal/c")
+	tracker.skip("repo", "d0ugal/b")
+	tracker.begin()
+
+	repos := []string{"d0ugal/a", "d0ugal/b", "d0ugal/c", "d0ugal/d"}
+
+	got := prioritize(tracker, "repo", repos, identity)
+	if expected := []string{"d0ugal/b", "d0ugal/c", "d0ugal/a", "d0ugal/d"}; !slices.Equal(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+
+	if repos[0] != "d0ugal/a" {
+		t.Error("Expected the configured order to be left untouched")
+	}
+
+	tracker.begin()
+
+	if got := prioritize(tracker, "repo", repos, identity); !slices.Equal(got, repos) {
+		t.Errorf("Expected configured order once no targets were skipped, got %v", got)
+	}
+}
+
+// TestCollectDiscoveredReposPastDeadline tests that repositories not reached before the deadline are counted as skipped
+func TestCollectDiscoveredReposPastDeadline(t *testing.T) {
+	collector := newAPITestCollector(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Unexpected request to %s after the deadline", r.URL.Path)
+	})
+
+	ctx, cancel := context.WithDeadline(t.Context(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	repos := []*github.Repository{
+		{Name: github.Ptr("a"), Owner: &github.User{Login: github.Ptr("d0ugal")}},
+		{Name: github.Ptr("b"), Owner: &github.User{Login: github.Ptr("d0ugal")}},
+	}
+
+	if _, err := collector.collectDiscoveredRepos(ctx, repos); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubCollectionSkipped.WithLabelValues("deadline")); got != 2 {
+		t.Errorf("Expected 2 skipped targets, got %v", got)
+	}
+
+	if got := collector.skipped.skipped(); got != 2 {
+		t.Errorf("Expected 2 targets to be prioritized next cycle, got %d", got)
+	}
+}
+
+// TestCollectOrgReposPastDeadline tests that organization repositories not
+// reached before the deadline are skipped rather than failing the organization
+func TestCollectOrgReposPastDeadline(t *testing.T) {
+	collector := newAPITestCollector(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Unexpected request to %s after the deadline", r.URL.Path)
+	})
+	collector.app = app.New("github-exporter")
+
+	repos := []*github.Repository{
+		{Name: github.Ptr("a"), Owner: &github.User{Login: github.Ptr("d0ugal")}},
+		{Name: github.Ptr("b"), Owner: &github.User{Login: github.Ptr("d0ugal")}},
+	}
+
+	// Served from the discovery cache, as listing after the deadline would fail
+	collector.config.GitHub.DiscoveryInterval.Duration = time.Hour
+	collector.discovery.set("org:d0ugal", repos)
+
+	ctx, cancel := context.WithDeadline(t.Context(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	if _, err := collector.collectOrgRepos(ctx, "d0ugal"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubCollectionSkipped.WithLabelValues("deadline")); got != 2 {
+		t.Errorf("Expected 2 skipped targets, got %v", got)
+	}
+
+	if got := collector.skipped.skipped(); got != 2 {
+		t.Errorf("Expected 2 targets to be prioritized next cycle, got %d", got)
+	}
+}
+
+// TestCollectBuildStatusPastDeadline tests that repositories whose slot is past
+// the deadline are skipped rather than ending the build status phase
+func TestCollectBuildStatusPastDeadline(t *testing.T) {
+	collector := createTestCollector()
+	collector.config.GitHub.Repos = []string{"d0ugal/a", "d0ugal/b"}
+	collector.config.GitHub.StaggerTargets = true
+	collector.scheduler = &targetScheduler{next: 2}
+	collector.scheduler.begin(time.Hour, 0)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+	defer cancel()
+
+	if err := collector.collectBuildStatusMetrics(ctx, nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubCollectionSkipped.WithLabelValues("deadline")); got != 1 {
+		t.Errorf("Expected 1 skipped target, got %v", got)
+	}
+
+	if got := collector.skipped.skipped(); got != 1 {
+		t.Errorf("Expected 1 target to be prioritized next cycle, got %d", got)
+	}
+}
@@ -0,0 +1,76 @@
+package collectors
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/d0ugal/github-exporter/internal/redact"
+	"github.com/d0ugal/promexporter/tracing"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// updateDegradedMode switches to degraded mode when the remaining rate limit drops
+// below github.degraded_mode_floor and back once the rate limit has reset. It
+// returns whether the collector is in degraded mode.
+func (gc *GitHubCollector) updateDegradedMode() bool {
+	floor := gc.config.GitHub.DegradedModeFloor
+
+	gc.mu.Lock()
+	degraded := floor > 0 && gc.rateLimitTotal > 0 && gc.rateLimitRemaining < floor && time.Now().Before(gc.rateLimitReset)
+	changed := degraded != gc.degraded
+	gc.degraded = degraded
+	remaining := gc.rateLimitRemaining
+	reset := gc.rateLimitReset
+	gc.mu.Unlock()
+
+	value := 0.0
+	if degraded {
+		value = 1
+	}
+
+	gc.metrics.GitHubExporterDegradedMode.With(prometheus.Labels{}).Set(value)
+
+	if changed && degraded {
+		slog.Warn("Rate limit nearly exhausted, only refreshing high-priority metrics until it resets",
+			"remaining", remaining,
+			"floor", floor,
+			"reset", reset,
+		)
+	} else if changed {
+		slog.Info("Rate limit recovered, leaving degraded mode", "remaining", remaining)
+	}
+
+	return degraded
+}
+
+// collectDegradedMetrics refreshes the build status of priority branches only. The
+// rate limit itself is kept current from the headers of these requests.
+func (gc *GitHubCollector) collectDegradedMetrics(ctx context.Context, collectorSpan *tracing.CollectorSpan) {
+	if collectorSpan != nil {
+		collectorSpan.AddEvent("degraded_mode")
+	}
+
+	branches := gc.config.GitHub.PriorityBranches
+	if len(branches) == 0 || !gc.config.Collectors.BuildStatusEnabled() {
+		return
+	}
+
+	buildStart := time.Now()
+	if err := gc.collectBuildStatusMetrics(ctx, branches); err != nil {
+		slog.Error("Failed to collect priority build status metrics", "error", err)
+		if collectorSpan != nil {
+			collectorSpan.RecordError(redact.Error(err), attribute.String("operation", "collect-priority-build-status"))
+		}
+		gc.recordError("build_status", "collection_error", err)
+
+		return
+	}
+
+	if collectorSpan != nil {
+		collectorSpan.AddEvent("priority_build_status_collected",
+			attribute.Float64("duration_seconds", time.Since(buildStart).Seconds()),
+		)
+	}
+}
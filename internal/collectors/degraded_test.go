@@ -0,0 +1,63 @@
+package collectors
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// TestUpdateDegradedMode tests entering degraded mode below the floor and leaving it after the reset
+func TestUpdateDegradedMode(t *testing.T) {
+	collector := createTestCollector()
+	collector.config.GitHub.DegradedModeFloor = 500
+	collector.rateLimitTotal = 5000
+	collector.rateLimitRemaining = 100
+	collector.rateLimitReset = time.Now().Add(time.Hour)
+
+	if !collector.updateDegradedMode() {
+		t.Error("Expected degraded mode below the floor")
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubExporterDegradedMode); got != 1 {
+		t.Errorf("Expected degraded mode gauge 1, got %v", got)
+	}
+
+	collector.rateLimitRemaining = 5000
+
+	if collector.updateDegradedMode() {
+		t.Error("Expected degraded mode to end once the rate limit recovered")
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubExporterDegradedMode); got != 0 {
+		t.Errorf("Expected degraded mode gauge 0, got %v", got)
+	}
+
+	collector.config.GitHub.DegradedModeFloor = 0
+	collector.rateLimitRemaining = 0
+
+	if collector.updateDegradedMode() {
+		t.Error("Expected degraded mode to be disabled without a floor")
+	}
+}
+
+// TestCalculateRefreshIntervalDegraded tests that degraded mode only budgets for priority branches
+func TestCalculateRefreshIntervalDegraded(t *testing.T) {
+	collector := createTestCollector()
+	collector.config.GitHub.RateLimitBuffer = 1
+	collector.config.GitHub.Repos = []string{"d0ugal/a", "d0ugal/b"}
+	collector.config.GitHub.Branches = []string{"main", "develop"}
+	collector.config.GitHub.PriorityBranches = []string{"main"}
+	collector.rateLimitTotal = 5000
+	collector.rateLimitRemaining = 60
+	collector.rateLimitReset = time.Now().Add(time.Hour)
+
+	normal := collector.calculateRefreshInterval()
+
+	collector.degraded = true
+	degraded := collector.calculateRefreshInterval()
+
+	if degraded >= normal {
+		t.Errorf("Expected a shorter interval in degraded mode, got %s (normal %s)", degraded, normal)
+	}
+}
@@ -0,0 +1,60 @@
+package collectors
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// dependencyAutomationConfigs are the configuration files of each dependency
+// update tool, by directory ("" being the repository root)
+var dependencyAutomationConfigs = map[string]map[string][]string{
+	"renovate": {
+		"":        {"renovate.json", "renovate.json5", ".renovaterc", ".renovaterc.json", ".renovaterc.json5"},
+		".github": {"renovate.json", "renovate.json5"},
+	},
+	"dependabot": {
+		".github": {"dependabot.yml", "dependabot.yaml"},
+	},
+}
+
+// dependencyAutomationTools lists the tools in a stable order
+var dependencyAutomationTools = []string{"dependabot", "renovate"}
+
+// setDependencyAutomationMetrics exports whether a repository is configured for
+// automated dependency updates by Renovate or Dependabot
+func (gc *GitHubCollector) setDependencyAutomationMetrics(ctx context.Context, owner, repo string, pushedAt time.Time) error {
+	dirs := make(map[string][]repoFile)
+
+	for _, dir := range []string{"", ".github"} {
+		files, err := gc.cachedDirectory(ctx, owner, repo, dir, pushedAt)
+		if err != nil {
+			logError("Failed to list dependency automation configuration", err)
+			return err
+		}
+
+		dirs[dir] = files
+	}
+
+	for _, tool := range dependencyAutomationTools {
+		configured := false
+
+		for dir, names := range dependencyAutomationConfigs[tool] {
+			for _, file := range dirs[dir] {
+				if file.kind == "file" && slices.Contains(names, file.name) {
+					configured = true
+				}
+			}
+		}
+
+		gc.metrics.GitHubDependencyAutomation.With(prometheus.Labels{
+			"org":  owner,
+			"repo": repo,
+			"tool": tool,
+		}).Set(boolToFloat(configured))
+	}
+
+	return nil
+}
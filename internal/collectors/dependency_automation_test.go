@@ -0,0 +1,44 @@
+package collectors
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// TestSetDependencyAutomationMetrics tests detecting Renovate and Dependabot
+// configuration, sharing directory listings with other collectors
+func TestSetDependencyAutomationMetrics(t *testing.T) {
+	calls := 0
+
+	collector := newAPITestCollector(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+
+		switch r.URL.Path {
+		case "/repos/d0ugal/private/contents/", "/repos/d0ugal/private/contents":
+			_, _ = w.Write([]byte(`[{"name": "go.mod", "type": "file"}, {"name": ".github", "type": "dir"}]`))
+		case "/repos/d0ugal/private/contents/.github":
+			_, _ = w.Write([]byte(`[{"name": "renovate.json5", "type": "file"}, {"name": "workflows", "type": "dir"}]`))
+		default:
+			t.Errorf("Unexpected request path %s", r.URL.Path)
+		}
+	})
+
+	pushedAt := time.Now()
+
+	collector.setDependencyAutomationMetrics(t.Context(), "d0ugal", "private", pushedAt)
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubDependencyAutomation.WithLabelValues("d0ugal", "private", "renovate")); got != 1 {
+		t.Errorf("Expected renovate to be configured, got %v", got)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubDependencyAutomation.WithLabelValues("d0ugal", "private", "dependabot")); got != 0 {
+		t.Errorf("Expected dependabot not to be configured, got %v", got)
+	}
+
+	if _, err := collector.cachedDirectory(t.Context(), "d0ugal", "private", ".github", pushedAt); err != nil || calls != 2 {
+		t.Errorf("Expected the cached .github listing to be reused, got %d calls (error: %v)", calls, err)
+	}
+}
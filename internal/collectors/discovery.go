@@ -0,0 +1,418 @@
+package collectors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/d0ugal/github-exporter/internal/config"
+	"github.com/google/go-github/v76/github"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Cache keys (and max_repos scopes) for user-level repository discovery
+const (
+	wildcardDiscoveryKey = "*"       // Repositories discovered via "*"
+	starredDiscoveryKey  = "starred" // Repositories starred by the authenticated user
+)
+
+// errMaxReposExceeded is returned when discovery exceeds github.max_repos with the abort policy
+var errMaxReposExceeded = errors.New("repository discovery exceeded github.max_repos")
+
+// discoveryEntry holds the result of a single repository discovery
+type discoveryEntry struct {
+	repos     []*github.Repository
+	fetchedAt time.Time
+}
+
+// discoveryCache caches repository discovery results so that wildcard and
+// organization listings can be refreshed less often than per-repo metrics
+type discoveryCache struct {
+	mu      sync.Mutex
+	entries map[string]discoveryEntry
+}
+
+func newDiscoveryCache() *discoveryCache {
+	return &discoveryCache{
+		entries: make(map[string]discoveryEntry),
+	}
+}
+
+// get returns the cached repositories for key if they are younger than maxAge
+func (dc *discoveryCache) get(key string, maxAge time.Duration) ([]*github.Repository, bool) {
+	if maxAge <= 0 {
+		return nil, false
+	}
+
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+
+	entry, ok := dc.entries[key]
+	if !ok || time.Since(entry.fetchedAt) >= maxAge {
+		return nil, false
+	}
+
+	return entry.repos, true
+}
+
+// set stores the repositories discovered for key
+func (dc *discoveryCache) set(key string, repos []*github.Repository) {
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+
+	dc.entries[key] = discoveryEntry{
+		repos:     repos,
+		fetchedAt: time.Now(),
+	}
+}
+
+// clear drops all cached discoveries, so the next collection lists repositories again
+func (dc *discoveryCache) clear() {
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+
+	dc.entries = make(map[string]discoveryEntry)
+}
+
+// discoverAllRepos returns all repositories the authenticated user has access to.
+// The second return value reports whether the repositories were freshly listed
+// (true) or served from the discovery cache (false).
+func (gc *GitHubCollector) discoverAllRepos(ctx context.Context) ([]*github.Repository, bool, error) {
+	if repos, ok := gc.discovery.get(wildcardDiscoveryKey, gc.config.GitHub.DiscoveryInterval.Duration); ok {
+		slog.Debug("Using cached repository discovery", "count", len(repos))
+		return repos, false, nil
+	}
+
+	// Repositories kept after exclude_repos, skip_archived and skip_forks, which
+	// are the ones counting towards max_repos
+	var allRepos []*github.Repository
+	page := 1
+	perPage := 100
+
+	for {
+		// Wait for rate limiter
+		if err := gc.limiter.Wait(ctx); err != nil {
+			return nil, false, fmt.Errorf("rate limiter error: %w", err)
+		}
+
+		// Get repositories for current page
+		reqCtx, cancel := gc.requestContext(ctx, "repos")
+		repos, resp, err := gc.api.ListUserRepositories(reqCtx, &github.RepositoryListByAuthenticatedUserOptions{
+			Type: "all",
+			ListOptions: github.ListOptions{
+				Page:    page,
+				PerPage: perPage,
+			},
+		})
+		cancel()
+		if err != nil {
+			err = wrapAPIError("repos", target{}, err)
+			logError("Failed to list repositories", err, "page", page)
+			gc.recordAPIError("repos", err)
+			return nil, false, fmt.Errorf("failed to list repositories page %d: %w", page, err)
+		}
+
+		// Add repos to our collection
+		allRepos = append(allRepos, gc.filterSkipped(gc.filterExcluded(repos))...)
+
+		// Check if we've reached the last page
+		if resp == nil || page >= resp.LastPage || len(repos) < perPage {
+			break
+		}
+
+		// Stop paginating once the cap is exceeded rather than enumerating everything
+		if maxRepos := gc.config.GitHub.MaxRepos; maxRepos > 0 && len(allRepos) > maxRepos {
+			break
+		}
+
+		page++
+	}
+
+	allRepos, err := gc.limitRepos(wildcardDiscoveryKey, allRepos)
+	if err != nil {
+		return nil, false, err
+	}
+
+	gc.discovery.set(wildcardDiscoveryKey, allRepos)
+
+	return allRepos, true, nil
+}
+
+// discoverOrgRepos returns the repositories of an organization, using the
+// discovery cache when it is still fresh
+func (gc *GitHubCollector) discoverOrgRepos(ctx context.Context, org string) ([]*github.Repository, bool, *github.Response, error) {
+	key := "org:" + org
+	if repos, ok := gc.discovery.get(key, gc.config.GitHub.DiscoveryInterval.Duration); ok {
+		slog.Debug("Using cached organization repository discovery", "org", org, "count", len(repos))
+		return repos, false, nil, nil
+	}
+
+	opts := &github.RepositoryListByOrgOptions{
+		Type:        "all",
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+
+	var (
+		repos []*github.Repository
+		kept  []*github.Repository // After exclude_repos, skip_archived and skip_forks
+		resp  *github.Response
+	)
+
+	for {
+		// Wait for rate limiter
+		if err := gc.limiter.Wait(ctx); err != nil {
+			return nil, false, nil, fmt.Errorf("rate limiter error: %w", err)
+		}
+
+		reqCtx, cancel := gc.requestContext(ctx, "repos")
+		page, pageResp, err := gc.api.ListOrgRepositories(reqCtx, org, opts)
+		cancel()
+		if err != nil {
+			return nil, false, pageResp, err
+		}
+
+		repos = append(repos, page...)
+		kept = append(kept, gc.filterSkipped(gc.filterExcluded(page))...)
+		resp = pageResp
+
+		if resp == nil || resp.NextPage == 0 {
+			break
+		}
+
+		// Stop paginating once the cap is exceeded rather than enumerating
+		// everything. Only repositories that are collected count towards it, so
+		// a listing cut short always exceeds the cap.
+		if maxRepos := gc.config.GitHub.MaxRepos; maxRepos > 0 && len(kept) > maxRepos {
+			break
+		}
+
+		opts.Page = resp.NextPage
+	}
+
+	// Count repositories created and archived since the previous discovery,
+	// before archived repositories may be skipped
+	gc.observeOrgRepos(org, repos)
+
+	repos, err := gc.limitRepos(org, kept)
+	if err != nil {
+		return nil, false, resp, err
+	}
+
+	if resp == nil || resp.StatusCode != 404 {
+		gc.discovery.set(key, repos)
+	}
+
+	return repos, true, resp, nil
+}
+
+// discoverTeamRepos returns the repositories a team ("org/team-slug") has access to,
+// using the discovery cache when it is still fresh
+func (gc *GitHubCollector) discoverTeamRepos(ctx context.Context, team string) ([]*github.Repository, bool, error) {
+	org, slug, _ := strings.Cut(team, "/")
+
+	key := "team:" + team
+	if repos, ok := gc.discovery.get(key, gc.config.GitHub.DiscoveryInterval.Duration); ok {
+		slog.Debug("Using cached team repository discovery", "team", team, "count", len(repos))
+		return repos, false, nil
+	}
+
+	var allRepos []*github.Repository
+
+	opts := &github.ListOptions{PerPage: 100}
+
+	for {
+		// Wait for rate limiter
+		if err := gc.limiter.Wait(ctx); err != nil {
+			return nil, false, fmt.Errorf("rate limiter error: %w", err)
+		}
+
+		reqCtx, cancel := gc.requestContext(ctx, "team_repos")
+		repos, resp, err := gc.api.ListTeamRepositories(reqCtx, org, slug, opts)
+		cancel()
+		if err != nil {
+			gc.recordAPIError("team_repos", err)
+			return nil, false, wrapAPIError("team_repos", target{Org: org}, fmt.Errorf("failed to list repositories for team %s: %w", slug, err))
+		}
+
+		allRepos = append(allRepos, repos...)
+
+		if resp == nil || resp.NextPage == 0 {
+			break
+		}
+
+		// Stop paginating once the cap is exceeded rather than enumerating everything
+		if maxRepos := gc.config.GitHub.MaxRepos; maxRepos > 0 && len(allRepos) > maxRepos {
+			break
+		}
+
+		opts.Page = resp.NextPage
+	}
+
+	allRepos, err := gc.limitRepos(team, gc.filterExcluded(allRepos))
+	if err != nil {
+		return nil, false, err
+	}
+
+	gc.discovery.set(key, allRepos)
+
+	return allRepos, true, nil
+}
+
+// discoverStarredRepos returns the repositories starred by the authenticated user,
+// using the discovery cache when it is still fresh
+func (gc *GitHubCollector) discoverStarredRepos(ctx context.Context) ([]*github.Repository, bool, error) {
+	if repos, ok := gc.discovery.get(starredDiscoveryKey, gc.config.GitHub.DiscoveryInterval.Duration); ok {
+		slog.Debug("Using cached starred repository discovery", "count", len(repos))
+		return repos, false, nil
+	}
+
+	var allRepos []*github.Repository
+
+	opts := &github.ActivityListStarredOptions{
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+
+	for {
+		// Wait for rate limiter
+		if err := gc.limiter.Wait(ctx); err != nil {
+			return nil, false, fmt.Errorf("rate limiter error: %w", err)
+		}
+
+		reqCtx, cancel := gc.requestContext(ctx, "starred")
+		starred, resp, err := gc.api.ListStarredRepositories(reqCtx, opts)
+		cancel()
+		if err != nil {
+			gc.recordAPIError("starred", err)
+			return nil, false, wrapAPIError("starred", target{}, fmt.Errorf("failed to list starred repositories: %w", err))
+		}
+
+		for _, star := range starred {
+			if star.Repository != nil {
+				allRepos = append(allRepos, star.Repository)
+			}
+		}
+
+		if resp == nil || resp.NextPage == 0 {
+			break
+		}
+
+		// Stop paginating once the cap is exceeded rather than enumerating everything
+		if maxRepos := gc.config.GitHub.MaxRepos; maxRepos > 0 && len(allRepos) > maxRepos {
+			break
+		}
+
+		opts.Page = resp.NextPage
+	}
+
+	allRepos, err := gc.limitRepos(starredDiscoveryKey, gc.filterExcluded(allRepos))
+	if err != nil {
+		return nil, false, err
+	}
+
+	gc.discovery.set(starredDiscoveryKey, allRepos)
+
+	return allRepos, true, nil
+}
+
+// parseRepoPatterns parses the configured repository exclusions. The
+// configuration is validated on load, so patterns that fail to parse are only
+// logged and skipped.
+func parseRepoPatterns(patterns []string) []config.RepoPattern {
+	var parsed []config.RepoPattern
+
+	for _, pattern := range patterns {
+		repoPattern, err := config.ParseRepoPattern(pattern)
+		if err != nil {
+			slog.Error("Ignoring invalid repository exclusion", "pattern", pattern, "error", err)
+			continue
+		}
+
+		parsed = append(parsed, repoPattern)
+	}
+
+	return parsed
+}
+
+// filterExcluded drops discovered repositories matching github.exclude_repos
+func (gc *GitHubCollector) filterExcluded(repos []*github.Repository) []*github.Repository {
+	if len(gc.excludeRepos) == 0 {
+		return repos
+	}
+
+	included := make([]*github.Repository, 0, len(repos))
+
+	for _, repo := range repos {
+		fullName := repoFullName(repo)
+
+		if slices.ContainsFunc(gc.excludeRepos, func(pattern config.RepoPattern) bool {
+			return pattern.Match(fullName)
+		}) {
+			slog.Debug("Skipping excluded repository", "repo", fullName)
+			continue
+		}
+
+		included = append(included, repo)
+	}
+
+	return included
+}
+
+// filterSkipped drops archived and forked repositories found by wildcard and
+// organization discovery when github.skip_archived or github.skip_forks is set
+func (gc *GitHubCollector) filterSkipped(repos []*github.Repository) []*github.Repository {
+	skipArchived, skipForks := gc.config.GitHub.SkipArchived, gc.config.GitHub.SkipForks
+	if !skipArchived && !skipForks {
+		return repos
+	}
+
+	included := make([]*github.Repository, 0, len(repos))
+
+	for _, repo := range repos {
+		if (skipArchived && repo.GetArchived()) || (skipForks && repo.GetFork()) {
+			slog.Debug("Skipping archived or forked repository", "repo", repoFullName(repo))
+			continue
+		}
+
+		included = append(included, repo)
+	}
+
+	return included
+}
+
+// limitRepos applies github.max_repos to the repositories discovered for scope
+// (an organization or "*"), returning errMaxReposExceeded or the truncated list
+// depending on the configured policy
+func (gc *GitHubCollector) limitRepos(scope string, repos []*github.Repository) ([]*github.Repository, error) {
+	maxRepos := gc.config.GitHub.MaxRepos
+	if maxRepos <= 0 {
+		return repos, nil
+	}
+
+	if len(repos) <= maxRepos {
+		gc.metrics.GitHubDiscoveryMaxReposExceeded.With(prometheus.Labels{"scope": scope}).Set(0)
+		return repos, nil
+	}
+
+	gc.metrics.GitHubDiscoveryMaxReposExceeded.With(prometheus.Labels{"scope": scope}).Set(1)
+
+	if gc.config.GitHub.MaxReposPolicy == config.MaxReposPolicyTruncate {
+		slog.Warn("Repository discovery exceeded max_repos, truncating",
+			"scope", scope,
+			"max_repos", maxRepos,
+		)
+
+		return repos[:maxRepos], nil
+	}
+
+	slog.Error("Repository discovery exceeded max_repos, skipping",
+		"scope", scope,
+		"max_repos", maxRepos,
+	)
+
+	return nil, fmt.Errorf("%w: more than %d repositories found for %s", errMaxReposExceeded, maxRepos, scope)
+}
@@ -0,0 +1,320 @@
+package collectors
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/d0ugal/github-exporter/internal/config"
+	"github.com/google/go-github/v76/github"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// TestDiscoveryCache tests that cached discovery results respect the max age
+func TestDiscoveryCache(t *testing.T) {
+	cache := newDiscoveryCache()
+
+	if _, ok := cache.get(wildcardDiscoveryKey, time.Hour); ok {
+		t.Error("Expected empty cache to miss")
+	}
+
+	cache.set(wildcardDiscoveryKey, []*github.Repository{{Name: github.Ptr("test-repo")}})
+
+	repos, ok := cache.get(wildcardDiscoveryKey, time.Hour)
+	if !ok {
+		t.Fatal("Expected cache hit for fresh entry")
+	}
+
+	if len(repos) != 1 || repos[0].GetName() != "test-repo" {
+		t.Errorf("Unexpected cached repos: %v", repos)
+	}
+
+	// A zero interval disables caching entirely
+	if _, ok := cache.get(wildcardDiscoveryKey, 0); ok {
+		t.Error("Expected cache miss with zero discovery interval")
+	}
+
+	// Expired entries are not returned
+	cache.entries[wildcardDiscoveryKey] = discoveryEntry{
+		repos:     repos,
+		fetchedAt: time.Now().Add(-2 * time.Hour),
+	}
+	if _, ok := cache.get(wildcardDiscoveryKey, time.Hour); ok {
+		t.Error("Expected cache miss for expired entry")
+	}
+}
+
+// TestLimitRepos tests the max_repos cap with both policies
+func TestLimitRepos(t *testing.T) {
+	collector := createTestCollector()
+	repos := []*github.Repository{
+		{Name: github.Ptr("a")},
+		{Name: github.Ptr("b")},
+		{Name: github.Ptr("c")},
+	}
+
+	// No cap configured
+	if limited, err := collector.limitRepos("d0ugal", repos); err != nil || len(limited) != 3 {
+		t.Errorf("Expected all repos without a cap, got %d (err %v)", len(limited), err)
+	}
+
+	collector.config.GitHub.MaxRepos = 2
+	collector.config.GitHub.MaxReposPolicy = config.MaxReposPolicyAbort
+
+	if _, err := collector.limitRepos("d0ugal", repos); !errors.Is(err, errMaxReposExceeded) {
+		t.Errorf("Expected errMaxReposExceeded with abort policy, got %v", err)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubDiscoveryMaxReposExceeded.WithLabelValues("d0ugal")); got != 1 {
+		t.Errorf("Expected exceeded metric to be 1, got %v", got)
+	}
+
+	collector.config.GitHub.MaxReposPolicy = config.MaxReposPolicyTruncate
+
+	limited, err := collector.limitRepos("d0ugal", repos)
+	if err != nil {
+		t.Fatalf("Unexpected error with truncate policy: %v", err)
+	}
+
+	if len(limited) != 2 || limited[1].GetName() != "b" {
+		t.Errorf("Expected first 2 repos, got %v", limited)
+	}
+
+	// Within the cap the metric is reset
+	if _, err := collector.limitRepos("d0ugal", repos[:1]); err != nil {
+		t.Errorf("Unexpected error within cap: %v", err)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubDiscoveryMaxReposExceeded.WithLabelValues("d0ugal")); got != 0 {
+		t.Errorf("Expected exceeded metric to be reset, got %v", got)
+	}
+}
+
+// TestDiscoverExcludedRepos tests that repositories matching exclude_repos are
+// dropped from discovery before the max_repos cap is applied
+func TestDiscoverExcludedRepos(t *testing.T) {
+	collector := newAPITestCollector(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[
+			{"name": "github-exporter", "owner": {"login": "d0ugal"}},
+			{"name": "test-fixtures", "owner": {"login": "d0ugal"}},
+			{"name": "linux-mirror", "owner": {"login": "d0ugal"}}
+		]`))
+	})
+	collector.excludeRepos = parseRepoPatterns([]string{"*/test-*", "/-mirror$/"})
+	collector.config.GitHub.MaxRepos = 1
+
+	repos, _, _, err := collector.discoverOrgRepos(t.Context(), "d0ugal")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(repos) != 1 || repos[0].GetName() != "github-exporter" {
+		t.Errorf("Expected only github-exporter to be discovered, got %v", repos)
+	}
+}
+
+// TestDiscoverSkippedRepos tests that archived and forked repositories are
+// skipped by organization and wildcard discovery when configured
+func TestDiscoverSkippedRepos(t *testing.T) {
+	collector := newAPITestCollector(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[
+			{"name": "github-exporter", "owner": {"login": "d0ugal"}},
+			{"name": "old", "owner": {"login": "d0ugal"}, "archived": true},
+			{"name": "linux", "owner": {"login": "d0ugal"}, "fork": true}
+		]`))
+	})
+
+	tests := []struct {
+		name         string
+		skipArchived bool
+		skipForks    bool
+		want         int
+	}{
+		{name: "none", want: 3},
+		{name: "archived", skipArchived: true, want: 2},
+		{name: "forks", skipForks: true, want: 2},
+		{name: "both", skipArchived: true, skipForks: true, want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector.config.GitHub.SkipArchived = tt.skipArchived
+			collector.config.GitHub.SkipForks = tt.skipForks
+
+			repos, _, _, err := collector.discoverOrgRepos(t.Context(), "d0ugal")
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if len(repos) != tt.want {
+				t.Errorf("Expected %d organization repos, got %d", tt.want, len(repos))
+			}
+
+			repos, _, err = collector.discoverAllRepos(t.Context())
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if len(repos) != tt.want {
+				t.Errorf("Expected %d wildcard repos, got %d", tt.want, len(repos))
+			}
+		})
+	}
+}
+
+// TestDiscoverOrgRepos tests paginated organization repository discovery and caching
+func TestDiscoverOrgRepos(t *testing.T) {
+	requests := 0
+	collector := newAPITestCollector(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/orgs/d0ugal/repos" {
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		requests++
+
+		if r.URL.Query().Get("page") == "2" {
+			_, _ = w.Write([]byte(`[{"name": "c", "owner": {"login": "d0ugal"}}]`))
+			return
+		}
+
+		w.Header().Set("Link", `<`+"http://"+r.Host+r.URL.Path+`?page=2>; rel="next"`)
+		_, _ = w.Write([]byte(`[{"name": "a", "owner": {"login": "d0ugal"}}, {"name": "b", "owner": {"login": "d0ugal"}}]`))
+	})
+	collector.config.GitHub.DiscoveryInterval.Duration = time.Hour
+
+	repos, fresh, _, err := collector.discoverOrgRepos(t.Context(), "d0ugal")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !fresh || len(repos) != 3 {
+		t.Errorf("Expected 3 freshly discovered repos, got %d (fresh %v)", len(repos), fresh)
+	}
+
+	if _, fresh, _, _ := collector.discoverOrgRepos(t.Context(), "d0ugal"); fresh || requests != 2 {
+		t.Errorf("Expected cached discovery on second call, got fresh %v after %d requests", fresh, requests)
+	}
+}
+
+// TestDiscoverOrgReposMaxReposFiltered tests that only repositories kept after
+// the skip filters count towards max_repos, so filtered pages don't end
+// pagination while the cap isn't exceeded
+func TestDiscoverOrgReposMaxReposFiltered(t *testing.T) {
+	collector := newAPITestCollector(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			_, _ = w.Write([]byte(`[{"name": "c", "owner": {"login": "d0ugal"}}, {"name": "d", "owner": {"login": "d0ugal"}}]`))
+			return
+		}
+
+		w.Header().Set("Link", `<`+"http://"+r.Host+r.URL.Path+`?page=2>; rel="next"`)
+		_, _ = w.Write([]byte(`[
+			{"name": "a", "owner": {"login": "d0ugal"}},
+			{"name": "old-1", "owner": {"login": "d0ugal"}, "archived": true},
+			{"name": "old-2", "owner": {"login": "d0ugal"}, "archived": true}
+		]`))
+	})
+	collector.config.GitHub.SkipArchived = true
+	collector.config.GitHub.MaxRepos = 2
+	collector.config.GitHub.MaxReposPolicy = config.MaxReposPolicyTruncate
+
+	repos, _, _, err := collector.discoverOrgRepos(t.Context(), "d0ugal")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(repos) != 2 || repos[0].GetName() != "a" || repos[1].GetName() != "c" {
+		t.Errorf("Expected repositories a and c, got %v", repos)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubDiscoveryMaxReposExceeded.WithLabelValues("d0ugal")); got != 1 {
+		t.Errorf("Expected max_repos to be exceeded, got %v", got)
+	}
+}
+
+// TestDiscoverSkippedArchivedCounted tests that repositories archived between
+// two discoveries are counted even though archived repositories are skipped
+func TestDiscoverSkippedArchivedCounted(t *testing.T) {
+	archived := false
+	collector := newAPITestCollector(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, `[
+			{"name": "github-exporter", "owner": {"login": "d0ugal"}},
+			{"name": "old", "owner": {"login": "d0ugal"}, "archived": %t}
+		]`, archived)
+	})
+	collector.config.GitHub.SkipArchived = true
+
+	for _, archived = range []bool{false, true} {
+		if _, _, _, err := collector.discoverOrgRepos(t.Context(), "d0ugal"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubOrgReposArchived.WithLabelValues("d0ugal")); got != 1 {
+		t.Errorf("Expected 1 archived repository, got %v", got)
+	}
+}
+
+// TestDiscoverTeamRepos tests paginated team repository discovery and caching
+func TestDiscoverTeamRepos(t *testing.T) {
+	requests := 0
+	collector := newAPITestCollector(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/orgs/d0ugal/teams/platform/repos" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		requests++
+
+		if r.URL.Query().Get("page") == "2" {
+			_, _ = w.Write([]byte(`[{"name": "c", "owner": {"login": "d0ugal"}}]`))
+			return
+		}
+
+		w.Header().Set("Link", `<`+"http://"+r.Host+r.URL.Path+`?page=2>; rel="next"`)
+		_, _ = w.Write([]byte(`[{"name": "a", "owner": {"login": "d0ugal"}}, {"name": "b", "owner": {"login": "d0ugal"}}]`))
+	})
+	collector.config.GitHub.DiscoveryInterval.Duration = time.Hour
+
+	repos, fresh, err := collector.discoverTeamRepos(t.Context(), "d0ugal/platform")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !fresh || len(repos) != 3 {
+		t.Errorf("Expected 3 freshly discovered repos, got %d (fresh %v)", len(repos), fresh)
+	}
+
+	if _, fresh, _ := collector.discoverTeamRepos(t.Context(), "d0ugal/platform"); fresh || requests != 2 {
+		t.Errorf("Expected cached discovery on second call, got fresh %v after %d requests", fresh, requests)
+	}
+}
+
+// TestDiscoverStarredRepos tests discovery of repositories starred by the authenticated user
+func TestDiscoverStarredRepos(t *testing.T) {
+	collector := newAPITestCollector(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/user/starred" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		// The star media type wraps each repository with the time it was starred
+		_, _ = w.Write([]byte(`[
+			{"starred_at": "2025-01-01T00:00:00Z", "repo": {"name": "prometheus", "owner": {"login": "prometheus"}}},
+			{"starred_at": "2025-02-01T00:00:00Z", "repo": {"name": "go", "owner": {"login": "golang"}}}
+		]`))
+	})
+
+	repos, fresh, err := collector.discoverStarredRepos(t.Context())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !fresh || len(repos) != 2 || repos[1].GetOwner().GetLogin() != "golang" {
+		t.Errorf("Unexpected starred repos %v (fresh %v)", repos, fresh)
+	}
+}
@@ -0,0 +1,196 @@
+package collectors
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/go-github/v76/github"
+)
+
+// fakeGitHubAPI is an in-memory GitHubAPI for tests. It serves organizations,
+// repositories, file contents, workflows, workflow runs and their usage, check
+// runs, required status checks and PR searches; calling any other method panics
+// through the embedded nil interface.
+type fakeGitHubAPI struct {
+	GitHubAPI
+
+	orgs         map[string]*github.Organization
+	orgRepos     map[string][]*github.Repository
+	repos        map[string]*github.Repository           // "owner/repo"
+	contents     map[string]string                       // "owner/repo/path"
+	workflows    map[string][]*github.Workflow           // "owner/repo"
+	workflowRuns map[string][]*github.WorkflowRun        // "owner/repo@branch"
+	checkRuns    map[string][]*github.CheckRun           // "owner/repo@ref"
+	required     map[string]*github.RequiredStatusChecks // "owner/repo@branch", unprotected if absent
+	runUsage     map[int64]*github.WorkflowRunUsage      // run ID
+	searchTotals map[string]int                          // search query -> total count
+	calls        map[string]int                          // method -> number of calls
+}
+
+func newFakeGitHubAPI() *fakeGitHubAPI {
+	return &fakeGitHubAPI{
+		orgs:         make(map[string]*github.Organization),
+		orgRepos:     make(map[string][]*github.Repository),
+		repos:        make(map[string]*github.Repository),
+		contents:     make(map[string]string),
+		workflows:    make(map[string][]*github.Workflow),
+		workflowRuns: make(map[string][]*github.WorkflowRun),
+		checkRuns:    make(map[string][]*github.CheckRun),
+		required:     make(map[string]*github.RequiredStatusChecks),
+		runUsage:     make(map[int64]*github.WorkflowRunUsage),
+		searchTotals: make(map[string]int),
+		calls:        make(map[string]int),
+	}
+}
+
+// addRepo registers a repository and lists it under its owner's organization
+func (f *fakeGitHubAPI) addRepo(owner, name string, private bool) *github.Repository {
+	repo := &github.Repository{
+		Name:     github.Ptr(name),
+		FullName: github.Ptr(owner + "/" + name),
+		Owner:    &github.User{Login: github.Ptr(owner)},
+		Private:  github.Ptr(private),
+	}
+
+	f.repos[owner+"/"+name] = repo
+	f.orgRepos[owner] = append(f.orgRepos[owner], repo)
+
+	return repo
+}
+
+// fakeResponse returns a successful response
+func fakeResponse() *github.Response {
+	return &github.Response{Response: &http.Response{StatusCode: http.StatusOK, Header: http.Header{}}}
+}
+
+// fakeNotFound returns the error go-github returns for a 404 response
+func fakeNotFound() error {
+	resp := &http.Response{StatusCode: http.StatusNotFound, Header: http.Header{}}
+
+	return &github.ErrorResponse{Response: resp, Message: "Not Found"}
+}
+
+func (f *fakeGitHubAPI) GetOrganization(_ context.Context, org string) (*github.Organization, *github.Response, error) {
+	f.calls["GetOrganization"]++
+
+	if o, ok := f.orgs[org]; ok {
+		return o, fakeResponse(), nil
+	}
+
+	return nil, nil, fakeNotFound()
+}
+
+func (f *fakeGitHubAPI) GetRepository(_ context.Context, owner, repo string) (*github.Repository, *github.Response, error) {
+	f.calls["GetRepository"]++
+
+	if r, ok := f.repos[owner+"/"+repo]; ok {
+		return r, fakeResponse(), nil
+	}
+
+	return nil, nil, fakeNotFound()
+}
+
+func (f *fakeGitHubAPI) ListOrgRepositories(_ context.Context, org string, _ *github.RepositoryListByOrgOptions) ([]*github.Repository, *github.Response, error) {
+	f.calls["ListOrgRepositories"]++
+
+	if _, ok := f.orgs[org]; !ok {
+		return nil, nil, fakeNotFound()
+	}
+
+	return f.orgRepos[org], fakeResponse(), nil
+}
+
+func (f *fakeGitHubAPI) GetContents(_ context.Context, owner, repo, path string, _ *github.RepositoryContentGetOptions) (*github.RepositoryContent, []*github.RepositoryContent, *github.Response, error) {
+	f.calls["GetContents"]++
+
+	if content, ok := f.contents[owner+"/"+repo+"/"+path]; ok {
+		return &github.RepositoryContent{Path: github.Ptr(path), Content: github.Ptr(content)}, nil, fakeResponse(), nil
+	}
+
+	return nil, nil, nil, fakeNotFound()
+}
+
+func (f *fakeGitHubAPI) SearchIssues(_ context.Context, query string, _ *github.SearchOptions) (*github.IssuesSearchResult, *github.Response, error) {
+	f.calls["SearchIssues"]++
+	return &github.IssuesSearchResult{Total: github.Ptr(f.searchTotals[query])}, fakeResponse(), nil
+}
+
+func (f *fakeGitHubAPI) ListWorkflowRuns(_ context.Context, owner, repo string, opts *github.ListWorkflowRunsOptions) (*github.WorkflowRuns, *github.Response, error) {
+	f.calls["ListWorkflowRuns"]++
+
+	var runs []*github.WorkflowRun
+
+	for _, run := range f.workflowRuns[owner+"/"+repo] {
+		if opts != nil && opts.Branch != "" && run.GetHeadBranch() != opts.Branch {
+			continue
+		}
+
+		if opts != nil && opts.Status != "" && run.GetStatus() != opts.Status {
+			continue
+		}
+
+		runs = append(runs, run)
+	}
+
+	if opts != nil && opts.PerPage > 0 && len(runs) > opts.PerPage {
+		runs = runs[:opts.PerPage]
+	}
+
+	return &github.WorkflowRuns{TotalCount: github.Ptr(len(runs)), WorkflowRuns: runs}, fakeResponse(), nil
+}
+
+func (f *fakeGitHubAPI) ListWorkflows(_ context.Context, owner, repo string, _ *github.ListOptions) (*github.Workflows, *github.Response, error) {
+	f.calls["ListWorkflows"]++
+
+	workflows := f.workflows[owner+"/"+repo]
+
+	return &github.Workflows{TotalCount: github.Ptr(len(workflows)), Workflows: workflows}, fakeResponse(), nil
+}
+
+func (f *fakeGitHubAPI) ListWorkflowRunsByID(_ context.Context, owner, repo string, workflowID int64, opts *github.ListWorkflowRunsOptions) (*github.WorkflowRuns, *github.Response, error) {
+	f.calls["ListWorkflowRunsByID"]++
+
+	var runs []*github.WorkflowRun
+
+	for _, run := range f.workflowRuns[owner+"/"+repo] {
+		if run.GetWorkflowID() != workflowID {
+			continue
+		}
+
+		if opts != nil && opts.Event != "" && run.GetEvent() != opts.Event {
+			continue
+		}
+
+		runs = append(runs, run)
+	}
+
+	return &github.WorkflowRuns{TotalCount: github.Ptr(len(runs)), WorkflowRuns: runs}, fakeResponse(), nil
+}
+
+func (f *fakeGitHubAPI) GetWorkflowRunUsage(_ context.Context, _, _ string, runID int64) (*github.WorkflowRunUsage, *github.Response, error) {
+	f.calls["GetWorkflowRunUsage"]++
+
+	if usage, ok := f.runUsage[runID]; ok {
+		return usage, fakeResponse(), nil
+	}
+
+	return nil, nil, fakeNotFound()
+}
+
+func (f *fakeGitHubAPI) ListCheckRunsForRef(_ context.Context, owner, repo, ref string, _ *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+	f.calls["ListCheckRunsForRef"]++
+
+	runs := f.checkRuns[owner+"/"+repo+"@"+ref]
+
+	return &github.ListCheckRunsResults{Total: github.Ptr(len(runs)), CheckRuns: runs}, fakeResponse(), nil
+}
+
+func (f *fakeGitHubAPI) GetRequiredStatusChecks(_ context.Context, owner, repo, branch string) (*github.RequiredStatusChecks, *github.Response, error) {
+	f.calls["GetRequiredStatusChecks"]++
+
+	if checks, ok := f.required[owner+"/"+repo+"@"+branch]; ok {
+		return checks, fakeResponse(), nil
+	}
+
+	return nil, nil, fakeNotFound()
+}
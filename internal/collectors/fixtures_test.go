@@ -0,0 +1,166 @@
+package collectors
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/d0ugal/promexporter/app"
+	"github.com/google/go-github/v76/github"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"golang.org/x/time/rate"
+)
+
+// fixtureRoutes maps GitHub API paths to canned responses in testdata/github.
+// Every response reports a rate limit resetting shortly after the request, so
+// the collector's rate limiter stays fast. An empty fixture name answers with a 404.
+var fixtureRoutes = map[string]string{
+	"/orgs/d0ugal":                                          "org.json",
+	"/orgs/d0ugal/repos":                                    "org_repos.json",
+	"/repos/d0ugal/github-exporter":                         "repo.json",
+	"/repos/d0ugal/github-exporter/actions/runs":            "workflow_runs.json",
+	"/repos/d0ugal/github-exporter/commits/main/check-runs": "check_runs.json",
+	"/search/issues":                                        "search_prs.json",
+}
+
+// newFixtureServer starts a fake GitHub API serving the given fixtures. Requests for
+// paths without a fixture fail the test, so new API calls have to be added explicitly.
+func newFixtureServer(t *testing.T, routes map[string]string) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name, ok := routes[r.URL.Path]
+		if !ok {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		w.Header().Set("X-RateLimit-Limit", "5000")
+		w.Header().Set("X-RateLimit-Remaining", "4750")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(10*time.Second).Unix(), 10))
+		w.Header().Set("X-RateLimit-Resource", "core")
+
+		if name == "" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+
+			return
+		}
+
+		fixture, err := os.ReadFile(filepath.Join("testdata", "github", name))
+		if err != nil {
+			t.Errorf("Failed to read fixture %s: %v", name, err)
+			w.WriteHeader(http.StatusInternalServerError)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(fixture)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// newFixtureCollector creates a collector that talks to a fixture server
+func newFixtureCollector(t *testing.T, routes map[string]string) *GitHubCollector {
+	t.Helper()
+
+	server := newFixtureServer(t, routes)
+
+	collector := createTestCollector()
+
+	transport := newInstrumentedTransport(nil, collector.metrics)
+	transport.onRateLimit = collector.observeRateLimit
+
+	client := github.NewClient(&http.Client{Transport: transport})
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+
+	collector.app = app.New("github-exporter")
+	collector.api = NewGitHubAPI(client)
+	collector.limiter = newSharedLimiter(rate.Inf, 1)
+	collector.config.GitHub.RateLimitBuffer = 0.8
+
+	return collector
+}
+
+func TestCollectMetricsFixtures(t *testing.T) {
+	collector := newFixtureCollector(t, fixtureRoutes)
+	collector.config.GitHub.Orgs = []string{"d0ugal"}
+	collector.config.GitHub.Repos = []string{"d0ugal/github-exporter"}
+	collector.config.GitHub.Branches = []string{"main"}
+
+	collector.collectMetrics(t.Context())
+
+	expected := `
+# HELP github_rate_limit_remaining Number of GitHub API requests remaining in the current rate limit window
+# TYPE github_rate_limit_remaining gauge
+github_rate_limit_remaining 4750
+# HELP github_org_public_repos Number of public repositories for a GitHub organization
+# TYPE github_org_public_repos gauge
+github_org_public_repos{org="d0ugal"} 2
+# HELP github_repo_stars Number of stars for a GitHub repository
+# TYPE github_repo_stars gauge
+github_repo_stars{org="d0ugal",repo="github-exporter",visibility="public"} 42
+github_repo_stars{org="d0ugal",repo="private",visibility="private"} 1
+# HELP github_repo_open_prs Number of open pull requests for a GitHub repository
+# TYPE github_repo_open_prs gauge
+github_repo_open_prs{org="d0ugal",repo="github-exporter",visibility="public"} 3
+github_repo_open_prs{org="d0ugal",repo="private",visibility="private"} 3
+# HELP github_branch_build_status Build status for GitHub repository branches (0=failed, 1=success, 2=pending, 3=skipped)
+# TYPE github_branch_build_status gauge
+github_branch_build_status{branch="main",org="d0ugal",repo="github-exporter"} 1
+# HELP github_workflow_run_status Status of GitHub workflow runs (0=failed, 1=success, 2=pending, 3=skipped)
+# TYPE github_workflow_run_status gauge
+github_workflow_run_status{branch="main",conclusion="success",org="d0ugal",repo="github-exporter",workflow="CI"} 1
+github_workflow_run_status{branch="main",conclusion="unknown",org="d0ugal",repo="github-exporter",workflow="Release"} 2
+# HELP github_check_run_status Status of GitHub check runs (0=failed, 1=success, 2=pending, 3=skipped)
+# TYPE github_check_run_status gauge
+github_check_run_status{branch="main",check_name="build",conclusion="success",org="d0ugal",repo="github-exporter"} 1
+github_check_run_status{branch="main",check_name="lint",conclusion="failure",org="d0ugal",repo="github-exporter"} 0
+# HELP github_collection_phase_targets Number of targets a collection phase succeeded or failed for in the last cycle
+# TYPE github_collection_phase_targets gauge
+github_collection_phase_targets{phase="orgs",result="error"} 0
+github_collection_phase_targets{phase="orgs",result="success"} 3
+github_collection_phase_targets{phase="repos",result="error"} 0
+github_collection_phase_targets{phase="repos",result="success"} 1
+`
+
+	err := testutil.GatherAndCompare(collector.metrics.GetRegistry(), strings.NewReader(expected),
+		"github_rate_limit_remaining",
+		"github_org_public_repos",
+		"github_repo_stars",
+		"github_repo_open_prs",
+		"github_branch_build_status",
+		"github_workflow_run_status",
+		"github_check_run_status",
+		"github_collection_phase_targets",
+	)
+	if err != nil {
+		t.Fatalf("Unexpected metrics: %v", err)
+	}
+}
+
+func TestCollectMetricsFixturesUnknownRepo(t *testing.T) {
+	routes := map[string]string{
+		"/repos/d0ugal/github-exporter": "",
+	}
+
+	collector := newFixtureCollector(t, routes)
+	collector.config.GitHub.Repos = []string{"d0ugal/github-exporter"}
+
+	result, err := collector.collectRepoMetrics(t.Context())
+	if err == nil {
+		t.Fatal("Expected an error for a repository without a fixture")
+	}
+
+	if result.Failed != 1 {
+		t.Errorf("Expected 1 failed repository, got %d", result.Failed)
+	}
+}
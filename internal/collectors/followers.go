@@ -0,0 +1,126 @@
+package collectors
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/d0ugal/github-exporter/internal/state"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Kinds of accounts whose followers are tracked
+const (
+	followerKindOrg  = ""
+	followerKindUser = "user"
+)
+
+// followerTracker remembers the follower count last seen for each account and
+// the gains and losses counted from its changes, so the counters continue from
+// where they were after a restart when a state file is configured
+type followerTracker struct {
+	mu       sync.Mutex
+	accounts map[string]state.Followers // By kind and account
+}
+
+// followerKey returns the key an account is tracked under
+func followerKey(kind, account string) string {
+	return kind + "/" + account
+}
+
+// restore replaces the tracked accounts with saved ones
+func (t *followerTracker) restore(saved []state.Followers) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.accounts = make(map[string]state.Followers, len(saved))
+	for _, followers := range saved {
+		t.accounts[followerKey(followers.Kind, followers.Account)] = followers
+	}
+}
+
+// observe records the current follower count of an account and returns the
+// followers gained and lost since it was last seen. The first observation of
+// an account only sets its baseline.
+func (t *followerTracker) observe(kind, account string, count int) (gained, lost int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.accounts == nil {
+		t.accounts = make(map[string]state.Followers)
+	}
+
+	key := followerKey(kind, account)
+
+	followers, ok := t.accounts[key]
+	if ok {
+		if change := count - followers.Count; change > 0 {
+			gained = change
+		} else {
+			lost = -change
+		}
+	}
+
+	followers.Account = account
+	followers.Kind = kind
+	followers.Count = count
+	followers.Gained += gained
+	followers.Lost += lost
+	t.accounts[key] = followers
+
+	return gained, lost
+}
+
+// snapshot returns the tracked accounts, sorted by account
+func (t *followerTracker) snapshot() []state.Followers {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	accounts := make([]state.Followers, 0, len(t.accounts))
+	for _, followers := range t.accounts {
+		accounts = append(accounts, followers)
+	}
+
+	sort.Slice(accounts, func(i, j int) bool {
+		if accounts[i].Account != accounts[j].Account {
+			return accounts[i].Account < accounts[j].Account
+		}
+
+		return accounts[i].Kind < accounts[j].Kind
+	})
+
+	return accounts
+}
+
+// restoreFollowers continues the follower counters from a previous run
+func (gc *GitHubCollector) restoreFollowers(saved []state.Followers) {
+	gc.followers.restore(saved)
+
+	for _, followers := range saved {
+		gained, lost := gc.followerCounters(followers.Kind, followers.Account)
+		gained.Add(float64(followers.Gained))
+		lost.Add(float64(followers.Lost))
+	}
+}
+
+// observeFollowers counts the followers an organization or user gained or lost
+// since the previous collection. Only the net change is visible, so a follow
+// and an unfollow between two collections cancel out.
+func (gc *GitHubCollector) observeFollowers(kind, account string, count int) {
+	gained, lost := gc.followers.observe(kind, account, count)
+
+	gainedCounter, lostCounter := gc.followerCounters(kind, account)
+	gainedCounter.Add(float64(gained))
+	lostCounter.Add(float64(lost))
+}
+
+// followerCounters returns the follower gain and loss counters of an account
+func (gc *GitHubCollector) followerCounters(kind, account string) (prometheus.Counter, prometheus.Counter) {
+	if kind == followerKindUser {
+		labels := prometheus.Labels{"user": account}
+		return gc.metrics.GitHubUserFollowersGained.With(labels), gc.metrics.GitHubUserFollowersLost.With(labels)
+	}
+
+	labels := prometheus.Labels{"org": account}
+
+	return gc.metrics.GitHubOrgFollowersGained.With(labels), gc.metrics.GitHubOrgFollowersLost.With(labels)
+}
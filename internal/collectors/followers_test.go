@@ -0,0 +1,53 @@
+package collectors
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/d0ugal/github-exporter/internal/state"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// TestObserveFollowers tests that follower count changes are counted as gains
+// and losses, with the first observation only setting the baseline
+func TestObserveFollowers(t *testing.T) {
+	collector := createTestCollector()
+
+	for _, count := range []int{100, 105, 102, 102, 110} {
+		collector.observeFollowers(followerKindOrg, "d0ugal", count)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubOrgFollowersGained.WithLabelValues("d0ugal")); got != 13 {
+		t.Errorf("Expected 13 followers gained, got %v", got)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubOrgFollowersLost.WithLabelValues("d0ugal")); got != 3 {
+		t.Errorf("Expected 3 followers lost, got %v", got)
+	}
+}
+
+// TestFollowersPersisted tests that the follower counters continue after a restart
+func TestFollowersPersisted(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+
+	previous := createTestCollector()
+	previous.state = state.NewStore(path)
+	previous.observeFollowers(followerKindOrg, "d0ugal", 100)
+	previous.observeFollowers(followerKindOrg, "d0ugal", 104)
+	previous.saveSnapshot()
+
+	restarted := createTestCollector()
+	restarted.state = state.NewStore(path)
+	restarted.restoreSnapshot()
+
+	if got := testutil.ToFloat64(restarted.metrics.GitHubOrgFollowersGained.WithLabelValues("d0ugal")); got != 4 {
+		t.Errorf("Expected the restored 4 followers gained, got %v", got)
+	}
+
+	// Changes while the exporter was down are counted against the saved count
+	restarted.observeFollowers(followerKindOrg, "d0ugal", 101)
+
+	if got := testutil.ToFloat64(restarted.metrics.GitHubOrgFollowersLost.WithLabelValues("d0ugal")); got != 3 {
+		t.Errorf("Expected 3 followers lost, got %v", got)
+	}
+}
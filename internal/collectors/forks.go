@@ -0,0 +1,90 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v76/github"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// activeForkWindow is how recently a fork must have been pushed to to count as active
+	activeForkWindow = 30 * 24 * time.Hour
+
+	// maxForkPages bounds the pages of forks listed per upstream repository and cycle
+	maxForkPages = 100
+)
+
+// collectForkMetrics counts the active forks of all configured upstream repositories
+func (gc *GitHubCollector) collectForkMetrics(ctx context.Context) error {
+	var failed int
+
+	for _, upstream := range gc.config.GitHub.ForkActivity {
+		owner, repo, _ := strings.Cut(upstream, "/")
+
+		active, err := gc.countActiveForks(ctx, owner, repo, time.Now().Add(-activeForkWindow))
+		gc.status.record("fork_activity", upstream, err)
+
+		if err != nil {
+			logError("Failed to count active forks", err, "repo", upstream)
+
+			failed++
+
+			continue
+		}
+
+		gc.metrics.GitHubRepoActiveForks.With(prometheus.Labels{"org": owner, "repo": repo}).Set(float64(active))
+	}
+
+	if failed > 0 && failed == len(gc.config.GitHub.ForkActivity) {
+		return fmt.Errorf("failed to count active forks of all %d repositories", failed)
+	}
+
+	return nil
+}
+
+// countActiveForks returns the number of forks of a repository pushed to after
+// since. A new fork reports the upstream's last push until it is pushed to, so
+// only pushes after the fork was created count.
+func (gc *GitHubCollector) countActiveForks(ctx context.Context, owner, repo string, since time.Time) (int, error) {
+	t := target{Org: owner, Repo: repo}
+	opts := &github.RepositoryListForksOptions{ListOptions: github.ListOptions{PerPage: 100}}
+
+	active := 0
+
+	for page := 0; page < maxForkPages; page++ {
+		// Wait for rate limiter
+		if err := gc.limiter.Wait(ctx); err != nil {
+			return 0, wrapAPIError("forks", t, fmt.Errorf("rate limiter error: %w", err))
+		}
+
+		reqCtx, cancel := gc.requestContext(ctx, "forks")
+		forks, resp, err := gc.api.ListForks(reqCtx, owner, repo, opts)
+		cancel()
+		if err != nil {
+			gc.recordAPIError("forks", err)
+			return 0, wrapAPIError("forks", t, err)
+		}
+
+		for _, fork := range forks {
+			pushedAt := fork.GetPushedAt().Time
+			if pushedAt.After(since) && pushedAt.After(fork.GetCreatedAt().Time) {
+				active++
+			}
+		}
+
+		if resp == nil || resp.NextPage == 0 {
+			return active, nil
+		}
+
+		opts.Page = resp.NextPage
+	}
+
+	slog.Warn("Too many forks to list, only the newest were counted", "org", owner, "repo", repo, "forks", maxForkPages*100)
+
+	return active, nil
+}
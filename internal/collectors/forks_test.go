@@ -0,0 +1,67 @@
+package collectors
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// TestCollectForkMetrics tests that only forks pushed to recently, after they
+// were created, are counted across all pages of forks
+func TestCollectForkMetrics(t *testing.T) {
+	now := time.Now().UTC()
+	at := func(ago time.Duration) string {
+		return now.Add(-ago).Format(time.RFC3339)
+	}
+
+	collector := newAPITestCollector(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/d0ugal/upstream/forks" {
+			t.Errorf("Unexpected request path %s", r.URL.Path)
+		}
+
+		if r.URL.Query().Get("page") == "" {
+			w.Header().Set("Link", fmt.Sprintf(`<http://%s/repos/d0ugal/upstream/forks?page=2>; rel="next"`, r.Host))
+			_, _ = fmt.Fprintf(w, `[
+				{"full_name": "a/upstream", "created_at": %q, "pushed_at": %q},
+				{"full_name": "b/upstream", "created_at": %q, "pushed_at": %q}
+			]`, at(48*time.Hour), at(time.Hour), at(time.Hour), at(72*time.Hour))
+
+			return
+		}
+
+		_, _ = fmt.Fprintf(w, `[
+			{"full_name": "c/upstream", "created_at": %q, "pushed_at": %q},
+			{"full_name": "d/upstream", "created_at": %q, "pushed_at": %q}
+		]`, at(365*24*time.Hour), at(24*time.Hour), at(365*24*time.Hour), at(60*24*time.Hour))
+	})
+	collector.config.GitHub.ForkActivity = []string{"d0ugal/upstream"}
+
+	if err := collector.collectForkMetrics(t.Context()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// a and c were pushed to recently; b was only forked and d was pushed to too long ago
+	if got := testutil.ToFloat64(collector.metrics.GitHubRepoActiveForks.WithLabelValues("d0ugal", "upstream")); got != 2 {
+		t.Errorf("Expected 2 active forks, got %v", got)
+	}
+}
+
+// TestCollectForkMetricsError tests that failing to list forks is reported
+func TestCollectForkMetricsError(t *testing.T) {
+	collector := newAPITestCollector(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+	})
+	collector.config.GitHub.ForkActivity = []string{"d0ugal/missing"}
+
+	if err := collector.collectForkMetrics(t.Context()); err == nil {
+		t.Error("Expected an error when no forks could be listed")
+	}
+
+	if got := testutil.CollectAndCount(collector.metrics.GitHubRepoActiveForks); got != 0 {
+		t.Errorf("Expected no active fork series, got %d", got)
+	}
+}
@@ -2,14 +2,19 @@ package collectors
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/d0ugal/github-exporter/internal/config"
 	"github.com/d0ugal/github-exporter/internal/metrics"
+	"github.com/d0ugal/github-exporter/internal/notify"
+	"github.com/d0ugal/github-exporter/internal/redact"
+	"github.com/d0ugal/github-exporter/internal/state"
 	"github.com/d0ugal/promexporter/app"
 	"github.com/d0ugal/promexporter/tracing"
 	"github.com/google/go-github/v76/github"
@@ -22,68 +27,264 @@ type GitHubCollector struct {
 	config  *config.Config
 	metrics *metrics.GitHubRegistry
 	app     *app.App
-	client  *github.Client
-	limiter *rate.Limiter
+	api     GitHubAPI
+	limiter *sharedLimiter
 	mu      sync.RWMutex
 
+	// GraphQL API rate limit, tracked separately from the REST API quota
+	graphqlLimiter *sharedLimiter
+	graphqlQuota   *graphqlQuota
+
+	// Repository discovery cache
+	discovery *discoveryCache
+
+	// Per-target scheduling within a refresh interval
+	scheduler *targetScheduler
+
+	// Per-target collection status for the status page
+	status *statusTracker
+
+	// Targets discovered at runtime (e.g. from Kubernetes)
+	dynamic dynamicTargets
+
+	// Monitored repositories that were renamed or transferred
+	renames renameTracker
+
+	// Labels of the exported latest run of each workflow and branch
+	latestRuns latestRunTracker
+
+	// Monitored targets that do not exist
+	missing notFoundTracker
+
+	// Collected repositories for service discovery
+	inventory *repoInventory
+
+	// Organization project items seen in the previous cycle
+	projects *projectTracker
+
+	// Branch heads seen in the previous cycle
+	commits *commitTracker
+
+	// Completed workflow runs seen in the previous cycle, and the API calls left
+	// for backfilling the runs of newly seen branches in this cycle
+	runs     runTracker
+	backfill backfillBudget
+
+	// Workflow runs completed within the success ratio window
+	window runWindow
+
+	// Workflow totals of each organization in the current day and week
+	rollups orgRollups
+
+	// Follower counts of each organization and the changes counted so far
+	followers followerTracker
+
+	// Repositories of each organization and the creations and archivals counted so far
+	orgRepos orgRepoTracker
+
+	// Billable time of the latest completed run of each workflow
+	usage *usageCache
+
+	// Cron schedules parsed from workflow files
+	schedules *scheduleCache
+
+	// Directory listings of each repository, until it is pushed to
+	directories directoryCache
+
+	// Gauges of user-defined GraphQL queries, by query name
+	customQueries map[string]*prometheus.GaugeVec
+
+	// Time of the previous fetch of incremental activity, per repository and kind
+	activity *sinceTracker
+
+	// Issues waiting for their first response
+	responses *responseTracker
+
+	// Targets monitored during the current collection cycle
+	cycle *cycleTargets
+
+	// Targets not reached before the collection deadline
+	skipped *skipTracker
+
+	// Repositories queued for a resync between collection cycles
+	resyncs chan target
+
+	// Persisted state, nil unless the state store is enabled
+	state *state.Store
+
+	// API calls made in the current cycle, by endpoint
+	calls cycleCalls
+
+	// Patterns of repositories skipped by discovery
+	excludeRepos []config.RepoPattern
+
+	// Repositories resolved at the last revalidation
+	drift driftTracker
+
+	// Repository labels whose cardinality was warned about
+	repoLabelWarnings map[string]bool
+
+	// Blackout windows during which collection is paused
+	pause pauseState
+
+	// Notifications about failing targets and the token expiry, nil when disabled
+	notifier      *notify.Notifier
+	notifications notificationTracker
+
+	// Whether only high-priority metrics are refreshed until the rate limit resets
+	degraded bool
+
+	// Whether the authenticating transport paces REST requests instead of limiter
+	transportPaced bool
+
 	// Rate limiting state
 	rateLimitTotal     int
 	rateLimitRemaining int
 	rateLimitReset     time.Time
-	lastRateLimitCheck time.Time
 }
 
 func NewGitHubCollector(cfg *config.Config, metricsRegistry *metrics.GitHubRegistry, app *app.App) *GitHubCollector {
-	// Create GitHub client
-	client := github.NewClient(nil).WithAuthToken(cfg.GitHub.Token)
+	// Create GitHub client with a transport that records response details of every request
+	transport := newInstrumentedTransport(nil, metricsRegistry)
+	httpClient := &http.Client{Transport: transport}
+	client := github.NewClient(httpClient).WithAuthToken(cfg.GitHub.Token)
 
 	// Create initial conservative rate limiter - will be updated dynamically based on actual API limits
 	// Start with a very conservative rate (1 request per second)
-	limiter := rate.NewLimiter(1, 1)
+	limiter := newSharedLimiter(1, 1)
+
+	gc := &GitHubCollector{
+		config:    cfg,
+		metrics:   metricsRegistry,
+		app:       app,
+		api:       NewGitHubAPI(client),
+		limiter:   limiter,
+		discovery: newDiscoveryCache(),
+		scheduler: &targetScheduler{},
+		status:    newStatusTracker(),
+		inventory: newRepoInventory(),
+		projects:  newProjectTracker(),
+		commits:   newCommitTracker(),
+		usage:     newUsageCache(),
+		schedules: newScheduleCache(),
+		activity:  newSinceTracker(),
+		responses: newResponseTracker(),
+		cycle:     newCycleTargets(),
+		skipped:   newSkipTracker(),
+		resyncs:   make(chan target, resyncQueueSize),
+	}
+
+	if cfg.State.Enabled {
+		gc.state = state.NewStore(cfg.State.Path)
+	}
+
+	// Keep the rate limit current from the headers of every response
+	transport.onRateLimit = gc.observeRateLimit
+	transport.onRequest = gc.calls.add
+
+	// The GraphQL quota is separate from REST, start as conservatively as for REST
+	gc.graphqlLimiter = newSharedLimiter(1, 1)
+	gc.graphqlQuota = newGraphQLQuota()
+
+	gc.customQueries = newCustomQueryGauges(cfg.GitHub.CustomQueries, metricsRegistry)
+	gc.pause.blackouts = parseBlackouts(cfg.GitHub.Blackouts)
+	gc.excludeRepos = parseRepoPatterns(cfg.GitHub.ExcludeRepos)
+
+	if cfg.Notifications.Enabled() {
+		gc.notifier = notify.New(cfg.Notifications.WebhookURL)
+		transport.onTokenExpiration = gc.notifications.observeTokenExpiration
+	}
+
+	return gc
+}
 
-	return &GitHubCollector{
-		config:  cfg,
-		metrics: metricsRegistry,
-		app:     app,
-		client:  client,
-		limiter: limiter,
+// WithAuthTransport authenticates requests with transport instead of the
+// configured token, e.g. with the tokens of GitHub App installations. The
+// transport paces requests itself, so the collector's REST rate limiter is disabled.
+func (gc *GitHubCollector) WithAuthTransport(transport http.RoundTripper) *GitHubCollector {
+	instrumented := newInstrumentedTransport(transport, gc.metrics)
+	instrumented.onRateLimit = gc.observeRateLimit
+	instrumented.onRequest = gc.calls.add
+
+	if gc.config.Notifications.Enabled() {
+		instrumented.onTokenExpiration = gc.notifications.observeTokenExpiration
 	}
+
+	gc.api = NewGitHubAPI(github.NewClient(&http.Client{Transport: instrumented}))
+	gc.limiter.SetLimit(rate.Inf)
+	gc.transportPaced = true
+
+	return gc
 }
 
 func (gc *GitHubCollector) Start(ctx context.Context) {
+	// Serve the last snapshot until the first collection completes
+	gc.restoreSnapshot()
+
 	go gc.run(ctx)
+
+	gc.startSyntheticChecks(ctx)
 }
 
 func (gc *GitHubCollector) run(ctx context.Context) {
+	// Check the token can serve the enabled collectors
+	gc.checkPermissions(ctx)
+
 	// Run immediately on start
 	gc.collectMetrics(ctx)
+	gc.metrics.EndWarmup()
+	gc.saveSnapshot()
+	gc.setDriftBaseline(ctx)
 
 	// Calculate initial refresh interval
 	refreshInterval := gc.calculateRefreshInterval()
+	gc.status.setCycle(refreshInterval, time.Time{})
+	gc.metrics.GitHubExporterRefreshInterval.With(prometheus.Labels{}).Set(refreshInterval.Seconds())
 
 	ticker := time.NewTicker(refreshInterval)
 	defer ticker.Stop()
 
+	reconcileC, stopReconcile := gc.reconcileTicker()
+	defer stopReconcile()
+
+	revalidateC, stopRevalidate := gc.revalidateTicker()
+	defer stopRevalidate()
+
 	for {
 		select {
 		case <-ctx.Done():
 			slog.Info("Shutting down GitHub collector")
 			return
+		case t := <-gc.resyncs:
+			gc.resyncRepo(ctx, t)
+		case <-reconcileC:
+			gc.reconcile(ctx)
+		case <-revalidateC:
+			gc.revalidate(ctx)
 		case <-ticker.C:
+			if gc.config.GitHub.StaggerTargets {
+				gc.scheduler.begin(refreshInterval, gc.config.GitHub.StaggerJitter)
+			}
+
 			gc.collectMetrics(ctx)
+			gc.saveSnapshot()
 
 			// Recalculate refresh interval based on current rate limits
-			newInterval := gc.calculateRefreshInterval()
-			if newInterval != refreshInterval {
-				slog.Info("Updating refresh interval", "old", refreshInterval, "new", newInterval)
+			if newInterval, changed := gc.recalculateRefreshInterval(refreshInterval); changed {
 				refreshInterval = newInterval
 				ticker.Reset(refreshInterval)
+				gc.status.setCycle(refreshInterval, time.Time{})
 			}
 		}
 	}
 }
 
 func (gc *GitHubCollector) collectMetrics(ctx context.Context) {
+	// Keep the last metrics without making any API calls during blackouts
+	if gc.updatePaused(time.Now()) {
+		return
+	}
+
 	startTime := time.Now()
 
 	slog.Debug("Collecting GitHub metrics")
@@ -97,8 +298,8 @@ func (gc *GitHubCollector) collectMetrics(ctx context.Context) {
 	if tracer != nil && tracer.IsEnabled() {
 		collectorSpan = tracer.NewCollectorSpan(ctx, "github-collector", "collect-metrics")
 		collectorSpan.SetAttributes(
-			attribute.Int("github.orgs_count", len(gc.config.GitHub.Orgs)),
-			attribute.Int("github.repos_count", len(gc.config.GitHub.Repos)),
+			attribute.Int("github.orgs_count", len(gc.targetOrgs())),
+			attribute.Int("github.repos_count", len(gc.targetRepos())),
 			attribute.Int("github.branches_count", len(gc.config.GitHub.Branches)),
 		)
 		spanCtx = collectorSpan.Context()
@@ -107,66 +308,56 @@ func (gc *GitHubCollector) collectMetrics(ctx context.Context) {
 		spanCtx = ctx
 	}
 
+	// Bound the whole cycle so a runaway collection cannot delay the next one
+	if deadline := gc.config.GitHub.CollectionDeadline.Duration; deadline > 0 {
+		var cancel context.CancelFunc
+		spanCtx, cancel = context.WithTimeout(spanCtx, deadline)
+		defer cancel()
+	}
+
 	if collectorSpan != nil {
 		collectorSpan.AddEvent("collection_started")
 	}
 
-	// Check and update rate limits first
-	rateLimitStart := time.Now()
-	if err := gc.updateRateLimits(spanCtx); err != nil {
-		rateLimitDuration := time.Since(rateLimitStart).Seconds()
-		slog.Error("Failed to update rate limits", "error", err)
+	gc.cycle.begin()
+	gc.calls.take() // Calls of resyncs between cycles are not part of the share
+	gc.backfill.reset(gc.config.GitHub.BuildStatus.BackfillBudget)
+	gc.skipped.begin()
 
+	// Wait for rate limiter
+	if err := gc.limiter.Wait(spanCtx); err != nil {
+		slog.Error("Rate limiter error", "error", err)
 		if collectorSpan != nil {
-			collectorSpan.SetAttributes(
-				attribute.Float64("rate_limit.duration_seconds", rateLimitDuration),
-			)
-			collectorSpan.RecordError(err, attribute.String("operation", "update-rate-limits"))
-			collectorSpan.AddEvent("rate_limit_update_failed",
-				attribute.String("error", err.Error()),
-			)
+			collectorSpan.RecordError(redact.Error(err), attribute.String("operation", "rate-limiter-wait"))
 		}
-
-		gc.metrics.GitHubAPIErrorsTotal.With(prometheus.Labels{
-			"endpoint":   "rate_limit",
-			"error_type": "update_error",
-		}).Inc()
-
 		return
 	}
-	rateLimitDuration := time.Since(rateLimitStart).Seconds()
 
-	if collectorSpan != nil {
-		collectorSpan.SetAttributes(
-			attribute.Float64("rate_limit.duration_seconds", rateLimitDuration),
-		)
-		collectorSpan.AddEvent("rate_limit_updated")
-	}
+	// Only refresh high-priority metrics while the rate limit is nearly exhausted
+	if gc.updateDegradedMode() {
+		gc.collectDegradedMetrics(spanCtx, collectorSpan)
+		gc.finishCycle(collectorSpan, startTime)
 
-	// Wait for rate limiter
-	if err := gc.limiter.Wait(spanCtx); err != nil {
-		slog.Error("Rate limiter error", "error", err)
-		if collectorSpan != nil {
-			collectorSpan.RecordError(err, attribute.String("operation", "rate-limiter-wait"))
-		}
 		return
 	}
 
+	// Degraded cycles don't list repositories, so only full cycles rebuild the inventory
+	gc.inventory.begin()
+
 	// Collect organization metrics
 	orgStart := time.Now()
-	if err := gc.collectOrgMetrics(spanCtx); err != nil {
+	orgResult, err := gc.collectOrgMetrics(spanCtx)
+	gc.setPhaseMetrics("orgs", orgResult)
+	if err != nil {
 		orgDuration := time.Since(orgStart).Seconds()
-		slog.Error("Failed to collect organization metrics", "error", err)
+		slog.Error("Failed to collect organization metrics", "error", err, "failed", orgResult.Failed, "succeeded", orgResult.Succeeded)
 		if collectorSpan != nil {
 			collectorSpan.SetAttributes(
 				attribute.Float64("org_metrics.duration_seconds", orgDuration),
 			)
-			collectorSpan.RecordError(err, attribute.String("operation", "collect-org-metrics"))
+			collectorSpan.RecordError(redact.Error(err), attribute.String("operation", "collect-org-metrics"))
 		}
-		gc.metrics.GitHubAPIErrorsTotal.With(prometheus.Labels{
-			"endpoint":   "orgs",
-			"error_type": "collection_error",
-		}).Inc()
+		gc.recordError("orgs", "collection_error", err)
 	} else {
 		orgDuration := time.Since(orgStart).Seconds()
 		if collectorSpan != nil {
@@ -179,21 +370,30 @@ func (gc *GitHubCollector) collectMetrics(ctx context.Context) {
 		}
 	}
 
+	// Collect user metrics if users are configured
+	if len(gc.config.GitHub.Users) > 0 {
+		if err := gc.collectUserMetrics(spanCtx); err != nil {
+			if collectorSpan != nil {
+				collectorSpan.RecordError(redact.Error(err), attribute.String("operation", "collect-user-metrics"))
+			}
+			gc.recordError("users", "collection_error", err)
+		}
+	}
+
 	// Collect repository metrics
 	repoStart := time.Now()
-	if err := gc.collectRepoMetrics(spanCtx); err != nil {
+	repoResult, err := gc.collectRepoMetrics(spanCtx)
+	gc.setPhaseMetrics("repos", repoResult)
+	if err != nil {
 		repoDuration := time.Since(repoStart).Seconds()
-		slog.Error("Failed to collect repository metrics", "error", err)
+		slog.Error("Failed to collect repository metrics", "error", err, "failed", repoResult.Failed, "succeeded", repoResult.Succeeded)
 		if collectorSpan != nil {
 			collectorSpan.SetAttributes(
 				attribute.Float64("repo_metrics.duration_seconds", repoDuration),
 			)
-			collectorSpan.RecordError(err, attribute.String("operation", "collect-repo-metrics"))
+			collectorSpan.RecordError(redact.Error(err), attribute.String("operation", "collect-repo-metrics"))
 		}
-		gc.metrics.GitHubAPIErrorsTotal.With(prometheus.Labels{
-			"endpoint":   "repos",
-			"error_type": "collection_error",
-		}).Inc()
+		gc.recordError("repos", "collection_error", err)
 	} else {
 		repoDuration := time.Since(repoStart).Seconds()
 		if collectorSpan != nil {
@@ -207,21 +407,18 @@ func (gc *GitHubCollector) collectMetrics(ctx context.Context) {
 	}
 
 	// Collect build status metrics if branches are configured
-	if len(gc.config.GitHub.Branches) > 0 {
+	if len(gc.config.GitHub.Branches) > 0 && gc.config.Collectors.BuildStatusEnabled() {
 		buildStart := time.Now()
-		if err := gc.collectBuildStatusMetrics(spanCtx); err != nil {
+		if err := gc.collectBuildStatusMetrics(spanCtx, gc.config.GitHub.Branches); err != nil {
 			buildDuration := time.Since(buildStart).Seconds()
 			slog.Error("Failed to collect build status metrics", "error", err)
 			if collectorSpan != nil {
 				collectorSpan.SetAttributes(
 					attribute.Float64("build_status.duration_seconds", buildDuration),
 				)
-				collectorSpan.RecordError(err, attribute.String("operation", "collect-build-status"))
+				collectorSpan.RecordError(redact.Error(err), attribute.String("operation", "collect-build-status"))
 			}
-			gc.metrics.GitHubAPIErrorsTotal.With(prometheus.Labels{
-				"endpoint":   "build_status",
-				"error_type": "collection_error",
-			}).Inc()
+			gc.recordError("build_status", "collection_error", err)
 		} else {
 			buildDuration := time.Since(buildStart).Seconds()
 			if collectorSpan != nil {
@@ -235,6 +432,143 @@ func (gc *GitHubCollector) collectMetrics(ctx context.Context) {
 		}
 	}
 
+	// Collect organization project metrics if projects are configured
+	if len(gc.config.GitHub.Projects) > 0 {
+		projectsStart := time.Now()
+		if err := gc.collectProjectMetrics(spanCtx); err != nil {
+			projectsDuration := time.Since(projectsStart).Seconds()
+			slog.Error("Failed to collect project metrics", "error", err)
+			if collectorSpan != nil {
+				collectorSpan.SetAttributes(
+					attribute.Float64("projects.duration_seconds", projectsDuration),
+				)
+				collectorSpan.RecordError(redact.Error(err), attribute.String("operation", "collect-projects"))
+			}
+			gc.recordError("projects", "collection_error", err)
+		} else {
+			projectsDuration := time.Since(projectsStart).Seconds()
+			if collectorSpan != nil {
+				collectorSpan.SetAttributes(
+					attribute.Float64("projects.duration_seconds", projectsDuration),
+				)
+				collectorSpan.AddEvent("projects_collected",
+					attribute.Float64("duration_seconds", projectsDuration),
+				)
+			}
+		}
+	}
+
+	// Count the active forks of configured upstream repositories
+	if len(gc.config.GitHub.ForkActivity) > 0 {
+		forksStart := time.Now()
+		if err := gc.collectForkMetrics(spanCtx); err != nil {
+			forksDuration := time.Since(forksStart).Seconds()
+			slog.Error("Failed to collect fork metrics", "error", err)
+			if collectorSpan != nil {
+				collectorSpan.SetAttributes(
+					attribute.Float64("fork_activity.duration_seconds", forksDuration),
+				)
+				collectorSpan.RecordError(redact.Error(err), attribute.String("operation", "collect-fork-activity"))
+			}
+			gc.recordError("fork_activity", "collection_error", err)
+		} else {
+			forksDuration := time.Since(forksStart).Seconds()
+			if collectorSpan != nil {
+				collectorSpan.SetAttributes(
+					attribute.Float64("fork_activity.duration_seconds", forksDuration),
+				)
+				collectorSpan.AddEvent("fork_activity_collected",
+					attribute.Float64("duration_seconds", forksDuration),
+				)
+			}
+		}
+	}
+
+	// Collect GitHub Packages metrics for monitored organizations
+	if gc.config.Collectors.PackagesEnabled() {
+		packagesStart := time.Now()
+		if err := gc.collectPackageMetrics(spanCtx); err != nil {
+			packagesDuration := time.Since(packagesStart).Seconds()
+			slog.Error("Failed to collect package metrics", "error", err)
+			if collectorSpan != nil {
+				collectorSpan.SetAttributes(
+					attribute.Float64("packages.duration_seconds", packagesDuration),
+				)
+				collectorSpan.RecordError(redact.Error(err), attribute.String("operation", "collect-packages"))
+			}
+			gc.recordError("packages", "collection_error", err)
+		} else {
+			packagesDuration := time.Since(packagesStart).Seconds()
+			if collectorSpan != nil {
+				collectorSpan.SetAttributes(
+					attribute.Float64("packages.duration_seconds", packagesDuration),
+				)
+				collectorSpan.AddEvent("packages_collected",
+					attribute.Float64("duration_seconds", packagesDuration),
+				)
+			}
+		}
+	}
+
+	// Collect user-defined search query metrics
+	if len(gc.config.GitHub.CustomSearches) > 0 {
+		searchesStart := time.Now()
+		if err := gc.collectCustomSearchMetrics(spanCtx); err != nil {
+			searchesDuration := time.Since(searchesStart).Seconds()
+			slog.Error("Failed to collect custom search metrics", "error", err)
+			if collectorSpan != nil {
+				collectorSpan.SetAttributes(
+					attribute.Float64("custom_searches.duration_seconds", searchesDuration),
+				)
+				collectorSpan.RecordError(redact.Error(err), attribute.String("operation", "collect-custom-searches"))
+			}
+			gc.recordError("custom_searches", "collection_error", err)
+		} else {
+			searchesDuration := time.Since(searchesStart).Seconds()
+			if collectorSpan != nil {
+				collectorSpan.SetAttributes(
+					attribute.Float64("custom_searches.duration_seconds", searchesDuration),
+				)
+				collectorSpan.AddEvent("custom_searches_collected",
+					attribute.Float64("duration_seconds", searchesDuration),
+				)
+			}
+		}
+	}
+
+	// Collect user-defined GraphQL query metrics
+	if len(gc.config.GitHub.CustomQueries) > 0 {
+		queriesStart := time.Now()
+		if err := gc.collectCustomQueryMetrics(spanCtx); err != nil {
+			queriesDuration := time.Since(queriesStart).Seconds()
+			slog.Error("Failed to collect custom query metrics", "error", err)
+			if collectorSpan != nil {
+				collectorSpan.SetAttributes(
+					attribute.Float64("custom_queries.duration_seconds", queriesDuration),
+				)
+				collectorSpan.RecordError(redact.Error(err), attribute.String("operation", "collect-custom-queries"))
+			}
+			gc.recordError("custom_queries", "collection_error", err)
+		} else {
+			queriesDuration := time.Since(queriesStart).Seconds()
+			if collectorSpan != nil {
+				collectorSpan.SetAttributes(
+					attribute.Float64("custom_queries.duration_seconds", queriesDuration),
+				)
+				collectorSpan.AddEvent("custom_queries_collected",
+					attribute.Float64("duration_seconds", queriesDuration),
+				)
+			}
+		}
+	}
+
+	gc.finishCycle(collectorSpan, startTime)
+}
+
+// finishCycle records the end of a collection cycle and exports the per-cycle metrics
+func (gc *GitHubCollector) finishCycle(collectorSpan *tracing.CollectorSpan, startTime time.Time) {
+	gc.graphqlQuota.endCycle()
+
 	duration := time.Since(startTime).Seconds()
 
 	if collectorSpan != nil {
@@ -246,9 +580,55 @@ func (gc *GitHubCollector) collectMetrics(ctx context.Context) {
 		)
 	}
 
+	gc.status.setCycle(0, time.Now())
+
+	gc.mu.RLock()
+	degraded := gc.degraded
+	gc.mu.RUnlock()
+
+	// Degraded cycles only visit priority branches, so keep the counts of the last full cycle
+	if !degraded {
+		gc.setTargetMetrics()
+	}
+
+	if removed := gc.inventory.sweep(); len(removed) > 0 {
+		slog.Info("Removed repositories no longer collected from the inventory", "count", len(removed))
+		gc.forgetRepos(removed)
+	}
+
+	gc.setStaleMetrics()
+	gc.setQuotaShare()
+	gc.checkRepoLabelCardinality()
+
+	if skipped := gc.skipped.skipped(); skipped > 0 {
+		slog.Warn("Collection deadline reached, skipped targets will be collected first in the next cycle",
+			"skipped", skipped,
+			"deadline", gc.config.GitHub.CollectionDeadline.Duration,
+		)
+	}
+
+	gc.sendNotifications()
+
 	slog.Debug("GitHub metrics collection completed")
 }
 
+// recalculateRefreshInterval calculates the refresh interval after a collection
+// cycle and exports it, reporting whether it differs from current
+func (gc *GitHubCollector) recalculateRefreshInterval(current time.Duration) (time.Duration, bool) {
+	interval := gc.calculateRefreshInterval()
+	if interval == current {
+		gc.metrics.GitHubRefreshIntervalUpdates.With(prometheus.Labels{"result": "unchanged"}).Inc()
+		return current, false
+	}
+
+	slog.Info("Updating refresh interval", "old", current, "new", interval)
+
+	gc.metrics.GitHubExporterRefreshInterval.With(prometheus.Labels{}).Set(interval.Seconds())
+	gc.metrics.GitHubRefreshIntervalUpdates.With(prometheus.Labels{"result": "changed"}).Inc()
+
+	return interval, true
+}
+
 // calculateRefreshInterval calculates the optimal refresh interval based on rate limits
 func (gc *GitHubCollector) calculateRefreshInterval() time.Duration {
 	// If a specific refresh interval is configured, use it
@@ -267,16 +647,53 @@ func (gc *GitHubCollector) calculateRefreshInterval() time.Duration {
 	// Calculate how many API calls we need per collection cycle
 	// Each org requires: 1 call for org info + 1 call for repos
 	// Each specific repo requires: 1 call
-	totalCallsPerCycle := len(gc.config.GitHub.Orgs)*2 + len(gc.config.GitHub.Repos)
+	collectors := gc.config.Collectors
+	totalCallsPerCycle := 0
+
+	if collectors.OrgStatsEnabled() {
+		totalCallsPerCycle += len(gc.targetOrgs())
+	}
+
+	if collectors.RepoStatsEnabled() {
+		totalCallsPerCycle += len(gc.targetOrgs()) + len(gc.targetRepos())
+	}
+
+	branches := gc.config.GitHub.Branches
+	if gc.degraded {
+		// Degraded mode only refreshes build status for priority branches
+		totalCallsPerCycle = 0
+		branches = gc.config.GitHub.PriorityBranches
+	}
 
 	// Add calls for build status metrics if branches are configured
-	if len(gc.config.GitHub.Branches) > 0 {
+	if len(branches) > 0 && collectors.BuildStatusEnabled() {
 		// Each repo + branch combination requires: 1 call for workflow runs + 1 call for check runs
-		totalCallsPerCycle += len(gc.config.GitHub.Repos) * len(gc.config.GitHub.Branches) * 2
+		// + 1 call for required checks + 1 call for commits + 1 call for workflow usage (only when
+		// a new run completed)
+		callsPerBranch := 1
+		if collectors.CheckRunsEnabled() {
+			callsPerBranch++
+
+			if gc.config.GitHub.BuildStatus.RequiredOnly {
+				callsPerBranch++
+			}
+		}
+
+		if collectors.WorkflowUsageEnabled() {
+			callsPerBranch++
+		}
+
+		if collectors.CommitsEnabled() {
+			callsPerBranch++
+		}
+
+		totalCallsPerCycle += len(gc.targetRepos()) * len(branches) * callsPerBranch
 	}
 
-	// Add 1 for rate limit check
-	totalCallsPerCycle++
+	// Rate limits are read from response headers, so a cycle without any calls
+	// (e.g. degraded mode without priority branches) costs nothing; count it as
+	// one call so the division below stays defined
+	totalCallsPerCycle = max(totalCallsPerCycle, 1)
 
 	// Calculate how many cycles we can do with remaining rate limit
 	// Apply buffer to stay under limit
@@ -305,6 +722,11 @@ func (gc *GitHubCollector) calculateRefreshInterval() time.Duration {
 
 	interval := timeUntilReset / time.Duration(cyclesPossible)
 
+	// GraphQL queries draw from a separate quota, which may be the tighter one
+	if graphqlInterval := gc.graphqlRefreshInterval(); graphqlInterval > interval {
+		interval = graphqlInterval
+	}
+
 	// Ensure minimum interval of 30 seconds
 	if interval < 30*time.Second {
 		interval = 30 * time.Second
@@ -325,122 +747,12 @@ func (gc *GitHubCollector) calculateRefreshInterval() time.Duration {
 	return interval
 }
 
-// updateRateLimits fetches current rate limit information from GitHub
-func (gc *GitHubCollector) updateRateLimits(ctx context.Context) error {
-	tracer := gc.app.GetTracer()
-
-	var collectorSpan *tracing.CollectorSpan
-	var spanCtx context.Context
-
-	if tracer != nil && tracer.IsEnabled() {
-		collectorSpan = tracer.NewCollectorSpan(ctx, "github-collector", "update-rate-limits")
-		spanCtx = collectorSpan.Context()
-		defer collectorSpan.End()
-	} else {
-		spanCtx = ctx
-	}
-
-	// Only check rate limits if it's been more than 5 minutes since last check
-	// or if we've never checked before
-	gc.mu.RLock()
-	lastCheck := gc.lastRateLimitCheck
-	gc.mu.RUnlock()
-
-	if time.Since(lastCheck) < 5*time.Minute && !lastCheck.IsZero() {
-		if collectorSpan != nil {
-			collectorSpan.AddEvent("rate_limit_check_skipped",
-				attribute.String("reason", "recent_check"),
-			)
-		}
-		return nil // Skip rate limit check
-	}
-
-	// Wait for rate limiter
-	waitStart := time.Now()
-	if err := gc.limiter.Wait(spanCtx); err != nil {
-		if collectorSpan != nil {
-			collectorSpan.RecordError(err, attribute.String("operation", "rate-limiter-wait"))
-		}
-		return fmt.Errorf("rate limiter error: %w", err)
-	}
-	waitDuration := time.Since(waitStart).Seconds()
-
-	// Get rate limit information using the new API
-	apiStart := time.Now()
-	rateLimit, resp, err := gc.client.RateLimit.Get(spanCtx)
-	apiDuration := time.Since(apiStart).Seconds()
-
-	if err != nil {
-		if collectorSpan != nil {
-			collectorSpan.SetAttributes(
-				attribute.Float64("api_call.duration_seconds", apiDuration),
-			)
-			collectorSpan.RecordError(err, attribute.String("operation", "get-rate-limit"))
-		}
-		return fmt.Errorf("failed to get rate limit info: %w", err)
-	}
-
-	// Update API call metrics
-	gc.metrics.GitHubAPICallsTotal.With(prometheus.Labels{
-		"endpoint": "rate_limit",
-		"status":   fmt.Sprintf("%d", resp.StatusCode),
-	}).Inc()
-
-	// Update rate limit state
-	gc.mu.Lock()
-	var limit, remaining int
-	var resetTime time.Time
-	if rateLimit.Core != nil {
-		gc.rateLimitTotal = rateLimit.Core.Limit
-		gc.rateLimitRemaining = rateLimit.Core.Remaining
-		limit = rateLimit.Core.Limit
-		remaining = rateLimit.Core.Remaining
-		if !rateLimit.Core.Reset.IsZero() {
-			gc.rateLimitReset = rateLimit.Core.Reset.Time
-			resetTime = rateLimit.Core.Reset.Time
-		}
-	}
-	gc.lastRateLimitCheck = time.Now()
-	gc.mu.Unlock()
-
-	// Update rate limit metrics
-	if rateLimit.Core != nil {
-		gc.metrics.GitHubRateLimitTotal.With(prometheus.Labels{}).Set(float64(rateLimit.Core.Limit))
-		gc.metrics.GitHubRateLimitRemaining.With(prometheus.Labels{}).Set(float64(rateLimit.Core.Remaining))
-		if !rateLimit.Core.Reset.IsZero() {
-			gc.metrics.GitHubRateLimitReset.With(prometheus.Labels{}).Set(float64(rateLimit.Core.Reset.Unix()))
-		}
-	}
-
-	// Update rate limiter based on current limits
-	limiterStart := time.Now()
-	gc.updateRateLimiter()
-	limiterDuration := time.Since(limiterStart).Seconds()
-
-	if collectorSpan != nil {
-		collectorSpan.SetAttributes(
-			attribute.Float64("rate_limiter_wait.duration_seconds", waitDuration),
-			attribute.Float64("api_call.duration_seconds", apiDuration),
-			attribute.Float64("rate_limiter_update.duration_seconds", limiterDuration),
-			attribute.Int("rate_limit.total", limit),
-			attribute.Int("rate_limit.remaining", remaining),
-		)
-		if !resetTime.IsZero() {
-			collectorSpan.SetAttributes(
-				attribute.Int64("rate_limit.reset_timestamp", resetTime.Unix()),
-			)
-		}
-		collectorSpan.AddEvent("rate_limit_updated",
-			attribute.Int("total", limit),
-			attribute.Int("remaining", remaining),
-		)
-	}
-
-	return nil
-}
-
 // updateRateLimiter updates the rate limiter based on current rate limit information
 func (gc *GitHubCollector) updateRateLimiter() {
+	if gc.transportPaced {
+		return
+	}
+
 	gc.mu.RLock()
 	remaining := gc.rateLimitRemaining
 	resetTime := gc.rateLimitReset
@@ -461,12 +773,9 @@ func (gc *GitHubCollector) updateRateLimiter() {
 	effectiveRemaining := int(float64(remaining) * gc.config.GitHub.RateLimitBuffer)
 	ratePerSecond := float64(effectiveRemaining) / timeUntilReset.Seconds()
 
-	// Create new rate limiter
-	newLimiter := rate.NewLimiter(rate.Limit(ratePerSecond), 1)
-
-	gc.mu.Lock()
-	gc.limiter = newLimiter
-	gc.mu.Unlock()
+	// Adjust the shared limiter in place, requests waiting on it pick up the new rate
+	gc.limiter.SetLimit(rate.Limit(ratePerSecond))
+	gc.limiter.SetBurst(1)
 
 	slog.Debug("Updated rate limiter",
 		"rate_per_second", ratePerSecond,
@@ -475,7 +784,14 @@ func (gc *GitHubCollector) updateRateLimiter() {
 		"effective_remaining", effectiveRemaining)
 }
 
-func (gc *GitHubCollector) collectOrgMetrics(ctx context.Context) error {
+func (gc *GitHubCollector) collectOrgMetrics(ctx context.Context) (phaseResult, error) {
+	collectors := gc.config.Collectors
+	if !collectors.OrgStatsEnabled() && !collectors.RepoStatsEnabled() && !collectors.ActionsPolicyEnabled() && !collectors.SSOEnabled() && !collectors.SecretsEnabled() {
+		return phaseResult{}, nil
+	}
+
+	orgs := gc.targetOrgs()
+
 	tracer := gc.app.GetTracer()
 
 	var collectorSpan *tracing.CollectorSpan
@@ -484,7 +800,7 @@ func (gc *GitHubCollector) collectOrgMetrics(ctx context.Context) error {
 	if tracer != nil && tracer.IsEnabled() {
 		collectorSpan = tracer.NewCollectorSpan(ctx, "github-collector", "collect-org-metrics")
 		collectorSpan.SetAttributes(
-			attribute.Int("orgs.count", len(gc.config.GitHub.Orgs)),
+			attribute.Int("orgs.count", len(orgs)),
 		)
 		spanCtx = collectorSpan.Context()
 		defer collectorSpan.End()
@@ -495,106 +811,91 @@ func (gc *GitHubCollector) collectOrgMetrics(ctx context.Context) error {
 	successCount := 0
 	errorCount := 0
 
-	// Collect metrics for each organization
-	for _, org := range gc.config.GitHub.Orgs {
-		orgStart := time.Now()
+	var errs []error
 
-		// Wait for rate limiter
-		if err := gc.limiter.Wait(spanCtx); err != nil {
-			if collectorSpan != nil {
-				collectorSpan.RecordError(err, attribute.String("org", org), attribute.String("operation", "rate-limiter-wait"))
-			}
-			errorCount++
+	// Collect metrics for each organization
+	for _, org := range prioritize(gc.skipped, "org", orgs, identity) {
+		if gc.pastDeadline(spanCtx, "org", org) {
 			continue
 		}
 
-		// Get organization information
-		apiStart := time.Now()
-		orgInfo, resp, err := gc.client.Organizations.Get(spanCtx, org)
-		apiDuration := time.Since(apiStart).Seconds()
+		orgStart := time.Now()
 
-		if err != nil {
-			slog.Error("Failed to get organization info", "org", org, "error", err)
-			if collectorSpan != nil {
-				collectorSpan.SetAttributes(
-					attribute.Float64("org.api_duration_seconds", apiDuration),
-				)
-				collectorSpan.RecordError(err, attribute.String("org", org), attribute.String("operation", "get-org-info"))
+		gc.cycle.add("org", org)
+
+		if gc.config.Collectors.OrgStatsEnabled() {
+			ok, err := gc.collectOrgInfo(spanCtx, collectorSpan, org)
+			if err != nil {
+				gc.status.record("org", org, err)
+				gc.inventory.keep(org, "")
+				errs = append(errs, err)
+				errorCount++
+				continue
 			}
-			gc.metrics.GitHubAPIErrorsTotal.With(prometheus.Labels{
-				"endpoint":   "orgs",
-				"error_type": "api_error",
-			}).Inc()
-			errorCount++
-			// Skip this org entirely - don't collect repos for a non-existent org
-			continue
-		}
 
-		// Update API call metrics
-		statusCode := "unknown"
-		if resp != nil {
-			statusCode = fmt.Sprintf("%d", resp.StatusCode)
+			if !ok {
+				// Skip this org entirely - don't collect repos for a non-existent org
+				continue
+			}
 		}
-		gc.metrics.GitHubAPICallsTotal.With(prometheus.Labels{
-			"endpoint": "orgs",
-			"status":   statusCode,
-		}).Inc()
 
-		// Check for 404 even if err is nil (some APIs return status without error)
-		if resp != nil && resp.StatusCode == 404 {
-			slog.Warn("Organization not found (404), skipping", "org", org)
-			// Skip this org entirely - don't collect repos for a non-existent org
-			continue
+		// Actions and runner permission settings
+		if gc.config.Collectors.ActionsPolicyEnabled() {
+			gc.setActionsPolicyMetrics(spanCtx, org)
 		}
 
-		// Validate organization info before proceeding
-		if orgInfo == nil {
-			slog.Error("Organization info is nil", "org", org)
-			continue
+		// SAML single sign-on configuration
+		if gc.config.Collectors.SSOEnabled() {
+			gc.setSSOMetrics(spanCtx, org)
 		}
 
-		// Set organization metrics
-		if orgInfo.PublicRepos != nil {
-			gc.metrics.GitHubOrgsPublicRepos.With(prometheus.Labels{
-				"org": org,
-			}).Set(float64(*orgInfo.PublicRepos))
-		}
-		if orgInfo.Followers != nil {
-			gc.metrics.GitHubOrgsFollowers.With(prometheus.Labels{
-				"org": org,
-			}).Set(float64(*orgInfo.Followers))
+		// Organization Actions secrets and variables
+		if gc.config.Collectors.SecretsEnabled() {
+			gc.setSecretMetrics(spanCtx, org, "")
 		}
-		if orgInfo.Following != nil {
-			gc.metrics.GitHubOrgsFollowing.With(prometheus.Labels{
-				"org": org,
-			}).Set(float64(*orgInfo.Following))
-		}
-
-		orgDuration := time.Since(orgStart).Seconds()
 
-		if collectorSpan != nil {
-			collectorSpan.AddEvent("org_info_retrieved",
-				attribute.String("org", org),
-				attribute.Float64("duration_seconds", orgDuration),
-			)
+		if !gc.config.Collectors.RepoStatsEnabled() {
+			gc.status.record("org", org, nil)
+			successCount++
+			continue
 		}
 
 		// Get repositories for the organization
 		// Only collect repos if org fetch was successful
 		reposStart := time.Now()
-		if err := gc.collectOrgRepos(spanCtx, org); err != nil {
+		repos, err := gc.collectOrgRepos(spanCtx, org)
+		successCount += repos.Succeeded
+		errorCount += repos.Failed
+
+		// A failure to list the repositories comes with an empty result
+		if err != nil && repos == (phaseResult{}) {
+			// Reaching the collection deadline while listing is not a failure of the organization
+			if gc.pastDeadline(spanCtx, "org", org) {
+				continue
+			}
+
 			reposDuration := time.Since(reposStart).Seconds()
-			slog.Error("Failed to collect organization repositories", "org", org, "error", err)
+			logError("Failed to collect organization repositories", wrapAPIError("repos", target{Org: org}, err))
 			if collectorSpan != nil {
 				collectorSpan.SetAttributes(
 					attribute.Float64("org.repos_duration_seconds", reposDuration),
 				)
-				collectorSpan.RecordError(err, attribute.String("org", org), attribute.String("operation", "collect-org-repos"))
+				collectorSpan.RecordError(redact.Error(err), attribute.String("org", org), attribute.String("operation", "collect-org-repos"))
 			}
+			gc.status.record("org", org, err)
+			gc.applyFailurePolicy(org, "")
+			gc.inventory.keep(org, "")
+			errs = append(errs, wrapAPIError("repos", target{Org: org}, err))
 			errorCount++
 			// Continue to next org instead of failing completely
 			continue
 		}
+
+		if err != nil {
+			// Some repositories failed, the organization itself was collected
+			errs = append(errs, err)
+		}
+
 		reposDuration := time.Since(reposStart).Seconds()
 		totalOrgDuration := time.Since(orgStart).Seconds()
 
@@ -609,17 +910,18 @@ func (gc *GitHubCollector) collectOrgMetrics(ctx context.Context) error {
 			)
 		}
 
+		gc.status.record("org", org, nil)
 		successCount++
 	}
 
 	// Set total organizations count
-	gc.metrics.GitHubOrgsTotal.With(prometheus.Labels{}).Set(float64(len(gc.config.GitHub.Orgs)))
+	gc.metrics.GitHubOrgsTotal.With(prometheus.Labels{}).Set(float64(len(orgs)))
 
 	if collectorSpan != nil {
 		collectorSpan.SetAttributes(
 			attribute.Int("collection.successful", successCount),
 			attribute.Int("collection.errors", errorCount),
-			attribute.Int("collection.total", len(gc.config.GitHub.Orgs)),
+			attribute.Int("collection.total", len(orgs)),
 		)
 		collectorSpan.AddEvent("org_metrics_completed",
 			attribute.Int("successful", successCount),
@@ -627,10 +929,110 @@ func (gc *GitHubCollector) collectOrgMetrics(ctx context.Context) error {
 		)
 	}
 
-	return nil
+	return phaseResult{Succeeded: successCount, Failed: errorCount}, errors.Join(errs...)
+}
+
+// collectOrgInfo fetches organization information and sets the organization metrics.
+// It returns false when the organization should be skipped, e.g. because it does not exist.
+func (gc *GitHubCollector) collectOrgInfo(ctx context.Context, collectorSpan *tracing.CollectorSpan, org string) (bool, error) {
+	orgStart := time.Now()
+
+	// Wait for rate limiter
+	if err := gc.limiter.Wait(ctx); err != nil {
+		if collectorSpan != nil {
+			collectorSpan.RecordError(redact.Error(err), attribute.String("org", org), attribute.String("operation", "rate-limiter-wait"))
+		}
+		return false, err
+	}
+
+	// Get organization information
+	apiStart := time.Now()
+	reqCtx, cancel := gc.requestContext(ctx, "orgs")
+	orgInfo, resp, err := gc.api.GetOrganization(reqCtx, org)
+	cancel()
+	apiDuration := time.Since(apiStart).Seconds()
+
+	if err != nil {
+		err = wrapAPIError("orgs", target{Org: org}, err)
+		gc.logTargetError("Failed to get organization info", "org", org, err)
+		if collectorSpan != nil {
+			collectorSpan.SetAttributes(
+				attribute.Float64("org.api_duration_seconds", apiDuration),
+			)
+			collectorSpan.RecordError(redact.Error(err), attribute.String("org", org), attribute.String("operation", "get-org-info"))
+		}
+		gc.recordAPIError("orgs", err)
+		return false, err
+	}
+
+	// Check for 404 even if err is nil (some APIs return status without error)
+	if resp != nil && resp.StatusCode == 404 {
+		slog.Warn("Organization not found (404), skipping", "org", org)
+		gc.status.record("org", org, fmt.Errorf("organization not found"))
+		return false, nil
+	}
+
+	// Validate organization info before proceeding
+	if orgInfo == nil {
+		slog.Error("Organization info is nil", "org", org)
+		gc.status.record("org", org, fmt.Errorf("organization info is nil"))
+		return false, nil
+	}
+
+	// Organization info metric, replacing the series of a previous plan or verification
+	verified := "false"
+	if orgInfo.IsVerified != nil && *orgInfo.IsVerified {
+		verified = "true"
+	}
+
+	gc.metrics.GitHubOrgInfo.DeletePartialMatch(prometheus.Labels{"org": org})
+	gc.metrics.GitHubOrgInfo.With(prometheus.Labels{
+		"org":      org,
+		"plan":     orgInfo.GetPlan().GetName(), // Only returned to organization members
+		"verified": verified,
+	}).Set(1)
+
+	// Set organization metrics
+	if orgInfo.PublicRepos != nil && gc.plausibleCount("org_public_repos", target{Org: org}, *orgInfo.PublicRepos) {
+		gc.metrics.GitHubOrgsPublicRepos.With(prometheus.Labels{
+			"org": org,
+		}).Set(float64(*orgInfo.PublicRepos))
+	}
+	if orgInfo.Followers != nil && gc.plausibleCount("org_followers", target{Org: org}, *orgInfo.Followers) {
+		gc.metrics.GitHubOrgsFollowers.With(prometheus.Labels{
+			"org": org,
+		}).Set(float64(*orgInfo.Followers))
+
+		gc.observeFollowers(followerKindOrg, org, *orgInfo.Followers)
+	}
+	if orgInfo.Following != nil && gc.plausibleCount("org_following", target{Org: org}, *orgInfo.Following) {
+		gc.metrics.GitHubOrgsFollowing.With(prometheus.Labels{
+			"org": org,
+		}).Set(float64(*orgInfo.Following))
+	}
+	// Only returned to organization owners
+	if orgInfo.TwoFactorRequirementEnabled != nil {
+		gc.metrics.GitHubOrgTwoFactorRequired.With(prometheus.Labels{
+			"org": org,
+		}).Set(boolToFloat(*orgInfo.TwoFactorRequirementEnabled))
+	}
+
+	orgDuration := time.Since(orgStart).Seconds()
+
+	if collectorSpan != nil {
+		collectorSpan.AddEvent("org_info_retrieved",
+			attribute.String("org", org),
+			attribute.Float64("duration_seconds", orgDuration),
+		)
+	}
+
+	return true, nil
 }
 
-func (gc *GitHubCollector) collectOrgRepos(ctx context.Context, org string) error {
+// collectOrgRepos collects the metrics of the repositories of an organization,
+// returning the joined errors of the repositories that failed. A failure to list
+// the repositories is returned with an empty result.
+func (gc *GitHubCollector) collectOrgRepos(ctx context.Context, org string) (phaseResult, error) {
 	tracer := gc.app.GetTracer()
 
 	var collectorSpan *tracing.CollectorSpan
@@ -651,27 +1053,14 @@ func (gc *GitHubCollector) collectOrgRepos(ctx context.Context, org string) erro
 	if org == "" {
 		err := fmt.Errorf("org parameter cannot be empty")
 		if collectorSpan != nil {
-			collectorSpan.RecordError(err)
+			collectorSpan.RecordError(redact.Error(err))
 		}
-		return err
-	}
-
-	// Wait for rate limiter
-	if err := gc.limiter.Wait(spanCtx); err != nil {
-		if collectorSpan != nil {
-			collectorSpan.RecordError(err, attribute.String("operation", "rate-limiter-wait"))
-		}
-		return fmt.Errorf("rate limiter error: %w", err)
+		return phaseResult{}, err
 	}
 
-	// List repositories for the organization
+	// List repositories for the organization (served from the discovery cache when fresh)
 	apiStart := time.Now()
-	repos, resp, err := gc.client.Repositories.ListByOrg(spanCtx, org, &github.RepositoryListByOrgOptions{
-		Type: "all",
-		ListOptions: github.ListOptions{
-			PerPage: 100,
-		},
-	})
+	repos, _, resp, err := gc.discoverOrgRepos(spanCtx, org)
 	apiDuration := time.Since(apiStart).Seconds()
 
 	if err != nil {
@@ -679,38 +1068,47 @@ func (gc *GitHubCollector) collectOrgRepos(ctx context.Context, org string) erro
 			collectorSpan.SetAttributes(
 				attribute.Float64("api_call.duration_seconds", apiDuration),
 			)
-			collectorSpan.RecordError(err, attribute.String("operation", "list-repos-by-org"))
+			collectorSpan.RecordError(redact.Error(err), attribute.String("operation", "list-repos-by-org"))
 		}
-		return fmt.Errorf("failed to list repositories for org %s: %w", org, err)
+		return phaseResult{}, wrapAPIError("repos", target{Org: org}, fmt.Errorf("failed to list repositories: %w", err))
 	}
 
 	// Skip if organization not found (404)
 	if resp != nil && resp.StatusCode == 404 {
 		slog.Warn("Organization not found, skipping repository collection", "org", org)
-		return nil
+		return phaseResult{}, nil
 	}
 
-	// Update API call metrics
-	statusCode := "unknown"
-	if resp != nil {
-		statusCode = fmt.Sprintf("%d", resp.StatusCode)
-	}
-	gc.metrics.GitHubAPICallsTotal.With(prometheus.Labels{
-		"endpoint": "repos",
-		"status":   statusCode,
-	}).Inc()
-
 	// Count repositories by visibility
 	publicCount := 0
 	privateCount := 0
 
-	for _, repo := range repos {
+	var result phaseResult
+	var errs []error
+
+	for _, repo := range prioritize(gc.skipped, "repo", repos, repoFullName) {
 		// Skip repos with missing required fields
 		if repo == nil || repo.Name == nil || *repo.Name == "" {
 			slog.Warn("Skipping repository with missing or empty name", "org", org)
 			continue
 		}
 
+		if gc.pastDeadline(ctx, "repo", org+"/"+*repo.Name) {
+			continue
+		}
+
+		// Wait for this target's slot in the refresh interval
+		if err := gc.waitForTargetSlot(ctx, org+"/"+*repo.Name); err != nil {
+			if gc.pastDeadline(ctx, "repo", org+"/"+*repo.Name) {
+				continue
+			}
+
+			gc.inventory.keep(org, *repo.Name)
+			errs = append(errs, wrapAPIError("repos", target{Org: org, Repo: *repo.Name}, err))
+			result.Failed++
+			continue
+		}
+
 		visibility := "public"
 		if repo.Private != nil && *repo.Private {
 			visibility = "private"
@@ -723,7 +1121,13 @@ func (gc *GitHubCollector) collectOrgRepos(ctx context.Context, org string) erro
 		}
 
 		// Set repository metrics
-		gc.setRepoMetrics(ctx, org, *repo.Name, visibility, repo)
+		if err := gc.setRepoMetrics(ctx, org, *repo.Name, visibility, repo); err != nil {
+			errs = append(errs, err)
+			result.Failed++
+			continue
+		}
+
+		result.Succeeded++
 	}
 
 	// Set repository counts (double-check org is not empty before setting metrics)
@@ -731,9 +1135,9 @@ func (gc *GitHubCollector) collectOrgRepos(ctx context.Context, org string) erro
 		err := fmt.Errorf("org parameter is empty when setting repo counts")
 		slog.Error("Cannot set GitHubReposTotal: org is empty")
 		if collectorSpan != nil {
-			collectorSpan.RecordError(err)
+			collectorSpan.RecordError(redact.Error(err))
 		}
-		return err
+		return phaseResult{}, err
 	}
 	gc.metrics.GitHubReposTotal.With(prometheus.Labels{
 		"org":        org,
@@ -758,10 +1162,16 @@ func (gc *GitHubCollector) collectOrgRepos(ctx context.Context, org string) erro
 		)
 	}
 
-	return nil
+	return result, errors.Join(errs...)
 }
 
-func (gc *GitHubCollector) collectRepoMetrics(ctx context.Context) error {
+func (gc *GitHubCollector) collectRepoMetrics(ctx context.Context) (phaseResult, error) {
+	if !gc.config.Collectors.RepoStatsEnabled() {
+		return phaseResult{}, nil
+	}
+
+	repos := gc.targetRepos()
+
 	tracer := gc.app.GetTracer()
 
 	var collectorSpan *tracing.CollectorSpan
@@ -770,7 +1180,7 @@ func (gc *GitHubCollector) collectRepoMetrics(ctx context.Context) error {
 	if tracer != nil && tracer.IsEnabled() {
 		collectorSpan = tracer.NewCollectorSpan(ctx, "github-collector", "collect-repo-metrics")
 		collectorSpan.SetAttributes(
-			attribute.Int("repos.count", len(gc.config.GitHub.Repos)),
+			attribute.Int("repos.count", len(repos)),
 			attribute.Bool("repos.wildcard", gc.hasWildcardRepos()),
 		)
 		spanCtx = collectorSpan.Context()
@@ -790,13 +1200,20 @@ func (gc *GitHubCollector) collectRepoMetrics(ctx context.Context) error {
 	successCount := 0
 	errorCount := 0
 
+	var errs []error
+
 	// Collect metrics for specific repositories
-	for _, repoFullName := range gc.config.GitHub.Repos {
+	for _, repoFullName := range prioritize(gc.skipped, "repo", repos, identity) {
+		if gc.pastDeadline(spanCtx, "repo", repoFullName) {
+			continue
+		}
+
 		repoStart := time.Now()
 
 		parts := strings.Split(repoFullName, "/")
 		if len(parts) != 2 {
 			slog.Error("Invalid repository format", "repo", repoFullName)
+			errs = append(errs, fmt.Errorf("invalid repository format %q", repoFullName))
 			errorCount++
 			continue
 		}
@@ -807,6 +1224,19 @@ func (gc *GitHubCollector) collectRepoMetrics(ctx context.Context) error {
 		// Skip if owner or repo is empty
 		if owner == "" || repo == "" {
 			slog.Error("Invalid repository format: owner or repo is empty", "repo", repoFullName)
+			errs = append(errs, fmt.Errorf("invalid repository format %q: owner or repo is empty", repoFullName))
+			errorCount++
+			continue
+		}
+
+		// Wait for this target's slot in the refresh interval
+		if err := gc.waitForTargetSlot(spanCtx, owner+"/"+repo); err != nil {
+			if gc.pastDeadline(spanCtx, "repo", repoFullName) {
+				continue
+			}
+
+			gc.inventory.keep(owner, repo)
+			errs = append(errs, wrapAPIError("repos", target{Org: owner, Repo: repo}, err))
 			errorCount++
 			continue
 		}
@@ -814,40 +1244,49 @@ func (gc *GitHubCollector) collectRepoMetrics(ctx context.Context) error {
 		// Wait for rate limiter
 		if err := gc.limiter.Wait(spanCtx); err != nil {
 			if collectorSpan != nil {
-				collectorSpan.RecordError(err, attribute.String("repo", repoFullName), attribute.String("operation", "rate-limiter-wait"))
+				collectorSpan.RecordError(redact.Error(err), attribute.String("repo", repoFullName), attribute.String("operation", "rate-limiter-wait"))
 			}
+			gc.status.record("repo", repoFullName, err)
+			gc.inventory.keep(owner, repo)
+			errs = append(errs, wrapAPIError("repos", target{Org: owner, Repo: repo}, fmt.Errorf("rate limiter error: %w", err)))
 			errorCount++
 			continue
 		}
 
 		// Get repository information
 		apiStart := time.Now()
-		repoInfo, resp, err := gc.client.Repositories.Get(spanCtx, owner, repo)
+		reqCtx, cancel := gc.requestContext(spanCtx, "repos")
+		repoInfo, _, err := gc.api.GetRepository(reqCtx, owner, repo)
+		cancel()
 		apiDuration := time.Since(apiStart).Seconds()
 
 		if err != nil {
 			repoDuration := time.Since(repoStart).Seconds()
-			slog.Error("Failed to get repository info", "owner", owner, "repo", repo, "error", err)
+			err = wrapAPIError("repos", target{Org: owner, Repo: repo}, err)
+			gc.logTargetError("Failed to get repository info", "repo", repoFullName, err)
 			if collectorSpan != nil {
 				collectorSpan.SetAttributes(
 					attribute.Float64("repo.api_duration_seconds", apiDuration),
 					attribute.Float64("repo.total_duration_seconds", repoDuration),
 				)
-				collectorSpan.RecordError(err, attribute.String("repo", repoFullName), attribute.String("operation", "get-repo-info"))
+				collectorSpan.RecordError(redact.Error(err), attribute.String("repo", repoFullName), attribute.String("operation", "get-repo-info"))
+			}
+			gc.recordAPIError("repos", err)
+			gc.status.record("repo", repoFullName, err)
+			gc.applyFailurePolicy(owner, repo)
+
+			// Repositories that no longer exist are dropped from the inventory
+			if apiErrorStatusCode(err) != http.StatusNotFound {
+				gc.inventory.keep(owner, repo)
 			}
-			gc.metrics.GitHubAPIErrorsTotal.With(prometheus.Labels{
-				"endpoint":   "repos",
-				"error_type": "api_error",
-			}).Inc()
+
+			errs = append(errs, err)
 			errorCount++
 			continue
 		}
 
-		// Update API call metrics
-		gc.metrics.GitHubAPICallsTotal.With(prometheus.Labels{
-			"endpoint": "repos",
-			"status":   fmt.Sprintf("%d", resp.StatusCode),
-		}).Inc()
+		// Collect renamed and transferred repositories under their new name
+		owner, repo = gc.canonicalRepo(owner, repo, repoInfo)
 
 		// Set repository metrics
 		visibility := "public"
@@ -856,7 +1295,7 @@ func (gc *GitHubCollector) collectRepoMetrics(ctx context.Context) error {
 		}
 
 		metricsStart := time.Now()
-		gc.setRepoMetrics(spanCtx, owner, repo, visibility, repoInfo)
+		metricsErr := gc.setRepoMetrics(spanCtx, owner, repo, visibility, repoInfo)
 		metricsDuration := time.Since(metricsStart).Seconds()
 		repoDuration := time.Since(repoStart).Seconds()
 
@@ -872,14 +1311,51 @@ func (gc *GitHubCollector) collectRepoMetrics(ctx context.Context) error {
 			)
 		}
 
+		if metricsErr != nil {
+			if collectorSpan != nil {
+				collectorSpan.RecordError(redact.Error(metricsErr), attribute.String("repo", repoFullName), attribute.String("operation", "set-repo-metrics"))
+			}
+			errs = append(errs, metricsErr)
+			errorCount++
+			continue
+		}
+
 		successCount++
 	}
 
+	// Collect metrics for repositories of the configured teams
+	for _, team := range gc.config.GitHub.Teams {
+		result, err := gc.collectTeamRepos(spanCtx, team)
+		successCount += result.Succeeded
+		errorCount += result.Failed
+
+		if err != nil {
+			if collectorSpan != nil {
+				collectorSpan.RecordError(redact.Error(err), attribute.String("team", team), attribute.String("operation", "collect-team-repos"))
+			}
+			errs = append(errs, fmt.Errorf("team %s: %w", team, err))
+		}
+	}
+
+	// Collect metrics for repositories starred by the authenticated user
+	if gc.config.GitHub.Starred {
+		result, err := gc.collectStarredRepos(spanCtx)
+		successCount += result.Succeeded
+		errorCount += result.Failed
+
+		if err != nil {
+			if collectorSpan != nil {
+				collectorSpan.RecordError(redact.Error(err), attribute.String("operation", "collect-starred-repos"))
+			}
+			errs = append(errs, fmt.Errorf("starred repositories: %w", err))
+		}
+	}
+
 	if collectorSpan != nil {
 		collectorSpan.SetAttributes(
 			attribute.Int("collection.successful", successCount),
 			attribute.Int("collection.errors", errorCount),
-			attribute.Int("collection.total", len(gc.config.GitHub.Repos)),
+			attribute.Int("collection.total", len(repos)+len(gc.config.GitHub.Teams)),
 		)
 		collectorSpan.AddEvent("repo_metrics_completed",
 			attribute.Int("successful", successCount),
@@ -887,23 +1363,64 @@ func (gc *GitHubCollector) collectRepoMetrics(ctx context.Context) error {
 		)
 	}
 
-	return nil
+	return phaseResult{Succeeded: successCount, Failed: errorCount}, errors.Join(errs...)
+}
+
+// collectStarredRepos collects metrics for all repositories starred by the
+// authenticated user. Failing to list them counts as a single failed target.
+func (gc *GitHubCollector) collectStarredRepos(ctx context.Context) (phaseResult, error) {
+	repos, _, err := gc.discoverStarredRepos(ctx)
+	if err != nil {
+		logError("Failed to collect starred repositories", err)
+		gc.inventory.keepAll()
+
+		return phaseResult{Failed: 1}, err
+	}
+
+	slog.Debug("Collecting starred repositories", "count", len(repos))
+
+	return gc.collectDiscoveredRepos(ctx, repos)
 }
 
-func (gc *GitHubCollector) setRepoMetrics(ctx context.Context, owner, repo, visibility string, repoInfo *github.Repository) {
+// collectTeamRepos collects metrics for all repositories a team has access to.
+// Failing to list them counts as a single failed target.
+func (gc *GitHubCollector) collectTeamRepos(ctx context.Context, team string) (phaseResult, error) {
+	repos, _, err := gc.discoverTeamRepos(ctx, team)
+	gc.status.record("team", team, err)
+
+	if err != nil {
+		logError("Failed to collect team repositories", err, "team", team)
+		gc.inventory.keepAll()
+
+		return phaseResult{Failed: 1}, err
+	}
+
+	slog.Debug("Collecting team repositories", "team", team, "count", len(repos))
+
+	return gc.collectDiscoveredRepos(ctx, repos)
+}
+
+// setRepoMetrics sets the metrics of a repository, returning the joined errors of
+// the collectors that failed. The metrics of the other collectors are still set.
+func (gc *GitHubCollector) setRepoMetrics(ctx context.Context, owner, repo, visibility string, repoInfo *github.Repository) error {
 	// Validate required parameters to prevent panic from missing labels
 	if owner == "" {
 		slog.Warn("Skipping setRepoMetrics: owner is empty", "repo", repo)
-		return
+		return nil
 	}
 	if repo == "" {
 		slog.Warn("Skipping setRepoMetrics: repo is empty", "owner", owner)
-		return
+		return nil
 	}
 	if visibility == "" {
 		visibility = "unknown"
 	}
 
+	gc.status.record("repo", owner+"/"+repo, nil)
+	gc.inventory.add(owner, repo, visibility, repoInfo)
+	gc.cycle.add("repo", owner+"/"+repo)
+	gc.setRepoLabels(owner, repo, repoInfo)
+
 	// Repository info metric with labels
 	archived := "false"
 	if repoInfo.Archived != nil && *repoInfo.Archived {
@@ -930,8 +1447,10 @@ func (gc *GitHubCollector) setRepoMetrics(ctx context.Context, owner, repo, visi
 		"language":   language,
 	}).Set(1)
 
+	t := target{Org: owner, Repo: repo}
+
 	// Stars
-	if repoInfo.StargazersCount != nil {
+	if repoInfo.StargazersCount != nil && gc.plausibleCount("repo_stars", t, *repoInfo.StargazersCount) {
 		gc.metrics.GitHubReposStars.With(prometheus.Labels{
 			"org":        owner,
 			"repo":       repo,
@@ -940,7 +1459,7 @@ func (gc *GitHubCollector) setRepoMetrics(ctx context.Context, owner, repo, visi
 	}
 
 	// Forks
-	if repoInfo.ForksCount != nil {
+	if repoInfo.ForksCount != nil && gc.plausibleCount("repo_forks", t, *repoInfo.ForksCount) {
 		gc.metrics.GitHubReposForks.With(prometheus.Labels{
 			"org":        owner,
 			"repo":       repo,
@@ -949,7 +1468,7 @@ func (gc *GitHubCollector) setRepoMetrics(ctx context.Context, owner, repo, visi
 	}
 
 	// Watchers
-	if repoInfo.WatchersCount != nil {
+	if repoInfo.WatchersCount != nil && gc.plausibleCount("repo_watchers", t, *repoInfo.WatchersCount) {
 		gc.metrics.GitHubReposWatchers.With(prometheus.Labels{
 			"org":        owner,
 			"repo":       repo,
@@ -958,7 +1477,7 @@ func (gc *GitHubCollector) setRepoMetrics(ctx context.Context, owner, repo, visi
 	}
 
 	// Open issues
-	if repoInfo.OpenIssuesCount != nil {
+	if repoInfo.OpenIssuesCount != nil && gc.plausibleCount("repo_open_issues", t, *repoInfo.OpenIssuesCount) {
 		gc.metrics.GitHubReposOpenIssues.With(prometheus.Labels{
 			"org":        owner,
 			"repo":       repo,
@@ -966,11 +1485,85 @@ func (gc *GitHubCollector) setRepoMetrics(ctx context.Context, owner, repo, visi
 		}).Set(float64(*repoInfo.OpenIssuesCount))
 	}
 
+	var errs []error
+
 	// Open PRs - we need to fetch this separately as it's not in the basic repo info
-	gc.setOpenPRsMetric(ctx, owner, repo, visibility)
+	if gc.config.Collectors.PullRequestsEnabled() {
+		errs = append(errs, gc.setOpenPRsMetric(ctx, owner, repo, visibility))
+	}
+
+	// Comments created since the previous cycle
+	if gc.config.Collectors.CommentsEnabled() {
+		errs = append(errs, gc.setCommentMetrics(ctx, owner, repo))
+	}
+
+	// Time to first response for issues opened since the previous cycle
+	if gc.config.Collectors.FirstResponseEnabled() {
+		errs = append(errs, gc.setFirstResponseMetrics(ctx, owner, repo))
+	}
+
+	// Issues opened and closed since the previous cycle
+	if gc.config.Collectors.IssueActivityEnabled() {
+		errs = append(errs, gc.setIssueActivityMetrics(ctx, owner, repo))
+	}
+
+	// CODEOWNERS coverage
+	if gc.config.Collectors.CodeownersEnabled() {
+		errs = append(errs, gc.setCodeownersMetrics(ctx, owner, repo))
+	}
+
+	// Security policy compliance
+	if gc.config.Collectors.SecurityPolicyEnabled() {
+		errs = append(errs, gc.setSecurityPolicyMetrics(ctx, owner, repo))
+	}
+
+	// Tag protection
+	if gc.config.Collectors.TagProtectionEnabled() {
+		errs = append(errs, gc.setTagProtectionMetrics(ctx, owner, repo))
+	}
+
+	// Ruleset and branch protection bypass actors
+	if gc.config.Collectors.BypassActorsEnabled() {
+		errs = append(errs, gc.setBypassActorMetrics(ctx, owner, repo))
+	}
+
+	// Actions secrets and variables
+	if gc.config.Collectors.SecretsEnabled() {
+		errs = append(errs, gc.setSecretMetrics(ctx, owner, repo))
+	}
+
+	// OIDC subject claims and deployment environment secrets
+	if gc.config.Collectors.OIDCEnabled() {
+		errs = append(errs, gc.setOIDCMetrics(ctx, owner, repo, int(repoInfo.GetID())))
+	}
+
+	// Issue and pull request templates and contributing guides
+	if gc.config.Collectors.CommunityFilesEnabled() {
+		errs = append(errs, gc.setCommunityFileMetrics(ctx, owner, repo, repoInfo.GetPushedAt().Time))
+	}
+
+	// Renovate and Dependabot configuration
+	if gc.config.Collectors.DependencyAutomationEnabled() {
+		errs = append(errs, gc.setDependencyAutomationMetrics(ctx, owner, repo, repoInfo.GetPushedAt().Time))
+	}
+
+	// Release cadence
+	if gc.config.Collectors.ReleasesEnabled() {
+		errs = append(errs, gc.setReleaseMetrics(ctx, owner, repo, repoInfo.GetDefaultBranch()))
+	}
+
+	// Workflow state and schedules
+	if gc.config.Collectors.WorkflowStateEnabled() || gc.config.Collectors.SchedulesEnabled() {
+		errs = append(errs, gc.setWorkflowMetrics(ctx, owner, repo))
+	}
+
+	// Webhook deliveries can only be read with admin access to the repository
+	if gc.config.Collectors.WebhooksEnabled() && repoInfo.GetPermissions()["admin"] {
+		errs = append(errs, gc.setWebhookMetrics(ctx, owner, repo))
+	}
 
 	// Size
-	if repoInfo.Size != nil {
+	if repoInfo.Size != nil && gc.plausibleCount("repo_size_bytes", t, *repoInfo.Size) {
 		gc.metrics.GitHubReposSize.With(prometheus.Labels{
 			"org":        owner,
 			"repo":       repo,
@@ -979,7 +1572,7 @@ func (gc *GitHubCollector) setRepoMetrics(ctx context.Context, owner, repo, visi
 	}
 
 	// Last updated
-	if repoInfo.UpdatedAt != nil {
+	if repoInfo.UpdatedAt != nil && gc.plausibleTimestamp("repo_last_updated_timestamp", t, repoInfo.UpdatedAt.Time) {
 		gc.metrics.GitHubReposLastUpdated.With(prometheus.Labels{
 			"org":        owner,
 			"repo":       repo,
@@ -988,63 +1581,71 @@ func (gc *GitHubCollector) setRepoMetrics(ctx context.Context, owner, repo, visi
 	}
 
 	// Created at
-	if repoInfo.CreatedAt != nil {
+	if repoInfo.CreatedAt != nil && gc.plausibleTimestamp("repo_created_timestamp", t, repoInfo.CreatedAt.Time) {
 		gc.metrics.GitHubReposCreatedAt.With(prometheus.Labels{
 			"org":        owner,
 			"repo":       repo,
 			"visibility": visibility,
 		}).Set(float64(repoInfo.CreatedAt.Unix()))
 	}
+
+	return errors.Join(errs...)
 }
 
 // setOpenPRsMetric fetches and sets the open PRs count for a repository
-func (gc *GitHubCollector) setOpenPRsMetric(ctx context.Context, owner, repo, visibility string) {
+func (gc *GitHubCollector) setOpenPRsMetric(ctx context.Context, owner, repo, visibility string) error {
+	// Use GitHub Search API to get exact count of open pull requests
+	query := fmt.Sprintf("repo:%s/%s type:pr state:open", owner, repo)
+
+	openPRsCount, err := gc.searchTotal(ctx, target{Org: owner, Repo: repo}, query)
+	if err != nil {
+		logError("Failed to search open PRs", err)
+		return err
+	}
+
+	if !gc.plausibleCount("repo_open_prs", target{Org: owner, Repo: repo}, openPRsCount) {
+		return nil
+	}
+
+	gc.metrics.GitHubReposOpenPRs.With(prometheus.Labels{
+		"org":        owner,
+		"repo":       repo,
+		"visibility": visibility,
+	}).Set(float64(openPRsCount))
+
+	return nil
+}
+
+// searchTotal returns the number of issues and pull requests matching a search query
+func (gc *GitHubCollector) searchTotal(ctx context.Context, t target, query string) (int, error) {
 	// Wait for rate limiter
 	if err := gc.limiter.Wait(ctx); err != nil {
-		slog.Error("Rate limiter error while fetching PRs", "owner", owner, "repo", repo, "error", err)
-		return
+		return 0, wrapAPIError("search_issues", t, fmt.Errorf("rate limiter error: %w", err))
 	}
 
-	// Use GitHub Search API to get exact count of open pull requests
-	query := fmt.Sprintf("repo:%s/%s type:pr state:open", owner, repo)
-	searchResult, resp, err := gc.client.Search.Issues(ctx, query, &github.SearchOptions{
+	reqCtx, cancel := gc.requestContext(ctx, "search_issues")
+	searchResult, _, err := gc.api.SearchIssues(reqCtx, query, &github.SearchOptions{
 		ListOptions: github.ListOptions{
-			PerPage: 1, // We only need the count, not the actual PRs
+			PerPage: 1, // We only need the count, not the actual results
 		},
 	})
+	cancel()
 	if err != nil {
-		slog.Error("Failed to search open PRs", "owner", owner, "repo", repo, "error", err)
-		gc.metrics.GitHubAPIErrorsTotal.With(prometheus.Labels{
-			"endpoint":   "search_issues",
-			"error_type": "api_error",
-		}).Inc()
-		return
-	}
-
-	// Update API call metrics
-	if resp != nil {
-		gc.metrics.GitHubAPICallsTotal.With(prometheus.Labels{
-			"endpoint": "search_issues",
-			"status":   fmt.Sprintf("%d", resp.StatusCode),
-		}).Inc()
+		gc.recordAPIError("search_issues", err)
+		return 0, wrapAPIError("search_issues", t, err)
 	}
 
 	// Get the exact count from search results
-	openPRsCount := 0
-	if searchResult != nil && searchResult.Total != nil {
-		openPRsCount = *searchResult.Total
+	if searchResult == nil || searchResult.Total == nil {
+		return 0, nil
 	}
 
-	gc.metrics.GitHubReposOpenPRs.With(prometheus.Labels{
-		"org":        owner,
-		"repo":       repo,
-		"visibility": visibility,
-	}).Set(float64(openPRsCount))
+	return *searchResult.Total, nil
 }
 
 // hasWildcardRepos checks if "*" is specified in the repos list
 func (gc *GitHubCollector) hasWildcardRepos() bool {
-	for _, repo := range gc.config.GitHub.Repos {
+	for _, repo := range gc.targetRepos() {
 		if repo == "*" {
 			return true
 		}
@@ -1052,58 +1653,33 @@ func (gc *GitHubCollector) hasWildcardRepos() bool {
 	return false
 }
 
-// collectAllRepos collects metrics for all repositories the user has access to
-func (gc *GitHubCollector) collectAllRepos(ctx context.Context) error {
+// collectAllRepos collects metrics for all repositories the user has access to.
+// Failing to list them counts as a single failed target.
+func (gc *GitHubCollector) collectAllRepos(ctx context.Context) (phaseResult, error) {
 	slog.Info("Wildcard repos specified, collecting all accessible repositories")
 
-	var allRepos []*github.Repository
-	page := 1
-	perPage := 100
-
-	for {
-		// Wait for rate limiter
-		if err := gc.limiter.Wait(ctx); err != nil {
-			return fmt.Errorf("rate limiter error: %w", err)
-		}
-
-		// Get repositories for current page
-		repos, resp, err := gc.client.Repositories.ListByAuthenticatedUser(ctx, &github.RepositoryListByAuthenticatedUserOptions{
-			Type: "all",
-			ListOptions: github.ListOptions{
-				Page:    page,
-				PerPage: perPage,
-			},
-		})
-		if err != nil {
-			slog.Error("Failed to list repositories", "page", page, "error", err)
-			gc.metrics.GitHubAPIErrorsTotal.With(prometheus.Labels{
-				"endpoint":   "repos",
-				"error_type": "api_error",
-			}).Inc()
-			return fmt.Errorf("failed to list repositories page %d: %w", page, err)
-		}
+	allRepos, _, err := gc.discoverAllRepos(ctx)
+	if err != nil {
+		gc.inventory.keepAll()
+		return phaseResult{Failed: 1}, err
+	}
 
-		// Update API call metrics
-		if resp != nil {
-			gc.metrics.GitHubAPICallsTotal.With(prometheus.Labels{
-				"endpoint": "repos",
-				"status":   fmt.Sprintf("%d", resp.StatusCode),
-			}).Inc()
-		}
+	result, err := gc.collectDiscoveredRepos(ctx, allRepos)
 
-		// Add repos to our collection
-		allRepos = append(allRepos, repos...)
+	slog.Info("Collected metrics for repositories", "count", len(allRepos), "failed", result.Failed)
 
-		// Check if we've reached the last page
-		if resp == nil || page >= resp.LastPage || len(repos) < perPage {
-			break
-		}
+	return result, err
+}
 
-		page++
-	}
+// collectDiscoveredRepos sets repository metrics for repositories returned by
+// discovery, returning the joined errors of the repositories that failed.
+// Repositories served from the discovery cache are not fetched again, their
+// details are as recent as the listing.
+func (gc *GitHubCollector) collectDiscoveredRepos(ctx context.Context, repos []*github.Repository) (phaseResult, error) {
+	var result phaseResult
+	var errs []error
 
-	// Process each repository
-	for _, repo := range allRepos {
+	for _, repo := range prioritize(gc.skipped, "repo", repos, repoFullName) {
 		if repo == nil || repo.Name == nil || repo.Owner == nil || repo.Owner.Login == nil {
 			slog.Warn("Skipping repository with missing required fields", "repo", repo)
 			continue
@@ -1118,6 +1694,22 @@ func (gc *GitHubCollector) collectAllRepos(ctx context.Context) error {
 			continue
 		}
 
+		if gc.pastDeadline(ctx, "repo", owner+"/"+repoName) {
+			continue
+		}
+
+		// Wait for this target's slot in the refresh interval
+		if err := gc.waitForTargetSlot(ctx, owner+"/"+repoName); err != nil {
+			if gc.pastDeadline(ctx, "repo", owner+"/"+repoName) {
+				continue
+			}
+
+			gc.inventory.keep(owner, repoName)
+			errs = append(errs, wrapAPIError("repos", target{Org: owner, Repo: repoName}, err))
+			result.Failed++
+			continue
+		}
+
 		// Determine visibility
 		visibility := "public"
 		if repo.Private != nil && *repo.Private {
@@ -1125,40 +1717,65 @@ func (gc *GitHubCollector) collectAllRepos(ctx context.Context) error {
 		}
 
 		// Set repository metrics
-		gc.setRepoMetrics(ctx, owner, repoName, visibility, repo)
-	}
+		if err := gc.setRepoMetrics(ctx, owner, repoName, visibility, repo); err != nil {
+			errs = append(errs, err)
+			result.Failed++
+			continue
+		}
 
-	slog.Info("Collected metrics for repositories", "count", len(allRepos))
+		result.Succeeded++
+	}
 
-	return nil
+	return result, errors.Join(errs...)
 }
 
-// collectBuildStatusMetrics collects build status metrics for configured branches
-func (gc *GitHubCollector) collectBuildStatusMetrics(ctx context.Context) error {
+// collectBuildStatusMetrics collects build status metrics for the given branches
+func (gc *GitHubCollector) collectBuildStatusMetrics(ctx context.Context, branches []string) error {
+	defer gc.setBuildStatusRollups()
+	defer gc.setSuccessRatios()
+	defer gc.setOrgRollups()
+
 	// Check if wildcard is specified for repos
 	if gc.hasWildcardRepos() {
-		return gc.collectBuildStatusForAllRepos(ctx)
+		return gc.collectBuildStatusForAllRepos(ctx, branches)
 	}
 
 	// Collect metrics for specific repositories
-	for _, repoFullName := range gc.config.GitHub.Repos {
+	for _, repoFullName := range prioritize(gc.skipped, "build_status", gc.targetRepos(), identity) {
 		parts := strings.Split(repoFullName, "/")
 		if len(parts) != 2 {
 			slog.Error("Invalid repository format", "repo", repoFullName)
 			continue
 		}
 
+		if gc.pastDeadline(ctx, "build_status", repoFullName) {
+			continue
+		}
+
 		owner := parts[0]
 		repo := parts[1]
 
-		// Collect build status for each configured branch
-		for _, branchName := range gc.config.GitHub.Branches {
-			if err := gc.collectBranchBuildStatus(ctx, owner, repo, branchName); err != nil {
-				slog.Error("Failed to collect branch build status", "owner", owner, "repo", repo, "branch", branchName, "error", err)
-				gc.metrics.GitHubAPIErrorsTotal.With(prometheus.Labels{
-					"endpoint":   "build_status",
-					"error_type": "branch_error",
-				}).Inc()
+		// Wait for this target's slot in the refresh interval, unless it took one collecting repository metrics
+		if err := gc.waitForTargetSlot(ctx, repoFullName); err != nil {
+			if gc.pastDeadline(ctx, "build_status", repoFullName) {
+				continue
+			}
+
+			return err
+		}
+
+		// Collect build status for each branch
+		for _, branchName := range branches {
+			branch := owner + "/" + repo + "@" + branchName
+			if gc.isMissing("branch", branch) {
+				continue
+			}
+
+			err := gc.collectBranchBuildStatus(ctx, owner, repo, branchName)
+			gc.status.record("branch", branch, err)
+			if err != nil {
+				gc.logTargetError("Failed to collect branch build status", "branch", branch, err)
+				gc.recordError("build_status", "branch_error", err)
 			}
 		}
 	}
@@ -1167,57 +1784,16 @@ func (gc *GitHubCollector) collectBuildStatusMetrics(ctx context.Context) error
 }
 
 // collectBuildStatusForAllRepos collects build status metrics for all accessible repositories
-func (gc *GitHubCollector) collectBuildStatusForAllRepos(ctx context.Context) error {
+func (gc *GitHubCollector) collectBuildStatusForAllRepos(ctx context.Context, branches []string) error {
 	slog.Debug("Collecting build status metrics for all accessible repositories")
 
-	var allRepos []*github.Repository
-	page := 1
-	perPage := 100
-
-	for {
-		// Wait for rate limiter
-		if err := gc.limiter.Wait(ctx); err != nil {
-			return fmt.Errorf("rate limiter error: %w", err)
-		}
-
-		// Get repositories for current page
-		repos, resp, err := gc.client.Repositories.ListByAuthenticatedUser(ctx, &github.RepositoryListByAuthenticatedUserOptions{
-			Type: "all",
-			ListOptions: github.ListOptions{
-				Page:    page,
-				PerPage: perPage,
-			},
-		})
-		if err != nil {
-			slog.Error("Failed to list repositories", "page", page, "error", err)
-			gc.metrics.GitHubAPIErrorsTotal.With(prometheus.Labels{
-				"endpoint":   "repos",
-				"error_type": "api_error",
-			}).Inc()
-			return fmt.Errorf("failed to list repositories page %d: %w", page, err)
-		}
-
-		// Update API call metrics
-		if resp != nil {
-			gc.metrics.GitHubAPICallsTotal.With(prometheus.Labels{
-				"endpoint": "repos",
-				"status":   fmt.Sprintf("%d", resp.StatusCode),
-			}).Inc()
-		}
-
-		// Add repos to our collection
-		allRepos = append(allRepos, repos...)
-
-		// Check if we've reached the last page
-		if resp == nil || page >= resp.LastPage || len(repos) < perPage {
-			break
-		}
-
-		page++
+	allRepos, _, err := gc.discoverAllRepos(ctx)
+	if err != nil {
+		return err
 	}
 
 	// Collect build status for each repository and branch
-	for _, repo := range allRepos {
+	for _, repo := range prioritize(gc.skipped, "build_status", allRepos, repoFullName) {
 		if repo == nil || repo.Owner == nil || repo.Name == nil {
 			slog.Warn("Skipping repository with missing required fields for build status", "repo", repo)
 			continue
@@ -1232,14 +1808,26 @@ func (gc *GitHubCollector) collectBuildStatusForAllRepos(ctx context.Context) er
 			continue
 		}
 
-		// Collect build status for each configured branch
-		for _, branchName := range gc.config.GitHub.Branches {
-			if err := gc.collectBranchBuildStatus(ctx, owner, repoName, branchName); err != nil {
-				slog.Error("Failed to collect branch build status", "owner", owner, "repo", repoName, "branch", branchName, "error", err)
-				gc.metrics.GitHubAPIErrorsTotal.With(prometheus.Labels{
-					"endpoint":   "build_status",
-					"error_type": "branch_error",
-				}).Inc()
+		if gc.pastDeadline(ctx, "build_status", owner+"/"+repoName) {
+			continue
+		}
+
+		// Wait for this target's slot in the refresh interval, unless it took one collecting repository metrics
+		if err := gc.waitForTargetSlot(ctx, owner+"/"+repoName); err != nil {
+			if gc.pastDeadline(ctx, "build_status", owner+"/"+repoName) {
+				continue
+			}
+
+			return err
+		}
+
+		// Collect build status for each branch
+		for _, branchName := range branches {
+			err := gc.collectBranchBuildStatus(ctx, owner, repoName, branchName)
+			gc.status.record("branch", owner+"/"+repoName+"@"+branchName, err)
+			if err != nil {
+				logError("Failed to collect branch build status", err)
+				gc.recordError("build_status", "branch_error", err)
 			}
 		}
 	}
@@ -1250,33 +1838,39 @@ func (gc *GitHubCollector) collectBuildStatusForAllRepos(ctx context.Context) er
 
 // collectBranchBuildStatus collects build status for a specific branch
 func (gc *GitHubCollector) collectBranchBuildStatus(ctx context.Context, owner, repo, branch string) error {
+	t := target{Org: owner, Repo: repo, Branch: branch}
+
+	gc.cycle.add("branch", t.String())
+
 	// Wait for rate limiter
 	if err := gc.limiter.Wait(ctx); err != nil {
-		return fmt.Errorf("rate limiter error: %w", err)
+		return wrapAPIError("workflow_runs", t, fmt.Errorf("rate limiter error: %w", err))
 	}
 
 	// Get workflow runs for the repository (we'll filter by branch in processing)
-	workflowRuns, resp, err := gc.client.Actions.ListRepositoryWorkflowRuns(ctx, owner, repo, &github.ListWorkflowRunsOptions{
+	reqCtx, cancel := gc.requestContext(ctx, "workflow_runs")
+	workflowRuns, _, err := gc.api.ListWorkflowRuns(reqCtx, owner, repo, &github.ListWorkflowRunsOptions{
 		ListOptions: github.ListOptions{
 			PerPage: 50, // Get more runs to filter by branch
 		},
 	})
+	cancel()
 	if err != nil {
-		return fmt.Errorf("failed to get workflow runs for branch %s: %w", branch, err)
-	}
-
-	// Update API call metrics
-	if resp != nil {
-		gc.metrics.GitHubAPICallsTotal.With(prometheus.Labels{
-			"endpoint": "workflow_runs",
-			"status":   fmt.Sprintf("%d", resp.StatusCode),
-		}).Inc()
+		gc.recordAPIError("workflow_runs", err)
+		return wrapAPIError("workflow_runs", t, fmt.Errorf("failed to get workflow runs: %w", err))
 	}
 
 	// Process workflow runs
 	branchStatus := 1.0 // Default to success
 	hasRuns := false
 
+	// Runs on the branch, for counting completed runs
+	var branchRuns []*github.WorkflowRun
+
+	// Latest run of each workflow, for deep links, and latest completed run, for billable time
+	latest := make(map[int64]*github.WorkflowRun)
+	latestCompleted := make(map[int64]*github.WorkflowRun)
+
 	for _, run := range workflowRuns.WorkflowRuns {
 		if run.WorkflowID == nil || run.Name == nil || run.HeadBranch == nil {
 			continue
@@ -1288,7 +1882,9 @@ func (gc *GitHubCollector) collectBranchBuildStatus(ctx context.Context, owner,
 		}
 
 		hasRuns = true
+		branchRuns = append(branchRuns, run)
 		workflowName := *run.Name
+		gc.cycle.add("workflow", owner+"/"+repo+"/"+workflowName)
 		conclusion := "unknown"
 		if run.Conclusion != nil {
 			conclusion = *run.Conclusion
@@ -1320,6 +1916,15 @@ func (gc *GitHubCollector) collectBranchBuildStatus(ctx context.Context, owner,
 		if statusValue < branchStatus {
 			branchStatus = statusValue
 		}
+
+		// Runs are listed newest first
+		if _, ok := latest[*run.WorkflowID]; !ok {
+			latest[*run.WorkflowID] = run
+		}
+
+		if _, ok := latestCompleted[*run.WorkflowID]; !ok && run.GetStatus() == "completed" {
+			latestCompleted[*run.WorkflowID] = run
+		}
 	}
 
 	// Set branch build status metric
@@ -1331,9 +1936,36 @@ func (gc *GitHubCollector) collectBranchBuildStatus(ctx context.Context, owner,
 		}).Set(branchStatus)
 	}
 
+	for _, run := range latest {
+		gc.setLatestRunInfo(owner, repo, branch, run)
+	}
+
+	// Count runs that completed since the previous cycle
+	if gc.config.Collectors.WorkflowRunsEnabled() {
+		gc.countWorkflowRuns(ctx, owner, repo, branch, branchRuns)
+	}
+
+	// Get billable time of the latest completed runs
+	if gc.config.Collectors.WorkflowUsageEnabled() {
+		for _, run := range latestCompleted {
+			if err := gc.collectWorkflowRunUsage(ctx, owner, repo, branch, run); err != nil {
+				logError("Failed to collect workflow run usage", err)
+			}
+		}
+	}
+
 	// Get check runs for the branch
-	if err := gc.collectCheckRuns(ctx, owner, repo, branch); err != nil {
-		slog.Error("Failed to collect check runs", "owner", owner, "repo", repo, "branch", branch, "error", err)
+	if gc.config.Collectors.CheckRunsEnabled() {
+		if err := gc.collectCheckRuns(ctx, owner, repo, branch); err != nil {
+			logError("Failed to collect check runs", err)
+		}
+	}
+
+	// Count new commits on the branch
+	if gc.config.Collectors.CommitsEnabled() {
+		if err := gc.collectBranchCommits(ctx, owner, repo, branch); err != nil {
+			logError("Failed to collect branch commits", err)
+		}
 	}
 
 	return nil
@@ -1341,31 +1973,56 @@ func (gc *GitHubCollector) collectBranchBuildStatus(ctx context.Context, owner,
 
 // collectCheckRuns collects check run status for a specific branch
 func (gc *GitHubCollector) collectCheckRuns(ctx context.Context, owner, repo, branch string) error {
+	t := target{Org: owner, Repo: repo, Branch: branch}
+
 	// Wait for rate limiter
 	if err := gc.limiter.Wait(ctx); err != nil {
-		return fmt.Errorf("rate limiter error: %w", err)
+		return wrapAPIError("check_runs", t, fmt.Errorf("rate limiter error: %w", err))
 	}
 
 	// Get check runs for the branch
-	checkRuns, resp, err := gc.client.Checks.ListCheckRunsForRef(ctx, owner, repo, branch, &github.ListCheckRunsOptions{
+	reqCtx, cancel := gc.requestContext(ctx, "check_runs")
+	checkRuns, _, err := gc.api.ListCheckRunsForRef(reqCtx, owner, repo, branch, &github.ListCheckRunsOptions{
 		ListOptions: github.ListOptions{
 			PerPage: 100,
 		},
 	})
+	cancel()
 	if err != nil {
-		return fmt.Errorf("failed to get check runs for branch %s: %w", branch, err)
+		gc.recordAPIError("check_runs", err)
+		return wrapAPIError("check_runs", t, fmt.Errorf("failed to get check runs: %w", err))
+	}
+
+	runs := checkRuns.CheckRuns
+	if gc.config.GitHub.BuildStatus.LatestCheckSuite {
+		runs = latestCheckRuns(runs)
+	}
+
+	// Report only required checks, which then decide the branch status
+	requiredOnly := false
+	if gc.config.GitHub.BuildStatus.RequiredOnly {
+		required, err := gc.requiredChecks(ctx, owner, repo, branch)
+		if err != nil {
+			return err
+		}
+
+		if len(required) > 0 {
+			runs = requiredRuns(runs, required)
+			requiredOnly = true
+		}
 	}
 
-	// Update API call metrics
-	if resp != nil {
-		gc.metrics.GitHubAPICallsTotal.With(prometheus.Labels{
-			"endpoint": "check_runs",
-			"status":   fmt.Sprintf("%d", resp.StatusCode),
-		}).Inc()
+	// Drop the series of superseded runs, including earlier conclusions of
+	// checks that were re-run
+	if gc.config.GitHub.BuildStatus.LatestCheckSuite {
+		gc.metrics.GitHubCheckRunStatus.DeletePartialMatch(prometheus.Labels{"org": owner, "repo": repo, "branch": branch})
 	}
 
+	// Pending until a required check reports
+	branchStatus := 2.0
+
 	// Process check runs
-	for _, checkRun := range checkRuns.CheckRuns {
+	for i, checkRun := range runs {
 		if checkRun.Name == nil {
 			continue
 		}
@@ -1385,11 +2042,37 @@ func (gc *GitHubCollector) collectCheckRuns(ctx context.Context, owner, repo, br
 			"branch":     branch,
 			"conclusion": conclusion,
 		}).Set(statusValue)
+
+		// Worst status wins, as for workflow runs
+		if i == 0 || statusValue < branchStatus {
+			branchStatus = statusValue
+		}
+	}
+
+	if requiredOnly {
+		gc.metrics.GitHubBranchBuildStatus.With(prometheus.Labels{
+			"org":    owner,
+			"repo":   repo,
+			"branch": branch,
+		}).Set(branchStatus)
 	}
 
 	return nil
 }
 
+// requestContext returns a context bounded by the configured timeout for an API
+// endpoint, labelling the requests made with it for the API metrics
+func (gc *GitHubCollector) requestContext(ctx context.Context, endpoint string) (context.Context, context.CancelFunc) {
+	ctx = withEndpoint(ctx, endpoint)
+
+	timeout := gc.config.GitHub.TimeoutFor(endpoint)
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, timeout)
+}
+
 // getStatusValue converts GitHub status/conclusion to numeric value
 func (gc *GitHubCollector) getStatusValue(conclusion string) float64 {
 	switch conclusion {
@@ -6,6 +6,7 @@ import (
 	"github.com/d0ugal/github-exporter/internal/config"
 	"github.com/d0ugal/github-exporter/internal/metrics"
 	promexporter_metrics "github.com/d0ugal/promexporter/metrics"
+	"golang.org/x/time/rate"
 )
 
 // createTestCollector creates a test GitHubCollector for testing
@@ -20,8 +21,23 @@ func createTestCollector() *GitHubCollector {
 	metricsRegistry := metrics.NewGitHubRegistry(baseRegistry)
 
 	return &GitHubCollector{
-		config:  cfg,
-		metrics: metricsRegistry,
+		config:         cfg,
+		metrics:        metricsRegistry,
+		discovery:      newDiscoveryCache(),
+		scheduler:      &targetScheduler{},
+		status:         newStatusTracker(),
+		inventory:      newRepoInventory(),
+		projects:       newProjectTracker(),
+		commits:        newCommitTracker(),
+		usage:          newUsageCache(),
+		schedules:      newScheduleCache(),
+		activity:       newSinceTracker(),
+		responses:      newResponseTracker(),
+		cycle:          newCycleTargets(),
+		skipped:        newSkipTracker(),
+		resyncs:        make(chan target, resyncQueueSize),
+		graphqlQuota:   newGraphQLQuota(),
+		graphqlLimiter: newSharedLimiter(rate.Inf, 1),
 	}
 }
 
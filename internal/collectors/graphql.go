@@ -0,0 +1,63 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// graphqlRequest is the body of a GraphQL API request
+type graphqlRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables,omitempty"`
+}
+
+// graphqlError is a single error returned by the GraphQL API
+type graphqlError struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+// graphqlResponse is the envelope of a GraphQL API response
+type graphqlResponse struct {
+	Data   interface{}    `json:"data"`
+	Errors []graphqlError `json:"errors"`
+}
+
+// graphql executes a GraphQL query through the REST client, so it shares its
+// authentication and transport, and decodes the data field into out
+func (gc *GitHubCollector) graphql(ctx context.Context, endpoint, query string, variables map[string]interface{}, out interface{}) error {
+	// Wait for the GraphQL rate limiter, the GraphQL quota is separate from REST
+	if err := gc.graphqlLimiter.Wait(ctx); err != nil {
+		return fmt.Errorf("rate limiter error: %w", err)
+	}
+
+	result := graphqlResponse{Data: out}
+
+	reqCtx, cancel := gc.requestContext(ctx, endpoint)
+	resp, err := gc.api.GraphQL(reqCtx, &graphqlRequest{
+		Query:     query,
+		Variables: variables,
+	}, &result)
+	cancel()
+
+	gc.recordGraphQLRate(endpoint, resp)
+
+	if err != nil {
+		gc.recordAPIError(endpoint, err)
+		return err
+	}
+
+	if len(result.Errors) > 0 {
+		messages := make([]string, 0, len(result.Errors))
+		for _, e := range result.Errors {
+			messages = append(messages, e.Message)
+		}
+
+		gc.recordError(endpoint, "graphql_error", nil)
+
+		return fmt.Errorf("GraphQL query failed: %s", strings.Join(messages, "; "))
+	}
+
+	return nil
+}
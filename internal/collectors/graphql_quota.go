@@ -0,0 +1,171 @@
+package collectors
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v76/github"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+)
+
+// graphqlQuota tracks the point-based rate limit of the GraphQL API, which is
+// independent of the REST API quota
+type graphqlQuota struct {
+	mu        sync.Mutex
+	limit     int
+	remaining int
+	used      int
+	reset     time.Time
+
+	// Points and queries spent in the current and the last completed collection cycle
+	cycleCost        int
+	cycleQueries     int
+	lastCycleCost    int
+	lastCycleQueries int
+}
+
+func newGraphQLQuota() *graphqlQuota {
+	return &graphqlQuota{}
+}
+
+// observe records the rate limit reported with a GraphQL response and returns
+// the points the query cost. The cost is the increase of the used points since
+// the previous response, so queries made with the same token by other clients
+// in the meantime are attributed to this one. It is at least 1, the minimum
+// cost of a query.
+func (q *graphqlQuota) observe(r github.Rate) int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	cost := 1
+	sameWindow := r.Reset.Time.Equal(q.reset)
+
+	switch {
+	case sameWindow && r.Used > q.used:
+		cost = r.Used - q.used
+	case !sameWindow && !q.reset.IsZero() && r.Used > 0:
+		// A new rate limit window started, everything used in it is ours
+		cost = r.Used
+	}
+
+	q.update(r)
+	q.cycleCost += cost
+	q.cycleQueries++
+
+	return cost
+}
+
+// update records the current rate limit without attributing any cost
+func (q *graphqlQuota) update(r github.Rate) {
+	q.limit = r.Limit
+	q.remaining = r.Remaining
+	q.used = r.Used
+	q.reset = r.Reset.Time
+}
+
+// endCycle records the cost of the completed collection cycle and starts
+// counting the next one
+func (q *graphqlQuota) endCycle() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.lastCycleCost, q.lastCycleQueries = q.cycleCost, q.cycleQueries
+	q.cycleCost, q.cycleQueries = 0, 0
+}
+
+// snapshot returns the remaining points, the reset time and the points and
+// queries spent in the last completed cycle
+func (q *graphqlQuota) snapshot() (remaining int, reset time.Time, cycleCost, cycleQueries int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.remaining, q.reset, q.lastCycleCost, q.lastCycleQueries
+}
+
+// recordGraphQLRate updates the GraphQL rate limit state and metrics from a
+// response, attributing the cost of the query to endpoint
+func (gc *GitHubCollector) recordGraphQLRate(endpoint string, resp *github.Response) {
+	// Responses without rate limit headers, e.g. from a proxy, carry no information
+	if resp == nil || resp.Rate.Limit == 0 {
+		return
+	}
+
+	cost := gc.graphqlQuota.observe(resp.Rate)
+
+	gc.metrics.GitHubGraphQLQueryCost.With(prometheus.Labels{"endpoint": endpoint}).Add(float64(cost))
+	gc.setGraphQLRateMetrics(resp.Rate)
+	gc.updateGraphQLRateLimiter()
+}
+
+// setGraphQLRateMetrics exports the GraphQL rate limit
+func (gc *GitHubCollector) setGraphQLRateMetrics(r github.Rate) {
+	gc.metrics.GitHubGraphQLRateLimitTotal.With(prometheus.Labels{}).Set(float64(r.Limit))
+	gc.metrics.GitHubGraphQLRateLimitRemaining.With(prometheus.Labels{}).Set(float64(r.Remaining))
+
+	if !r.Reset.IsZero() {
+		gc.metrics.GitHubGraphQLRateLimitReset.With(prometheus.Labels{}).Set(float64(r.Reset.Unix()))
+	}
+}
+
+// updateGraphQLRateLimiter paces GraphQL queries so the remaining points last
+// until the rate limit resets, based on the average cost of the queries made
+// in the last cycle
+func (gc *GitHubCollector) updateGraphQLRateLimiter() {
+	remaining, resetTime, cycleCost, cycleQueries := gc.graphqlQuota.snapshot()
+
+	if remaining <= 0 || resetTime.IsZero() {
+		return
+	}
+
+	timeUntilReset := time.Until(resetTime)
+	if timeUntilReset <= 0 {
+		timeUntilReset = time.Hour
+	}
+
+	averageCost := 1.0
+	if cycleQueries > 0 && cycleCost > cycleQueries {
+		averageCost = float64(cycleCost) / float64(cycleQueries)
+	}
+
+	effectiveRemaining := float64(remaining) * gc.config.GitHub.RateLimitBuffer
+	queriesPerSecond := effectiveRemaining / averageCost / timeUntilReset.Seconds()
+
+	gc.graphqlLimiter.SetLimit(rate.Limit(queriesPerSecond))
+	gc.graphqlLimiter.SetBurst(1)
+
+	slog.Debug("Updated GraphQL rate limiter",
+		"queries_per_second", queriesPerSecond,
+		"remaining_points", remaining,
+		"average_cost", averageCost,
+		"time_until_reset", timeUntilReset)
+}
+
+// graphqlRefreshInterval returns the shortest refresh interval the remaining
+// GraphQL points allow at the cost of the last cycle, or 0 if it made no
+// GraphQL queries
+func (gc *GitHubCollector) graphqlRefreshInterval() time.Duration {
+	remaining, resetTime, cycleCost, _ := gc.graphqlQuota.snapshot()
+
+	if cycleCost == 0 || resetTime.IsZero() {
+		return 0
+	}
+
+	timeUntilReset := time.Until(resetTime)
+	if timeUntilReset <= 0 {
+		return 0
+	}
+
+	availablePoints := int(float64(remaining) * gc.config.GitHub.RateLimitBuffer)
+	if availablePoints <= 0 {
+		return timeUntilReset
+	}
+
+	cyclesPossible := availablePoints / cycleCost
+	if cyclesPossible <= 0 {
+		cyclesPossible = 1
+	}
+
+	return timeUntilReset / time.Duration(cyclesPossible)
+}
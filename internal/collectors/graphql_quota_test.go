@@ -0,0 +1,107 @@
+package collectors
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v76/github"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// TestGraphQLQuotaObserve tests attributing points to queries from the used points
+func TestGraphQLQuotaObserve(t *testing.T) {
+	reset := github.Timestamp{Time: time.Now().Add(time.Hour).Truncate(time.Second)}
+	quota := newGraphQLQuota()
+
+	if cost := quota.observe(github.Rate{Limit: 5000, Remaining: 4900, Used: 100, Reset: reset}); cost != 1 {
+		t.Errorf("Expected the first query to cost the minimum of 1, got %d", cost)
+	}
+
+	if cost := quota.observe(github.Rate{Limit: 5000, Remaining: 4880, Used: 120, Reset: reset}); cost != 20 {
+		t.Errorf("Expected a cost of 20 points, got %d", cost)
+	}
+
+	nextReset := github.Timestamp{Time: reset.Add(time.Hour)}
+	if cost := quota.observe(github.Rate{Limit: 5000, Remaining: 4995, Used: 5, Reset: nextReset}); cost != 5 {
+		t.Errorf("Expected a cost of 5 points in the new window, got %d", cost)
+	}
+
+	quota.endCycle()
+
+	remaining, _, cycleCost, cycleQueries := quota.snapshot()
+	if remaining != 4995 || cycleCost != 26 || cycleQueries != 3 {
+		t.Errorf("Unexpected snapshot: remaining %d, cost %d, queries %d", remaining, cycleCost, cycleQueries)
+	}
+}
+
+// TestGraphQLRateLimitMetrics tests that GraphQL responses update the GraphQL
+// rate limit metrics rather than the REST ones
+func TestGraphQLRateLimitMetrics(t *testing.T) {
+	reset := time.Now().Add(time.Hour).Unix()
+	used := 10
+
+	collector := newAPITestCollector(t, func(w http.ResponseWriter, r *http.Request) {
+		used += 3
+		w.Header().Set("X-RateLimit-Limit", "5000")
+		w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(5000-used))
+		w.Header().Set("X-RateLimit-Used", strconv.Itoa(used))
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(reset, 10))
+		w.Header().Set("X-RateLimit-Resource", "graphql")
+		_, _ = w.Write([]byte(`{"data": {}}`))
+	})
+
+	var data struct{}
+	for i := 0; i < 2; i++ {
+		if err := collector.graphql(t.Context(), "graphql_test", "{ viewer { login } }", nil, &data); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubGraphQLRateLimitRemaining); got != 4984 {
+		t.Errorf("Expected 4984 GraphQL points remaining, got %v", got)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubGraphQLRateLimitReset); got != float64(reset) {
+		t.Errorf("Expected reset timestamp %d, got %v", reset, got)
+	}
+
+	// The first query costs the minimum, the second the increase in used points
+	if got := testutil.ToFloat64(collector.metrics.GitHubGraphQLQueryCost.WithLabelValues("graphql_test")); got != 4 {
+		t.Errorf("Expected 4 points spent, got %v", got)
+	}
+
+	if got := testutil.CollectAndCount(collector.metrics.GitHubRateLimitRemaining); got != 0 {
+		t.Errorf("Expected the REST rate limit to be untouched, got %d series", got)
+	}
+}
+
+// TestCalculateRefreshIntervalGraphQL tests that a tight GraphQL quota lengthens
+// the refresh interval even when the REST quota has plenty of room
+func TestCalculateRefreshIntervalGraphQL(t *testing.T) {
+	collector := createTestCollector()
+	collector.config.GitHub.RateLimitBuffer = 1
+	collector.config.GitHub.Repos = []string{"d0ugal/a"}
+	collector.rateLimitTotal = 5000
+	collector.rateLimitRemaining = 5000
+	collector.rateLimitReset = time.Now().Add(time.Hour)
+
+	restOnly := collector.calculateRefreshInterval()
+
+	// Each cycle spends 500 points and only 1000 remain, so two cycles fit in the hour
+	reset := github.Timestamp{Time: time.Now().Add(time.Hour)}
+	collector.graphqlQuota.update(github.Rate{Limit: 5000, Remaining: 1500, Used: 3500, Reset: reset})
+	collector.graphqlQuota.observe(github.Rate{Limit: 5000, Remaining: 1000, Used: 4000, Reset: reset})
+	collector.graphqlQuota.endCycle()
+
+	withGraphQL := collector.calculateRefreshInterval()
+
+	if withGraphQL <= restOnly {
+		t.Errorf("Expected a longer interval with a tight GraphQL quota, got %s (REST only %s)", withGraphQL, restOnly)
+	}
+
+	if withGraphQL < 29*time.Minute || withGraphQL > 30*time.Minute {
+		t.Errorf("Expected an interval of about 30 minutes, got %s", withGraphQL)
+	}
+}
@@ -0,0 +1,154 @@
+package collectors
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/google/go-github/v76/github"
+)
+
+// RepositoryInfo describes a collected repository for service discovery
+type RepositoryInfo struct {
+	Org        string
+	Repo       string
+	Visibility string
+	Language   string
+	Topics     []string
+}
+
+// FullName returns the repository in "org/repo" form
+func (ri RepositoryInfo) FullName() string {
+	return ri.Org + "/" + ri.Repo
+}
+
+// repoInventory tracks the repositories collected, keyed by full name. It is
+// rebuilt every collection cycle: repositories neither collected nor kept, such
+// as deleted, renamed, excluded or removed targets, are swept at its end.
+type repoInventory struct {
+	mu    sync.RWMutex
+	repos map[string]RepositoryInfo
+	seen  map[string]bool // Repositories collected or kept this cycle, nil outside a cycle
+	all   bool            // Keep every repository this cycle
+}
+
+func newRepoInventory() *repoInventory {
+	return &repoInventory{
+		repos: make(map[string]RepositoryInfo),
+	}
+}
+
+// add records a collected repository
+func (ri *repoInventory) add(owner, repo, visibility string, repoInfo *github.Repository) {
+	info := RepositoryInfo{
+		Org:        owner,
+		Repo:       repo,
+		Visibility: visibility,
+		Language:   repoInfo.GetLanguage(),
+		Topics:     append([]string(nil), repoInfo.Topics...),
+	}
+
+	ri.mu.Lock()
+	defer ri.mu.Unlock()
+
+	ri.repos[info.FullName()] = info
+
+	if ri.seen != nil {
+		ri.seen[info.FullName()] = true
+	}
+}
+
+// begin starts rebuilding the inventory for a collection cycle
+func (ri *repoInventory) begin() {
+	ri.mu.Lock()
+	defer ri.mu.Unlock()
+
+	ri.seen = make(map[string]bool)
+	ri.all = false
+}
+
+// keep retains the repositories of a target that failed or was skipped this
+// cycle: a repository, or all repositories of an owner when repo is empty
+func (ri *repoInventory) keep(owner, repo string) {
+	ri.mu.Lock()
+	defer ri.mu.Unlock()
+
+	if ri.seen == nil {
+		return
+	}
+
+	for name, info := range ri.repos {
+		if info.Org == owner && (repo == "" || info.Repo == repo) {
+			ri.seen[name] = true
+		}
+	}
+}
+
+// keepAll retains every repository this cycle, when a listing failed and it
+// is unknown which repositories it would have returned
+func (ri *repoInventory) keepAll() {
+	ri.mu.Lock()
+	defer ri.mu.Unlock()
+
+	ri.all = true
+}
+
+// sweep ends the cycle, removing the repositories that were neither collected
+// nor kept, and returns the full names of the removed repositories
+func (ri *repoInventory) sweep() []string {
+	ri.mu.Lock()
+	defer ri.mu.Unlock()
+
+	if ri.seen == nil {
+		return nil
+	}
+
+	var removed []string
+
+	if !ri.all {
+		for name := range ri.repos {
+			if !ri.seen[name] {
+				delete(ri.repos, name)
+				removed = append(removed, name)
+			}
+		}
+	}
+
+	ri.seen = nil
+
+	sort.Strings(removed)
+
+	return removed
+}
+
+// has reports whether a repository has been collected
+func (ri *repoInventory) has(owner, repo string) bool {
+	ri.mu.RLock()
+	defer ri.mu.RUnlock()
+
+	_, ok := ri.repos[owner+"/"+repo]
+
+	return ok
+}
+
+// list returns all recorded repositories sorted by full name
+func (ri *repoInventory) list() []RepositoryInfo {
+	ri.mu.RLock()
+	defer ri.mu.RUnlock()
+
+	repos := make([]RepositoryInfo, 0, len(ri.repos))
+	for _, info := range ri.repos {
+		repos = append(repos, info)
+	}
+
+	sort.Slice(repos, func(i, j int) bool {
+		return repos[i].FullName() < repos[j].FullName()
+	})
+
+	return repos
+}
+
+// Repositories returns the repositories collected so far, including those
+// discovered through organizations and wildcards
+func (gc *GitHubCollector) Repositories() []RepositoryInfo {
+	return gc.inventory.list()
+}
@@ -0,0 +1,51 @@
+package collectors
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v76/github"
+)
+
+// TestInventorySweep tests that repositories neither collected nor kept during
+// a cycle are removed from the inventory at its end
+func TestInventorySweep(t *testing.T) {
+	inventory := newRepoInventory()
+	for _, name := range []string{"collected", "failed", "deleted"} {
+		inventory.add("d0ugal", name, "public", &github.Repository{})
+	}
+
+	inventory.add("acme", "api", "public", &github.Repository{})
+
+	inventory.begin()
+	inventory.add("d0ugal", "collected", "public", &github.Repository{})
+	inventory.keep("d0ugal", "failed")
+	inventory.keep("acme", "")
+
+	if removed := inventory.sweep(); len(removed) != 1 || removed[0] != "d0ugal/deleted" {
+		t.Errorf("Expected d0ugal/deleted to be removed, got %v", removed)
+	}
+
+	if inventory.has("d0ugal", "deleted") {
+		t.Error("Expected the repository that was not collected to be removed")
+	}
+
+	for _, name := range []string{"d0ugal/collected", "d0ugal/failed", "acme/api"} {
+		owner, repo, _ := strings.Cut(name, "/")
+		if !inventory.has(owner, repo) {
+			t.Errorf("Expected %s to be kept", name)
+		}
+	}
+
+	// A failed listing keeps everything, and sweeping outside a cycle does nothing
+	inventory.begin()
+	inventory.keepAll()
+
+	if removed := inventory.sweep(); len(removed) != 0 {
+		t.Errorf("Expected nothing to be removed after a failed listing, got %v", removed)
+	}
+
+	if removed := inventory.sweep(); len(removed) != 0 || len(inventory.list()) != 3 {
+		t.Errorf("Expected the inventory to be unchanged outside a cycle, got %v removed", removed)
+	}
+}
@@ -0,0 +1,58 @@
+package collectors
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// setIssueActivityMetrics counts the issues opened and closed since the previous
+// cycle, so the growth of the backlog can be told apart from its throughput.
+// Pull requests are not counted.
+func (gc *GitHubCollector) setIssueActivityMetrics(ctx context.Context, owner, repo string) error {
+	t := target{Org: owner, Repo: repo}
+	now := time.Now()
+
+	labels := prometheus.Labels{"org": owner, "repo": repo}
+
+	key := "issue_activity:" + t.String()
+
+	since, ok := gc.activity.advance(key, now)
+	if !ok {
+		gc.metrics.GitHubIssuesOpenedTotal.With(labels).Add(0)
+		gc.metrics.GitHubIssuesClosedTotal.With(labels).Add(0)
+
+		return nil
+	}
+
+	// Opening or closing an issue updates it, so both are in this listing
+	issues, err := gc.listIssuesSince(ctx, owner, repo, since)
+	if err != nil {
+		gc.activity.rewind(key, since)
+		logError("Failed to list issues", err)
+
+		return err
+	}
+
+	opened, closed := 0, 0
+
+	for _, issue := range issues {
+		if issue.IsPullRequest() {
+			continue
+		}
+
+		if inWindow(issue.CreatedAt, since, now) {
+			opened++
+		}
+
+		if inWindow(issue.ClosedAt, since, now) {
+			closed++
+		}
+	}
+
+	gc.metrics.GitHubIssuesOpenedTotal.With(labels).Add(float64(opened))
+	gc.metrics.GitHubIssuesClosedTotal.With(labels).Add(float64(closed))
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package collectors
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// TestSetIssueActivityMetrics tests counting issues opened and closed since the
+// previous cycle, ignoring pull requests and issues only edited since
+func TestSetIssueActivityMetrics(t *testing.T) {
+	recent := time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)
+
+	collector := newAPITestCollector(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/d0ugal/private/issues" {
+			t.Errorf("Unexpected request path %s", r.URL.Path)
+		}
+
+		if r.URL.Query().Get("since") == "" || r.URL.Query().Get("state") != "all" {
+			t.Errorf("Unexpected query %s", r.URL.RawQuery)
+		}
+
+		_, _ = fmt.Fprintf(w, `[
+			{"number": 1, "created_at": %q},
+			{"number": 2, "created_at": %q, "closed_at": %q},
+			{"number": 3, "created_at": "2020-01-01T00:00:00Z", "closed_at": %q},
+			{"number": 4, "created_at": "2020-01-01T00:00:00Z"},
+			{"number": 5, "created_at": %q, "closed_at": %q, "pull_request": {"url": "https://api.github.com/repos/d0ugal/private/pulls/5"}}
+		]`, recent, recent, recent, recent, recent, recent)
+	})
+
+	// Seed the previous cycle
+	collector.activity.advance("issue_activity:d0ugal/private", time.Now().Add(-time.Hour))
+
+	collector.setIssueActivityMetrics(t.Context(), "d0ugal", "private")
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubIssuesOpenedTotal.WithLabelValues("d0ugal", "private")); got != 2 {
+		t.Errorf("Expected 2 opened issues, got %v", got)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubIssuesClosedTotal.WithLabelValues("d0ugal", "private")); got != 2 {
+		t.Errorf("Expected 2 closed issues, got %v", got)
+	}
+}
+
+// TestSetIssueActivityMetricsFirstCycle tests that the first cycle only seeds the tracker
+func TestSetIssueActivityMetricsFirstCycle(t *testing.T) {
+	collector := newAPITestCollector(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Unexpected request %s", r.URL)
+	})
+
+	collector.setIssueActivityMetrics(t.Context(), "d0ugal", "private")
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubIssuesOpenedTotal.WithLabelValues("d0ugal", "private")); got != 0 {
+		t.Errorf("Expected no opened issues on the first cycle, got %v", got)
+	}
+}
@@ -0,0 +1,83 @@
+package collectors
+
+import (
+	"strconv"
+	"sync"
+
+	"github.com/google/go-github/v76/github"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// latestRunTracker remembers the labels of the exported latest run of each
+// workflow and branch, so the series of a superseded run can be deleted without
+// scanning every series of the metric
+type latestRunTracker struct {
+	mu     sync.Mutex
+	series map[latestRunKey]prometheus.Labels
+}
+
+// latestRunKey identifies a workflow on a branch
+type latestRunKey struct {
+	branch   target
+	workflow string
+}
+
+// replace stores the labels of the latest run under key, returning the labels
+// it replaces or nil
+func (l *latestRunTracker) replace(key latestRunKey, labels prometheus.Labels) prometheus.Labels {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.series == nil {
+		l.series = make(map[latestRunKey]prometheus.Labels)
+	}
+
+	previous := l.series[key]
+	l.series[key] = labels
+
+	return previous
+}
+
+// setLatestRunInfo exports the latest run of a workflow on a branch, replacing
+// the series of the run it supersedes
+func (gc *GitHubCollector) setLatestRunInfo(owner, repo, branch string, run *github.WorkflowRun) {
+	conclusion := run.GetConclusion()
+	if conclusion == "" {
+		conclusion = "unknown"
+	}
+
+	// Left empty unless enabled, as each run adds a new SHA
+	headSHA, actor := "", ""
+	if gc.config.GitHub.RunAttribution {
+		headSHA = shortSHA(run.GetHeadSHA())
+		actor = run.GetActor().GetLogin()
+	}
+
+	labels := prometheus.Labels{
+		"org":        owner,
+		"repo":       repo,
+		"workflow":   run.GetName(),
+		"branch":     branch,
+		"run_id":     strconv.FormatInt(run.GetID(), 10),
+		"html_url":   run.GetHTMLURL(),
+		"conclusion": conclusion,
+		"head_sha":   headSHA,
+		"actor":      actor,
+	}
+
+	key := latestRunKey{branch: target{Org: owner, Repo: repo, Branch: branch}, workflow: run.GetName()}
+	if previous := gc.latestRuns.replace(key, labels); previous != nil {
+		gc.metrics.GitHubWorkflowLatestRunInfo.Delete(previous)
+	}
+
+	gc.metrics.GitHubWorkflowLatestRunInfo.With(labels).Set(1)
+}
+
+// shortSHA abbreviates a commit SHA to the 7 characters GitHub displays
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+
+	return sha
+}
@@ -0,0 +1,96 @@
+package collectors
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/google/go-github/v76/github"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// TestLatestRunInfo tests that only the newest run of each workflow on a branch
+// is exported, replacing the run it supersedes
+func TestLatestRunInfo(t *testing.T) {
+	collector, api := newFakeCollector()
+
+	run := func(id int64, conclusion string) *github.WorkflowRun {
+		return &github.WorkflowRun{
+			ID:         github.Ptr(id),
+			WorkflowID: github.Ptr(int64(1)),
+			Name:       github.Ptr("CI"),
+			HeadBranch: github.Ptr("main"),
+			Conclusion: github.Ptr(conclusion),
+			HTMLURL:    github.Ptr("https://github.com/d0ugal/github-exporter/actions/runs/" + strconv.FormatInt(id, 10)),
+		}
+	}
+
+	api.workflowRuns["d0ugal/github-exporter"] = []*github.WorkflowRun{run(2, "failure"), run(1, "success")}
+
+	if err := collector.collectBranchBuildStatus(t.Context(), "d0ugal", "github-exporter", "main"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	latest := prometheus.Labels{
+		"org":        "d0ugal",
+		"repo":       "github-exporter",
+		"workflow":   "CI",
+		"branch":     "main",
+		"run_id":     "2",
+		"html_url":   "https://github.com/d0ugal/github-exporter/actions/runs/2",
+		"conclusion": "failure",
+		"head_sha":   "",
+		"actor":      "",
+	}
+	if got := testutil.ToFloat64(collector.metrics.GitHubWorkflowLatestRunInfo.With(latest)); got != 1 {
+		t.Errorf("Expected the latest run to be exported, got %v", got)
+	}
+
+	// A new run in progress supersedes the failed one
+	inProgress := run(3, "")
+	inProgress.Conclusion = nil
+	api.workflowRuns["d0ugal/github-exporter"] = []*github.WorkflowRun{inProgress, run(2, "failure")}
+
+	if err := collector.collectBranchBuildStatus(t.Context(), "d0ugal", "github-exporter", "main"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	series := matchingSeries(collector.metrics.GitHubWorkflowLatestRunInfo, prometheus.Labels{"workflow": "CI"})
+	if len(series) != 1 {
+		t.Fatalf("Expected a single series for the workflow, got %v", series)
+	}
+
+	if got := series[0].labels; got["run_id"] != "3" || got["conclusion"] != "unknown" {
+		t.Errorf("Expected run 3 with an unknown conclusion, got %v", got)
+	}
+}
+
+// TestLatestRunInfoAttribution tests that the short head SHA and actor are only
+// exported when enabled, and that the series of a superseded run is removed
+func TestLatestRunInfoAttribution(t *testing.T) {
+	collector := createTestCollector()
+	collector.config.GitHub.RunAttribution = true
+
+	run := &github.WorkflowRun{
+		ID:      github.Ptr(int64(1)),
+		Name:    github.Ptr("CI"),
+		HeadSHA: github.Ptr("0123456789abcdef0123456789abcdef01234567"),
+		Actor:   &github.User{Login: github.Ptr("d0ugal")},
+	}
+
+	collector.setLatestRunInfo("d0ugal", "github-exporter", "main", run)
+
+	run.ID = github.Ptr(int64(2))
+	run.HeadSHA = github.Ptr("fedcba9876543210fedcba9876543210fedcba98")
+
+	collector.setLatestRunInfo("d0ugal", "github-exporter", "main", run)
+
+	series := matchingSeries(collector.metrics.GitHubWorkflowLatestRunInfo, nil)
+	if len(series) != 1 {
+		t.Fatalf("Expected the previous SHA to be removed, got %v", series)
+	}
+
+	if got := series[0].labels; got["head_sha"] != "fedcba9" || got["actor"] != "d0ugal" {
+		t.Errorf("Expected head_sha fedcba9 and actor d0ugal, got %v", got)
+	}
+}
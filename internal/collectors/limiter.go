@@ -0,0 +1,45 @@
+package collectors
+
+import (
+	"context"
+
+	"golang.org/x/time/rate"
+)
+
+// sharedLimiter paces the API requests of every goroutine using the collector.
+// Its rate is adjusted in place rather than by replacing the limiter, so no
+// caller keeps waiting on a limiter that is no longer updated. It is safe for
+// concurrent use.
+type sharedLimiter struct {
+	limiter *rate.Limiter
+}
+
+// newSharedLimiter creates a limiter allowing r requests per second with bursts of b
+func newSharedLimiter(r rate.Limit, b int) *sharedLimiter {
+	return &sharedLimiter{limiter: rate.NewLimiter(r, b)}
+}
+
+// Wait blocks until a request is allowed or ctx is done
+func (l *sharedLimiter) Wait(ctx context.Context) error {
+	return l.limiter.Wait(ctx)
+}
+
+// SetLimit changes the number of requests allowed per second
+func (l *sharedLimiter) SetLimit(r rate.Limit) {
+	l.limiter.SetLimit(r)
+}
+
+// SetBurst changes the number of requests allowed at once
+func (l *sharedLimiter) SetBurst(b int) {
+	l.limiter.SetBurst(b)
+}
+
+// Limit returns the number of requests allowed per second
+func (l *sharedLimiter) Limit() rate.Limit {
+	return l.limiter.Limit()
+}
+
+// Burst returns the number of requests allowed at once
+func (l *sharedLimiter) Burst() int {
+	return l.limiter.Burst()
+}
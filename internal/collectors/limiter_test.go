@@ -0,0 +1,64 @@
+package collectors
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// TestUpdateRateLimiterInPlace tests that rate limit updates adjust the limiter
+// callers already hold instead of replacing it
+func TestUpdateRateLimiterInPlace(t *testing.T) {
+	collector, _ := newFakeCollector()
+	collector.config.GitHub.RateLimitBuffer = 1
+
+	held := collector.limiter
+
+	collector.rateLimitRemaining = 3600
+	collector.rateLimitReset = time.Now().Add(time.Hour)
+	collector.updateRateLimiter()
+
+	if collector.limiter != held {
+		t.Fatal("Expected the limiter to be updated in place")
+	}
+
+	if limit := held.Limit(); limit < 0.9 || limit > 1.1 {
+		t.Errorf("Expected about 1 request per second, got %v", limit)
+	}
+
+	if burst := held.Burst(); burst != 1 {
+		t.Errorf("Expected a burst of 1, got %d", burst)
+	}
+}
+
+// TestSharedLimiterConcurrentUse tests that the limiter can be waited on while
+// its rate is changed, run with -race
+func TestSharedLimiterConcurrentUse(t *testing.T) {
+	limiter := newSharedLimiter(rate.Inf, 1)
+
+	var wg sync.WaitGroup
+
+	for range 4 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for range 100 {
+				if err := limiter.Wait(t.Context()); err != nil {
+					t.Errorf("Unexpected error: %v", err)
+					return
+				}
+			}
+		}()
+	}
+
+	for range 100 {
+		limiter.SetLimit(rate.Inf)
+		limiter.SetBurst(1)
+	}
+
+	wg.Wait()
+}
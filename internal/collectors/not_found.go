@@ -0,0 +1,114 @@
+package collectors
+
+import (
+	"log/slog"
+	"net/http"
+	"sync"
+
+	"github.com/d0ugal/github-exporter/internal/config"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// notFoundTracker remembers monitored targets the API returned 404 for, by
+// type ("org" or "repo") and name
+type notFoundTracker struct {
+	mu      sync.RWMutex
+	targets map[string]bool
+}
+
+// add stores a missing target, returning false if it was already known
+func (n *notFoundTracker) add(kind, name string) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.targets == nil {
+		n.targets = make(map[string]bool)
+	}
+
+	key := kind + ":" + name
+	if n.targets[key] {
+		return false
+	}
+
+	n.targets[key] = true
+
+	return true
+}
+
+// has reports whether a target is known to be missing
+func (n *notFoundTracker) has(kind, name string) bool {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	return n.targets[kind+":"+name]
+}
+
+// filter returns names without the targets of the given type known to be missing
+func (n *notFoundTracker) filter(kind string, names []string) []string {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	if len(n.targets) == 0 {
+		return names
+	}
+
+	filtered := make([]string, 0, len(names))
+
+	for _, name := range names {
+		if !n.targets[kind+":"+name] {
+			filtered = append(filtered, name)
+		}
+	}
+
+	return filtered
+}
+
+// logTargetError logs the failure to collect a monitored target. Targets that do
+// not exist are handled according to github.not_found_policy: logged every
+// cycle, logged once, or logged once and no longer requested.
+func (gc *GitHubCollector) logTargetError(msg, kind, name string, err error) {
+	if apiErrorStatusCode(err) != http.StatusNotFound {
+		logError(msg, err)
+		return
+	}
+
+	gc.notFound(msg, kind, name, err)
+}
+
+// notFound applies github.not_found_policy to a target the API returned 404 for
+func (gc *GitHubCollector) notFound(msg, kind, name string, err error) {
+	policy := gc.config.GitHub.NotFoundPolicy
+	if policy == config.NotFoundPolicyWarn || policy == "" {
+		logError(msg, err)
+		return
+	}
+
+	if !gc.missing.add(kind, name) {
+		slog.Debug("Monitored target still not found", "type", kind, "target", name)
+		return
+	}
+
+	if policy == config.NotFoundPolicySkip {
+		logError(msg+", no longer requesting it until restart", err)
+		gc.metrics.GitHubTargetNotFound.With(prometheus.Labels{"target": name}).Set(1)
+
+		return
+	}
+
+	logError(msg+", further errors for it are not logged", err)
+}
+
+// skipMissing drops targets of the given type that do not exist when
+// github.not_found_policy is "skip"
+func (gc *GitHubCollector) skipMissing(kind string, names []string) []string {
+	if gc.config.GitHub.NotFoundPolicy != config.NotFoundPolicySkip {
+		return names
+	}
+
+	return gc.missing.filter(kind, names)
+}
+
+// isMissing reports whether a target does not exist and is no longer requested
+func (gc *GitHubCollector) isMissing(kind, name string) bool {
+	return gc.config.GitHub.NotFoundPolicy == config.NotFoundPolicySkip && gc.missing.has(kind, name)
+}
@@ -0,0 +1,71 @@
+package collectors
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/d0ugal/github-exporter/internal/config"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// TestNotFoundPolicySkip tests that a missing repository is no longer requested
+// once it returned 404 and is exported as not found
+func TestNotFoundPolicySkip(t *testing.T) {
+	collector, api := newFakeCollector()
+	collector.config.GitHub.NotFoundPolicy = config.NotFoundPolicySkip
+	collector.config.GitHub.Repos = []string{"d0ugal/github-exporter", "d0ugal/decommissioned"}
+
+	api.addRepo("d0ugal", "github-exporter", false)
+
+	if _, err := collector.collectRepoMetrics(t.Context()); err == nil {
+		t.Error("Expected an error for the missing repository")
+	}
+
+	result, err := collector.collectRepoMetrics(t.Context())
+	if err != nil {
+		t.Fatalf("Expected the missing repository to be skipped, got %v", err)
+	}
+
+	if result.Succeeded != 1 || result.Failed != 0 {
+		t.Errorf("Expected 1 success and no failures, got %+v", result)
+	}
+
+	if api.calls["GetRepository"] != 3 {
+		t.Errorf("Expected 3 repository requests, got %d", api.calls["GetRepository"])
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubTargetNotFound.With(prometheus.Labels{"target": "d0ugal/decommissioned"})); got != 1 {
+		t.Errorf("Expected the repository to be exported as not found, got %v", got)
+	}
+}
+
+// TestNotFoundPolicyWarnOnce tests that missing targets keep being requested but
+// are only recorded once
+func TestNotFoundPolicyWarnOnce(t *testing.T) {
+	collector, api := newFakeCollector()
+	collector.config.GitHub.NotFoundPolicy = config.NotFoundPolicyWarnOnce
+	collector.config.GitHub.Repos = []string{"d0ugal/decommissioned"}
+
+	for range 2 {
+		if _, err := collector.collectRepoMetrics(t.Context()); err == nil {
+			t.Error("Expected an error for the missing repository")
+		}
+	}
+
+	if api.calls["GetRepository"] != 2 {
+		t.Errorf("Expected the repository to be requested every cycle, got %d requests", api.calls["GetRepository"])
+	}
+
+	if collector.missing.add("repo", "d0ugal/decommissioned") {
+		t.Error("Expected the missing repository to be recorded")
+	}
+
+	if got := testutil.CollectAndCount(collector.metrics.GitHubTargetNotFound); got != 0 {
+		t.Errorf("Expected no not found series without the skip policy, got %d", got)
+	}
+
+	if repos := collector.targetRepos(); !reflect.DeepEqual(repos, []string{"d0ugal/decommissioned"}) {
+		t.Errorf("Expected the repository to remain a target, got %v", repos)
+	}
+}
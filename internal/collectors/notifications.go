@@ -0,0 +1,164 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// notificationTimeout bounds the notifications sent at the end of a cycle
+const notificationTimeout = 30 * time.Second
+
+// notificationTracker remembers what was notified so each problem is reported once
+type notificationTracker struct {
+	mu              sync.Mutex
+	failing         map[string]bool // Targets notified as failing, by type and name
+	tokenExpiration time.Time       // Expiration of the token as last reported by GitHub
+	notifiedExpiry  time.Time       // Expiration that was notified
+}
+
+// observeTokenExpiration records the token expiration reported with a response
+func (nt *notificationTracker) observeTokenExpiration(expiration time.Time) {
+	nt.mu.Lock()
+	defer nt.mu.Unlock()
+
+	nt.tokenExpiration = expiration
+}
+
+// changedTargets returns the targets that started failing at least threshold
+// times in a row and the notified targets that recovered since
+func (nt *notificationTracker) changedTargets(targets []TargetStatus, threshold int) ([]TargetStatus, []TargetStatus) {
+	nt.mu.Lock()
+	defer nt.mu.Unlock()
+
+	var failing, recovered []TargetStatus
+
+	for _, target := range targets {
+		notified := nt.failing[target.Type+":"+target.Target]
+
+		switch {
+		case !notified && target.Failures >= threshold:
+			failing = append(failing, target)
+		case notified && target.Healthy():
+			recovered = append(recovered, target)
+		}
+	}
+
+	return failing, recovered
+}
+
+// markFailing records whether targets were notified as failing
+func (nt *notificationTracker) markFailing(targets []TargetStatus, failing bool) {
+	nt.mu.Lock()
+	defer nt.mu.Unlock()
+
+	if nt.failing == nil {
+		nt.failing = make(map[string]bool)
+	}
+
+	for _, target := range targets {
+		if failing {
+			nt.failing[target.Type+":"+target.Target] = true
+		} else {
+			delete(nt.failing, target.Type+":"+target.Target)
+		}
+	}
+}
+
+// expiringToken returns the token expiration when it is within warning and was
+// not notified yet
+func (nt *notificationTracker) expiringToken(warning time.Duration, now time.Time) (time.Time, bool) {
+	nt.mu.Lock()
+	defer nt.mu.Unlock()
+
+	if warning <= 0 || nt.tokenExpiration.IsZero() || nt.tokenExpiration.Equal(nt.notifiedExpiry) {
+		return time.Time{}, false
+	}
+
+	return nt.tokenExpiration, nt.tokenExpiration.Sub(now) <= warning
+}
+
+// markExpiryNotified records that the expiration was notified
+func (nt *notificationTracker) markExpiryNotified(expiration time.Time) {
+	nt.mu.Lock()
+	defer nt.mu.Unlock()
+
+	nt.notifiedExpiry = expiration
+}
+
+// sendNotifications posts a message about the targets that started failing
+// repeatedly or recovered, and about the token expiring soon. Problems whose
+// notification could not be sent are retried after the next cycle.
+func (gc *GitHubCollector) sendNotifications() {
+	if gc.notifier == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), notificationTimeout)
+	defer cancel()
+
+	targets, _, _ := gc.status.snapshot()
+	threshold := gc.config.Notifications.FailureThreshold
+	failing, recovered := gc.notifications.changedTargets(targets, threshold)
+
+	if len(failing) > 0 {
+		lines := []string{fmt.Sprintf(":warning: github-exporter failed to collect %d target(s) %d or more times in a row:", len(failing), threshold)}
+		for _, target := range failing {
+			lines = append(lines, fmt.Sprintf("• %s %s: %s", target.Type, target.Target, target.LastError))
+		}
+
+		if gc.notify(ctx, "target_failing", strings.Join(lines, "\n")) {
+			gc.notifications.markFailing(failing, true)
+		}
+	}
+
+	if len(recovered) > 0 {
+		lines := []string{fmt.Sprintf(":white_check_mark: github-exporter collected %d previously failing target(s) again:", len(recovered))}
+		for _, target := range recovered {
+			lines = append(lines, fmt.Sprintf("• %s %s", target.Type, target.Target))
+		}
+
+		if gc.notify(ctx, "target_recovered", strings.Join(lines, "\n")) {
+			gc.notifications.markFailing(recovered, false)
+		}
+	}
+
+	now := time.Now()
+	if expiration, ok := gc.notifications.expiringToken(gc.config.Notifications.ExpiryWarning(), now); ok {
+		text := fmt.Sprintf(":hourglass: The GitHub token used by github-exporter expires in %s, at %s",
+			formatRemaining(expiration.Sub(now)), expiration.UTC().Format(time.RFC3339))
+
+		if gc.notify(ctx, "token_expiring", text) {
+			gc.notifications.markExpiryNotified(expiration)
+		}
+	}
+}
+
+// formatRemaining formats the time left until the token expires in days or hours
+func formatRemaining(d time.Duration) string {
+	if hours := int(d.Round(time.Hour).Hours()); hours < 48 {
+		return fmt.Sprintf("%d hours", max(hours, 0))
+	}
+
+	return fmt.Sprintf("%d days", int(d.Round(24*time.Hour).Hours()/24))
+}
+
+// notify sends a single notification, reporting whether it was delivered
+func (gc *GitHubCollector) notify(ctx context.Context, kind, text string) bool {
+	result := "success"
+
+	err := gc.notifier.Send(ctx, text)
+	if err != nil {
+		slog.Error("Failed to send notification", "kind", kind, "error", err)
+		result = "error"
+	}
+
+	gc.metrics.GitHubNotifications.With(prometheus.Labels{"kind": kind, "result": result}).Inc()
+
+	return err == nil
+}
@@ -0,0 +1,134 @@
+package collectors
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/d0ugal/github-exporter/internal/notify"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// newNotifyingCollector creates a collector posting notifications to a test
+// server, returning the texts it received
+func newNotifyingCollector(t *testing.T) (*GitHubCollector, *[]string) {
+	t.Helper()
+
+	var texts []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			Text string `json:"text"`
+		}
+
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		texts = append(texts, payload.Text)
+	}))
+	t.Cleanup(server.Close)
+
+	collector := createTestCollector()
+	collector.config.Notifications.WebhookURL = server.URL
+	collector.config.Notifications.FailureThreshold = 2
+	collector.notifier = notify.New(server.URL)
+
+	return collector, &texts
+}
+
+// TestSendNotificationsFailures tests that targets are notified once after
+// failing repeatedly and once when they recover
+func TestSendNotificationsFailures(t *testing.T) {
+	collector, texts := newNotifyingCollector(t)
+
+	collector.status.record("repo", "d0ugal/a", errors.New("boom"))
+	collector.sendNotifications()
+
+	if len(*texts) != 0 {
+		t.Fatalf("Expected no notification below the threshold, got %v", *texts)
+	}
+
+	collector.status.record("repo", "d0ugal/a", errors.New("boom"))
+	collector.sendNotifications()
+	collector.status.record("repo", "d0ugal/a", errors.New("boom"))
+	collector.sendNotifications()
+
+	if len(*texts) != 1 || !strings.Contains((*texts)[0], "repo d0ugal/a: boom") {
+		t.Fatalf("Expected a single failure notification, got %v", *texts)
+	}
+
+	collector.status.record("repo", "d0ugal/a", nil)
+	collector.sendNotifications()
+	collector.sendNotifications()
+
+	if len(*texts) != 2 || !strings.Contains((*texts)[1], "repo d0ugal/a") {
+		t.Fatalf("Expected a single recovery notification, got %v", *texts)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubNotifications.WithLabelValues("target_failing", "success")); got != 1 {
+		t.Errorf("Expected 1 failure notification counted, got %v", got)
+	}
+}
+
+// TestSendNotificationsTokenExpiry tests that an expiring token is notified once per expiration
+func TestSendNotificationsTokenExpiry(t *testing.T) {
+	collector, texts := newNotifyingCollector(t)
+
+	collector.notifications.observeTokenExpiration(time.Now().Add(30 * 24 * time.Hour))
+	collector.sendNotifications()
+
+	if len(*texts) != 0 {
+		t.Fatalf("Expected no notification for a token expiring in 30 days, got %v", *texts)
+	}
+
+	collector.notifications.observeTokenExpiration(time.Now().Add(5 * 24 * time.Hour))
+	collector.sendNotifications()
+	collector.sendNotifications()
+
+	if len(*texts) != 1 || !strings.Contains((*texts)[0], "expires in 5 days") {
+		t.Errorf("Expected a single expiry notification, got %v", *texts)
+	}
+}
+
+// roundTripFunc serves requests with a function, in place of a network transport
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+// TestAuthTransportTokenExpiry tests that token expirations seen through an
+// authentication transport are tracked for notifications
+func TestAuthTransportTokenExpiry(t *testing.T) {
+	collector, _ := newNotifyingCollector(t)
+	collector.limiter = newSharedLimiter(1, 1)
+
+	expiration := time.Now().Add(5 * 24 * time.Hour).UTC().Truncate(time.Second)
+
+	collector.WithAuthTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		header := http.Header{}
+		header.Set(tokenExpirationHeader, expiration.Format("2006-01-02 15:04:05 MST"))
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     header,
+			Body:       io.NopCloser(strings.NewReader(`{"login": "d0ugal"}`)),
+			Request:    r,
+		}, nil
+	}))
+
+	if _, _, err := collector.api.GetAuthenticatedUser(t.Context()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	collector.notifications.mu.Lock()
+	got := collector.notifications.tokenExpiration
+	collector.notifications.mu.Unlock()
+
+	if !got.Equal(expiration) {
+		t.Errorf("Expected token expiration %s, got %s", expiration, got)
+	}
+}
@@ -0,0 +1,136 @@
+package collectors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/google/go-github/v76/github"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// setOIDCMetrics exports whether a repository customizes the subject claim of its
+// Actions OIDC tokens, and the secrets of each of its deployment environments.
+// Environments without secrets are assumed to deploy with OIDC, which is a
+// best-effort guess to track migrations off long-lived cloud credentials.
+func (gc *GitHubCollector) setOIDCMetrics(ctx context.Context, owner, repo string, repoID int) error {
+	repoLabels := prometheus.Labels{"org": owner, "repo": repo}
+
+	var errs []error
+
+	customized, err := gc.oidcSubjectClaimCustomized(ctx, owner, repo)
+	if err != nil {
+		logError("Failed to get OIDC subject claim template", err)
+		errs = append(errs, err)
+	} else {
+		gc.metrics.GitHubOIDCSubjectClaimCustomized.With(repoLabels).Set(boolToFloat(customized))
+	}
+
+	environments, err := gc.listEnvironments(ctx, owner, repo)
+	if err != nil {
+		logError("Failed to list deployment environments", err)
+		return errors.Join(append(errs, err)...)
+	}
+
+	secrets := make(map[string]int, len(environments))
+
+	for _, environment := range environments {
+		count, err := gc.countEnvironmentSecrets(ctx, owner, repo, repoID, environment)
+		if err != nil {
+			logError("Failed to list environment secrets", err, "environment", environment)
+			return errors.Join(append(errs, err)...)
+		}
+
+		secrets[environment] = count
+	}
+
+	// Drop environments that were deleted
+	gc.metrics.GitHubEnvironmentSecrets.DeletePartialMatch(repoLabels)
+	gc.metrics.GitHubEnvironmentOIDC.DeletePartialMatch(repoLabels)
+
+	for environment, count := range secrets {
+		labels := prometheus.Labels{"org": owner, "repo": repo, "environment": environment}
+		gc.metrics.GitHubEnvironmentSecrets.With(labels).Set(float64(count))
+		gc.metrics.GitHubEnvironmentOIDC.With(labels).Set(boolToFloat(count == 0))
+	}
+
+	return errors.Join(errs...)
+}
+
+// oidcSubjectClaimCustomized reports whether a repository overrides the default
+// subject claim of its OIDC tokens, typically to match cloud provider trust policies
+func (gc *GitHubCollector) oidcSubjectClaimCustomized(ctx context.Context, owner, repo string) (bool, error) {
+	t := target{Org: owner, Repo: repo}
+
+	// Wait for rate limiter
+	if err := gc.limiter.Wait(ctx); err != nil {
+		return false, wrapAPIError("oidc_subject_claim", t, fmt.Errorf("rate limiter error: %w", err))
+	}
+
+	reqCtx, cancel := gc.requestContext(ctx, "oidc_subject_claim")
+	template, _, err := gc.api.GetRepoOIDCSubjectClaimCustomTemplate(reqCtx, owner, repo)
+	cancel()
+	if err != nil {
+		gc.recordAPIError("oidc_subject_claim", err)
+		return false, wrapAPIError("oidc_subject_claim", t, err)
+	}
+
+	return !template.GetUseDefault() && len(template.IncludeClaimKeys) > 0, nil
+}
+
+// listEnvironments returns the names of the deployment environments of a repository
+func (gc *GitHubCollector) listEnvironments(ctx context.Context, owner, repo string) ([]string, error) {
+	t := target{Org: owner, Repo: repo}
+	opts := &github.EnvironmentListOptions{ListOptions: github.ListOptions{PerPage: 100}}
+
+	var names []string
+
+	for page := 0; page < maxActivityPages; page++ {
+		// Wait for rate limiter
+		if err := gc.limiter.Wait(ctx); err != nil {
+			return nil, wrapAPIError("environments", t, fmt.Errorf("rate limiter error: %w", err))
+		}
+
+		reqCtx, cancel := gc.requestContext(ctx, "environments")
+		environments, resp, err := gc.api.ListEnvironments(reqCtx, owner, repo, opts)
+		cancel()
+		if err != nil {
+			gc.recordAPIError("environments", err)
+			return nil, wrapAPIError("environments", t, err)
+		}
+
+		for _, environment := range environments.Environments {
+			names = append(names, environment.GetName())
+		}
+
+		if resp == nil || resp.NextPage == 0 {
+			break
+		}
+
+		opts.Page = resp.NextPage
+	}
+
+	return names, nil
+}
+
+// countEnvironmentSecrets returns the number of Actions secrets of a deployment environment
+func (gc *GitHubCollector) countEnvironmentSecrets(ctx context.Context, owner, repo string, repoID int, environment string) (int, error) {
+	t := target{Org: owner, Repo: repo}
+
+	// Wait for rate limiter
+	if err := gc.limiter.Wait(ctx); err != nil {
+		return 0, wrapAPIError("environment_secrets", t, fmt.Errorf("rate limiter error: %w", err))
+	}
+
+	// Environment names may contain spaces and slashes, which the client does not escape
+	reqCtx, cancel := gc.requestContext(ctx, "environment_secrets")
+	secrets, _, err := gc.api.ListEnvSecrets(reqCtx, repoID, url.PathEscape(environment), &github.ListOptions{PerPage: 1})
+	cancel()
+	if err != nil {
+		gc.recordAPIError("environment_secrets", err)
+		return 0, wrapAPIError("environment_secrets", t, err)
+	}
+
+	return secrets.TotalCount, nil
+}
@@ -0,0 +1,75 @@
+package collectors
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// TestSetOIDCMetrics tests exporting the subject claim customization and
+// guessing which environments deploy with OIDC from their secrets
+func TestSetOIDCMetrics(t *testing.T) {
+	collector := newAPITestCollector(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/repos/d0ugal/private/actions/oidc/customization/sub":
+			_, _ = w.Write([]byte(`{"use_default": false, "include_claim_keys": ["repo", "context"]}`))
+		case "/repos/d0ugal/private/environments":
+			_, _ = w.Write([]byte(`{"total_count": 2, "environments": [{"name": "production"}, {"name": "staging eu"}]}`))
+		case "/repositories/42/environments/production/secrets":
+			_, _ = w.Write([]byte(`{"total_count": 0, "secrets": []}`))
+		case "/repositories/42/environments/staging%20eu/secrets":
+			_, _ = w.Write([]byte(`{"total_count": 2, "secrets": [{"name": "AWS_ACCESS_KEY_ID"}]}`))
+		default:
+			t.Errorf("Unexpected request path %s", r.URL.EscapedPath())
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	// Seed a deleted environment
+	collector.metrics.GitHubEnvironmentOIDC.WithLabelValues("d0ugal", "private", "deleted").Set(1)
+
+	collector.setOIDCMetrics(t.Context(), "d0ugal", "private", 42)
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubOIDCSubjectClaimCustomized.WithLabelValues("d0ugal", "private")); got != 1 {
+		t.Errorf("Expected a customized subject claim, got %v", got)
+	}
+
+	for environment, expected := range map[string]float64{"production": 1, "staging eu": 0} {
+		if got := testutil.ToFloat64(collector.metrics.GitHubEnvironmentOIDC.WithLabelValues("d0ugal", "private", environment)); got != expected {
+			t.Errorf("Expected %s oidc=%v, got %v", environment, expected, got)
+		}
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubEnvironmentSecrets.WithLabelValues("d0ugal", "private", "staging eu")); got != 2 {
+		t.Errorf("Expected 2 staging eu secrets, got %v", got)
+	}
+
+	if got := testutil.CollectAndCount(collector.metrics.GitHubEnvironmentOIDC); got != 2 {
+		t.Errorf("Expected the deleted environment to be dropped, got %d series", got)
+	}
+}
+
+// TestSetOIDCMetricsDefaultClaim tests repositories using the default subject claim without environments
+func TestSetOIDCMetricsDefaultClaim(t *testing.T) {
+	collector := newAPITestCollector(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/d0ugal/private/actions/oidc/customization/sub":
+			_, _ = w.Write([]byte(`{"use_default": true}`))
+		case "/repos/d0ugal/private/environments":
+			_, _ = w.Write([]byte(`{"total_count": 0, "environments": []}`))
+		default:
+			t.Errorf("Unexpected request path %s", r.URL.Path)
+		}
+	})
+
+	collector.setOIDCMetrics(t.Context(), "d0ugal", "private", 42)
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubOIDCSubjectClaimCustomized.WithLabelValues("d0ugal", "private")); got != 0 {
+		t.Errorf("Expected the default subject claim, got %v", got)
+	}
+
+	if got := testutil.CollectAndCount(collector.metrics.GitHubEnvironmentSecrets); got != 0 {
+		t.Errorf("Expected no environment series, got %d", got)
+	}
+}
@@ -0,0 +1,43 @@
+package collectors
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/d0ugal/promexporter/app"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// TestCollectOrgInfoLabels tests the plan and verified labels of the organization
+// info metric, and that a plan change replaces the previous series
+func TestCollectOrgInfoLabels(t *testing.T) {
+	plan := "team"
+
+	collector := newAPITestCollector(t, func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = fmt.Fprintf(w, `{"login": "d0ugal", "is_verified": true, "plan": {"name": %q}}`, plan)
+	})
+	collector.app = app.New("github-exporter")
+
+	if ok, err := collector.collectOrgInfo(t.Context(), nil, "d0ugal"); !ok || err != nil {
+		t.Fatalf("Expected organization info, got %v, %v", ok, err)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubOrgInfo.WithLabelValues("d0ugal", "team", "true")); got != 1 {
+		t.Errorf("Expected organization info for the team plan, got %v", got)
+	}
+
+	plan = "enterprise"
+
+	if ok, err := collector.collectOrgInfo(t.Context(), nil, "d0ugal"); !ok || err != nil {
+		t.Fatalf("Expected organization info, got %v, %v", ok, err)
+	}
+
+	if got := testutil.CollectAndCount(collector.metrics.GitHubOrgInfo); got != 1 {
+		t.Errorf("Expected 1 organization info series after the plan changed, got %d", got)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubOrgInfo.WithLabelValues("d0ugal", "enterprise", "true")); got != 1 {
+		t.Errorf("Expected organization info for the enterprise plan, got %v", got)
+	}
+}
@@ -0,0 +1,126 @@
+package collectors
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/d0ugal/github-exporter/internal/state"
+	"github.com/google/go-github/v76/github"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// orgRepoTracker remembers the repositories last discovered in each
+// organization, so repositories created or archived since are counted, and
+// the counters continue from where they were after a restart when a state file
+// is configured
+type orgRepoTracker struct {
+	mu   sync.Mutex
+	orgs map[string]state.OrgRepos
+}
+
+// restore replaces the tracked organizations with saved ones
+func (t *orgRepoTracker) restore(saved []state.OrgRepos) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.orgs = make(map[string]state.OrgRepos, len(saved))
+	for _, repos := range saved {
+		t.orgs[repos.Org] = repos
+	}
+}
+
+// observe records the repositories discovered in an organization and returns
+// the number created and archived since the previous discovery. Repositories
+// count as created when they are newer than any seen before, so repositories
+// transferred in or newly visible to the token are not counted. The first
+// observation of an organization only sets its baseline.
+func (t *orgRepoTracker) observe(org string, repos []*github.Repository) (created, archived int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.orgs == nil {
+		t.orgs = make(map[string]state.OrgRepos)
+	}
+
+	tracked, ok := t.orgs[org]
+
+	wasActive := make(map[string]bool, len(tracked.Active))
+	for _, name := range tracked.Active {
+		wasActive[name] = true
+	}
+
+	newest := tracked.Newest
+	active := make([]string, 0, len(repos))
+
+	for _, repo := range repos {
+		name := repo.GetName()
+		if name == "" {
+			continue
+		}
+
+		createdAt := repo.GetCreatedAt().Time
+		if ok && createdAt.After(tracked.Newest) {
+			created++
+		}
+
+		if createdAt.After(newest) {
+			newest = createdAt
+		}
+
+		if !repo.GetArchived() {
+			active = append(active, name)
+		} else if wasActive[name] {
+			archived++
+		}
+	}
+
+	sort.Strings(active)
+
+	tracked.Org = org
+	tracked.Newest = newest
+	tracked.Active = active
+	tracked.Created += created
+	tracked.Archived += archived
+	t.orgs[org] = tracked
+
+	return created, archived
+}
+
+// snapshot returns the tracked organizations, sorted by organization
+func (t *orgRepoTracker) snapshot() []state.OrgRepos {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	orgs := make([]state.OrgRepos, 0, len(t.orgs))
+	for _, repos := range t.orgs {
+		orgs = append(orgs, repos)
+	}
+
+	sort.Slice(orgs, func(i, j int) bool {
+		return orgs[i].Org < orgs[j].Org
+	})
+
+	return orgs
+}
+
+// restoreOrgRepos continues the repository creation and archival counters from a previous run
+func (gc *GitHubCollector) restoreOrgRepos(saved []state.OrgRepos) {
+	gc.orgRepos.restore(saved)
+
+	for _, repos := range saved {
+		labels := prometheus.Labels{"org": repos.Org}
+		gc.metrics.GitHubOrgReposCreated.With(labels).Add(float64(repos.Created))
+		gc.metrics.GitHubOrgReposArchived.With(labels).Add(float64(repos.Archived))
+	}
+}
+
+// observeOrgRepos counts the repositories created and archived in an
+// organization since its previous discovery. Repositories created and deleted
+// between two discoveries are not seen.
+func (gc *GitHubCollector) observeOrgRepos(org string, repos []*github.Repository) {
+	created, archived := gc.orgRepos.observe(org, repos)
+
+	labels := prometheus.Labels{"org": org}
+	gc.metrics.GitHubOrgReposCreated.With(labels).Add(float64(created))
+	gc.metrics.GitHubOrgReposArchived.With(labels).Add(float64(archived))
+}
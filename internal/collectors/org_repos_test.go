@@ -0,0 +1,77 @@
+package collectors
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/d0ugal/github-exporter/internal/state"
+	"github.com/google/go-github/v76/github"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// testOrgRepoNow is the time test repositories are created relative to
+var testOrgRepoNow = time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+// testOrgRepo returns a repository created days before testOrgRepoNow
+func testOrgRepo(name string, days int, archived bool) *github.Repository {
+	return &github.Repository{
+		Name:      github.Ptr(name),
+		CreatedAt: &github.Timestamp{Time: testOrgRepoNow.Add(-time.Duration(days) * 24 * time.Hour)},
+		Archived:  github.Ptr(archived),
+	}
+}
+
+// TestObserveOrgRepos tests counting created and archived repositories between
+// discoveries, with the first discovery only setting the baseline
+func TestObserveOrgRepos(t *testing.T) {
+	collector := createTestCollector()
+
+	collector.observeOrgRepos("d0ugal", []*github.Repository{
+		testOrgRepo("api", 300, false),
+		testOrgRepo("web", 200, false),
+		testOrgRepo("legacy", 900, true),
+	})
+
+	collector.observeOrgRepos("d0ugal", []*github.Repository{
+		testOrgRepo("api", 300, true),
+		testOrgRepo("web", 200, false),
+		testOrgRepo("legacy", 900, true),
+		testOrgRepo("new", 0, false),
+		testOrgRepo("transferred", 500, false),
+	})
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubOrgReposCreated.WithLabelValues("d0ugal")); got != 1 {
+		t.Errorf("Expected 1 repository created, got %v", got)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubOrgReposArchived.WithLabelValues("d0ugal")); got != 1 {
+		t.Errorf("Expected 1 repository archived, got %v", got)
+	}
+}
+
+// TestOrgReposPersisted tests that the counters continue after a restart
+func TestOrgReposPersisted(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+
+	previous := createTestCollector()
+	previous.state = state.NewStore(path)
+	previous.observeOrgRepos("d0ugal", []*github.Repository{testOrgRepo("api", 300, false)})
+	previous.observeOrgRepos("d0ugal", []*github.Repository{testOrgRepo("api", 300, false), testOrgRepo("web", 1, false)})
+	previous.saveSnapshot()
+
+	restarted := createTestCollector()
+	restarted.state = state.NewStore(path)
+	restarted.restoreSnapshot()
+
+	if got := testutil.ToFloat64(restarted.metrics.GitHubOrgReposCreated.WithLabelValues("d0ugal")); got != 1 {
+		t.Errorf("Expected the restored repository creation, got %v", got)
+	}
+
+	// Changes while the exporter was down are counted against the saved repositories
+	restarted.observeOrgRepos("d0ugal", []*github.Repository{testOrgRepo("api", 300, false), testOrgRepo("web", 1, true)})
+
+	if got := testutil.ToFloat64(restarted.metrics.GitHubOrgReposArchived.WithLabelValues("d0ugal")); got != 1 {
+		t.Errorf("Expected 1 repository archived, got %v", got)
+	}
+}
@@ -0,0 +1,131 @@
+package collectors
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v76/github"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// rollupPeriods are the calendar periods organization workflow totals are kept for
+var rollupPeriods = []string{"day", "week"}
+
+// periodStart returns the start of the UTC day or ISO week (starting on Monday) t is in
+func periodStart(period string, t time.Time) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+
+	if period == "week" {
+		// Sunday is day 0 of the week in Go, but the last day of an ISO week
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	}
+
+	return day
+}
+
+// orgTotals are the workflow totals of an organization in one period
+type orgTotals struct {
+	start          time.Time
+	runs           float64
+	failed         float64
+	billableMillis float64
+}
+
+// orgRollups aggregates the workflow runs and billable time of each organization
+// in the current day and week
+type orgRollups struct {
+	mu     sync.Mutex
+	totals map[string]map[string]*orgTotals // org -> period -> totals
+}
+
+// add records a run that completed at completedAt in every period that is
+// still current at now
+func (r *orgRollups) add(org string, completedAt, now time.Time, runs, failed, billableMillis float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.totals == nil {
+		r.totals = make(map[string]map[string]*orgTotals)
+	}
+
+	if r.totals[org] == nil {
+		r.totals[org] = make(map[string]*orgTotals)
+	}
+
+	for _, period := range rollupPeriods {
+		start := periodStart(period, now)
+		if completedAt.Before(start) {
+			continue
+		}
+
+		totals := r.current(org, period, start)
+		totals.runs += runs
+		totals.failed += failed
+		totals.billableMillis += billableMillis
+	}
+}
+
+// current returns the totals of an organization in the period starting at start,
+// resetting them when a new period began. The caller must hold the lock.
+func (r *orgRollups) current(org, period string, start time.Time) *orgTotals {
+	totals, ok := r.totals[org][period]
+	if !ok || !totals.start.Equal(start) {
+		totals = &orgTotals{start: start}
+		r.totals[org][period] = totals
+	}
+
+	return totals
+}
+
+// snapshot returns the totals of every organization and period at now, with
+// periods that ended reset to zero
+func (r *orgRollups) snapshot(now time.Time) map[string]map[string]orgTotals {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	snapshot := make(map[string]map[string]orgTotals, len(r.totals))
+
+	for org, periods := range r.totals {
+		snapshot[org] = make(map[string]orgTotals, len(periods))
+
+		for _, period := range rollupPeriods {
+			snapshot[org][period] = *r.current(org, period, periodStart(period, now))
+		}
+	}
+
+	return snapshot
+}
+
+// addRunToRollups adds a completed run to the totals of its organization
+func (gc *GitHubCollector) addRunToRollups(owner string, run *github.WorkflowRun) {
+	failed := 0.0
+	if gc.getStatusValue(run.GetConclusion()) == 0 {
+		failed = 1
+	}
+
+	gc.rollups.add(owner, run.GetUpdatedAt().Time, time.Now(), 1, failed, 0)
+}
+
+// addUsageToRollups adds the billable time of a completed run to the totals of its organization
+func (gc *GitHubCollector) addUsageToRollups(owner string, run *github.WorkflowRun, billable github.WorkflowRunBillMap) {
+	var millis float64
+	for _, bill := range billable {
+		millis += float64(bill.GetTotalMS())
+	}
+
+	gc.rollups.add(owner, run.GetUpdatedAt().Time, time.Now(), 0, 0, millis)
+}
+
+// setOrgRollups exports the workflow totals of each organization in the current day and week
+func (gc *GitHubCollector) setOrgRollups() {
+	for org, periods := range gc.rollups.snapshot(time.Now()) {
+		for period, totals := range periods {
+			labels := prometheus.Labels{"org": org, "period": period}
+
+			gc.metrics.GitHubOrgWorkflowRuns.With(labels).Set(totals.runs)
+			gc.metrics.GitHubOrgWorkflowFailedRuns.With(labels).Set(totals.failed)
+			gc.metrics.GitHubOrgWorkflowBillableMinutes.With(labels).Set(totals.billableMillis / 60000)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package collectors
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v76/github"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// TestPeriodStart tests that weeks start on Monday in UTC
+func TestPeriodStart(t *testing.T) {
+	tests := []struct {
+		period   string
+		t        time.Time
+		expected time.Time
+	}{
+		{"day", time.Date(2026, 3, 4, 15, 0, 0, 0, time.UTC), time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)},
+		{"week", time.Date(2026, 3, 4, 15, 0, 0, 0, time.UTC), time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)},
+		{"week", time.Date(2026, 3, 8, 23, 0, 0, 0, time.UTC), time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)}, // Sunday
+		{"week", time.Date(2026, 3, 9, 1, 0, 0, 0, time.FixedZone("CET", 3600)), time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		if got := periodStart(tt.period, tt.t); !got.Equal(tt.expected) {
+			t.Errorf("Expected %s of %s to start at %s, got %s", tt.period, tt.t, tt.expected, got)
+		}
+	}
+}
+
+// TestOrgRollups tests that runs count towards the periods they completed in and
+// that totals reset when a new period begins
+func TestOrgRollups(t *testing.T) {
+	var rollups orgRollups
+
+	// A Wednesday
+	now := time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC)
+
+	rollups.add("d0ugal", now.Add(-time.Hour), now, 1, 1, 0)
+	rollups.add("d0ugal", now.Add(-time.Hour), now, 0, 0, 120000)
+	rollups.add("d0ugal", now.AddDate(0, 0, -1), now, 1, 0, 0) // Earlier this week
+	rollups.add("d0ugal", now.AddDate(0, 0, -7), now, 1, 0, 0) // Last week
+
+	totals := rollups.snapshot(now)["d0ugal"]
+	if day := totals["day"]; day.runs != 1 || day.failed != 1 || day.billableMillis != 120000 {
+		t.Errorf("Unexpected day totals %+v", day)
+	}
+
+	if week := totals["week"]; week.runs != 2 || week.failed != 1 {
+		t.Errorf("Unexpected week totals %+v", week)
+	}
+
+	// The next day starts from zero, the week carries on
+	totals = rollups.snapshot(now.AddDate(0, 0, 1))["d0ugal"]
+	if day := totals["day"]; day.runs != 0 {
+		t.Errorf("Expected the day totals to be reset, got %+v", day)
+	}
+
+	if week := totals["week"]; week.runs != 2 {
+		t.Errorf("Expected the week totals to carry on, got %+v", week)
+	}
+}
+
+// TestSetOrgRollups tests that counted runs and fetched usage are exported per organization
+func TestSetOrgRollups(t *testing.T) {
+	collector := createTestCollector()
+
+	run := &github.WorkflowRun{
+		ID:         github.Ptr(int64(1)),
+		Name:       github.Ptr("CI"),
+		Conclusion: github.Ptr("failure"),
+		UpdatedAt:  &github.Timestamp{Time: time.Now()},
+	}
+
+	collector.addRunToRollups("d0ugal", run)
+	collector.addUsageToRollups("d0ugal", run, github.WorkflowRunBillMap{
+		"UBUNTU":  &github.WorkflowRunBill{TotalMS: github.Ptr(int64(90000))},
+		"WINDOWS": &github.WorkflowRunBill{TotalMS: github.Ptr(int64(30000))},
+	})
+
+	collector.setOrgRollups()
+
+	for _, period := range rollupPeriods {
+		if got := testutil.ToFloat64(collector.metrics.GitHubOrgWorkflowFailedRuns.WithLabelValues("d0ugal", period)); got != 1 {
+			t.Errorf("Expected 1 failed run this %s, got %v", period, got)
+		}
+
+		if got := testutil.ToFloat64(collector.metrics.GitHubOrgWorkflowBillableMinutes.WithLabelValues("d0ugal", period)); got != 2 {
+			t.Errorf("Expected 2 billable minutes this %s, got %v", period, got)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/go-github/v76/github"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// collectPackageMetrics collects GitHub Packages metrics for all monitored organizations
+func (gc *GitHubCollector) collectPackageMetrics(ctx context.Context) error {
+	orgs := gc.targetOrgs()
+
+	var failed int
+
+	for _, org := range orgs {
+		for _, packageType := range gc.config.GitHub.PackageTypes {
+			if err := gc.collectOrgPackages(ctx, org, packageType); err != nil {
+				logError("Failed to collect package metrics", err, "package_type", packageType)
+				failed++
+			}
+		}
+	}
+
+	if total := len(orgs) * len(gc.config.GitHub.PackageTypes); failed > 0 && failed == total {
+		return fmt.Errorf("failed to list packages for all %d organizations and package types", len(orgs))
+	}
+
+	return nil
+}
+
+// collectOrgPackages exports metrics for the packages of one type owned by an organization
+func (gc *GitHubCollector) collectOrgPackages(ctx context.Context, org, packageType string) error {
+	packages, err := gc.listOrgPackages(ctx, org, packageType)
+	if err != nil {
+		return err
+	}
+
+	for _, pkg := range packages {
+		if pkg.VersionCount != nil {
+			gc.metrics.GitHubPackageVersions.With(prometheus.Labels{
+				"org":        org,
+				"package":    pkg.GetName(),
+				"ecosystem":  packageType,
+				"visibility": pkg.GetVisibility(),
+			}).Set(float64(pkg.GetVersionCount()))
+		}
+
+		// Container images additionally report tag freshness for registry cleanup
+		if packageType == "container" {
+			if err := gc.setContainerVersionMetrics(ctx, org, pkg.GetName()); err != nil {
+				logError("Failed to collect container package versions", err, "package", pkg.GetName())
+			}
+		}
+	}
+
+	return nil
+}
+
+// setContainerVersionMetrics exports the most recent publish time and the number
+// of untagged versions of a container package
+func (gc *GitHubCollector) setContainerVersionMetrics(ctx context.Context, org, name string) error {
+	versions, err := gc.listPackageVersions(ctx, org, "container", name)
+	if err != nil {
+		return err
+	}
+
+	var latest time.Time
+
+	untagged := 0
+
+	for _, version := range versions {
+		if version.CreatedAt != nil && version.CreatedAt.After(latest) {
+			latest = version.CreatedAt.Time
+		}
+
+		if metadata, ok := version.GetMetadata(); ok && metadata.Container != nil && len(metadata.Container.Tags) > 0 {
+			continue
+		}
+
+		untagged++
+	}
+
+	labels := prometheus.Labels{"org": org, "package": name}
+	gc.metrics.GitHubPackageUntaggedVersions.With(labels).Set(float64(untagged))
+
+	if !latest.IsZero() {
+		gc.metrics.GitHubPackageLatestVersionTimestamp.With(labels).Set(float64(latest.Unix()))
+	}
+
+	return nil
+}
+
+// listPackageVersions lists all versions of a package owned by an organization
+func (gc *GitHubCollector) listPackageVersions(ctx context.Context, org, packageType, name string) ([]*github.PackageVersion, error) {
+	t := target{Org: org}
+	opts := &github.PackageListOptions{
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+
+	var all []*github.PackageVersion
+
+	for {
+		// Wait for rate limiter
+		if err := gc.limiter.Wait(ctx); err != nil {
+			return nil, wrapAPIError("package_versions", t, fmt.Errorf("rate limiter error: %w", err))
+		}
+
+		reqCtx, cancel := gc.requestContext(ctx, "package_versions")
+		versions, resp, err := gc.api.ListPackageVersions(reqCtx, org, packageType, name, opts)
+		cancel()
+		if err != nil {
+			gc.recordAPIError("package_versions", err)
+			return nil, wrapAPIError("package_versions", t, err)
+		}
+
+		all = append(all, versions...)
+
+		if resp == nil || resp.NextPage == 0 {
+			break
+		}
+
+		opts.Page = resp.NextPage
+	}
+
+	return all, nil
+}
+
+// listOrgPackages lists all packages of one type owned by an organization
+func (gc *GitHubCollector) listOrgPackages(ctx context.Context, org, packageType string) ([]*github.Package, error) {
+	t := target{Org: org}
+	opts := &github.PackageListOptions{
+		PackageType: &packageType,
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+
+	var all []*github.Package
+
+	for {
+		// Wait for rate limiter
+		if err := gc.limiter.Wait(ctx); err != nil {
+			return nil, wrapAPIError("packages", t, fmt.Errorf("rate limiter error: %w", err))
+		}
+
+		reqCtx, cancel := gc.requestContext(ctx, "packages")
+		packages, resp, err := gc.api.ListOrgPackages(reqCtx, org, opts)
+		cancel()
+		if err != nil {
+			gc.recordAPIError("packages", err)
+			return nil, wrapAPIError("packages", t, err)
+		}
+
+		all = append(all, packages...)
+
+		if resp == nil || resp.NextPage == 0 {
+			break
+		}
+
+		opts.Page = resp.NextPage
+	}
+
+	return all, nil
+}
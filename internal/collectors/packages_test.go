@@ -0,0 +1,53 @@
+package collectors
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// TestCollectPackageMetrics tests exporting package version counts and container tag freshness
+func TestCollectPackageMetrics(t *testing.T) {
+	collector := newAPITestCollector(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/orgs/d0ugal/packages/container/github-exporter/versions" {
+			_, _ = w.Write([]byte(`[
+				{"id": 3, "created_at": "2026-01-03T00:00:00Z", "metadata": {"package_type": "container", "container": {"tags": ["latest"]}}},
+				{"id": 2, "created_at": "2026-01-02T00:00:00Z", "metadata": {"package_type": "container", "container": {"tags": []}}},
+				{"id": 1, "created_at": "2026-01-01T00:00:00Z", "metadata": {"package_type": "container", "container": {}}}
+			]`))
+
+			return
+		}
+
+		if r.URL.Path != "/orgs/d0ugal/packages" {
+			t.Errorf("Unexpected request path %s", r.URL.Path)
+		}
+
+		switch r.URL.Query().Get("package_type") {
+		case "container":
+			_, _ = w.Write([]byte(`[{"name": "github-exporter", "package_type": "container", "visibility": "public", "version_count": 42}]`))
+		case "npm":
+			_, _ = w.Write([]byte(`[]`))
+		default:
+			t.Errorf("Unexpected package type %s", r.URL.Query().Get("package_type"))
+		}
+	})
+	collector.config.GitHub.PackageTypes = []string{"container", "npm"}
+
+	if err := collector.collectPackageMetrics(t.Context()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubPackageVersions.WithLabelValues("d0ugal", "github-exporter", "container", "public")); got != 42 {
+		t.Errorf("Expected 42 versions, got %v", got)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubPackageUntaggedVersions.WithLabelValues("d0ugal", "github-exporter")); got != 2 {
+		t.Errorf("Expected 2 untagged versions, got %v", got)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubPackageLatestVersionTimestamp.WithLabelValues("d0ugal", "github-exporter")); got != 1767398400 {
+		t.Errorf("Expected latest version timestamp 1767398400, got %v", got)
+	}
+}
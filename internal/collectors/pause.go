@@ -0,0 +1,113 @@
+package collectors
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/d0ugal/github-exporter/internal/config"
+	"github.com/d0ugal/github-exporter/internal/cron"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// blackout is a parsed blackout window
+type blackout struct {
+	name     string
+	schedule *cron.Schedule
+	duration time.Duration
+}
+
+// activeAt reports whether the window covers t, i.e. whether the schedule fired
+// within duration before t
+func (b blackout) activeAt(t time.Time) bool {
+	start := b.schedule.Next(t.Add(-b.duration))
+
+	return !start.IsZero() && !start.After(t)
+}
+
+// parseBlackouts parses the configured blackout windows. The configuration is
+// validated on load, so windows that fail to parse are only logged and skipped.
+func parseBlackouts(windows []config.BlackoutWindow) []blackout {
+	var blackouts []blackout
+
+	for _, window := range windows {
+		schedule, err := cron.Parse(window.Schedule)
+		if err != nil {
+			slog.Error("Ignoring invalid blackout window", "name", window.Name, "error", err)
+			continue
+		}
+
+		blackouts = append(blackouts, blackout{name: window.Name, schedule: schedule, duration: window.Duration.Duration})
+	}
+
+	return blackouts
+}
+
+// pauseState tracks whether collection is paused
+type pauseState struct {
+	mu        sync.Mutex
+	blackouts []blackout
+	manual    bool // Paused through the admin endpoint
+	paused    bool
+}
+
+// active returns the name of the blackout window covering t, if any
+func (p *pauseState) active(t time.Time) (string, bool) {
+	for _, b := range p.blackouts {
+		if b.activeAt(t.UTC()) {
+			return b.name, true
+		}
+	}
+
+	return "", false
+}
+
+// Pause stops all API calls until Resume is called, keeping the last metrics.
+// A collection cycle in progress is completed.
+func (gc *GitHubCollector) Pause() {
+	gc.pause.mu.Lock()
+	gc.pause.manual = true
+	gc.pause.mu.Unlock()
+
+	gc.updatePaused(time.Now())
+}
+
+// Resume undoes Pause. Collection stays paused while a blackout window is active.
+func (gc *GitHubCollector) Resume() {
+	gc.pause.mu.Lock()
+	gc.pause.manual = false
+	gc.pause.mu.Unlock()
+
+	gc.updatePaused(time.Now())
+}
+
+// updatePaused exports whether collection is paused at now and logs when it is
+// paused or resumed. It returns whether no API calls should be made.
+func (gc *GitHubCollector) updatePaused(now time.Time) bool {
+	name, paused := gc.pause.active(now)
+
+	gc.pause.mu.Lock()
+	manual := gc.pause.manual
+	paused = paused || manual
+	changed := paused != gc.pause.paused
+	gc.pause.paused = paused
+	gc.pause.mu.Unlock()
+
+	value := 0.0
+	if paused {
+		value = 1
+	}
+
+	gc.metrics.GitHubExporterPaused.With(prometheus.Labels{}).Set(value)
+
+	switch {
+	case changed && manual:
+		slog.Warn("Collection paused through the admin endpoint")
+	case changed && paused:
+		slog.Info("Blackout window started, pausing collection", "blackout", name)
+	case changed:
+		slog.Info("Resuming collection")
+	}
+
+	return paused
+}
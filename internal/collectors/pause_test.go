@@ -0,0 +1,93 @@
+package collectors
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/d0ugal/github-exporter/internal/config"
+	"github.com/d0ugal/promexporter/app"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// TestBlackoutActiveAt tests that a window covers the duration after each time its schedule fires
+func TestBlackoutActiveAt(t *testing.T) {
+	blackouts := parseBlackouts([]config.BlackoutWindow{
+		{Name: "migrations", Schedule: "0 22 * * 6", Duration: config.Duration{Duration: 4 * time.Hour}},
+	})
+
+	tests := []struct {
+		time   string
+		active bool
+	}{
+		{"2026-10-17T21:59:00Z", false},
+		{"2026-10-17T22:00:00Z", true},
+		{"2026-10-18T01:59:00Z", true}, // Spans midnight
+		{"2026-10-18T02:00:00Z", false},
+		{"2026-10-21T23:00:00Z", false},
+	}
+
+	for _, tt := range tests {
+		now, _ := time.Parse(time.RFC3339, tt.time)
+
+		if got := blackouts[0].activeAt(now); got != tt.active {
+			t.Errorf("Expected active=%v at %s, got %v", tt.active, tt.time, got)
+		}
+	}
+}
+
+// TestCollectMetricsPaused tests that no API calls are made during a blackout and
+// that the paused gauge follows the window
+func TestCollectMetricsPaused(t *testing.T) {
+	collector := newAPITestCollector(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Unexpected request to %s during a blackout", r.URL.Path)
+	})
+	collector.app = app.New("github-exporter")
+	collector.pause.blackouts = parseBlackouts([]config.BlackoutWindow{
+		{Name: "always", Schedule: "* * * * *", Duration: config.Duration{Duration: time.Hour}},
+	})
+
+	collector.collectMetrics(t.Context())
+	collector.resyncRepo(t.Context(), target{Org: "d0ugal", Repo: "private"})
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubExporterPaused); got != 1 {
+		t.Errorf("Expected paused gauge 1, got %v", got)
+	}
+
+	collector.pause.blackouts = nil
+
+	if collector.updatePaused(time.Now()) {
+		t.Error("Expected collection to resume without an active window")
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubExporterPaused); got != 0 {
+		t.Errorf("Expected paused gauge 0, got %v", got)
+	}
+}
+
+// TestPauseResume tests that manual pauses stop collection and that resuming
+// keeps collection paused during a blackout
+func TestPauseResume(t *testing.T) {
+	collector := createTestCollector()
+
+	collector.Pause()
+
+	if !collector.updatePaused(time.Now()) {
+		t.Error("Expected collection to be paused")
+	}
+
+	collector.pause.blackouts = parseBlackouts([]config.BlackoutWindow{
+		{Name: "always", Schedule: "* * * * *", Duration: config.Duration{Duration: time.Hour}},
+	})
+	collector.Resume()
+
+	if !collector.updatePaused(time.Now()) {
+		t.Error("Expected collection to stay paused during a blackout")
+	}
+
+	collector.pause.blackouts = nil
+
+	if collector.updatePaused(time.Now()) {
+		t.Error("Expected collection to resume")
+	}
+}
@@ -0,0 +1,162 @@
+package collectors
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v76/github"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// Performance budget for a large installation. The benchmark shape is 5,000
+// repositories with one tracked branch each, which produces over 50,000 series.
+// TestPerformanceBudget fails when a change pushes a collection cycle or the
+// registry past these limits. They are generous enough to hold under -race on a
+// shared CI runner; tighten them rather than loosen them.
+const (
+	perfRepoCount = 5000
+
+	// perfMinSeries guards against the benchmark silently shrinking
+	perfMinSeries = 50000
+
+	// perfMaxCycleDuration bounds one full collection cycle against the in-memory API
+	perfMaxCycleDuration = 15 * time.Second
+
+	// perfMaxGatherDuration bounds one scrape of the populated registry
+	perfMaxGatherDuration = 10 * time.Second
+
+	// perfMaxHeapBytes bounds the live heap held by the populated registry and
+	// the in-memory API behind it
+	perfMaxHeapBytes = 256 << 20
+)
+
+// newLargeFakeCollector creates a collector tracking the given number of
+// repositories, each with workflow and check runs on main
+func newLargeFakeCollector(repos int) *GitHubCollector {
+	collector, api := newFakeCollector()
+	collector.config.GitHub.Branches = []string{"main"}
+	collector.config.GitHub.RateLimitBuffer = 0.8
+
+	for i := range repos {
+		name := fmt.Sprintf("repo-%05d", i)
+
+		collector.config.GitHub.Repos = append(collector.config.GitHub.Repos, "bench/"+name)
+
+		repo := api.addRepo("bench", name, i%2 == 0)
+		repo.Language = github.Ptr("Go")
+		repo.StargazersCount = github.Ptr(i)
+		repo.ForksCount = github.Ptr(i % 10)
+		repo.WatchersCount = github.Ptr(i)
+		repo.OpenIssuesCount = github.Ptr(i % 7)
+		repo.Size = github.Ptr(1024)
+		repo.CreatedAt = &github.Timestamp{Time: time.Unix(1704067200, 0)}
+		repo.UpdatedAt = &github.Timestamp{Time: time.Unix(1767225600, 0)}
+
+		api.workflowRuns["bench/"+name] = []*github.WorkflowRun{
+			{WorkflowID: github.Ptr(int64(1)), Name: github.Ptr("CI"), HeadBranch: github.Ptr("main"), Status: github.Ptr("completed"), Conclusion: github.Ptr("success")},
+			{WorkflowID: github.Ptr(int64(2)), Name: github.Ptr("Release"), HeadBranch: github.Ptr("main"), Status: github.Ptr("completed"), Conclusion: github.Ptr("success")},
+		}
+		api.checkRuns["bench/"+name+"@main"] = []*github.CheckRun{
+			{Name: github.Ptr("build"), Status: github.Ptr("completed"), Conclusion: github.Ptr("success")},
+		}
+	}
+
+	return collector
+}
+
+// heapInUse returns the live heap after a full garbage collection
+func heapInUse() uint64 {
+	var stats runtime.MemStats
+
+	runtime.GC()
+	runtime.ReadMemStats(&stats)
+
+	return stats.HeapAlloc
+}
+
+// TestPerformanceBudget enforces the performance budget for a large installation
+func TestPerformanceBudget(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping performance budget in short mode")
+	}
+
+	before := heapInUse()
+
+	collector := newLargeFakeCollector(perfRepoCount)
+
+	start := time.Now()
+	collector.collectMetrics(t.Context())
+	cycle := time.Since(start)
+
+	registry := collector.metrics.GetRegistry()
+
+	series, err := testutil.GatherAndCount(registry)
+	if err != nil {
+		t.Fatalf("Failed to gather metrics: %v", err)
+	}
+
+	start = time.Now()
+	if _, err := registry.Gather(); err != nil {
+		t.Fatalf("Failed to gather metrics: %v", err)
+	}
+	gather := time.Since(start)
+
+	after := heapInUse()
+	heap := after - min(before, after)
+
+	runtime.KeepAlive(collector)
+
+	t.Logf("%d repositories: %d series, cycle %s, gather %s, heap %d MiB", perfRepoCount, series, cycle, gather, heap>>20)
+
+	if series < perfMinSeries {
+		t.Errorf("Expected at least %d series, got %d", perfMinSeries, series)
+	}
+
+	if cycle > perfMaxCycleDuration {
+		t.Errorf("Collection cycle took %s, budget is %s", cycle, perfMaxCycleDuration)
+	}
+
+	if gather > perfMaxGatherDuration {
+		t.Errorf("Gather took %s, budget is %s", gather, perfMaxGatherDuration)
+	}
+
+	if heap > perfMaxHeapBytes {
+		t.Errorf("Registry holds %d MiB, budget is %d MiB", heap>>20, perfMaxHeapBytes>>20)
+	}
+}
+
+// BenchmarkCollectMetrics measures a full collection cycle for a large installation
+func BenchmarkCollectMetrics(b *testing.B) {
+	collector := newLargeFakeCollector(perfRepoCount)
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		collector.collectMetrics(b.Context())
+	}
+
+	series, err := testutil.GatherAndCount(collector.metrics.GetRegistry())
+	if err != nil {
+		b.Fatalf("Failed to gather metrics: %v", err)
+	}
+
+	b.ReportMetric(float64(series), "series")
+}
+
+// BenchmarkGather measures scraping a registry populated by a large installation
+func BenchmarkGather(b *testing.B) {
+	collector := newLargeFakeCollector(perfRepoCount)
+	collector.collectMetrics(b.Context())
+
+	registry := collector.metrics.GetRegistry()
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		if _, err := registry.Gather(); err != nil {
+			b.Fatalf("Failed to gather metrics: %v", err)
+		}
+	}
+}
@@ -0,0 +1,340 @@
+package collectors
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v76/github"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// oauthScopesHeader lists the scopes of a classic personal access token
+const oauthScopesHeader = "X-OAuth-Scopes"
+
+// collectorScopes lists the classic token scopes each collector needs to read
+// private resources. Any one of the listed scopes is sufficient.
+var collectorScopes = map[string][]string{
+	"repo_stats":            {"repo"},
+	"org_stats":             {"read:org", "write:org", "admin:org"},
+	"actions_policy":        {"admin:org"},
+	"sso":                   {"admin:org"},
+	"prs":                   {"repo"},
+	"build_status":          {"repo"},
+	"check_runs":            {"repo"},
+	"workflow_usage":        {"repo"},
+	"commits":               {"repo"},
+	"comments":              {"repo"},
+	"first_response":        {"repo"},
+	"issue_activity":        {"repo"},
+	"codeowners":            {"repo"},
+	"security_policy":       {"repo"},
+	"community_files":       {"repo"},
+	"dependency_automation": {"repo"},
+	"tag_protection":        {"repo"},
+	"bypass_actors":         {"repo"},
+	"secrets":               {"repo"},
+	"oidc":                  {"repo"},
+	"releases":              {"repo"},
+	"release_assets":        {"repo"},
+	"unreleased_commits":    {"repo"},
+	"schedules":             {"repo"},
+	"workflow_state":        {"repo"},
+	"packages":              {"read:packages", "write:packages", "delete:packages"},
+	"projects":              {"read:project", "project"},
+	"synthetic":             {"repo"},
+	"fork_activity":         {"repo"},
+	"webhooks":              {"read:repo_hook", "write:repo_hook", "admin:repo_hook", "repo"},
+}
+
+// enabledCollectors returns the names of the enabled collectors in a stable order
+func (gc *GitHubCollector) enabledCollectors() []string {
+	collectors := gc.config.Collectors
+
+	var enabled []string
+
+	for _, c := range []struct {
+		name    string
+		enabled bool
+	}{
+		{"repo_stats", collectors.RepoStatsEnabled()},
+		{"org_stats", collectors.OrgStatsEnabled()},
+		{"actions_policy", collectors.ActionsPolicyEnabled()},
+		{"sso", collectors.SSOEnabled()},
+		{"prs", collectors.PullRequestsEnabled()},
+		{"build_status", collectors.BuildStatusEnabled() && len(gc.config.GitHub.Branches) > 0},
+		{"check_runs", collectors.CheckRunsEnabled() && len(gc.config.GitHub.Branches) > 0},
+		{"workflow_usage", collectors.WorkflowUsageEnabled() && len(gc.config.GitHub.Branches) > 0},
+		{"commits", collectors.CommitsEnabled() && len(gc.config.GitHub.Branches) > 0},
+		{"comments", collectors.CommentsEnabled()},
+		{"first_response", collectors.FirstResponseEnabled()},
+		{"issue_activity", collectors.IssueActivityEnabled()},
+		{"codeowners", collectors.CodeownersEnabled()},
+		{"security_policy", collectors.SecurityPolicyEnabled()},
+		{"community_files", collectors.CommunityFilesEnabled()},
+		{"dependency_automation", collectors.DependencyAutomationEnabled()},
+		{"tag_protection", collectors.TagProtectionEnabled()},
+		{"bypass_actors", collectors.BypassActorsEnabled()},
+		{"secrets", collectors.SecretsEnabled()},
+		{"oidc", collectors.OIDCEnabled()},
+		{"releases", collectors.ReleasesEnabled()},
+		{"release_assets", collectors.ReleaseAssetsEnabled()},
+		{"unreleased_commits", collectors.UnreleasedCommitsEnabled()},
+		{"schedules", collectors.SchedulesEnabled()},
+		{"workflow_state", collectors.WorkflowStateEnabled()},
+		{"packages", collectors.PackagesEnabled()},
+		{"projects", len(gc.config.GitHub.Projects) > 0},
+		{"synthetic", len(gc.config.GitHub.SyntheticChecks) > 0},
+		{"fork_activity", len(gc.config.GitHub.ForkActivity) > 0},
+		{"webhooks", collectors.WebhooksEnabled()},
+	} {
+		if c.enabled {
+			enabled = append(enabled, c.name)
+		}
+	}
+
+	return enabled
+}
+
+// parseScopes parses the comma separated X-OAuth-Scopes header
+func parseScopes(header string) map[string]bool {
+	scopes := make(map[string]bool)
+
+	for _, scope := range strings.Split(header, ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			scopes[scope] = true
+		}
+	}
+
+	return scopes
+}
+
+// hasAnyScope reports whether any of the required scopes was granted
+func hasAnyScope(granted map[string]bool, required []string) bool {
+	if len(required) == 0 {
+		return true
+	}
+
+	for _, scope := range required {
+		if granted[scope] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkPermissions verifies at startup that the token can serve every enabled
+// collector and exports the result as github_collector_permission_ok. Classic
+// tokens are checked against their X-OAuth-Scopes header. Fine-grained and App
+// tokens have no scopes header, so a representative request is made for each
+// collector against the first configured target instead.
+func (gc *GitHubCollector) checkPermissions(ctx context.Context) {
+	enabled := gc.enabledCollectors()
+	if len(enabled) == 0 || gc.updatePaused(time.Now()) {
+		return
+	}
+
+	if err := gc.limiter.Wait(ctx); err != nil {
+		return
+	}
+
+	reqCtx, cancel := gc.requestContext(ctx, "user")
+	_, resp, err := gc.api.GetAuthenticatedUser(reqCtx)
+	cancel()
+
+	if err == nil && resp != nil && resp.Header.Get(oauthScopesHeader) != "" {
+		granted := parseScopes(resp.Header.Get(oauthScopesHeader))
+
+		for _, collector := range enabled {
+			gc.setPermission(collector, hasAnyScope(granted, collectorScopes[collector]),
+				"required_scopes", strings.Join(collectorScopes[collector], " or "))
+		}
+
+		return
+	}
+
+	slog.Debug("Token has no OAuth scopes header, probing collector access", "error", err)
+
+	for _, collector := range enabled {
+		ok, known := gc.probeCollector(ctx, collector)
+		if known {
+			gc.setPermission(collector, ok)
+		}
+	}
+}
+
+// setPermission exports and logs the permission check result for a collector
+func (gc *GitHubCollector) setPermission(collector string, ok bool, attrs ...any) {
+	value := 0.0
+	if ok {
+		value = 1
+	}
+
+	gc.metrics.GitHubCollectorPermissionOK.With(prometheus.Labels{
+		"collector": collector,
+	}).Set(value)
+
+	if !ok {
+		slog.Warn("Token is missing permissions for enabled collector", append([]any{"collector", collector}, attrs...)...)
+	}
+}
+
+// probeTarget returns the first configured organization and specific repository
+func (gc *GitHubCollector) probeTarget() (string, string, string) {
+	var org, owner, repo string
+
+	if orgs := gc.targetOrgs(); len(orgs) > 0 {
+		org = orgs[0]
+	}
+
+	for _, fullName := range gc.targetRepos() {
+		if parts := strings.Split(fullName, "/"); len(parts) == 2 && parts[0] != "" && parts[1] != "" {
+			owner, repo = parts[0], parts[1]
+			break
+		}
+	}
+
+	return org, owner, repo
+}
+
+// probeCollector makes one representative request for a collector. It returns
+// whether access was granted and whether the result is conclusive; probes are
+// inconclusive when there is no suitable target or the request failed for a
+// reason other than authorization.
+func (gc *GitHubCollector) probeCollector(ctx context.Context, collector string) (bool, bool) {
+	org, owner, repo := gc.probeTarget()
+
+	var (
+		endpoint string
+		call     func(context.Context) (*github.Response, error)
+	)
+
+	switch collector {
+	case "org_stats":
+		if org == "" {
+			return false, false
+		}
+
+		endpoint = "orgs"
+		call = func(reqCtx context.Context) (*github.Response, error) {
+			_, resp, err := gc.api.GetOrganization(reqCtx, org)
+			return resp, err
+		}
+	case "actions_policy":
+		if org == "" {
+			return false, false
+		}
+
+		endpoint = "actions_permissions"
+		call = func(reqCtx context.Context) (*github.Response, error) {
+			_, resp, err := gc.api.GetOrgActionsPermissions(reqCtx, org)
+			return resp, err
+		}
+	case "repo_stats", "prs":
+		if repo == "" {
+			return false, false
+		}
+
+		endpoint = "repos"
+		call = func(reqCtx context.Context) (*github.Response, error) {
+			_, resp, err := gc.api.GetRepository(reqCtx, owner, repo)
+			return resp, err
+		}
+	case "security_policy":
+		if repo == "" {
+			return false, false
+		}
+
+		endpoint = "private_vulnerability_reporting"
+		call = func(reqCtx context.Context) (*github.Response, error) {
+			_, resp, err := gc.api.IsPrivateReportingEnabled(reqCtx, owner, repo)
+			return resp, err
+		}
+	case "tag_protection", "bypass_actors":
+		if repo == "" {
+			return false, false
+		}
+
+		endpoint = "rulesets"
+		call = func(reqCtx context.Context) (*github.Response, error) {
+			_, resp, err := gc.api.GetAllRulesets(reqCtx, owner, repo, &github.RepositoryListRulesetsOptions{
+				ListOptions: github.ListOptions{PerPage: 1},
+			})
+			return resp, err
+		}
+	case "secrets":
+		if repo == "" {
+			return false, false
+		}
+
+		endpoint = "actions_secrets"
+		call = func(reqCtx context.Context) (*github.Response, error) {
+			_, resp, err := gc.api.ListRepoSecrets(reqCtx, owner, repo, &github.ListOptions{PerPage: 1})
+			return resp, err
+		}
+	case "build_status":
+		if repo == "" {
+			return false, false
+		}
+
+		endpoint = "workflow_runs"
+		call = func(reqCtx context.Context) (*github.Response, error) {
+			_, resp, err := gc.api.ListWorkflowRuns(reqCtx, owner, repo, &github.ListWorkflowRunsOptions{
+				ListOptions: github.ListOptions{PerPage: 1},
+			})
+			return resp, err
+		}
+	case "commits":
+		if repo == "" || len(gc.config.GitHub.Branches) == 0 {
+			return false, false
+		}
+
+		endpoint = "commits"
+		call = func(reqCtx context.Context) (*github.Response, error) {
+			_, resp, err := gc.api.ListCommits(reqCtx, owner, repo, &github.CommitsListOptions{
+				SHA:         gc.config.GitHub.Branches[0],
+				ListOptions: github.ListOptions{PerPage: 1},
+			})
+			return resp, err
+		}
+	case "check_runs":
+		if repo == "" || len(gc.config.GitHub.Branches) == 0 {
+			return false, false
+		}
+
+		endpoint = "check_runs"
+		call = func(reqCtx context.Context) (*github.Response, error) {
+			_, resp, err := gc.api.ListCheckRunsForRef(reqCtx, owner, repo, gc.config.GitHub.Branches[0], &github.ListCheckRunsOptions{
+				ListOptions: github.ListOptions{PerPage: 1},
+			})
+			return resp, err
+		}
+	default:
+		return false, false
+	}
+
+	if err := gc.limiter.Wait(ctx); err != nil {
+		return false, false
+	}
+
+	reqCtx, cancel := gc.requestContext(ctx, endpoint)
+	_, err := call(reqCtx)
+	cancel()
+
+	if err == nil {
+		return true, true
+	}
+
+	switch _, errorType := classifyAPIError(err); errorType {
+	case "unauthorized", "forbidden":
+		return false, true
+	case "not_found":
+		// A missing branch is not a permission problem for check runs or commits
+		return collector == "check_runs" || collector == "commits", true
+	default:
+		logError("Permission probe failed", wrapAPIError(endpoint, target{Org: owner, Repo: repo}, err), "collector", collector)
+		return false, false
+	}
+}
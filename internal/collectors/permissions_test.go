@@ -0,0 +1,75 @@
+package collectors
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-github/v76/github"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"golang.org/x/time/rate"
+)
+
+// newAPITestCollector creates a collector talking to a fake GitHub API
+func newAPITestCollector(t *testing.T, handler http.HandlerFunc) *GitHubCollector {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	collector := createTestCollector()
+	client := github.NewClient(nil)
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+
+	collector.api = NewGitHubAPI(client)
+	collector.limiter = newSharedLimiter(rate.Inf, 1)
+	collector.config.GitHub.Orgs = []string{"d0ugal"}
+	collector.config.GitHub.Repos = []string{"d0ugal/private"}
+
+	return collector
+}
+
+// TestCheckPermissionsScopes tests permission checks based on classic token scopes
+func TestCheckPermissionsScopes(t *testing.T) {
+	collector := newAPITestCollector(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(oauthScopesHeader, "repo, read:user")
+		_, _ = w.Write([]byte(`{"login": "d0ugal"}`))
+	})
+
+	collector.checkPermissions(t.Context())
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubCollectorPermissionOK.WithLabelValues("repo_stats")); got != 1 {
+		t.Errorf("Expected repo_stats permission ok, got %v", got)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubCollectorPermissionOK.WithLabelValues("org_stats")); got != 0 {
+		t.Errorf("Expected org_stats permission missing without read:org, got %v", got)
+	}
+}
+
+// TestCheckPermissionsProbe tests permission probes for tokens without a scopes header
+func TestCheckPermissionsProbe(t *testing.T) {
+	collector := newAPITestCollector(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/user":
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"message": "Resource not accessible by integration"}`))
+		case "/orgs/d0ugal":
+			_, _ = w.Write([]byte(`{"login": "d0ugal"}`))
+		default:
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"message": "Forbidden"}`))
+		}
+	})
+
+	collector.checkPermissions(t.Context())
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubCollectorPermissionOK.WithLabelValues("org_stats")); got != 1 {
+		t.Errorf("Expected org_stats permission ok, got %v", got)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubCollectorPermissionOK.WithLabelValues("repo_stats")); got != 0 {
+		t.Errorf("Expected repo_stats permission missing, got %v", got)
+	}
+}
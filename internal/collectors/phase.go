@@ -0,0 +1,27 @@
+package collectors
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// phaseResult counts the targets a collection phase succeeded and failed for
+type phaseResult struct {
+	Succeeded int
+	Failed    int
+}
+
+// add counts the targets of another result, e.g. the repositories discovered for a target
+func (r *phaseResult) add(other phaseResult) {
+	r.Succeeded += other.Succeeded
+	r.Failed += other.Failed
+}
+
+// setPhaseMetrics exports the per-target outcome of a collection phase
+func (gc *GitHubCollector) setPhaseMetrics(phase string, result phaseResult) {
+	gc.metrics.GitHubCollectionPhaseTargets.With(prometheus.Labels{
+		"phase":  phase,
+		"result": "success",
+	}).Set(float64(result.Succeeded))
+	gc.metrics.GitHubCollectionPhaseTargets.With(prometheus.Labels{
+		"phase":  phase,
+		"result": "error",
+	}).Set(float64(result.Failed))
+}
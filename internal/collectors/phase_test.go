@@ -0,0 +1,82 @@
+package collectors
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/d0ugal/promexporter/app"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// TestCollectRepoMetricsErrors tests that per-repository failures are joined and counted
+func TestCollectRepoMetricsErrors(t *testing.T) {
+	collector := newAPITestCollector(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/d0ugal/github-exporter":
+			_, _ = w.Write([]byte(`{"name": "github-exporter", "owner": {"login": "d0ugal"}}`))
+		case "/search/issues":
+			_, _ = w.Write([]byte(`{"total_count": 0}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+		}
+	})
+	collector.app = app.New("github-exporter")
+	collector.config.GitHub.Repos = []string{"d0ugal/github-exporter", "d0ugal/missing", "invalid"}
+
+	result, err := collector.collectRepoMetrics(t.Context())
+
+	if result.Succeeded != 1 || result.Failed != 2 {
+		t.Errorf("Expected 1 succeeded and 2 failed repositories, got %+v", result)
+	}
+
+	var targetErr *TargetError
+	if !errors.As(err, &targetErr) || targetErr.Repo != "missing" {
+		t.Errorf("Expected a target error for d0ugal/missing, got %v", err)
+	}
+
+	if err == nil || !strings.Contains(err.Error(), `invalid repository format "invalid"`) {
+		t.Errorf("Expected the invalid repository to be reported, got %v", err)
+	}
+
+	collector.setPhaseMetrics("repos", result)
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubCollectionPhaseTargets.WithLabelValues("repos", "error")); got != 2 {
+		t.Errorf("Expected 2 failed targets, got %v", got)
+	}
+}
+
+// TestCollectDiscoveredRepoErrors tests that every repository of a wildcard
+// discovery counts as a target, and failures of its collectors are returned
+func TestCollectDiscoveredRepoErrors(t *testing.T) {
+	collector := newAPITestCollector(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/user/repos":
+			_, _ = w.Write([]byte(`[
+				{"name": "github-exporter", "owner": {"login": "d0ugal"}},
+				{"name": "broken", "owner": {"login": "d0ugal"}}
+			]`))
+		case r.URL.Path == "/search/issues" && strings.Contains(r.URL.RawQuery, "broken"):
+			w.WriteHeader(http.StatusInternalServerError)
+		case r.URL.Path == "/search/issues":
+			_, _ = w.Write([]byte(`{"total_count": 0}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	collector.app = app.New("github-exporter")
+	collector.config.GitHub.Repos = []string{"*"}
+
+	result, err := collector.collectRepoMetrics(t.Context())
+
+	if result.Succeeded != 1 || result.Failed != 1 {
+		t.Errorf("Expected 1 succeeded and 1 failed repository, got %+v", result)
+	}
+
+	var targetErr *TargetError
+	if !errors.As(err, &targetErr) || targetErr.Repo != "broken" {
+		t.Errorf("Expected a target error for d0ugal/broken, got %v", err)
+	}
+}
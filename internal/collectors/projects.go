@@ -0,0 +1,289 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// projectItemsQuery lists the items of an organization project together with
+// their status and iteration field values
+const projectItemsQuery = `query($org: String!, $number: Int!, $statusField: String!, $iterationField: String!, $cursor: String) {
+  organization(login: $org) {
+    projectV2(number: $number) {
+      title
+      items(first: 100, after: $cursor) {
+        pageInfo { hasNextPage endCursor }
+        nodes {
+          id
+          isArchived
+          content {
+            __typename
+            ... on Issue { state }
+            ... on PullRequest { state }
+          }
+          status: fieldValueByName(name: $statusField) {
+            ... on ProjectV2ItemFieldSingleSelectValue { name }
+          }
+          iteration: fieldValueByName(name: $iterationField) {
+            ... on ProjectV2ItemFieldIterationValue { title startDate duration }
+          }
+        }
+      }
+    }
+  }
+}`
+
+// projectItem is a single item of an organization project
+type projectItem struct {
+	ID         string `json:"id"`
+	IsArchived bool   `json:"isArchived"`
+	Content    *struct {
+		TypeName string `json:"__typename"`
+		State    string `json:"state"`
+	} `json:"content"`
+	Status *struct {
+		Name string `json:"name"`
+	} `json:"status"`
+	Iteration *struct {
+		Title     string `json:"title"`
+		StartDate string `json:"startDate"`
+		Duration  int    `json:"duration"`
+	} `json:"iteration"`
+}
+
+// closed returns true if the item's issue or pull request is closed or merged
+func (pi projectItem) closed() bool {
+	if pi.Content == nil {
+		return false
+	}
+
+	return pi.Content.State == "CLOSED" || pi.Content.State == "MERGED"
+}
+
+// statusName returns the item's status field value, or "none" if it has none
+func (pi projectItem) statusName() string {
+	if pi.Status == nil || pi.Status.Name == "" {
+		return "none"
+	}
+
+	return pi.Status.Name
+}
+
+// inIteration returns true if the item is assigned to the iteration running at now
+func (pi projectItem) inIteration(now time.Time) bool {
+	if pi.Iteration == nil {
+		return false
+	}
+
+	start, err := time.Parse("2006-01-02", pi.Iteration.StartDate)
+	if err != nil {
+		return false
+	}
+
+	end := start.AddDate(0, 0, pi.Iteration.Duration)
+
+	return !now.Before(start) && now.Before(end)
+}
+
+// projectItemsResponse is the data returned by projectItemsQuery
+type projectItemsResponse struct {
+	Organization *struct {
+		ProjectV2 *struct {
+			Title string `json:"title"`
+			Items struct {
+				PageInfo struct {
+					HasNextPage bool   `json:"hasNextPage"`
+					EndCursor   string `json:"endCursor"`
+				} `json:"pageInfo"`
+				Nodes []projectItem `json:"nodes"`
+			} `json:"items"`
+		} `json:"projectV2"`
+	} `json:"organization"`
+}
+
+// projectTracker remembers the items seen in each project so that additions and
+// closures between collection cycles can be counted
+type projectTracker struct {
+	mu       sync.Mutex
+	projects map[string]map[string]bool // project -> item ID -> closed
+}
+
+func newProjectTracker() *projectTracker {
+	return &projectTracker{
+		projects: make(map[string]map[string]bool),
+	}
+}
+
+// observe records the current items of a project and returns how many were added
+// and how many were closed since the previous observation. The first observation
+// of a project only seeds the tracker.
+func (pt *projectTracker) observe(project string, items []projectItem) (int, int) {
+	pt.mu.Lock()
+	defer pt.mu.Unlock()
+
+	current := make(map[string]bool, len(items))
+	for _, item := range items {
+		current[item.ID] = item.closed()
+	}
+
+	previous, seen := pt.projects[project]
+	pt.projects[project] = current
+
+	if !seen {
+		return 0, 0
+	}
+
+	added, closed := 0, 0
+
+	for id, isClosed := range current {
+		wasClosed, existed := previous[id]
+		if !existed {
+			added++
+		}
+
+		if isClosed && (!existed || !wasClosed) {
+			closed++
+		}
+	}
+
+	return added, closed
+}
+
+// collectProjectMetrics collects item metrics for all configured organization projects
+func (gc *GitHubCollector) collectProjectMetrics(ctx context.Context) error {
+	var failed int
+
+	for _, project := range gc.config.GitHub.Projects {
+		if err := gc.collectProject(ctx, project); err != nil {
+			logError("Failed to collect project metrics", err, "project", project)
+			gc.status.record("project", project, err)
+
+			failed++
+
+			continue
+		}
+
+		gc.status.record("project", project, nil)
+	}
+
+	if failed > 0 && failed == len(gc.config.GitHub.Projects) {
+		return fmt.Errorf("failed to collect metrics for all %d projects", failed)
+	}
+
+	return nil
+}
+
+// collectProject collects item metrics for a single organization project ("org/number")
+func (gc *GitHubCollector) collectProject(ctx context.Context, project string) error {
+	org, numberStr, _ := strings.Cut(project, "/")
+
+	number, err := strconv.Atoi(numberStr)
+	if err != nil {
+		return fmt.Errorf("invalid project number %q: %w", numberStr, err)
+	}
+
+	var (
+		title  string
+		items  []projectItem
+		cursor *string
+	)
+
+	for {
+		var data projectItemsResponse
+
+		err := gc.graphql(ctx, "graphql_project_items", projectItemsQuery, map[string]interface{}{
+			"org":            org,
+			"number":         number,
+			"statusField":    gc.config.GitHub.ProjectStatusField,
+			"iterationField": gc.config.GitHub.ProjectIterationField,
+			"cursor":         cursor,
+		}, &data)
+		if err != nil {
+			return wrapAPIError("graphql_project_items", target{Org: org}, err)
+		}
+
+		if data.Organization == nil || data.Organization.ProjectV2 == nil {
+			return fmt.Errorf("project %d not found in organization %s", number, org)
+		}
+
+		projectData := data.Organization.ProjectV2
+		title = projectData.Title
+
+		for _, item := range projectData.Items.Nodes {
+			if !item.IsArchived {
+				items = append(items, item)
+			}
+		}
+
+		if !projectData.Items.PageInfo.HasNextPage {
+			break
+		}
+
+		endCursor := projectData.Items.PageInfo.EndCursor
+		cursor = &endCursor
+	}
+
+	gc.setProjectMetrics(org, project, title, items, time.Now())
+
+	return nil
+}
+
+// setProjectMetrics updates the project gauges and counters from the project's items
+func (gc *GitHubCollector) setProjectMetrics(org, project, title string, items []projectItem, now time.Time) {
+	byStatus := make(map[string]int)
+	iterations := make(map[string][2]int) // iteration -> {items, closed items}
+
+	for _, item := range items {
+		byStatus[item.statusName()]++
+
+		if item.inIteration(now) {
+			counts := iterations[item.Iteration.Title]
+			counts[0]++
+
+			if item.closed() {
+				counts[1]++
+			}
+
+			iterations[item.Iteration.Title] = counts
+		}
+	}
+
+	// Drop statuses and iterations that no longer have items
+	projectLabels := prometheus.Labels{"org": org, "project": title}
+	gc.metrics.GitHubProjectItems.DeletePartialMatch(projectLabels)
+	gc.metrics.GitHubProjectIterationItems.DeletePartialMatch(projectLabels)
+	gc.metrics.GitHubProjectIterationProgressRatio.DeletePartialMatch(projectLabels)
+
+	for status, count := range byStatus {
+		gc.metrics.GitHubProjectItems.With(prometheus.Labels{
+			"org":     org,
+			"project": title,
+			"status":  status,
+		}).Set(float64(count))
+	}
+
+	for iteration, counts := range iterations {
+		labels := prometheus.Labels{"org": org, "project": title, "iteration": iteration}
+		gc.metrics.GitHubProjectIterationItems.With(labels).Set(float64(counts[0]))
+		gc.metrics.GitHubProjectIterationProgressRatio.With(labels).Set(float64(counts[1]) / float64(counts[0]))
+	}
+
+	added, closed := gc.projects.observe(project, items)
+	gc.metrics.GitHubProjectItemsAdded.With(projectLabels).Add(float64(added))
+	gc.metrics.GitHubProjectItemsClosed.With(projectLabels).Add(float64(closed))
+
+	slog.Debug("Collected project metrics",
+		"project", project,
+		"title", title,
+		"items", len(items),
+		"added", added,
+		"closed", closed,
+	)
+}
@@ -0,0 +1,141 @@
+package collectors
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v76/github"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// TestProjectTrackerObserve tests counting added and closed project items between cycles
+func TestProjectTrackerObserve(t *testing.T) {
+	tracker := newProjectTracker()
+
+	open := func(id string) projectItem {
+		item := projectItem{ID: id}
+		item.Content = &struct {
+			TypeName string `json:"__typename"`
+			State    string `json:"state"`
+		}{TypeName: "Issue", State: "OPEN"}
+
+		return item
+	}
+	closed := func(id string) projectItem {
+		item := open(id)
+		item.Content.State = "CLOSED"
+
+		return item
+	}
+
+	if added, closedCount := tracker.observe("d0ugal/1", []projectItem{open("a"), closed("b")}); added != 0 || closedCount != 0 {
+		t.Errorf("Expected first observation to only seed the tracker, got added=%d closed=%d", added, closedCount)
+	}
+
+	added, closedCount := tracker.observe("d0ugal/1", []projectItem{closed("a"), closed("b"), open("c")})
+	if added != 1 {
+		t.Errorf("Expected 1 added item, got %d", added)
+	}
+
+	if closedCount != 1 {
+		t.Errorf("Expected 1 closed item, got %d", closedCount)
+	}
+}
+
+// TestCollectProject tests collecting project metrics from a paginated GraphQL response
+func TestCollectProject(t *testing.T) {
+	today := time.Now().UTC().Format("2006-01-02")
+
+	pages := []string{
+		`{"data": {"organization": {"projectV2": {"title": "Roadmap", "items": {
+			"pageInfo": {"hasNextPage": true, "endCursor": "c1"},
+			"nodes": [
+				{"id": "1", "content": {"__typename": "Issue", "state": "OPEN"}, "status": {"name": "Todo"},
+				 "iteration": {"title": "Sprint 1", "startDate": "` + today + `", "duration": 14}},
+				{"id": "2", "content": {"__typename": "PullRequest", "state": "MERGED"}, "status": {"name": "Done"},
+				 "iteration": {"title": "Sprint 1", "startDate": "` + today + `", "duration": 14}}
+			]}}}}}`,
+		`{"data": {"organization": {"projectV2": {"title": "Roadmap", "items": {
+			"pageInfo": {"hasNextPage": false, "endCursor": "c2"},
+			"nodes": [
+				{"id": "3", "content": {"__typename": "DraftIssue"}, "status": {}, "iteration": {}},
+				{"id": "4", "isArchived": true, "content": {"__typename": "Issue", "state": "OPEN"}, "status": {"name": "Todo"}}
+			]}}}}}`,
+	}
+
+	var requests int
+
+	collector := newAPITestCollector(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/graphql" {
+			t.Errorf("Unexpected request path %s", r.URL.Path)
+		}
+
+		var body graphqlRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("Failed to decode GraphQL request: %v", err)
+		}
+
+		if requests > 0 && body.Variables["cursor"] != "c1" {
+			t.Errorf("Expected cursor c1 on second page, got %v", body.Variables["cursor"])
+		}
+
+		_, _ = w.Write([]byte(pages[requests]))
+		requests++
+	})
+	collector.config.GitHub.ProjectStatusField = "Status"
+	collector.config.GitHub.ProjectIterationField = "Iteration"
+
+	if err := collector.collectProject(t.Context(), "d0ugal/1"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for status, expected := range map[string]float64{"Todo": 1, "Done": 1, "none": 1} {
+		if got := testutil.ToFloat64(collector.metrics.GitHubProjectItems.WithLabelValues("d0ugal", "Roadmap", status)); got != expected {
+			t.Errorf("Expected %v items with status %s, got %v", expected, status, got)
+		}
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubProjectIterationItems.WithLabelValues("d0ugal", "Roadmap", "Sprint 1")); got != 2 {
+		t.Errorf("Expected 2 items in the current iteration, got %v", got)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubProjectIterationProgressRatio.WithLabelValues("d0ugal", "Roadmap", "Sprint 1")); got != 0.5 {
+		t.Errorf("Expected iteration progress 0.5, got %v", got)
+	}
+}
+
+// TestGraphQLErrors tests that GraphQL errors are returned as errors
+func TestGraphQLErrors(t *testing.T) {
+	collector := newAPITestCollector(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data": null, "errors": [{"type": "NOT_FOUND", "message": "Could not resolve to an Organization"}]}`))
+	})
+
+	err := collector.collectProject(t.Context(), "missing/1")
+	if err == nil || !strings.Contains(err.Error(), "Could not resolve") {
+		t.Errorf("Expected GraphQL error, got %v", err)
+	}
+}
+
+// TestGraphQLPath tests the GraphQL endpoint for github.com and GitHub Enterprise Server
+func TestGraphQLPath(t *testing.T) {
+	for base, expected := range map[string]string{
+		"https://api.github.com/":            "https://api.github.com/graphql",
+		"https://github.example.com/api/v3/": "https://github.example.com/api/graphql",
+	} {
+		api := &githubAPI{client: github.NewClient(nil)}
+		api.client.BaseURL, _ = url.Parse(base)
+
+		req, err := api.client.NewRequest(http.MethodPost, api.graphqlPath(), nil)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if req.URL.String() != expected {
+			t.Errorf("Expected GraphQL URL %s for %s, got %s", expected, base, req.URL)
+		}
+	}
+}
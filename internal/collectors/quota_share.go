@@ -0,0 +1,57 @@
+package collectors
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// cycleCalls counts the API calls of the current collection cycle by endpoint
+type cycleCalls struct {
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+// add counts a call to endpoint
+func (c *cycleCalls) add(endpoint string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.calls == nil {
+		c.calls = make(map[string]int)
+	}
+
+	c.calls[endpoint]++
+}
+
+// take returns the calls counted so far and starts counting from zero
+func (c *cycleCalls) take() map[string]int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	calls := c.calls
+	c.calls = nil
+
+	return calls
+}
+
+// setQuotaShare exports the share of the cycle's API calls each endpoint made,
+// so the collectors to disable when the rate limit runs out are easy to find
+func (gc *GitHubCollector) setQuotaShare() {
+	calls := gc.calls.take()
+
+	total := 0
+	for _, count := range calls {
+		total += count
+	}
+
+	gc.metrics.GitHubAPIQuotaUsedRatio.Reset()
+
+	if total == 0 {
+		return
+	}
+
+	for endpoint, count := range calls {
+		gc.metrics.GitHubAPIQuotaUsedRatio.With(prometheus.Labels{"endpoint": endpoint}).Set(float64(count) / float64(total))
+	}
+}
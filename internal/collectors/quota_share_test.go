@@ -0,0 +1,49 @@
+package collectors
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// TestSetQuotaShare tests that each endpoint's share of the cycle's calls is
+// exported and that counting restarts with every cycle
+func TestSetQuotaShare(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	collector := createTestCollector()
+	transport := newInstrumentedTransport(nil, collector.metrics)
+	transport.onRequest = collector.calls.add
+	client := &http.Client{Transport: transport}
+
+	for _, endpoint := range []string{"repos", "workflow_runs", "workflow_runs", "workflow_runs"} {
+		req, _ := http.NewRequestWithContext(withEndpoint(t.Context(), endpoint), http.MethodGet, server.URL, nil)
+
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+
+		_ = resp.Body.Close()
+	}
+
+	collector.setQuotaShare()
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubAPIQuotaUsedRatio.WithLabelValues("workflow_runs")); got != 0.75 {
+		t.Errorf("Expected workflow runs to use 0.75 of the calls, got %v", got)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubAPIQuotaUsedRatio.WithLabelValues("repos")); got != 0.25 {
+		t.Errorf("Expected repos to use 0.25 of the calls, got %v", got)
+	}
+
+	collector.calls.add("releases")
+	collector.setQuotaShare()
+
+	if got := testutil.CollectAndCount(collector.metrics.GitHubAPIQuotaUsedRatio); got != 1 {
+		t.Errorf("Expected only the endpoints of the last cycle, got %d series", got)
+	}
+}
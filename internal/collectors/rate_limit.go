@@ -0,0 +1,92 @@
+package collectors
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/go-github/v76/github"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// tokenExpirationHeader is the response header GitHub reports the expiry of expiring tokens in
+const tokenExpirationHeader = "GitHub-Authentication-Token-Expiration"
+
+// tokenExpirationLayouts are the formats of the token expiration header
+var tokenExpirationLayouts = []string{"2006-01-02 15:04:05 MST", "2006-01-02 15:04:05 -0700"}
+
+// parseRate returns the rate limit reported in the headers of a response. It
+// returns false for responses without rate limit headers, e.g. from a proxy.
+func parseRate(header http.Header) (github.Rate, bool) {
+	limit, err := strconv.Atoi(header.Get("X-RateLimit-Limit"))
+	if err != nil {
+		return github.Rate{}, false
+	}
+
+	remaining, err := strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return github.Rate{}, false
+	}
+
+	r := github.Rate{Limit: limit, Remaining: remaining}
+
+	if used, err := strconv.Atoi(header.Get("X-RateLimit-Used")); err == nil {
+		r.Used = used
+	}
+
+	if reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		r.Reset = github.Timestamp{Time: time.Unix(reset, 0)}
+	}
+
+	return r, true
+}
+
+// parseTokenExpiration returns when the token a request was made with expires,
+// or false for tokens that do not expire
+func parseTokenExpiration(header http.Header) (time.Time, bool) {
+	value := header.Get(tokenExpirationHeader)
+	if value == "" {
+		return time.Time{}, false
+	}
+
+	for _, layout := range tokenExpirationLayouts {
+		if expiration, err := time.Parse(layout, value); err == nil {
+			return expiration, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// observeRateLimit updates the REST rate limit state and the rate limiter from
+// the headers of an API response, so they stay current without polling the
+// rate limit endpoint. GraphQL and search have quotas of their own; GraphQL
+// responses are recorded per query by recordGraphQLRate.
+func (gc *GitHubCollector) observeRateLimit(resource string, r github.Rate) {
+	if resource != "core" && resource != "" {
+		return
+	}
+
+	gc.mu.Lock()
+
+	// Responses can complete out of order. Within a window the lowest remaining
+	// count is the most recent, and late responses of a past window are stale.
+	if r.Reset.Time.Before(gc.rateLimitReset) || (r.Reset.Time.Equal(gc.rateLimitReset) && r.Remaining > gc.rateLimitRemaining) {
+		gc.mu.Unlock()
+		return
+	}
+
+	gc.rateLimitTotal = r.Limit
+	gc.rateLimitRemaining = r.Remaining
+	gc.rateLimitReset = r.Reset.Time
+	gc.mu.Unlock()
+
+	gc.metrics.GitHubRateLimitTotal.With(prometheus.Labels{}).Set(float64(r.Limit))
+	gc.metrics.GitHubRateLimitRemaining.With(prometheus.Labels{}).Set(float64(r.Remaining))
+
+	if !r.Reset.IsZero() {
+		gc.metrics.GitHubRateLimitReset.With(prometheus.Labels{}).Set(float64(r.Reset.Unix()))
+	}
+
+	gc.updateRateLimiter()
+}
@@ -0,0 +1,76 @@
+package collectors
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v76/github"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// TestObserveRateLimit tests that the rate limit state follows response headers,
+// ignoring responses that completed out of order
+func TestObserveRateLimit(t *testing.T) {
+	collector, _ := newFakeCollector()
+	collector.config.GitHub.RateLimitBuffer = 1
+
+	reset := github.Timestamp{Time: time.Now().Add(time.Hour).Truncate(time.Second)}
+
+	collector.observeRateLimit("core", github.Rate{Limit: 5000, Remaining: 3600, Reset: reset})
+
+	// A response that completed late within the same window
+	collector.observeRateLimit("core", github.Rate{Limit: 5000, Remaining: 3700, Reset: reset})
+
+	// Search has a quota of its own
+	collector.observeRateLimit("search", github.Rate{Limit: 30, Remaining: 29, Reset: reset})
+
+	if collector.rateLimitRemaining != 3600 {
+		t.Errorf("Expected 3600 requests remaining, got %d", collector.rateLimitRemaining)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubRateLimitRemaining); got != 3600 {
+		t.Errorf("Expected the remaining metric to be 3600, got %v", got)
+	}
+
+	if limit := collector.limiter.Limit(); limit < 0.9 || limit > 1.1 {
+		t.Errorf("Expected the limiter to be paced at about 1 request per second, got %v", limit)
+	}
+
+	// A new window replaces the state even though more requests remain
+	next := github.Timestamp{Time: reset.Add(time.Hour)}
+	collector.observeRateLimit("core", github.Rate{Limit: 5000, Remaining: 4999, Reset: next})
+
+	if collector.rateLimitRemaining != 4999 || !collector.rateLimitReset.Equal(next.Time) {
+		t.Errorf("Expected the new window to be tracked, got %d remaining until %s", collector.rateLimitRemaining, collector.rateLimitReset)
+	}
+}
+
+// TestParseRateHeaders tests reading the rate limit and token expiration headers
+func TestParseRateHeaders(t *testing.T) {
+	header := http.Header{}
+
+	if _, ok := parseRate(header); ok {
+		t.Error("Expected no rate limit without headers")
+	}
+
+	header.Set("X-RateLimit-Limit", "5000")
+	header.Set("X-RateLimit-Remaining", "4990")
+	header.Set("X-RateLimit-Used", "10")
+	header.Set("X-RateLimit-Reset", "1767225600")
+	header.Set(tokenExpirationHeader, "2026-01-01 00:00:00 UTC")
+
+	r, ok := parseRate(header)
+	if !ok {
+		t.Fatal("Expected a rate limit")
+	}
+
+	if r.Limit != 5000 || r.Remaining != 4990 || r.Used != 10 || r.Reset.Unix() != 1767225600 {
+		t.Errorf("Unexpected rate limit %+v", r)
+	}
+
+	expiration, ok := parseTokenExpiration(header)
+	if !ok || expiration.Unix() != 1767225600 {
+		t.Errorf("Expected the token to expire at 1767225600, got %v", expiration)
+	}
+}
@@ -0,0 +1,277 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v76/github"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// releaseWindows are the rolling windows releases are counted over
+var releaseWindows = []struct {
+	label    string
+	duration time.Duration
+}{
+	{"30d", 30 * 24 * time.Hour},
+	{"90d", 90 * 24 * time.Hour},
+}
+
+// setReleaseMetrics exports release cadence metrics for a repository, split
+// into stable releases and pre-releases. Drafts are only counted.
+func (gc *GitHubCollector) setReleaseMetrics(ctx context.Context, owner, repo, defaultBranch string) error {
+	now := time.Now()
+
+	releases, err := gc.listRecentReleases(ctx, owner, repo, now.Add(-releaseWindows[len(releaseWindows)-1].duration))
+	if err != nil {
+		logError("Failed to list releases", err)
+		return err
+	}
+
+	latest := make(map[string]time.Time, 2) // prerelease -> newest publish time
+	counts := make(map[[2]string]int)       // {window, prerelease} -> releases
+	drafts := 0
+
+	var latestStable *github.RepositoryRelease
+
+	for _, release := range releases {
+		if release.GetDraft() || release.PublishedAt == nil {
+			drafts++
+			continue
+		}
+
+		prerelease := strconv.FormatBool(release.GetPrerelease())
+
+		published := release.PublishedAt.Time
+		if published.After(latest[prerelease]) {
+			latest[prerelease] = published
+
+			if !release.GetPrerelease() {
+				latestStable = release
+			}
+		}
+
+		for _, window := range releaseWindows {
+			if now.Sub(published) <= window.duration {
+				counts[[2]string{window.label, prerelease}]++
+			}
+		}
+	}
+
+	gc.metrics.GitHubDraftReleases.With(prometheus.Labels{"org": owner, "repo": repo}).Set(float64(drafts))
+
+	for _, prerelease := range []string{"false", "true"} {
+		for _, window := range releaseWindows {
+			gc.metrics.GitHubReleasesInWindow.With(prometheus.Labels{
+				"org":        owner,
+				"repo":       repo,
+				"window":     window.label,
+				"prerelease": prerelease,
+			}).Set(float64(counts[[2]string{window.label, prerelease}]))
+		}
+
+		published, ok := latest[prerelease]
+		if !ok {
+			continue
+		}
+
+		labels := prometheus.Labels{"org": owner, "repo": repo, "prerelease": prerelease}
+		gc.metrics.GitHubLastReleaseTimestamp.With(labels).Set(float64(published.Unix()))
+		gc.metrics.GitHubDaysSinceLastRelease.With(labels).Set(math.Floor(now.Sub(published).Hours() / 24))
+	}
+
+	if gc.config.Collectors.ReleaseAssetsEnabled() {
+		gc.setReleaseAssetMetrics(owner, repo, latestStable)
+	}
+
+	if gc.config.Collectors.UnreleasedCommitsEnabled() {
+		return gc.setUnreleasedCommitsMetric(ctx, owner, repo, defaultBranch, latestStable)
+	}
+
+	return nil
+}
+
+// setUnreleasedCommitsMetric exports the number of commits on the default branch
+// that are not in the latest stable release, by comparing the release's tag to
+// the branch. The series is removed while the repository has no stable release.
+func (gc *GitHubCollector) setUnreleasedCommitsMetric(ctx context.Context, owner, repo, defaultBranch string, release *github.RepositoryRelease) error {
+	labels := prometheus.Labels{"org": owner, "repo": repo}
+
+	if release == nil || release.GetTagName() == "" || defaultBranch == "" {
+		gc.metrics.GitHubUnreleasedCommits.Delete(labels)
+		return nil
+	}
+
+	unreleased, err := gc.countUnreleasedCommits(ctx, owner, repo, release.GetTagName(), defaultBranch)
+	if err != nil {
+		logError("Failed to compare latest release", err)
+		return err
+	}
+
+	gc.metrics.GitHubUnreleasedCommits.With(labels).Set(float64(unreleased))
+
+	return nil
+}
+
+// countUnreleasedCommits returns the number of commits on branch that are not reachable from tag
+func (gc *GitHubCollector) countUnreleasedCommits(ctx context.Context, owner, repo, tag, branch string) (int, error) {
+	t := target{Org: owner, Repo: repo}
+
+	// Wait for rate limiter
+	if err := gc.limiter.Wait(ctx); err != nil {
+		return 0, wrapAPIError("compare", t, fmt.Errorf("rate limiter error: %w", err))
+	}
+
+	// Only the ahead count is needed, so skip listing the commits themselves
+	reqCtx, cancel := gc.requestContext(ctx, "compare")
+	comparison, _, err := gc.api.CompareCommits(reqCtx, owner, repo, tag, branch, &github.ListOptions{PerPage: 1})
+	cancel()
+	if err != nil {
+		gc.recordAPIError("compare", err)
+		return 0, wrapAPIError("compare", t, err)
+	}
+
+	return comparison.GetAheadBy(), nil
+}
+
+// setReleaseAssetMetrics exports the assets of the latest stable release. Series
+// of older releases are removed once a newer release is published.
+func (gc *GitHubCollector) setReleaseAssetMetrics(owner, repo string, release *github.RepositoryRelease) {
+	repoLabels := prometheus.Labels{"org": owner, "repo": repo}
+	gc.metrics.GitHubReleaseAssetInfo.DeletePartialMatch(repoLabels)
+	gc.metrics.GitHubReleaseAssetSize.DeletePartialMatch(repoLabels)
+	gc.metrics.GitHubReleaseAssets.DeletePartialMatch(repoLabels)
+
+	if release == nil {
+		return
+	}
+
+	tag := release.GetTagName()
+
+	for _, asset := range release.Assets {
+		name := asset.GetName()
+		goos, goarch := guessAssetPlatform(name)
+
+		gc.metrics.GitHubReleaseAssetInfo.With(prometheus.Labels{
+			"org":   owner,
+			"repo":  repo,
+			"tag":   tag,
+			"asset": name,
+			"os":    goos,
+			"arch":  goarch,
+		}).Set(1)
+		gc.metrics.GitHubReleaseAssetSize.With(prometheus.Labels{
+			"org":   owner,
+			"repo":  repo,
+			"tag":   tag,
+			"asset": name,
+		}).Set(float64(asset.GetSize()))
+	}
+
+	gc.metrics.GitHubReleaseAssets.With(prometheus.Labels{"org": owner, "repo": repo, "tag": tag}).Set(float64(len(release.Assets)))
+}
+
+// assetOSes and assetArches map tokens found in asset names to Go's GOOS and
+// GOARCH names. Earlier entries win, so more specific tokens come first.
+var (
+	assetOSes = []struct{ token, name string }{
+		{"darwin", "darwin"},
+		{"macos", "darwin"},
+		{"osx", "darwin"},
+		{"apple", "darwin"},
+		{"linux", "linux"},
+		{"windows", "windows"},
+		{"win64", "windows"},
+		{"win32", "windows"},
+		{"freebsd", "freebsd"},
+		{"openbsd", "openbsd"},
+		{"netbsd", "netbsd"},
+	}
+	assetArches = []struct{ token, name string }{
+		{"amd64", "amd64"},
+		{"x64", "amd64"},
+		{"win64", "amd64"},
+		{"aarch64", "arm64"},
+		{"arm64", "arm64"},
+		{"armv7", "arm"},
+		{"armv6", "arm"},
+		{"armhf", "arm"},
+		{"arm", "arm"},
+		{"i386", "386"},
+		{"i686", "386"},
+		{"386", "386"},
+		{"win32", "386"},
+		{"ppc64le", "ppc64le"},
+		{"s390x", "s390x"},
+		{"riscv64", "riscv64"},
+		{"universal", "universal"},
+	}
+)
+
+// guessAssetPlatform guesses the operating system and architecture a release
+// asset was built for from its name, such as app_darwin_arm64.tar.gz. Parts that
+// can't be recognised are reported as "unknown".
+func guessAssetPlatform(name string) (string, string) {
+	// x86_64 would otherwise be split into two tokens
+	normalized := strings.NewReplacer("x86_64", "amd64", "x86-64", "amd64").Replace(strings.ToLower(name))
+
+	tokens := strings.FieldsFunc(normalized, func(r rune) bool {
+		return r == '_' || r == '-' || r == '.' || r == ' '
+	})
+
+	match := func(candidates []struct{ token, name string }) string {
+		for _, candidate := range candidates {
+			if slices.Contains(tokens, candidate.token) {
+				return candidate.name
+			}
+		}
+
+		return "unknown"
+	}
+
+	return match(assetOSes), match(assetArches)
+}
+
+// listRecentReleases lists releases newest first, stopping at the first page that
+// reaches back before the given time. The newest release is always included.
+func (gc *GitHubCollector) listRecentReleases(ctx context.Context, owner, repo string, after time.Time) ([]*github.RepositoryRelease, error) {
+	t := target{Org: owner, Repo: repo}
+	opts := &github.ListOptions{PerPage: 100}
+
+	var all []*github.RepositoryRelease
+
+	for page := 0; page < maxActivityPages; page++ {
+		// Wait for rate limiter
+		if err := gc.limiter.Wait(ctx); err != nil {
+			return nil, wrapAPIError("releases", t, fmt.Errorf("rate limiter error: %w", err))
+		}
+
+		reqCtx, cancel := gc.requestContext(ctx, "releases")
+		releases, resp, err := gc.api.ListReleases(reqCtx, owner, repo, opts)
+		cancel()
+		if err != nil {
+			gc.recordAPIError("releases", err)
+			return nil, wrapAPIError("releases", t, err)
+		}
+
+		all = append(all, releases...)
+
+		if resp == nil || resp.NextPage == 0 || len(releases) == 0 {
+			break
+		}
+
+		// Releases are sorted by creation date, so older pages are outside every window
+		if oldest := releases[len(releases)-1]; oldest.CreatedAt != nil && oldest.CreatedAt.Before(after) {
+			break
+		}
+
+		opts.Page = resp.NextPage
+	}
+
+	return all, nil
+}
@@ -0,0 +1,158 @@
+package collectors
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// TestSetReleaseMetrics tests release cadence metrics
+func TestSetReleaseMetrics(t *testing.T) {
+	now := time.Now().UTC()
+	ago := func(days int) string {
+		return now.Add(-time.Duration(days) * 24 * time.Hour).Format(time.RFC3339)
+	}
+
+	collector := newAPITestCollector(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/d0ugal/private/releases" {
+			t.Errorf("Unexpected request path %s", r.URL.Path)
+		}
+
+		_, _ = fmt.Fprintf(w, `[
+			{"id": 5, "draft": true, "created_at": %q},
+			{"id": 4, "prerelease": true, "created_at": %q, "published_at": %q},
+			{"id": 3, "created_at": %q, "published_at": %q},
+			{"id": 2, "created_at": %q, "published_at": %q},
+			{"id": 1, "created_at": %q, "published_at": %q}
+		]`, ago(0), ago(1), ago(1), ago(3), ago(3), ago(45), ago(45), ago(200), ago(200))
+	})
+
+	collector.setReleaseMetrics(t.Context(), "d0ugal", "private", "main")
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubDaysSinceLastRelease.WithLabelValues("d0ugal", "private", "false")); got != 3 {
+		t.Errorf("Expected 3 days since last stable release, got %v", got)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubDaysSinceLastRelease.WithLabelValues("d0ugal", "private", "true")); got != 1 {
+		t.Errorf("Expected 1 day since last pre-release, got %v", got)
+	}
+
+	for _, tc := range []struct {
+		window     string
+		prerelease string
+		expected   float64
+	}{
+		{"30d", "false", 1},
+		{"90d", "false", 2},
+		{"30d", "true", 1},
+		{"90d", "true", 1},
+	} {
+		if got := testutil.ToFloat64(collector.metrics.GitHubReleasesInWindow.WithLabelValues("d0ugal", "private", tc.window, tc.prerelease)); got != tc.expected {
+			t.Errorf("Expected %v releases in %s (prerelease=%s), got %v", tc.expected, tc.window, tc.prerelease, got)
+		}
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubDraftReleases.WithLabelValues("d0ugal", "private")); got != 1 {
+		t.Errorf("Expected 1 draft release, got %v", got)
+	}
+}
+
+// TestGuessAssetPlatform tests guessing the platform of release assets from their names
+func TestGuessAssetPlatform(t *testing.T) {
+	tests := []struct {
+		name, os, arch string
+	}{
+		{"github-exporter_1.2.3_darwin_arm64.tar.gz", "darwin", "arm64"},
+		{"github-exporter_1.2.3_linux_amd64.tar.gz", "linux", "amd64"},
+		{"tool-x86_64-unknown-linux-gnu.tar.gz", "linux", "amd64"},
+		{"tool-aarch64-apple-darwin.tar.gz", "darwin", "arm64"},
+		{"tool_Windows_x64.zip", "windows", "amd64"},
+		{"tool-linux-armv7", "linux", "arm"},
+		{"tool-macos-universal.dmg", "darwin", "universal"},
+		{"checksums.txt", "unknown", "unknown"},
+	}
+
+	for _, tt := range tests {
+		goos, goarch := guessAssetPlatform(tt.name)
+		if goos != tt.os || goarch != tt.arch {
+			t.Errorf("%s: expected %s/%s, got %s/%s", tt.name, tt.os, tt.arch, goos, goarch)
+		}
+	}
+}
+
+// TestSetReleaseAssetMetrics tests asset metrics of the latest stable release
+func TestSetReleaseAssetMetrics(t *testing.T) {
+	now := time.Now().UTC()
+
+	collector := newAPITestCollector(t, func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = fmt.Fprintf(w, `[
+			{"id": 3, "tag_name": "v2.0.0-rc1", "prerelease": true, "created_at": %[1]q, "published_at": %[1]q,
+			 "assets": [{"name": "app_darwin_arm64.tar.gz", "size": 10}]},
+			{"id": 2, "tag_name": "v1.1.0", "created_at": %[2]q, "published_at": %[2]q,
+			 "assets": [{"name": "app_linux_amd64.tar.gz", "size": 2048}, {"name": "app_darwin_amd64.tar.gz", "size": 1024}, {"name": "checksums.txt", "size": 128}]},
+			{"id": 1, "tag_name": "v1.0.0", "created_at": %[3]q, "published_at": %[3]q,
+			 "assets": [{"name": "app_darwin_arm64.tar.gz", "size": 1000}]}
+		]`, now.Format(time.RFC3339), now.Add(-time.Hour).Format(time.RFC3339), now.Add(-48*time.Hour).Format(time.RFC3339))
+	})
+
+	enabled := true
+	collector.config.Collectors.Releases = &enabled
+	collector.config.Collectors.ReleaseAssets = &enabled
+
+	collector.setReleaseMetrics(t.Context(), "d0ugal", "private", "main")
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubReleaseAssets.WithLabelValues("d0ugal", "private", "v1.1.0")); got != 3 {
+		t.Errorf("Expected 3 assets, got %v", got)
+	}
+
+	if got := testutil.CollectAndCount(collector.metrics.GitHubReleaseAssetInfo); got != 3 {
+		t.Errorf("Expected 3 asset series, got %d", got)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubReleaseAssetInfo.WithLabelValues("d0ugal", "private", "v1.1.0", "app_linux_amd64.tar.gz", "linux", "amd64")); got != 1 {
+		t.Errorf("Expected linux/amd64 asset info, got %v", got)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubReleaseAssetSize.WithLabelValues("d0ugal", "private", "v1.1.0", "app_linux_amd64.tar.gz")); got != 2048 {
+		t.Errorf("Expected asset size 2048, got %v", got)
+	}
+}
+
+// TestSetUnreleasedCommitsMetric tests comparing the latest stable release to the default branch
+func TestSetUnreleasedCommitsMetric(t *testing.T) {
+	now := time.Now().UTC()
+
+	collector := newAPITestCollector(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/d0ugal/private/releases":
+			_, _ = fmt.Fprintf(w, `[
+				{"id": 2, "tag_name": "v2.0.0-rc1", "prerelease": true, "created_at": %[1]q, "published_at": %[1]q},
+				{"id": 1, "tag_name": "v1.1.0", "created_at": %[2]q, "published_at": %[2]q}
+			]`, now.Format(time.RFC3339), now.Add(-time.Hour).Format(time.RFC3339))
+		case "/repos/d0ugal/private/compare/v1.1.0...main":
+			_, _ = w.Write([]byte(`{"status": "ahead", "ahead_by": 12, "behind_by": 0}`))
+		default:
+			t.Errorf("Unexpected request path %s", r.URL.Path)
+		}
+	})
+
+	enabled := true
+	collector.config.Collectors.Releases = &enabled
+	collector.config.Collectors.UnreleasedCommits = &enabled
+
+	collector.setReleaseMetrics(t.Context(), "d0ugal", "private", "main")
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubUnreleasedCommits.WithLabelValues("d0ugal", "private")); got != 12 {
+		t.Errorf("Expected 12 unreleased commits, got %v", got)
+	}
+
+	// Without a stable release there is nothing to compare to
+	collector.setUnreleasedCommitsMetric(t.Context(), "d0ugal", "private", "main", nil)
+
+	if got := testutil.CollectAndCount(collector.metrics.GitHubUnreleasedCommits); got != 0 {
+		t.Errorf("Expected no series without a stable release, got %d", got)
+	}
+}
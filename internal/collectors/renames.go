@@ -0,0 +1,178 @@
+package collectors
+
+import (
+	"log/slog"
+	"strings"
+	"sync"
+
+	"github.com/google/go-github/v76/github"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// renameTracker remembers monitored repositories that were renamed or
+// transferred, so they are collected under their new name
+type renameTracker struct {
+	mu      sync.RWMutex
+	renamed map[string]target // By the old full name
+}
+
+// record stores that a repository moved, returning false if this was already known
+func (r *renameTracker) record(from string, to target) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.renamed == nil {
+		r.renamed = make(map[string]target)
+	}
+
+	if current, ok := r.renamed[from]; ok && current == to {
+		return false
+	}
+
+	r.renamed[from] = to
+
+	return true
+}
+
+// resolve replaces the names of repositories known to have moved with their new
+// names, skipping repositories that end up listed twice
+func (r *renameTracker) resolve(repos []string) []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if len(r.renamed) == 0 {
+		return repos
+	}
+
+	seen := make(map[string]bool, len(repos))
+	resolved := make([]string, 0, len(repos))
+
+	for _, repo := range repos {
+		if to, ok := r.renamed[repo]; ok {
+			repo = to.String()
+		}
+
+		if seen[repo] {
+			continue
+		}
+
+		seen[repo] = true
+		resolved = append(resolved, repo)
+	}
+
+	return resolved
+}
+
+// canonicalRepo returns the owner and name a repository is collected under.
+// GitHub redirects requests for a renamed or transferred repository, so the
+// repository returned carries its new owner and name. The series of the old
+// name are then moved to the new one, rather than leaving them behind next to
+// a duplicate set under the new name.
+func (gc *GitHubCollector) canonicalRepo(owner, repo string, info *github.Repository) (string, string) {
+	newOwner := info.GetOwner().GetLogin()
+	newRepo := info.GetName()
+
+	// Names are case-insensitive, a differently cased configuration is not a rename
+	if newOwner == "" || newRepo == "" || (strings.EqualFold(newOwner, owner) && strings.EqualFold(newRepo, repo)) {
+		return owner, repo
+	}
+
+	from := target{Org: owner, Repo: repo}
+	to := target{Org: newOwner, Repo: newRepo}
+
+	if !gc.renames.record(from.String(), to) {
+		return newOwner, newRepo
+	}
+
+	slog.Warn("Repository was renamed or transferred, update the configuration to use its new name",
+		"repo", from.String(),
+		"new_repo", to.String(),
+	)
+
+	migrated := gc.migrateRepoSeries(from, to)
+
+	slog.Debug("Migrated metrics of renamed repository", "repo", from.String(), "new_repo", to.String(), "series", migrated)
+
+	gc.metrics.GitHubRepoRenamed.With(prometheus.Labels{
+		"org":      owner,
+		"repo":     repo,
+		"new_org":  newOwner,
+		"new_repo": newRepo,
+	}).Set(1)
+
+	return newOwner, newRepo
+}
+
+// migrateRepoSeries moves the series of a repository that moved to its new
+// name. Gauges keep their values until they are collected under the new name and
+// counters carry their totals over, so they continue rather than restart next to
+// a stale series under the old name. Histograms can't be carried over, their old
+// series are deleted. The trackers keyed by the old name are moved as well, so
+// incremental activity continues where it left off instead of being seeded again.
+func (gc *GitHubCollector) migrateRepoSeries(from, to target) int {
+	match := prometheus.Labels{"org": from.Org, "repo": from.Repo}
+	migrated := 0
+
+	for _, gauge := range gc.metrics.RepoGauges() {
+		for _, s := range matchingSeries(gauge, match) {
+			gauge.Delete(s.labels)
+
+			s.labels["org"] = to.Org
+			s.labels["repo"] = to.Repo
+			gauge.With(s.labels).Set(s.value)
+
+			migrated++
+		}
+	}
+
+	for _, counter := range gc.metrics.RepoCounters() {
+		for _, s := range matchingSeries(counter, match) {
+			counter.Delete(s.labels)
+
+			s.labels["org"] = to.Org
+			s.labels["repo"] = to.Repo
+			counter.With(s.labels).Add(s.value)
+
+			migrated++
+		}
+	}
+
+	for _, histogram := range gc.metrics.RepoHistograms() {
+		histogram.DeletePartialMatch(match)
+	}
+
+	gc.commits.rename(from.String(), to.String())
+	gc.runs.rename(from.String(), to.String())
+	gc.activity.rename(from.String(), to.String())
+	gc.responses.rename(from.String(), to.String())
+	gc.metrics.MoveRepoLabels(from.Org, from.Repo, to.Org, to.Repo)
+
+	return migrated
+}
+
+// renameRepoKeys moves the entries of a tracker keyed by a repository that moved
+// to its new name. Keys are the full name of the repository, the full name
+// followed by "@branch", or a kind followed by ":" and the full name. Entries
+// already known under the new name are kept.
+func renameRepoKeys[V any](entries map[string]V, from, to string) {
+	renamed := make(map[string]string)
+
+	for key := range entries {
+		switch {
+		case key == from:
+			renamed[key] = to
+		case strings.HasPrefix(key, from+"@"):
+			renamed[key] = to + strings.TrimPrefix(key, from)
+		case strings.HasSuffix(key, ":"+from):
+			renamed[key] = strings.TrimSuffix(key, from) + to
+		}
+	}
+
+	for key, newKey := range renamed {
+		if _, ok := entries[newKey]; !ok {
+			entries[newKey] = entries[key]
+		}
+
+		delete(entries, key)
+	}
+}
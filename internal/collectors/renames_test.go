@@ -0,0 +1,131 @@
+package collectors
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v76/github"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// TestCollectRenamedRepo tests that a repository GitHub redirects to a new name
+// is collected under that name, with the series of the old name moved over
+func TestCollectRenamedRepo(t *testing.T) {
+	collector, api := newFakeCollector()
+	collector.config.GitHub.Repos = []string{"d0ugal/old-name"}
+
+	// GitHub follows the redirect and returns the repository under its new name
+	api.repos["d0ugal/old-name"] = api.addRepo("acme", "new-name", false)
+
+	// A series left by a collection before the rename
+	oldLabels := prometheus.Labels{"org": "d0ugal", "repo": "old-name", "visibility": "public"}
+	collector.metrics.GitHubReposStars.With(oldLabels).Set(7)
+	collector.metrics.GitHubReleaseAssets.With(prometheus.Labels{"org": "d0ugal", "repo": "old-name", "tag": "v1.0.0"}).Set(3)
+
+	if _, err := collector.collectRepoMetrics(t.Context()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for _, gauge := range collector.metrics.RepoGauges() {
+		if old := matchingSeries(gauge, prometheus.Labels{"org": "d0ugal", "repo": "old-name"}); len(old) != 0 {
+			t.Errorf("Expected no series under the old name, got %v", old)
+		}
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubReleaseAssets.With(prometheus.Labels{"org": "acme", "repo": "new-name", "tag": "v1.0.0"})); got != 3 {
+		t.Errorf("Expected the release asset count to be migrated, got %v", got)
+	}
+
+	renamed := collector.metrics.GitHubRepoRenamed.With(prometheus.Labels{
+		"org":      "d0ugal",
+		"repo":     "old-name",
+		"new_org":  "acme",
+		"new_repo": "new-name",
+	})
+	if got := testutil.ToFloat64(renamed); got != 1 {
+		t.Errorf("Expected the rename to be exported, got %v", got)
+	}
+
+	if repos := collector.targetRepos(); !reflect.DeepEqual(repos, []string{"acme/new-name"}) {
+		t.Errorf("Expected the repository to be targeted under its new name, got %v", repos)
+	}
+}
+
+// TestMigrateRepoCounters tests that the counters and trackers of a moved
+// repository continue under its new name and no series of the old name remain
+func TestMigrateRepoCounters(t *testing.T) {
+	collector := createTestCollector()
+	from := target{Org: "d0ugal", Repo: "old-name"}
+	to := target{Org: "acme", Repo: "new-name"}
+
+	branch := prometheus.Labels{"org": "d0ugal", "repo": "old-name", "branch": "main"}
+	collector.metrics.GitHubBranchCommitsTotal.With(branch).Add(5)
+	collector.metrics.GitHubIssueCommentsTotal.With(prometheus.Labels{"org": "d0ugal", "repo": "old-name"}).Add(2)
+	collector.metrics.GitHubIssueFirstResponse.With(prometheus.Labels{"org": "d0ugal", "repo": "old-name"}).Observe(60)
+
+	collector.commits.observe("d0ugal/old-name@main", []*github.RepositoryCommit{{SHA: github.Ptr("abc")}})
+	collector.activity.advance("issue_comments:d0ugal/old-name", time.Now())
+	collector.responses.add("d0ugal/old-name", 1, pendingIssue{author: "d0ugal", createdAt: time.Now()})
+
+	collector.migrateRepoSeries(from, to)
+
+	old := prometheus.Labels{"org": "d0ugal", "repo": "old-name"}
+	for _, counter := range collector.metrics.RepoCounters() {
+		if series := matchingSeries(counter, old); len(series) != 0 {
+			t.Errorf("Expected no counter series under the old name, got %v", series)
+		}
+	}
+
+	if got := testutil.CollectAndCount(collector.metrics.GitHubIssueFirstResponse); got != 0 {
+		t.Errorf("Expected the first response histogram of the old name to be deleted, got %d series", got)
+	}
+
+	newBranch := prometheus.Labels{"org": "acme", "repo": "new-name", "branch": "main"}
+	if got := testutil.ToFloat64(collector.metrics.GitHubBranchCommitsTotal.With(newBranch)); got != 5 {
+		t.Errorf("Expected 5 commits carried over, got %v", got)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubIssueCommentsTotal.With(prometheus.Labels{"org": "acme", "repo": "new-name"})); got != 2 {
+		t.Errorf("Expected 2 comments carried over, got %v", got)
+	}
+
+	if _, ok := collector.commits.heads["acme/new-name@main"]; !ok {
+		t.Error("Expected the branch head to be tracked under the new name")
+	}
+
+	if _, ok := collector.activity.last["issue_comments:acme/new-name"]; !ok {
+		t.Error("Expected the comment fetch time to be tracked under the new name")
+	}
+
+	if _, ok := collector.responses.pending["acme/new-name"][1]; !ok {
+		t.Error("Expected the pending issue to be tracked under the new name")
+	}
+
+	if len(collector.commits.heads)+len(collector.activity.last)+len(collector.responses.pending) != 3 {
+		t.Error("Expected no tracker entries under the old name")
+	}
+}
+
+// TestRenameTrackerResolve tests that renamed repositories are replaced by their
+// new name and not listed twice when the new name is monitored too
+func TestRenameTrackerResolve(t *testing.T) {
+	var renames renameTracker
+
+	if !renames.record("d0ugal/old", target{Org: "d0ugal", Repo: "new"}) {
+		t.Error("Expected the first rename to be recorded")
+	}
+
+	if renames.record("d0ugal/old", target{Org: "d0ugal", Repo: "new"}) {
+		t.Error("Expected a known rename not to be recorded again")
+	}
+
+	resolved := renames.resolve([]string{"d0ugal/old", "d0ugal/other", "d0ugal/new"})
+
+	expected := []string{"d0ugal/new", "d0ugal/other"}
+	if !reflect.DeepEqual(resolved, expected) {
+		t.Errorf("Expected %v, got %v", expected, resolved)
+	}
+}
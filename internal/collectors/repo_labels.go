@@ -0,0 +1,64 @@
+package collectors
+
+import (
+	"log/slog"
+
+	"github.com/d0ugal/github-exporter/internal/config"
+	"github.com/google/go-github/v76/github"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// repoLabelCardinalityWarning is the number of distinct values of a repository
+// label above which a warning about the series it multiplies is logged
+const repoLabelCardinalityWarning = 50
+
+// setRepoLabels sets the repository attributes added as labels to the series
+// of a repository, as configured with metrics.repo_labels
+func (gc *GitHubCollector) setRepoLabels(owner, repo string, repoInfo *github.Repository) {
+	if len(gc.config.MetricsOptions.RepoLabels) == 0 {
+		return
+	}
+
+	labels := make(map[string]string, len(gc.config.MetricsOptions.RepoLabels))
+
+	for label, attribute := range gc.config.MetricsOptions.RepoLabels {
+		switch attribute {
+		case config.RepoAttributeLanguage:
+			labels[label] = repoInfo.GetLanguage()
+		case config.RepoAttributeDefaultBranch:
+			labels[label] = repoInfo.GetDefaultBranch()
+		case config.RepoAttributeTopic:
+			labels[label] = ""
+			if len(repoInfo.Topics) > 0 {
+				labels[label] = repoInfo.Topics[0]
+			}
+		}
+	}
+
+	gc.metrics.SetRepoLabels(owner, repo, labels)
+}
+
+// checkRepoLabelCardinality exports the number of distinct values of each
+// repository label and warns once per label when it grows large, since every
+// value multiplies the series of the repositories it is added to
+func (gc *GitHubCollector) checkRepoLabelCardinality() {
+	for label, values := range gc.metrics.RepoLabelCardinality() {
+		gc.metrics.GitHubRepoLabelValues.With(prometheus.Labels{"label": label}).Set(float64(values))
+
+		if values <= repoLabelCardinalityWarning || gc.repoLabelWarnings[label] {
+			continue
+		}
+
+		if gc.repoLabelWarnings == nil {
+			gc.repoLabelWarnings = make(map[string]bool)
+		}
+
+		gc.repoLabelWarnings[label] = true
+
+		slog.Warn("Repository label has many distinct values, consider removing it from metrics.repo_labels",
+			"label", label,
+			"attribute", gc.config.MetricsOptions.RepoLabels[label],
+			"values", values,
+		)
+	}
+}
@@ -0,0 +1,53 @@
+package collectors
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-github/v76/github"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// TestSetRepoLabels tests mapping repository attributes to labels and exporting
+// their cardinality
+func TestSetRepoLabels(t *testing.T) {
+	collector := createTestCollector()
+	collector.metrics.EnableRepoLabels()
+	collector.config.MetricsOptions.RepoLabels = map[string]string{
+		"lang":   "language",
+		"branch": "default_branch",
+		"team":   "topic",
+	}
+
+	for i := 0; i <= repoLabelCardinalityWarning; i++ {
+		collector.setRepoLabels("d0ugal", fmt.Sprintf("repo-%d", i), &github.Repository{
+			Language:      github.Ptr(fmt.Sprintf("Language%d", i)),
+			DefaultBranch: github.Ptr("main"),
+		})
+	}
+
+	collector.setRepoLabels("d0ugal", "github-exporter", &github.Repository{
+		Language:      github.Ptr("Go"),
+		DefaultBranch: github.Ptr("main"),
+		Topics:        []string{"prometheus", "exporter"},
+	})
+
+	collector.checkRepoLabelCardinality()
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubRepoLabelValues.WithLabelValues("lang")); got != repoLabelCardinalityWarning+2 {
+		t.Errorf("Expected %d distinct languages, got %v", repoLabelCardinalityWarning+2, got)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubRepoLabelValues.WithLabelValues("branch")); got != 1 {
+		t.Errorf("Expected 1 distinct default branch, got %v", got)
+	}
+
+	// Repositories without topics have an empty topic
+	if got := testutil.ToFloat64(collector.metrics.GitHubRepoLabelValues.WithLabelValues("team")); got != 2 {
+		t.Errorf("Expected 2 distinct topics, got %v", got)
+	}
+
+	if !collector.repoLabelWarnings["lang"] || collector.repoLabelWarnings["branch"] {
+		t.Errorf("Expected a cardinality warning for lang only, got %v", collector.repoLabelWarnings)
+	}
+}
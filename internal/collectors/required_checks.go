@@ -0,0 +1,72 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-github/v76/github"
+)
+
+// requiredChecks returns the status checks branch protection requires before
+// merging into a branch. It returns nil for branches that are not protected or
+// require no checks.
+func (gc *GitHubCollector) requiredChecks(ctx context.Context, owner, repo, branch string) ([]*github.RequiredStatusCheck, error) {
+	t := target{Org: owner, Repo: repo, Branch: branch}
+
+	// Wait for rate limiter
+	if err := gc.limiter.Wait(ctx); err != nil {
+		return nil, wrapAPIError("required_status_checks", t, fmt.Errorf("rate limiter error: %w", err))
+	}
+
+	reqCtx, cancel := gc.requestContext(ctx, "required_status_checks")
+	checks, _, err := gc.api.GetRequiredStatusChecks(reqCtx, owner, repo, branch)
+	cancel()
+	if err != nil {
+		// Also returned when the token may not read the branch protection
+		if _, errorType := classifyAPIError(err); errorType == "not_found" {
+			return nil, nil
+		}
+
+		gc.recordAPIError("required_status_checks", err)
+		return nil, wrapAPIError("required_status_checks", t, fmt.Errorf("failed to get required status checks: %w", err))
+	}
+
+	if checks.Checks != nil && len(*checks.Checks) > 0 {
+		return *checks.Checks, nil
+	}
+
+	// Branch protection created before apps could be required only lists names
+	var required []*github.RequiredStatusCheck
+	if checks.Contexts != nil {
+		for _, name := range *checks.Contexts {
+			required = append(required, &github.RequiredStatusCheck{Context: name})
+		}
+	}
+
+	return required, nil
+}
+
+// requiredRuns returns the check runs that satisfy a required check: runs named
+// after the check, created by the app the check requires if it names one
+func requiredRuns(runs []*github.CheckRun, required []*github.RequiredStatusCheck) []*github.CheckRun {
+	var matched []*github.CheckRun
+
+	for _, run := range runs {
+		for _, check := range required {
+			if run.GetName() != check.Context {
+				continue
+			}
+
+			// No app or -1 allows any app to provide the check
+			if appID := check.GetAppID(); appID > 0 && run.GetApp().GetID() != appID {
+				continue
+			}
+
+			matched = append(matched, run)
+
+			break
+		}
+	}
+
+	return matched
+}
@@ -0,0 +1,84 @@
+package collectors
+
+import (
+	"testing"
+
+	"github.com/google/go-github/v76/github"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// TestCollectRequiredChecksOnly tests that only required check runs are reported
+// and decide the branch status, and that unprotected branches are reported as usual
+func TestCollectRequiredChecksOnly(t *testing.T) {
+	collector, api := newFakeCollector()
+	collector.config.GitHub.BuildStatus.RequiredOnly = true
+
+	checkRun := func(name, conclusion string, app int64) *github.CheckRun {
+		return &github.CheckRun{
+			Name:       github.Ptr(name),
+			Status:     github.Ptr("completed"),
+			Conclusion: github.Ptr(conclusion),
+			App:        &github.App{ID: github.Ptr(app)},
+		}
+	}
+
+	runs := []*github.CheckRun{
+		checkRun("build", "success", 1),
+		checkRun("coverage", "failure", 1), // Optional
+		checkRun("deploy", "failure", 2),   // Required from app 3 only
+	}
+
+	api.workflowRuns["d0ugal/github-exporter"] = []*github.WorkflowRun{
+		{WorkflowID: github.Ptr(int64(1)), Name: github.Ptr("CI"), HeadBranch: github.Ptr("main"), Conclusion: github.Ptr("failure")},
+		{WorkflowID: github.Ptr(int64(1)), Name: github.Ptr("CI"), HeadBranch: github.Ptr("develop"), Conclusion: github.Ptr("failure")},
+	}
+	api.checkRuns["d0ugal/github-exporter@main"] = runs
+	api.checkRuns["d0ugal/github-exporter@develop"] = runs
+	api.required["d0ugal/github-exporter@main"] = &github.RequiredStatusChecks{
+		Checks: &[]*github.RequiredStatusCheck{
+			{Context: "build", AppID: github.Ptr(int64(-1))},
+			{Context: "deploy", AppID: github.Ptr(int64(3))},
+		},
+	}
+
+	for _, branch := range []string{"main", "develop"} {
+		if err := collector.collectBranchBuildStatus(t.Context(), "d0ugal", "github-exporter", branch); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	protected := matchingSeries(collector.metrics.GitHubCheckRunStatus, prometheus.Labels{"branch": "main"})
+	if len(protected) != 1 || protected[0].labels["check_name"] != "build" {
+		t.Errorf("Expected only the build check on main, got %v", protected)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubBranchBuildStatus.WithLabelValues("d0ugal", "github-exporter", "main")); got != 1 {
+		t.Errorf("Expected main to be successful, got %v", got)
+	}
+
+	// develop is not protected
+	if develop := matchingSeries(collector.metrics.GitHubCheckRunStatus, prometheus.Labels{"branch": "develop"}); len(develop) != 3 {
+		t.Errorf("Expected all checks on develop, got %v", develop)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubBranchBuildStatus.WithLabelValues("d0ugal", "github-exporter", "develop")); got != 0 {
+		t.Errorf("Expected develop to have failed, got %v", got)
+	}
+}
+
+// TestRequiredChecksContexts tests that branch protection listing only check
+// names is supported
+func TestRequiredChecksContexts(t *testing.T) {
+	collector, api := newFakeCollector()
+	api.required["d0ugal/github-exporter@main"] = &github.RequiredStatusChecks{Contexts: &[]string{"build"}}
+
+	required, err := collector.requiredChecks(t.Context(), "d0ugal", "github-exporter", "main")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(required) != 1 || required[0].Context != "build" || required[0].AppID != nil {
+		t.Errorf("Expected the build check from any app, got %v", required)
+	}
+}
@@ -0,0 +1,238 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v76/github"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// maxPendingResponseAge is how long an issue waits for its first response before
+// it is no longer tracked
+const maxPendingResponseAge = 30 * 24 * time.Hour
+
+// pendingIssue is an issue that has not received a first response yet
+type pendingIssue struct {
+	author    string
+	createdAt time.Time
+}
+
+// responseTracker tracks issues opened while the exporter is running until they
+// receive their first response
+type responseTracker struct {
+	mu      sync.Mutex
+	pending map[string]map[int]pendingIssue // "org/repo" -> issue number -> issue
+}
+
+func newResponseTracker() *responseTracker {
+	return &responseTracker{
+		pending: make(map[string]map[int]pendingIssue),
+	}
+}
+
+// add starts tracking an issue
+func (rt *responseTracker) add(repo string, number int, issue pendingIssue) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	if rt.pending[repo] == nil {
+		rt.pending[repo] = make(map[int]pendingIssue)
+	}
+
+	if _, ok := rt.pending[repo][number]; !ok {
+		rt.pending[repo][number] = issue
+	}
+}
+
+// respond stops tracking an issue if the comment is its first response, returning
+// the time it took to respond
+func (rt *responseTracker) respond(repo string, number int, commenter string, at time.Time) (time.Duration, bool) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	issue, ok := rt.pending[repo][number]
+	if !ok || commenter == issue.author {
+		return 0, false
+	}
+
+	delete(rt.pending[repo], number)
+
+	return at.Sub(issue.createdAt), true
+}
+
+// prune stops tracking issues that have waited longer than maxPendingResponseAge
+func (rt *responseTracker) prune(repo string, now time.Time) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	for number, issue := range rt.pending[repo] {
+		if now.Sub(issue.createdAt) > maxPendingResponseAge {
+			delete(rt.pending[repo], number)
+		}
+	}
+}
+
+// rename moves the pending issues of a repository that was renamed or transferred
+func (rt *responseTracker) rename(from, to string) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	renameRepoKeys(rt.pending, from, to)
+}
+
+// issueNumber extracts the issue number from a comment's issue URL
+func issueNumber(comment *github.IssueComment) (int, bool) {
+	number, err := strconv.Atoi(path.Base(comment.GetIssueURL()))
+	if err != nil {
+		return 0, false
+	}
+
+	return number, true
+}
+
+// setFirstResponseMetrics tracks issues opened since the previous cycle and observes
+// the time to first response for tracked issues that were commented on by someone
+// other than the author. Comments by bots are not counted as a response.
+func (gc *GitHubCollector) setFirstResponseMetrics(ctx context.Context, owner, repo string) error {
+	t := target{Org: owner, Repo: repo}
+	now := time.Now()
+
+	key := "first_response:" + t.String()
+
+	since, ok := gc.activity.advance(key, now)
+	if !ok {
+		// Only issues opened after the exporter started are tracked
+		return nil
+	}
+
+	issues, err := gc.listIssuesSince(ctx, owner, repo, since)
+	if err != nil {
+		gc.activity.rewind(key, since)
+		logError("Failed to list issues", err)
+
+		return err
+	}
+
+	comments, err := gc.listIssueCommentsSince(ctx, owner, repo, since)
+	if err != nil {
+		gc.activity.rewind(key, since)
+		logError("Failed to list issue comments", err)
+
+		return err
+	}
+
+	for _, issue := range issues {
+		if issue.IsPullRequest() || !inWindow(issue.CreatedAt, since, now) {
+			continue
+		}
+
+		gc.responses.add(t.String(), issue.GetNumber(), pendingIssue{
+			author:    issue.GetUser().GetLogin(),
+			createdAt: issue.GetCreatedAt().Time,
+		})
+	}
+
+	for _, comment := range comments {
+		if !inWindow(comment.CreatedAt, since, now) || comment.GetUser().GetType() == "Bot" {
+			continue
+		}
+
+		number, ok := issueNumber(comment)
+		if !ok {
+			continue
+		}
+
+		if elapsed, ok := gc.responses.respond(t.String(), number, comment.GetUser().GetLogin(), comment.GetCreatedAt().Time); ok {
+			gc.metrics.GitHubIssueFirstResponse.With(prometheus.Labels{
+				"org":  owner,
+				"repo": repo,
+			}).Observe(elapsed.Seconds())
+
+			slog.Debug("Issue received first response", "org", owner, "repo", repo, "issue", number, "seconds", elapsed.Seconds())
+		}
+	}
+
+	gc.responses.prune(t.String(), now)
+
+	return nil
+}
+
+// listIssuesSince lists the issues and pull requests updated since the given time
+func (gc *GitHubCollector) listIssuesSince(ctx context.Context, owner, repo string, since time.Time) ([]*github.Issue, error) {
+	t := target{Org: owner, Repo: repo}
+	opts := &github.IssueListByRepoOptions{
+		State:       "all",
+		Since:       since,
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+
+	var all []*github.Issue
+
+	for page := 0; page < maxActivityPages; page++ {
+		// Wait for rate limiter
+		if err := gc.limiter.Wait(ctx); err != nil {
+			return nil, wrapAPIError("issues", t, fmt.Errorf("rate limiter error: %w", err))
+		}
+
+		reqCtx, cancel := gc.requestContext(ctx, "issues")
+		issues, resp, err := gc.api.ListIssues(reqCtx, owner, repo, opts)
+		cancel()
+		if err != nil {
+			gc.recordAPIError("issues", err)
+			return nil, wrapAPIError("issues", t, err)
+		}
+
+		all = append(all, issues...)
+
+		if resp == nil || resp.NextPage == 0 {
+			break
+		}
+
+		opts.ListOptions.Page = resp.NextPage
+	}
+
+	return all, nil
+}
+
+// listIssueCommentsSince lists the issue and pull request conversation comments
+// updated since the given time
+func (gc *GitHubCollector) listIssueCommentsSince(ctx context.Context, owner, repo string, since time.Time) ([]*github.IssueComment, error) {
+	t := target{Org: owner, Repo: repo}
+	opts := &github.IssueListCommentsOptions{
+		Since:       &since,
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+
+	var all []*github.IssueComment
+
+	for page := 0; page < maxActivityPages; page++ {
+		// Wait for rate limiter
+		if err := gc.limiter.Wait(ctx); err != nil {
+			return nil, wrapAPIError("issue_comments", t, fmt.Errorf("rate limiter error: %w", err))
+		}
+
+		reqCtx, cancel := gc.requestContext(ctx, "issue_comments")
+		comments, resp, err := gc.api.ListIssueComments(reqCtx, owner, repo, opts)
+		cancel()
+		if err != nil {
+			gc.recordAPIError("issue_comments", err)
+			return nil, wrapAPIError("issue_comments", t, err)
+		}
+
+		all = append(all, comments...)
+
+		if resp == nil || resp.NextPage == 0 {
+			break
+		}
+
+		opts.Page = resp.NextPage
+	}
+
+	return all, nil
+}
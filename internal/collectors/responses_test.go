@@ -0,0 +1,78 @@
+package collectors
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// TestSetFirstResponseMetrics tests observing the time to first response for new issues
+func TestSetFirstResponseMetrics(t *testing.T) {
+	opened := time.Now().Add(-30 * time.Minute).UTC()
+	answered := opened.Add(10 * time.Minute)
+
+	collector := newAPITestCollector(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/d0ugal/private/issues":
+			_, _ = fmt.Fprintf(w, `[
+				{"number": 1, "user": {"login": "alice"}, "created_at": %[1]q},
+				{"number": 2, "user": {"login": "bob"}, "created_at": %[1]q, "pull_request": {"url": "x"}}
+			]`, opened.Format(time.RFC3339))
+		case "/repos/d0ugal/private/issues/comments":
+			_, _ = fmt.Fprintf(w, `[
+				{"issue_url": "https://api.github.com/repos/d0ugal/private/issues/1", "user": {"login": "alice"}, "created_at": %[1]q},
+				{"issue_url": "https://api.github.com/repos/d0ugal/private/issues/1", "user": {"login": "triage-bot", "type": "Bot"}, "created_at": %[1]q},
+				{"issue_url": "https://api.github.com/repos/d0ugal/private/issues/1", "user": {"login": "d0ugal"}, "created_at": %[2]q},
+				{"issue_url": "https://api.github.com/repos/d0ugal/private/issues/2", "user": {"login": "d0ugal"}, "created_at": %[2]q}
+			]`, opened.Add(time.Minute).Format(time.RFC3339), answered.Format(time.RFC3339))
+		default:
+			t.Errorf("Unexpected request path %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	collector.activity.advance("first_response:d0ugal/private", time.Now().Add(-time.Hour))
+	collector.setFirstResponseMetrics(t.Context(), "d0ugal", "private")
+
+	var metric dto.Metric
+	if err := collector.metrics.GitHubIssueFirstResponse.WithLabelValues("d0ugal", "private").(prometheus.Metric).Write(&metric); err != nil {
+		t.Fatalf("Failed to read histogram: %v", err)
+	}
+
+	// Only issue 1 is observed: the author's and the bot's comments are not a response,
+	// and issue 2 is a pull request
+	if got := metric.GetHistogram().GetSampleCount(); got != 1 {
+		t.Fatalf("Expected 1 observation, got %d", got)
+	}
+
+	if got := metric.GetHistogram().GetSampleSum(); got != 600 {
+		t.Errorf("Expected first response after 600 seconds, got %v", got)
+	}
+
+	// A second response to the same issue must not be observed again
+	if _, ok := collector.responses.respond("d0ugal/private", 1, "someone", answered); ok {
+		t.Error("Expected issue 1 to no longer be pending")
+	}
+}
+
+// TestResponseTrackerPrune tests that issues waiting too long are no longer tracked
+func TestResponseTrackerPrune(t *testing.T) {
+	tracker := newResponseTracker()
+	now := time.Now()
+
+	tracker.add("d0ugal/private", 1, pendingIssue{author: "alice", createdAt: now.Add(-maxPendingResponseAge - time.Hour)})
+	tracker.add("d0ugal/private", 2, pendingIssue{author: "alice", createdAt: now})
+	tracker.prune("d0ugal/private", now)
+
+	if _, ok := tracker.respond("d0ugal/private", 1, "bob", now); ok {
+		t.Error("Expected stale issue to be pruned")
+	}
+
+	if elapsed, ok := tracker.respond("d0ugal/private", 2, "bob", now.Add(time.Hour)); !ok || elapsed != time.Hour {
+		t.Errorf("Expected response after 1h, got %v (ok=%v)", elapsed, ok)
+	}
+}
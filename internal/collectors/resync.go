@@ -0,0 +1,133 @@
+package collectors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// resyncQueueSize bounds the number of pending resync requests
+const resyncQueueSize = 16
+
+var (
+	// ErrUnknownRepository is returned when a resync is requested for a repository
+	// that has not been collected
+	ErrUnknownRepository = errors.New("repository is not monitored")
+
+	// ErrResyncQueueFull is returned when too many resyncs are already pending
+	ErrResyncQueueFull = errors.New("too many pending resyncs")
+)
+
+// RequestResync queues a poll of a single repository, correcting metrics that
+// webhook deliveries may have left stale. The poll runs between collection
+// cycles, so it never overlaps a cycle. Only repositories that have been
+// collected before can be resynced.
+func (gc *GitHubCollector) RequestResync(owner, repo string) error {
+	if !gc.inventory.has(owner, repo) {
+		return ErrUnknownRepository
+	}
+
+	select {
+	case gc.resyncs <- target{Org: owner, Repo: repo}:
+		return nil
+	default:
+		return ErrResyncQueueFull
+	}
+}
+
+// resyncRepo polls a repository and its configured branches outside of the
+// regular collection cycle
+func (gc *GitHubCollector) resyncRepo(ctx context.Context, t target) {
+	if gc.updatePaused(time.Now()) {
+		slog.Debug("Skipping resync during blackout", "org", t.Org, "repo", t.Repo)
+		return
+	}
+
+	slog.Info("Resyncing repository", "org", t.Org, "repo", t.Repo)
+
+	err := gc.collectRepo(ctx, t.Org, t.Repo)
+	if err == nil && len(gc.config.GitHub.Branches) > 0 && gc.config.Collectors.BuildStatusEnabled() {
+		var errs []error
+
+		for _, branch := range gc.config.GitHub.Branches {
+			branchErr := gc.collectBranchBuildStatus(ctx, t.Org, t.Repo, branch)
+			gc.status.record("branch", t.Org+"/"+t.Repo+"@"+branch, branchErr)
+			errs = append(errs, branchErr)
+		}
+
+		err = errors.Join(errs...)
+	}
+
+	gc.metrics.GitHubResyncs.With(prometheus.Labels{"trigger": "manual"}).Inc()
+
+	if err != nil {
+		logError("Failed to resync repository", err, "org", t.Org, "repo", t.Repo)
+	}
+}
+
+// collectRepo fetches a repository and sets its metrics
+func (gc *GitHubCollector) collectRepo(ctx context.Context, owner, repo string) error {
+	t := target{Org: owner, Repo: repo}
+
+	if err := gc.limiter.Wait(ctx); err != nil {
+		return wrapAPIError("repos", t, fmt.Errorf("rate limiter error: %w", err))
+	}
+
+	reqCtx, cancel := gc.requestContext(ctx, "repos")
+	repoInfo, _, err := gc.api.GetRepository(reqCtx, owner, repo)
+	cancel()
+
+	if err != nil {
+		gc.recordAPIError("repos", err)
+		err = wrapAPIError("repos", t, err)
+		gc.status.record("repo", t.String(), err)
+
+		return err
+	}
+
+	owner, repo = gc.canonicalRepo(owner, repo, repoInfo)
+
+	visibility := "public"
+	if repoInfo.GetPrivate() {
+		visibility = "private"
+	}
+
+	err = gc.setRepoMetrics(ctx, owner, repo, visibility, repoInfo)
+	gc.status.record("repo", t.String(), nil)
+
+	return err
+}
+
+// reconcile runs a full collection that lists repositories again instead of
+// using the discovery cache, so repositories whose webhook deliveries were
+// missed entirely are picked up too
+func (gc *GitHubCollector) reconcile(ctx context.Context) {
+	if gc.updatePaused(time.Now()) {
+		return
+	}
+
+	slog.Info("Reconciling webhook data with a full poll")
+
+	gc.discovery.clear()
+	gc.collectMetrics(ctx)
+	gc.saveSnapshot()
+
+	gc.metrics.GitHubResyncs.With(prometheus.Labels{"trigger": "reconcile"}).Inc()
+}
+
+// reconcileTicker returns the channel of the periodic reconciliation pass, or
+// nil when reconciliation is disabled, which blocks forever in a select
+func (gc *GitHubCollector) reconcileTicker() (<-chan time.Time, func()) {
+	interval := gc.config.Webhook.ReconcileInterval.Duration
+	if !gc.config.Webhook.Enabled || interval <= 0 {
+		return nil, func() {}
+	}
+
+	ticker := time.NewTicker(interval)
+
+	return ticker.C, ticker.Stop
+}
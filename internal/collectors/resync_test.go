@@ -0,0 +1,65 @@
+package collectors
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-github/v76/github"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// TestRequestResync tests that only collected repositories can be queued and
+// that the queue is bounded
+func TestRequestResync(t *testing.T) {
+	collector, _ := newFakeCollector()
+
+	if err := collector.RequestResync("d0ugal", "unknown"); !errors.Is(err, ErrUnknownRepository) {
+		t.Errorf("Expected ErrUnknownRepository, got %v", err)
+	}
+
+	collector.inventory.add("d0ugal", "github-exporter", "public", &github.Repository{})
+
+	for i := 0; i < resyncQueueSize; i++ {
+		if err := collector.RequestResync("d0ugal", "github-exporter"); err != nil {
+			t.Fatalf("Unexpected error queueing resync %d: %v", i, err)
+		}
+	}
+
+	if err := collector.RequestResync("d0ugal", "github-exporter"); !errors.Is(err, ErrResyncQueueFull) {
+		t.Errorf("Expected ErrResyncQueueFull, got %v", err)
+	}
+
+	if queued := <-collector.resyncs; queued.Org != "d0ugal" || queued.Repo != "github-exporter" {
+		t.Errorf("Unexpected queued target %+v", queued)
+	}
+}
+
+// TestResyncRepo tests that a resync refreshes repository and build status metrics
+func TestResyncRepo(t *testing.T) {
+	collector, api := newFakeCollector()
+	collector.config.GitHub.Branches = []string{"main"}
+
+	repo := api.addRepo("d0ugal", "github-exporter", false)
+	repo.StargazersCount = github.Ptr(7)
+	api.workflowRuns["d0ugal/github-exporter@main"] = []*github.WorkflowRun{{
+		ID:         github.Ptr(int64(1)),
+		Name:       github.Ptr("CI"),
+		HeadBranch: github.Ptr("main"),
+		Status:     github.Ptr("completed"),
+		Conclusion: github.Ptr("success"),
+	}}
+
+	collector.resyncRepo(t.Context(), target{Org: "d0ugal", Repo: "github-exporter"})
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubReposStars.WithLabelValues("d0ugal", "github-exporter", "public")); got != 7 {
+		t.Errorf("Expected 7 stars, got %v", got)
+	}
+
+	if got := api.calls["ListWorkflowRuns"]; got != 1 {
+		t.Errorf("Expected build status to be collected once, got %d calls", got)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubResyncs.WithLabelValues("manual")); got != 1 {
+		t.Errorf("Expected 1 manual resync, got %v", got)
+	}
+}
@@ -0,0 +1,203 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v76/github"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// maxDriftExamples bounds the repositories named in a drift warning
+const maxDriftExamples = 10
+
+// driftTracker remembers the repositories resolved at the last revalidation
+type driftTracker struct {
+	previous map[string]bool // nil until the baseline is set
+}
+
+// revalidateTicker returns the channel of the periodic revalidation, or nil
+// when revalidation is disabled, which blocks forever in a select
+func (gc *GitHubCollector) revalidateTicker() (<-chan time.Time, func()) {
+	interval := gc.config.GitHub.RevalidateInterval.Duration
+	if interval <= 0 {
+		return nil, func() {}
+	}
+
+	ticker := time.NewTicker(interval)
+
+	return ticker.C, ticker.Stop
+}
+
+// setDriftBaseline records the repositories the first collection monitored, as
+// served from the discovery cache, for the first revalidation to compare with
+func (gc *GitHubCollector) setDriftBaseline(ctx context.Context) {
+	if gc.config.GitHub.RevalidateInterval.Duration <= 0 {
+		return
+	}
+
+	repos, err := gc.resolveRepos(ctx)
+	if err != nil {
+		logError("Failed to resolve repositories for revalidation", err)
+		return
+	}
+
+	gc.drift.previous = repos
+}
+
+// revalidate lists the repositories of all targets again, outside of the
+// collection cycle, and warns when they changed materially since the last
+// revalidation, e.g. after the token lost access or an organization was
+// restructured. The fresh listings replace the discovery cache.
+func (gc *GitHubCollector) revalidate(ctx context.Context) {
+	if gc.updatePaused(time.Now()) {
+		return
+	}
+
+	gc.discovery.clear()
+
+	current, err := gc.resolveRepos(ctx)
+	if err != nil {
+		// Keep the baseline, a failed listing says nothing about the targets
+		logError("Failed to revalidate repositories", err)
+		gc.metrics.GitHubTargetRevalidations.With(prometheus.Labels{"result": "error"}).Inc()
+
+		return
+	}
+
+	gc.metrics.GitHubTargetRevalidations.With(prometheus.Labels{"result": "success"}).Inc()
+
+	previous := gc.drift.previous
+	gc.drift.previous = current
+
+	if previous == nil {
+		return
+	}
+
+	appeared, disappeared := diffRepos(previous, current)
+
+	gc.metrics.GitHubTargetDriftRepos.With(prometheus.Labels{"change": "appeared"}).Set(float64(len(appeared)))
+	gc.metrics.GitHubTargetDriftRepos.With(prometheus.Labels{"change": "disappeared"}).Set(float64(len(disappeared)))
+
+	drifted := driftRatio(len(previous), len(appeared)+len(disappeared)) > gc.config.GitHub.DriftThreshold
+	gc.metrics.GitHubTargetDrift.With(prometheus.Labels{}).Set(boolToFloat(drifted))
+
+	if drifted {
+		slog.Warn("Monitored repositories changed materially since the last revalidation",
+			"previous", len(previous),
+			"current", len(current),
+			"appeared", len(appeared),
+			"disappeared", len(disappeared),
+			"appeared_examples", examples(appeared),
+			"disappeared_examples", examples(disappeared),
+		)
+	} else if len(appeared)+len(disappeared) > 0 {
+		slog.Info("Monitored repositories changed since the last revalidation",
+			"appeared", len(appeared),
+			"disappeared", len(disappeared),
+		)
+	}
+}
+
+// resolveRepos returns the lowercased full names of all repositories the
+// configured and dynamic targets currently resolve to, after exclusions
+func (gc *GitHubCollector) resolveRepos(ctx context.Context) (map[string]bool, error) {
+	repos := make(map[string]bool)
+
+	add := func(discovered []*github.Repository) {
+		for _, repo := range discovered {
+			if repo.GetName() != "" {
+				repos[strings.ToLower(repoFullName(repo))] = true
+			}
+		}
+	}
+
+	if gc.hasWildcardRepos() {
+		discovered, _, err := gc.discoverAllRepos(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		add(discovered)
+	} else {
+		for _, fullName := range gc.targetRepos() {
+			repos[strings.ToLower(fullName)] = true
+		}
+	}
+
+	for _, org := range gc.targetOrgs() {
+		discovered, _, _, err := gc.discoverOrgRepos(ctx, org)
+		if err != nil {
+			return nil, wrapAPIError("repos", target{Org: org}, fmt.Errorf("failed to list repositories: %w", err))
+		}
+
+		add(discovered)
+	}
+
+	for _, team := range gc.config.GitHub.Teams {
+		discovered, _, err := gc.discoverTeamRepos(ctx, team)
+		if err != nil {
+			return nil, err
+		}
+
+		add(discovered)
+	}
+
+	if gc.config.GitHub.Starred {
+		discovered, _, err := gc.discoverStarredRepos(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		add(discovered)
+	}
+
+	return repos, nil
+}
+
+// diffRepos returns the repositories only in current and only in previous, sorted
+func diffRepos(previous, current map[string]bool) (appeared, disappeared []string) {
+	for repo := range current {
+		if !previous[repo] {
+			appeared = append(appeared, repo)
+		}
+	}
+
+	for repo := range previous {
+		if !current[repo] {
+			disappeared = append(disappeared, repo)
+		}
+	}
+
+	sort.Strings(appeared)
+	sort.Strings(disappeared)
+
+	return appeared, disappeared
+}
+
+// driftRatio returns the share of previous repositories that changed. Any
+// change to an empty set counts as complete drift.
+func driftRatio(previous, changed int) float64 {
+	if changed == 0 {
+		return 0
+	}
+
+	if previous == 0 {
+		return 1
+	}
+
+	return float64(changed) / float64(previous)
+}
+
+// examples returns the first repositories of a sorted list for logging
+func examples(repos []string) []string {
+	if len(repos) > maxDriftExamples {
+		return repos[:maxDriftExamples]
+	}
+
+	return repos
+}
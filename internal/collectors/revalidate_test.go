@@ -0,0 +1,97 @@
+package collectors
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// TestRevalidate tests that revalidation lists repositories again and flags
+// drift beyond the threshold, keeping the baseline when listing fails
+func TestRevalidate(t *testing.T) {
+	responses := []string{
+		`[{"name": "a", "owner": {"login": "d0ugal"}}, {"name": "b", "owner": {"login": "d0ugal"}}, {"name": "c", "owner": {"login": "d0ugal"}}]`,
+		`[{"name": "a", "owner": {"login": "d0ugal"}}, {"name": "b", "owner": {"login": "d0ugal"}}, {"name": "c", "owner": {"login": "d0ugal"}}]`,
+		`[{"name": "a", "owner": {"login": "d0ugal"}}, {"name": "d", "owner": {"login": "d0ugal"}}]`,
+	}
+
+	requests := 0
+	collector := newAPITestCollector(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/orgs/d0ugal/repos" {
+			t.Errorf("Unexpected request path %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if requests >= len(responses) {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"message": "Resource not accessible by integration"}`))
+			return
+		}
+
+		_, _ = w.Write([]byte(responses[requests]))
+		requests++
+	})
+	collector.config.GitHub.RevalidateInterval.Duration = time.Hour
+	collector.config.GitHub.DiscoveryInterval.Duration = time.Hour
+	collector.config.GitHub.DriftThreshold = 0.1
+
+	collector.setDriftBaseline(t.Context())
+
+	// The repositories are listed again rather than served from the cache
+	collector.revalidate(t.Context())
+
+	if requests != 2 {
+		t.Errorf("Expected the revalidation to list repositories again, got %d requests", requests)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubTargetDrift.WithLabelValues()); got != 0 {
+		t.Errorf("Expected no drift for unchanged repositories, got %v", got)
+	}
+
+	// b and c disappeared and d appeared, out of four repositories
+	collector.revalidate(t.Context())
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubTargetDrift.WithLabelValues()); got != 1 {
+		t.Errorf("Expected drift, got %v", got)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubTargetDriftRepos.WithLabelValues("appeared")); got != 1 {
+		t.Errorf("Expected 1 appeared repository, got %v", got)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubTargetDriftRepos.WithLabelValues("disappeared")); got != 2 {
+		t.Errorf("Expected 2 disappeared repositories, got %v", got)
+	}
+
+	// A failed listing keeps the baseline
+	collector.revalidate(t.Context())
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubTargetRevalidations.WithLabelValues("error")); got != 1 {
+		t.Errorf("Expected 1 failed revalidation, got %v", got)
+	}
+
+	if !collector.drift.previous["d0ugal/d"] || collector.drift.previous["d0ugal/b"] {
+		t.Errorf("Expected the baseline of the last successful revalidation, got %v", collector.drift.previous)
+	}
+}
+
+// TestDriftRatio tests the share of changed repositories
+func TestDriftRatio(t *testing.T) {
+	for _, tc := range []struct {
+		previous, changed int
+		want              float64
+	}{
+		{0, 0, 0},
+		{0, 3, 1},
+		{10, 0, 0},
+		{10, 1, 0.1},
+		{4, 6, 1.5},
+	} {
+		if got := driftRatio(tc.previous, tc.changed); got != tc.want {
+			t.Errorf("driftRatio(%d, %d) = %v, want %v", tc.previous, tc.changed, got, tc.want)
+		}
+	}
+}
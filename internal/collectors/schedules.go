@@ -0,0 +1,313 @@
+package collectors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/d0ugal/github-exporter/internal/cron"
+	"github.com/google/go-github/v76/github"
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/yaml.v3"
+)
+
+// scheduleCache remembers the cron schedules of each workflow file, so the file
+// is only fetched again when the workflow changes
+type scheduleCache struct {
+	mu      sync.Mutex
+	entries map[string]scheduleEntry // "org/repo/workflow ID" -> schedules
+}
+
+type scheduleEntry struct {
+	updatedAt time.Time
+	crons     []string
+}
+
+func newScheduleCache() *scheduleCache {
+	return &scheduleCache{
+		entries: make(map[string]scheduleEntry),
+	}
+}
+
+// get returns the cached schedules of a workflow if it hasn't changed since
+func (sc *scheduleCache) get(key string, updatedAt time.Time) ([]string, bool) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	entry, ok := sc.entries[key]
+	if !ok || !entry.updatedAt.Equal(updatedAt) {
+		return nil, false
+	}
+
+	return entry.crons, true
+}
+
+func (sc *scheduleCache) set(key string, updatedAt time.Time, crons []string) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	sc.entries[key] = scheduleEntry{updatedAt: updatedAt, crons: crons}
+}
+
+// parseWorkflowSchedules returns the cron expressions of a workflow file's
+// on.schedule trigger
+func parseWorkflowSchedules(content []byte) ([]string, error) {
+	var workflow struct {
+		On yaml.Node `yaml:"on"`
+	}
+
+	if err := yaml.Unmarshal(content, &workflow); err != nil {
+		return nil, fmt.Errorf("failed to parse workflow: %w", err)
+	}
+
+	// on: can also be a single event or a list of events, neither of which can
+	// carry a schedule
+	if workflow.On.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+
+	for i := 0; i+1 < len(workflow.On.Content); i += 2 {
+		if workflow.On.Content[i].Value != "schedule" {
+			continue
+		}
+
+		var schedule []struct {
+			Cron string `yaml:"cron"`
+		}
+
+		if err := workflow.On.Content[i+1].Decode(&schedule); err != nil {
+			return nil, fmt.Errorf("failed to parse schedule: %w", err)
+		}
+
+		crons := make([]string, 0, len(schedule))
+		for _, entry := range schedule {
+			if entry.Cron != "" {
+				crons = append(crons, entry.Cron)
+			}
+		}
+
+		return crons, nil
+	}
+
+	return nil, nil
+}
+
+// setWorkflowMetrics lists a repository's workflows once for the workflow state
+// and schedules collectors
+func (gc *GitHubCollector) setWorkflowMetrics(ctx context.Context, owner, repo string) error {
+	workflows, err := gc.listWorkflows(ctx, owner, repo)
+	if err != nil {
+		logError("Failed to list workflows", err)
+		return err
+	}
+
+	if gc.config.Collectors.WorkflowStateEnabled() {
+		gc.setWorkflowStateMetrics(owner, repo, workflows)
+	}
+
+	if gc.config.Collectors.SchedulesEnabled() {
+		return gc.setScheduleMetrics(ctx, owner, repo, workflows)
+	}
+
+	return nil
+}
+
+// setWorkflowStateMetrics exports the inventory of workflows and the state of each
+func (gc *GitHubCollector) setWorkflowStateMetrics(owner, repo string, workflows []*github.Workflow) {
+	// The inventory is rebuilt every cycle so a workflow only has its current
+	// path and state, and deleted workflows disappear
+	repoLabels := prometheus.Labels{"org": owner, "repo": repo}
+	gc.metrics.GitHubWorkflowInfo.DeletePartialMatch(repoLabels)
+	gc.metrics.GitHubWorkflowState.DeletePartialMatch(repoLabels)
+
+	for _, workflow := range workflows {
+		gc.metrics.GitHubWorkflowInfo.With(prometheus.Labels{
+			"org":      owner,
+			"repo":     repo,
+			"workflow": workflow.GetName(),
+			"path":     workflow.GetPath(),
+			"state":    workflow.GetState(),
+		}).Set(1)
+
+		gc.metrics.GitHubWorkflowState.With(prometheus.Labels{
+			"org":      owner,
+			"repo":     repo,
+			"workflow": workflow.GetName(),
+			"state":    workflow.GetState(),
+		}).Set(1)
+	}
+}
+
+// setScheduleMetrics exports the cron schedules of a repository's workflows, the
+// estimated next run of each and how long ago each scheduled workflow last ran
+func (gc *GitHubCollector) setScheduleMetrics(ctx context.Context, owner, repo string, workflows []*github.Workflow) error {
+	// Schedules are rebuilt every cycle so removed workflows and crons disappear
+	repoLabels := prometheus.Labels{"org": owner, "repo": repo}
+	gc.metrics.GitHubWorkflowScheduleInfo.DeletePartialMatch(repoLabels)
+	gc.metrics.GitHubWorkflowScheduleNextRun.DeletePartialMatch(repoLabels)
+
+	now := time.Now()
+
+	var errs []error
+
+	for _, workflow := range workflows {
+		crons, err := gc.workflowSchedules(ctx, owner, repo, workflow)
+		if err != nil {
+			logError("Failed to get workflow schedules", err, "workflow", workflow.GetPath())
+			errs = append(errs, err)
+			continue
+		}
+
+		if len(crons) == 0 {
+			continue
+		}
+
+		for _, expr := range crons {
+			labels := prometheus.Labels{"org": owner, "repo": repo, "workflow": workflow.GetName(), "cron": expr}
+			gc.metrics.GitHubWorkflowScheduleInfo.With(labels).Set(1)
+
+			schedule, err := cron.Parse(expr)
+			if err != nil {
+				slog.Warn("Invalid workflow schedule", "org", owner, "repo", repo, "workflow", workflow.GetPath(), "error", err)
+				continue
+			}
+
+			if next := schedule.Next(now); !next.IsZero() {
+				gc.metrics.GitHubWorkflowScheduleNextRun.With(labels).Set(float64(next.Unix()))
+			}
+		}
+
+		last, err := gc.lastScheduledRun(ctx, owner, repo, workflow.GetID())
+		if err != nil {
+			logError("Failed to get last scheduled run", err, "workflow", workflow.GetPath())
+			errs = append(errs, err)
+			continue
+		}
+
+		if last.IsZero() {
+			continue
+		}
+
+		labels := prometheus.Labels{"org": owner, "repo": repo, "workflow": workflow.GetName()}
+		gc.metrics.GitHubWorkflowLastScheduledRun.With(labels).Set(float64(last.Unix()))
+		gc.metrics.GitHubWorkflowSecondsSinceScheduledRun.With(labels).Set(now.Sub(last).Seconds())
+	}
+
+	return errors.Join(errs...)
+}
+
+// listWorkflows lists the workflows of a repository
+func (gc *GitHubCollector) listWorkflows(ctx context.Context, owner, repo string) ([]*github.Workflow, error) {
+	t := target{Org: owner, Repo: repo}
+	opts := &github.ListOptions{PerPage: 100}
+
+	var all []*github.Workflow
+
+	for page := 0; page < maxActivityPages; page++ {
+		// Wait for rate limiter
+		if err := gc.limiter.Wait(ctx); err != nil {
+			return nil, wrapAPIError("workflows", t, fmt.Errorf("rate limiter error: %w", err))
+		}
+
+		reqCtx, cancel := gc.requestContext(ctx, "workflows")
+		workflows, resp, err := gc.api.ListWorkflows(reqCtx, owner, repo, opts)
+		cancel()
+		if err != nil {
+			gc.recordAPIError("workflows", err)
+			return nil, wrapAPIError("workflows", t, err)
+		}
+
+		all = append(all, workflows.Workflows...)
+
+		if resp == nil || resp.NextPage == 0 {
+			break
+		}
+
+		opts.Page = resp.NextPage
+	}
+
+	return all, nil
+}
+
+// workflowSchedules returns the cron schedules of a workflow, reading its file
+// only when the workflow changed since it was last read
+func (gc *GitHubCollector) workflowSchedules(ctx context.Context, owner, repo string, workflow *github.Workflow) ([]string, error) {
+	key := fmt.Sprintf("%s/%s/%d", owner, repo, workflow.GetID())
+	updatedAt := workflow.GetUpdatedAt().Time
+
+	if crons, ok := gc.schedules.get(key, updatedAt); ok {
+		return crons, nil
+	}
+
+	t := target{Org: owner, Repo: repo}
+
+	// Wait for rate limiter
+	if err := gc.limiter.Wait(ctx); err != nil {
+		return nil, wrapAPIError("contents", t, fmt.Errorf("rate limiter error: %w", err))
+	}
+
+	reqCtx, cancel := gc.requestContext(ctx, "contents")
+	file, _, _, err := gc.api.GetContents(reqCtx, owner, repo, workflow.GetPath(), nil)
+	cancel()
+
+	if err != nil {
+		// Workflows of deleted files stay listed; they can't be scheduled
+		if _, errorType := classifyAPIError(err); errorType == "not_found" {
+			gc.schedules.set(key, updatedAt, nil)
+			return nil, nil
+		}
+
+		gc.recordAPIError("contents", err)
+
+		return nil, wrapAPIError("contents", t, err)
+	}
+
+	if file == nil {
+		return nil, nil
+	}
+
+	content, err := file.GetContent()
+	if err != nil {
+		return nil, wrapAPIError("contents", t, fmt.Errorf("failed to decode %s: %w", workflow.GetPath(), err))
+	}
+
+	crons, err := parseWorkflowSchedules([]byte(content))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", workflow.GetPath(), err)
+	}
+
+	gc.schedules.set(key, updatedAt, crons)
+
+	return crons, nil
+}
+
+// lastScheduledRun returns when the most recent scheduled run of a workflow was
+// created, or the zero time if it never ran on a schedule
+func (gc *GitHubCollector) lastScheduledRun(ctx context.Context, owner, repo string, workflowID int64) (time.Time, error) {
+	t := target{Org: owner, Repo: repo}
+
+	// Wait for rate limiter
+	if err := gc.limiter.Wait(ctx); err != nil {
+		return time.Time{}, wrapAPIError("workflow_runs", t, fmt.Errorf("rate limiter error: %w", err))
+	}
+
+	reqCtx, cancel := gc.requestContext(ctx, "workflow_runs")
+	runs, _, err := gc.api.ListWorkflowRunsByID(reqCtx, owner, repo, workflowID, &github.ListWorkflowRunsOptions{
+		Event:       "schedule",
+		ListOptions: github.ListOptions{PerPage: 1},
+	})
+	cancel()
+	if err != nil {
+		gc.recordAPIError("workflow_runs", err)
+		return time.Time{}, wrapAPIError("workflow_runs", t, err)
+	}
+
+	if len(runs.WorkflowRuns) == 0 {
+		return time.Time{}, nil
+	}
+
+	return runs.WorkflowRuns[0].GetCreatedAt().Time, nil
+}
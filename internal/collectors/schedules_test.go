@@ -0,0 +1,164 @@
+package collectors
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v76/github"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// TestParseWorkflowSchedules tests extracting cron schedules from workflow files
+func TestParseWorkflowSchedules(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{
+			name: "schedule",
+			content: `name: Nightly
+on:
+  schedule:
+    - cron: "0 3 * * *"
+    - cron: "30 12 * * 1-5"
+  workflow_dispatch:
+jobs: {}
+`,
+			want: []string{"0 3 * * *", "30 12 * * 1-5"},
+		},
+		{name: "single event", content: "on: push\n"},
+		{name: "event list", content: "on: [push, pull_request]\n"},
+		{name: "no schedule", content: "on:\n  push:\n    branches: [main]\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseWorkflowSchedules([]byte(tt.content))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+
+	if _, err := parseWorkflowSchedules([]byte("on: [")); err == nil {
+		t.Error("Expected an error for invalid YAML")
+	}
+}
+
+// TestSetScheduleMetrics tests scheduled workflow inventory and last scheduled runs
+func TestSetScheduleMetrics(t *testing.T) {
+	collector, api := newFakeCollector()
+	enabled := true
+	collector.config.Collectors.Schedules = &enabled
+
+	updated := &github.Timestamp{Time: time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)}
+	api.workflows["d0ugal/github-exporter"] = []*github.Workflow{
+		{ID: github.Ptr(int64(1)), Name: github.Ptr("Nightly"), Path: github.Ptr(".github/workflows/nightly.yml"), UpdatedAt: updated},
+		{ID: github.Ptr(int64(2)), Name: github.Ptr("CI"), Path: github.Ptr(".github/workflows/ci.yml"), UpdatedAt: updated},
+	}
+	api.contents["d0ugal/github-exporter/.github/workflows/nightly.yml"] = "on:\n  schedule:\n    - cron: \"0 3 * * *\"\n"
+	api.contents["d0ugal/github-exporter/.github/workflows/ci.yml"] = "on: [push]\n"
+
+	lastRun := time.Now().Add(-2 * time.Hour)
+	api.workflowRuns["d0ugal/github-exporter"] = []*github.WorkflowRun{
+		{ID: github.Ptr(int64(11)), WorkflowID: github.Ptr(int64(1)), Event: github.Ptr("workflow_dispatch"), CreatedAt: &github.Timestamp{Time: time.Now()}},
+		{ID: github.Ptr(int64(10)), WorkflowID: github.Ptr(int64(1)), Event: github.Ptr("schedule"), CreatedAt: &github.Timestamp{Time: lastRun}},
+	}
+
+	collector.setWorkflowMetrics(t.Context(), "d0ugal", "github-exporter")
+	collector.setWorkflowMetrics(t.Context(), "d0ugal", "github-exporter")
+
+	if got := testutil.CollectAndCount(collector.metrics.GitHubWorkflowScheduleInfo); got != 1 {
+		t.Errorf("Expected 1 schedule, got %d", got)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubWorkflowScheduleInfo.WithLabelValues("d0ugal", "github-exporter", "Nightly", "0 3 * * *")); got != 1 {
+		t.Errorf("Expected schedule info 1, got %v", got)
+	}
+
+	next := testutil.ToFloat64(collector.metrics.GitHubWorkflowScheduleNextRun.WithLabelValues("d0ugal", "github-exporter", "Nightly", "0 3 * * *"))
+	if nextRun := time.Unix(int64(next), 0).UTC(); nextRun.Hour() != 3 || nextRun.Minute() != 0 || time.Until(nextRun) > 24*time.Hour {
+		t.Errorf("Expected the next run within a day at 03:00, got %s", nextRun)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubWorkflowLastScheduledRun.WithLabelValues("d0ugal", "github-exporter", "Nightly")); got != float64(lastRun.Unix()) {
+		t.Errorf("Expected last scheduled run %d, got %v", lastRun.Unix(), got)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubWorkflowSecondsSinceScheduledRun.WithLabelValues("d0ugal", "github-exporter", "Nightly")); got < 7200 {
+		t.Errorf("Expected at least 7200 seconds since the last scheduled run, got %v", got)
+	}
+
+	// Unchanged workflow files are only read once
+	if api.calls["GetContents"] != 2 {
+		t.Errorf("Expected 2 workflow files to be read, got %d", api.calls["GetContents"])
+	}
+}
+
+// TestSetScheduleMetricsRemovedSchedule tests that removed schedules disappear
+func TestSetScheduleMetricsRemovedSchedule(t *testing.T) {
+	collector, api := newFakeCollector()
+	enabled := true
+	collector.config.Collectors.Schedules = &enabled
+
+	workflow := &github.Workflow{ID: github.Ptr(int64(1)), Name: github.Ptr("Nightly"), Path: github.Ptr("nightly.yml"), UpdatedAt: &github.Timestamp{Time: time.Unix(1, 0)}}
+	api.workflows["d0ugal/github-exporter"] = []*github.Workflow{workflow}
+	api.contents["d0ugal/github-exporter/nightly.yml"] = "on:\n  schedule:\n    - cron: \"0 3 * * *\"\n"
+
+	collector.setWorkflowMetrics(t.Context(), "d0ugal", "github-exporter")
+
+	workflow.UpdatedAt = &github.Timestamp{Time: time.Unix(2, 0)}
+	api.contents["d0ugal/github-exporter/nightly.yml"] = "on: workflow_dispatch\n"
+
+	collector.setWorkflowMetrics(t.Context(), "d0ugal", "github-exporter")
+
+	if got := testutil.CollectAndCount(collector.metrics.GitHubWorkflowScheduleInfo); got != 0 {
+		t.Errorf("Expected no schedules, got %d", got)
+	}
+}
+
+// TestSetWorkflowStateMetrics tests that each workflow reports its current state
+func TestSetWorkflowStateMetrics(t *testing.T) {
+	collector, api := newFakeCollector()
+	enabled := true
+	collector.config.Collectors.WorkflowState = &enabled
+
+	backup := &github.Workflow{ID: github.Ptr(int64(1)), Name: github.Ptr("Backup"), Path: github.Ptr(".github/workflows/backup.yml"), State: github.Ptr("active")}
+	api.workflows["d0ugal/github-exporter"] = []*github.Workflow{
+		backup,
+		{ID: github.Ptr(int64(2)), Name: github.Ptr("CI"), Path: github.Ptr(".github/workflows/ci.yml"), State: github.Ptr("disabled_manually")},
+	}
+
+	collector.setWorkflowMetrics(t.Context(), "d0ugal", "github-exporter")
+
+	backup.State = github.Ptr("disabled_inactivity")
+
+	collector.setWorkflowMetrics(t.Context(), "d0ugal", "github-exporter")
+
+	if got := testutil.CollectAndCount(collector.metrics.GitHubWorkflowState); got != 2 {
+		t.Errorf("Expected 2 workflow states, got %d", got)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubWorkflowState.WithLabelValues("d0ugal", "github-exporter", "Backup", "disabled_inactivity")); got != 1 {
+		t.Errorf("Expected Backup to be disabled due to inactivity, got %v", got)
+	}
+
+	if got := testutil.CollectAndCount(collector.metrics.GitHubWorkflowInfo); got != 2 {
+		t.Errorf("Expected 2 workflows in the inventory, got %d", got)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubWorkflowInfo.WithLabelValues("d0ugal", "github-exporter", "CI", ".github/workflows/ci.yml", "disabled_manually")); got != 1 {
+		t.Errorf("Expected CI in the inventory with its path, got %v", got)
+	}
+
+	// Workflow files are only read for schedules
+	if api.calls["GetContents"] != 0 {
+		t.Errorf("Expected no workflow files to be read, got %d", api.calls["GetContents"])
+	}
+}
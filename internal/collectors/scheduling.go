@@ -0,0 +1,111 @@
+package collectors
+
+import (
+	"context"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// targetScheduler spreads per-target collection evenly across the refresh interval.
+// The number of targets seen in the previous cycle is used to size the slots, so the
+// first cycle after startup always runs back-to-back. Each target takes one slot
+// per cycle, however many collection phases it is visited in.
+type targetScheduler struct {
+	mu        sync.Mutex
+	start     time.Time
+	window    time.Duration
+	slot      time.Duration
+	jitter    float64
+	next      int
+	lastCount int
+	taken     map[string]bool // Targets that took a slot in the current cycle
+}
+
+// begin starts a new collection cycle spread across window
+func (ts *targetScheduler) begin(window time.Duration, jitter float64) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	ts.lastCount = ts.next
+	ts.next = 0
+	ts.taken = make(map[string]bool, ts.lastCount)
+	ts.start = time.Now()
+	ts.window = window
+	ts.jitter = jitter
+	ts.slot = 0
+
+	if ts.lastCount > 0 && window > 0 {
+		ts.slot = window / time.Duration(ts.lastCount)
+	}
+}
+
+// offset returns the scheduled start offset of target i, including jitter. The
+// offset is clamped to the last slot of the window, so neither jitter nor more
+// targets than in the previous cycle push a target past the refresh interval.
+func (ts *targetScheduler) offset(i int, slot, window time.Duration, jitter float64) time.Duration {
+	offset := time.Duration(i) * slot
+	if jitter > 0 {
+		offset += time.Duration((rand.Float64()*2 - 1) * jitter * float64(slot)) //nolint:gosec // Jitter does not need a secure source
+	}
+
+	offset = min(offset, window-slot)
+
+	if offset < 0 {
+		offset = 0
+	}
+
+	return offset
+}
+
+// wait blocks until the slot of the named target in the current cycle. A target
+// that already took its slot in this cycle, e.g. a repository visited again for
+// its build status, does not wait again.
+func (ts *targetScheduler) wait(ctx context.Context, name string) error {
+	ts.mu.Lock()
+	if ts.taken[name] {
+		ts.mu.Unlock()
+		return nil
+	}
+
+	if ts.taken == nil {
+		ts.taken = make(map[string]bool)
+	}
+
+	ts.taken[name] = true
+	i := ts.next
+	ts.next++
+	start := ts.start
+	window := ts.window
+	slot := ts.slot
+	jitter := ts.jitter
+	ts.mu.Unlock()
+
+	if slot <= 0 {
+		return nil
+	}
+
+	delay := time.Until(start.Add(ts.offset(i, slot, window, jitter)))
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// waitForTargetSlot waits for the scheduled slot of a target when staggering is enabled
+func (gc *GitHubCollector) waitForTargetSlot(ctx context.Context, name string) error {
+	if !gc.config.GitHub.StaggerTargets || gc.scheduler == nil {
+		return nil
+	}
+
+	return gc.scheduler.wait(ctx, name)
+}
@@ -0,0 +1,130 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// TestTargetSchedulerFirstCycle tests that the first cycle is not staggered
+func TestTargetSchedulerFirstCycle(t *testing.T) {
+	scheduler := &targetScheduler{}
+	scheduler.begin(time.Hour, 0)
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		if err := scheduler.wait(context.Background(), fmt.Sprintf("d0ugal/repo-%d", i)); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	if time.Since(start) > time.Second {
+		t.Error("Expected first cycle to run without delays")
+	}
+}
+
+// TestTargetSchedulerSlots tests that targets are spread across the window
+func TestTargetSchedulerSlots(t *testing.T) {
+	scheduler := &targetScheduler{next: 4}
+	scheduler.begin(time.Hour, 0)
+
+	if scheduler.slot != 15*time.Minute {
+		t.Errorf("Expected 15m slot, got %s", scheduler.slot)
+	}
+
+	if offset := scheduler.offset(2, scheduler.slot, time.Hour, 0); offset != 30*time.Minute {
+		t.Errorf("Expected 30m offset, got %s", offset)
+	}
+
+	// Jitter stays within the configured fraction of the slot
+	for i := 0; i < 100; i++ {
+		offset := scheduler.offset(2, scheduler.slot, time.Hour, 0.1)
+		if offset < 30*time.Minute-90*time.Second || offset > 30*time.Minute+90*time.Second {
+			t.Fatalf("Jittered offset out of range: %s", offset)
+		}
+	}
+
+	// Neither jitter nor more targets than in the previous cycle go past the window
+	for i := 0; i < 100; i++ {
+		if offset := scheduler.offset(3, scheduler.slot, time.Hour, 0.5); offset > 45*time.Minute {
+			t.Fatalf("Expected the last target to start within the window, got %s", offset)
+		}
+	}
+
+	if offset := scheduler.offset(10, scheduler.slot, time.Hour, 0); offset != 45*time.Minute {
+		t.Errorf("Expected additional targets in the last slot, got %s", offset)
+	}
+
+	// The first target is never delayed
+	if err := scheduler.wait(context.Background(), "d0ugal/first"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Later targets respect context cancellation
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := scheduler.wait(ctx, "d0ugal/second"); err == nil {
+		t.Error("Expected cancelled context to abort the wait")
+	}
+}
+
+// TestTargetSchedulerOneSlotPerTarget tests that a target visited in several
+// phases of a cycle takes a single slot
+func TestTargetSchedulerOneSlotPerTarget(t *testing.T) {
+	scheduler := &targetScheduler{}
+	scheduler.begin(time.Hour, 0)
+
+	for _, name := range []string{"d0ugal/a", "d0ugal/b", "d0ugal/a", "d0ugal/b"} {
+		if err := scheduler.wait(context.Background(), name); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	scheduler.begin(time.Hour, 0)
+
+	if scheduler.slot != 30*time.Minute {
+		t.Errorf("Expected 2 targets sharing the window in 30m slots, got %s", scheduler.slot)
+	}
+
+	// A target that already took its slot does not wait again
+	if err := scheduler.wait(context.Background(), "d0ugal/a"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	if err := scheduler.wait(ctx, "d0ugal/a"); err != nil {
+		t.Errorf("Expected no second wait for the same target, got %v", err)
+	}
+}
+
+// TestRecalculateRefreshInterval tests that the effective refresh interval and
+// its recalculations are exported
+func TestRecalculateRefreshInterval(t *testing.T) {
+	collector, _ := newFakeCollector()
+	collector.config.GitHub.RefreshInterval.Duration = 10 * time.Minute
+
+	interval, changed := collector.recalculateRefreshInterval(5 * time.Minute)
+	if !changed || interval != 10*time.Minute {
+		t.Errorf("Expected the interval to change to 10m, got %s (changed %v)", interval, changed)
+	}
+
+	if _, changed := collector.recalculateRefreshInterval(interval); changed {
+		t.Error("Expected the interval to stay the same")
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubExporterRefreshInterval); got != 600 {
+		t.Errorf("Expected a refresh interval of 600s, got %v", got)
+	}
+
+	for _, result := range []string{"changed", "unchanged"} {
+		if got := testutil.ToFloat64(collector.metrics.GitHubRefreshIntervalUpdates.WithLabelValues(result)); got != 1 {
+			t.Errorf("Expected 1 %s recalculation, got %v", result, got)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package collectors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/go-github/v76/github"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// setSecretMetrics exports the number of Actions secrets and variables of a
+// repository, or of an organization when repo is empty, and the age of the
+// secret that was updated longest ago. Org-level series have an empty repo label.
+func (gc *GitHubCollector) setSecretMetrics(ctx context.Context, owner, repo string) error {
+	labels := prometheus.Labels{"org": owner, "repo": repo}
+
+	var errs []error
+
+	secrets, total, err := gc.listSecrets(ctx, owner, repo)
+	if err != nil {
+		logError("Failed to list Actions secrets", err)
+		errs = append(errs, err)
+	} else {
+		gc.metrics.GitHubActionsSecrets.With(labels).Set(float64(total))
+
+		if oldest := oldestSecret(secrets); oldest != nil {
+			age := time.Since(oldest.UpdatedAt.Time).Hours() / 24
+			gc.metrics.GitHubActionsSecretMaxAge.With(labels).Set(age)
+		} else {
+			gc.metrics.GitHubActionsSecretMaxAge.Delete(labels)
+		}
+	}
+
+	variables, err := gc.countVariables(ctx, owner, repo)
+	if err != nil {
+		logError("Failed to list Actions variables", err)
+		errs = append(errs, err)
+	} else {
+		gc.metrics.GitHubActionsVariables.With(labels).Set(float64(variables))
+	}
+
+	return errors.Join(errs...)
+}
+
+// oldestSecret returns the secret that was updated longest ago, or nil if there are none
+func oldestSecret(secrets []*github.Secret) *github.Secret {
+	var oldest *github.Secret
+
+	for _, secret := range secrets {
+		if oldest == nil || secret.UpdatedAt.Before(oldest.UpdatedAt.Time) {
+			oldest = secret
+		}
+	}
+
+	return oldest
+}
+
+// listSecrets lists the Actions secrets of a repository, or of an organization
+// when repo is empty, and returns them with the total count. Secret values are
+// never returned by the API, only their names and timestamps.
+func (gc *GitHubCollector) listSecrets(ctx context.Context, owner, repo string) ([]*github.Secret, int, error) {
+	t := target{Org: owner, Repo: repo}
+	opts := &github.ListOptions{PerPage: 100}
+
+	var (
+		secrets []*github.Secret
+		total   int
+	)
+
+	for page := 0; page < maxActivityPages; page++ {
+		// Wait for rate limiter
+		if err := gc.limiter.Wait(ctx); err != nil {
+			return nil, 0, wrapAPIError("actions_secrets", t, fmt.Errorf("rate limiter error: %w", err))
+		}
+
+		reqCtx, cancel := gc.requestContext(ctx, "actions_secrets")
+
+		var (
+			list *github.Secrets
+			resp *github.Response
+			err  error
+		)
+
+		if repo == "" {
+			list, resp, err = gc.api.ListOrgSecrets(reqCtx, owner, opts)
+		} else {
+			list, resp, err = gc.api.ListRepoSecrets(reqCtx, owner, repo, opts)
+		}
+
+		cancel()
+
+		if err != nil {
+			gc.recordAPIError("actions_secrets", err)
+			return nil, 0, wrapAPIError("actions_secrets", t, err)
+		}
+
+		secrets = append(secrets, list.Secrets...)
+		total = list.TotalCount
+
+		if resp == nil || resp.NextPage == 0 {
+			break
+		}
+
+		opts.Page = resp.NextPage
+	}
+
+	return secrets, total, nil
+}
+
+// countVariables returns the number of Actions variables of a repository, or of
+// an organization when repo is empty
+func (gc *GitHubCollector) countVariables(ctx context.Context, owner, repo string) (int, error) {
+	t := target{Org: owner, Repo: repo}
+	opts := &github.ListOptions{PerPage: 1}
+
+	// Wait for rate limiter
+	if err := gc.limiter.Wait(ctx); err != nil {
+		return 0, wrapAPIError("actions_variables", t, fmt.Errorf("rate limiter error: %w", err))
+	}
+
+	reqCtx, cancel := gc.requestContext(ctx, "actions_variables")
+
+	var (
+		variables *github.ActionsVariables
+		err       error
+	)
+
+	if repo == "" {
+		variables, _, err = gc.api.ListOrgVariables(reqCtx, owner, opts)
+	} else {
+		variables, _, err = gc.api.ListRepoVariables(reqCtx, owner, repo, opts)
+	}
+
+	cancel()
+
+	if err != nil {
+		gc.recordAPIError("actions_variables", err)
+		return 0, wrapAPIError("actions_variables", t, err)
+	}
+
+	return variables.TotalCount, nil
+}
@@ -0,0 +1,65 @@
+package collectors
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// TestSetSecretMetrics tests counting the secrets and variables of an
+// organization and a repository and the age of the oldest secret
+func TestSetSecretMetrics(t *testing.T) {
+	updated := func(days int) string {
+		return time.Now().Add(-time.Duration(days) * 24 * time.Hour).UTC().Format(time.RFC3339)
+	}
+
+	collector := newAPITestCollector(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/orgs/d0ugal/actions/secrets":
+			_, _ = fmt.Fprintf(w, `{"total_count": 2, "secrets": [
+				{"name": "DEPLOY_KEY", "updated_at": %q},
+				{"name": "NPM_TOKEN", "updated_at": %q}
+			]}`, updated(10), updated(400))
+		case "/orgs/d0ugal/actions/variables":
+			_, _ = w.Write([]byte(`{"total_count": 3, "variables": [{"name": "REGION"}]}`))
+		case "/repos/d0ugal/private/actions/secrets":
+			_, _ = w.Write([]byte(`{"total_count": 0, "secrets": []}`))
+		case "/repos/d0ugal/private/actions/variables":
+			_, _ = w.Write([]byte(`{"total_count": 1, "variables": [{"name": "ENV"}]}`))
+		default:
+			t.Errorf("Unexpected request path %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	collector.setSecretMetrics(t.Context(), "d0ugal", "")
+	collector.setSecretMetrics(t.Context(), "d0ugal", "private")
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubActionsSecrets.WithLabelValues("d0ugal", "")); got != 2 {
+		t.Errorf("Expected 2 organization secrets, got %v", got)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubActionsSecretMaxAge.WithLabelValues("d0ugal", "")); got < 399.9 || got > 400.1 {
+		t.Errorf("Expected the oldest organization secret to be 400 days old, got %v", got)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubActionsVariables.WithLabelValues("d0ugal", "")); got != 3 {
+		t.Errorf("Expected 3 organization variables, got %v", got)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubActionsSecrets.WithLabelValues("d0ugal", "private")); got != 0 {
+		t.Errorf("Expected no repository secrets, got %v", got)
+	}
+
+	// Repositories without secrets have no age
+	if got := testutil.CollectAndCount(collector.metrics.GitHubActionsSecretMaxAge); got != 1 {
+		t.Errorf("Expected 1 secret age, got %d", got)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubActionsVariables.WithLabelValues("d0ugal", "private")); got != 1 {
+		t.Errorf("Expected 1 repository variable, got %v", got)
+	}
+}
@@ -0,0 +1,59 @@
+package collectors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// securityPolicyPaths are the locations GitHub reads a SECURITY.md file from, in order of precedence
+var securityPolicyPaths = []string{".github/SECURITY.md", "SECURITY.md", "docs/SECURITY.md"}
+
+// setSecurityPolicyMetrics exports whether a repository accepts private
+// vulnerability reports and whether it has a security policy
+func (gc *GitHubCollector) setSecurityPolicyMetrics(ctx context.Context, owner, repo string) error {
+	labels := prometheus.Labels{"org": owner, "repo": repo}
+
+	var errs []error
+
+	enabled, err := gc.isPrivateReportingEnabled(ctx, owner, repo)
+	if err != nil {
+		logError("Failed to get private vulnerability reporting status", err)
+		errs = append(errs, err)
+	} else {
+		gc.metrics.GitHubPrivateVulnReportingEnabled.With(labels).Set(boolToFloat(enabled))
+	}
+
+	_, found, err := gc.getFirstFile(ctx, owner, repo, securityPolicyPaths)
+	if err != nil {
+		logError("Failed to get SECURITY.md file", err)
+		return errors.Join(append(errs, err)...)
+	}
+
+	gc.metrics.GitHubSecurityPolicyExists.With(labels).Set(boolToFloat(found))
+
+	return errors.Join(errs...)
+}
+
+// isPrivateReportingEnabled returns whether private vulnerability reporting is
+// enabled for a repository
+func (gc *GitHubCollector) isPrivateReportingEnabled(ctx context.Context, owner, repo string) (bool, error) {
+	t := target{Org: owner, Repo: repo}
+
+	// Wait for rate limiter
+	if err := gc.limiter.Wait(ctx); err != nil {
+		return false, wrapAPIError("private_vulnerability_reporting", t, fmt.Errorf("rate limiter error: %w", err))
+	}
+
+	reqCtx, cancel := gc.requestContext(ctx, "private_vulnerability_reporting")
+	enabled, _, err := gc.api.IsPrivateReportingEnabled(reqCtx, owner, repo)
+	cancel()
+	if err != nil {
+		gc.recordAPIError("private_vulnerability_reporting", err)
+		return false, wrapAPIError("private_vulnerability_reporting", t, err)
+	}
+
+	return enabled, nil
+}
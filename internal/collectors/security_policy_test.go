@@ -0,0 +1,64 @@
+package collectors
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// TestSetSecurityPolicyMetrics tests reading private vulnerability reporting and
+// finding SECURITY.md in a fallback location
+func TestSetSecurityPolicyMetrics(t *testing.T) {
+	collector := newAPITestCollector(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/d0ugal/private/private-vulnerability-reporting":
+			_, _ = w.Write([]byte(`{"enabled": true}`))
+		case "/repos/d0ugal/private/contents/.github/SECURITY.md":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+		case "/repos/d0ugal/private/contents/SECURITY.md":
+			_, _ = w.Write([]byte(`{"type": "file", "encoding": "base64", "content": "UmVwb3J0IGlzc3Vlcwo="}`))
+		default:
+			t.Errorf("Unexpected request path %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	collector.setSecurityPolicyMetrics(t.Context(), "d0ugal", "private")
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubPrivateVulnReportingEnabled.WithLabelValues("d0ugal", "private")); got != 1 {
+		t.Errorf("Expected private vulnerability reporting to be enabled, got %v", got)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubSecurityPolicyExists.WithLabelValues("d0ugal", "private")); got != 1 {
+		t.Errorf("Expected SECURITY.md to exist, got %v", got)
+	}
+}
+
+// TestSetSecurityPolicyMetricsMissing tests repositories without a security policy
+func TestSetSecurityPolicyMetricsMissing(t *testing.T) {
+	collector := newAPITestCollector(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/repos/d0ugal/private/private-vulnerability-reporting" {
+			_, _ = w.Write([]byte(`{"enabled": false}`))
+			return
+		}
+
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+	})
+
+	collector.setSecurityPolicyMetrics(t.Context(), "d0ugal", "private")
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubPrivateVulnReportingEnabled.WithLabelValues("d0ugal", "private")); got != 0 {
+		t.Errorf("Expected private vulnerability reporting to be disabled, got %v", got)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubSecurityPolicyExists.WithLabelValues("d0ugal", "private")); got != 0 {
+		t.Errorf("Expected SECURITY.md to be missing, got %v", got)
+	}
+
+	if got := testutil.CollectAndCount(collector.metrics.GitHubAPIErrorsTotal); got != 0 {
+		t.Errorf("Expected missing files not to be recorded as API errors, got %d", got)
+	}
+}
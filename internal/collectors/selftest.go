@@ -0,0 +1,120 @@
+package collectors
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// SelfTestReport summarizes a single collection made by the selftest subcommand
+type SelfTestReport struct {
+	Duration    time.Duration
+	Permissions map[string]bool // Permission check result by collector
+	Calls       map[string]int  // API calls by endpoint and response status, e.g. "repos 200"
+	Series      map[string]int  // Exported series by metric family of the exporter
+	Targets     []TargetStatus
+
+	// QuotaUsed is the core rate limit consumed by the collection, -1 when it is
+	// unknown because no response reported it or the rate limit was reset meanwhile
+	QuotaUsed      int
+	QuotaRemaining int
+}
+
+// Failed reports whether the token lacks permissions for an enabled collector
+// or a target could not be collected
+func (r SelfTestReport) Failed() bool {
+	for _, ok := range r.Permissions {
+		if !ok {
+			return true
+		}
+	}
+
+	for _, target := range r.Targets {
+		if !target.Healthy() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SelfTest checks the token's permissions and runs a single collection cycle,
+// reporting the API calls it made, the series it exported and the quota it used
+func (gc *GitHubCollector) SelfTest(ctx context.Context) SelfTestReport {
+	start := time.Now()
+
+	gc.checkPermissions(ctx)
+
+	gc.mu.RLock()
+	remaining, reset := gc.rateLimitRemaining, gc.rateLimitReset
+	gc.mu.RUnlock()
+
+	gc.collectMetrics(ctx)
+
+	report := SelfTestReport{
+		Duration:    time.Since(start),
+		Permissions: make(map[string]bool),
+		Calls:       make(map[string]int),
+		Series:      make(map[string]int),
+		QuotaUsed:   -1,
+	}
+
+	for _, s := range matchingSeries(gc.metrics.GitHubCollectorPermissionOK, nil) {
+		report.Permissions[s.labels["collector"]] = s.value == 1
+	}
+
+	for _, s := range counterSeries(gc.metrics.GitHubAPICallsTotal) {
+		report.Calls[s.labels["endpoint"]+" "+s.labels["status"]] += int(s.value)
+	}
+
+	// Only the exporter's own metrics, not those of the Go runtime and process
+	if families, err := gc.metrics.GetRegistry().Gather(); err == nil {
+		for _, family := range families {
+			if strings.HasPrefix(family.GetName(), gc.metrics.Namespace()+"_") {
+				report.Series[family.GetName()] = len(family.GetMetric())
+			}
+		}
+	}
+
+	report.Targets, _, _ = gc.status.snapshot()
+
+	gc.mu.RLock()
+	report.QuotaRemaining = gc.rateLimitRemaining
+	if !reset.IsZero() && gc.rateLimitReset.Equal(reset) {
+		report.QuotaUsed = remaining - gc.rateLimitRemaining
+	}
+	gc.mu.RUnlock()
+
+	return report
+}
+
+// counterSeries returns every series of a counter
+func counterSeries(counter *prometheus.CounterVec) []series {
+	ch := make(chan prometheus.Metric)
+
+	go func() {
+		counter.Collect(ch)
+		close(ch)
+	}()
+
+	var all []series
+
+	for metric := range ch {
+		var m dto.Metric
+		if err := metric.Write(&m); err != nil {
+			continue
+		}
+
+		labels := make(prometheus.Labels, len(m.GetLabel()))
+		for _, pair := range m.GetLabel() {
+			labels[pair.GetName()] = pair.GetValue()
+		}
+
+		all = append(all, series{labels: labels, value: m.GetCounter().GetValue()})
+	}
+
+	return all
+}
@@ -0,0 +1,82 @@
+package collectors
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/d0ugal/promexporter/app"
+	"github.com/google/go-github/v76/github"
+	"golang.org/x/time/rate"
+)
+
+// TestSelfTest tests that a self-test reports the calls made, the series
+// exported and the quota used by a single collection
+func TestSelfTest(t *testing.T) {
+	var remaining atomic.Int64
+	remaining.Store(5000)
+
+	reset := time.Now().Add(time.Hour).Unix()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "5000")
+		w.Header().Set("X-RateLimit-Remaining", fmt.Sprint(remaining.Add(-1)))
+		w.Header().Set("X-RateLimit-Reset", fmt.Sprint(reset))
+		w.Header().Set("X-RateLimit-Resource", "core")
+
+		switch r.URL.Path {
+		case "/user":
+			w.Header().Set(oauthScopesHeader, "repo, read:org")
+			_, _ = w.Write([]byte(`{"login": "d0ugal"}`))
+		case "/repos/d0ugal/private":
+			_, _ = w.Write([]byte(`{"name": "private", "full_name": "d0ugal/private", "private": true, "stargazers_count": 3}`))
+		case "/search/issues":
+			_, _ = w.Write([]byte(`{"total_count": 2}`))
+		default:
+			t.Errorf("Unexpected request path %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	collector := createTestCollector()
+	collector.app = app.New("github-exporter")
+	collector.limiter = newSharedLimiter(rate.Inf, 1)
+	collector.transportPaced = true // Keep the limiter unlimited when the rate limit is observed
+	collector.config.GitHub.Repos = []string{"d0ugal/private"}
+
+	// Count calls and observe the rate limit like the production client
+	transport := newInstrumentedTransport(nil, collector.metrics)
+	transport.onRateLimit = collector.observeRateLimit
+
+	client := github.NewClient(&http.Client{Transport: transport})
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+	collector.api = NewGitHubAPI(client)
+
+	report := collector.SelfTest(t.Context())
+
+	if report.Failed() {
+		t.Errorf("Expected the self-test to pass, got %+v", report)
+	}
+
+	if got := report.Calls["repos 200"]; got != 1 {
+		t.Errorf("Expected 1 repository call, got %d", got)
+	}
+
+	if got := report.Series["github_repo_stars"]; got != 1 {
+		t.Errorf("Expected 1 github_repo_stars series, got %d", got)
+	}
+
+	if _, ok := report.Series["go_goroutines"]; ok {
+		t.Error("Expected runtime metrics to be excluded")
+	}
+
+	// Every call but the permission check counts towards the collection
+	if calls := 5000 - remaining.Load(); report.QuotaUsed != int(calls)-1 {
+		t.Errorf("Expected %d calls of quota used, got %d", calls-1, report.QuotaUsed)
+	}
+}
@@ -0,0 +1,48 @@
+package collectors
+
+import (
+	"sync"
+	"time"
+)
+
+// sinceTracker remembers when incremental data was last fetched for each key,
+// so that only activity since the previous cycle is listed and counted
+type sinceTracker struct {
+	mu   sync.Mutex
+	last map[string]time.Time
+}
+
+func newSinceTracker() *sinceTracker {
+	return &sinceTracker{
+		last: make(map[string]time.Time),
+	}
+}
+
+// advance records now as the latest fetch for key and returns the previous one.
+// It returns false on the first call for a key, which only seeds the tracker.
+func (st *sinceTracker) advance(key string, now time.Time) (time.Time, bool) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	since, ok := st.last[key]
+	st.last[key] = now
+
+	return since, ok
+}
+
+// rewind restores the previous fetch time for key after a failed fetch, so the
+// same window is retried in the next cycle
+func (st *sinceTracker) rewind(key string, since time.Time) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	st.last[key] = since
+}
+
+// rename moves the fetch times of a repository that was renamed or transferred
+func (st *sinceTracker) rename(from, to string) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	renameRepoKeys(st.last, from, to)
+}
@@ -0,0 +1,83 @@
+package collectors
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/d0ugal/github-exporter/internal/state"
+)
+
+// restoreSnapshot serves the metric snapshot saved by the previous run, if any,
+// until the first collection completes, and restores the recently completed
+// workflow runs, follower counts and organization repositories
+func (gc *GitHubCollector) restoreSnapshot() {
+	if gc.state == nil {
+		return
+	}
+
+	saved, err := gc.state.Load()
+	if err != nil {
+		logError("Failed to load state", err, "path", gc.state.Path())
+		return
+	}
+
+	now := time.Now()
+	for _, run := range saved.WorkflowRuns {
+		gc.window.add(run, now)
+	}
+
+	gc.restoreFollowers(saved.Followers)
+	gc.restoreOrgRepos(saved.OrgRepos)
+
+	if saved.Snapshot == nil {
+		return
+	}
+
+	families, err := saved.Snapshot.Families()
+	if err != nil {
+		logError("Failed to restore metric snapshot", err, "path", gc.state.Path())
+		return
+	}
+
+	gc.metrics.ServeSnapshot(families, saved.Snapshot.SavedAt)
+
+	slog.Info("Serving metric snapshot until the first collection completes",
+		"saved_at", saved.Snapshot.SavedAt,
+		"families", len(families),
+	)
+}
+
+// saveSnapshot persists the live metrics so the next run can serve them while
+// warming up, along with the workflow runs in the success ratio window, the
+// follower counts and the organization repositories
+func (gc *GitHubCollector) saveSnapshot() {
+	if gc.state == nil {
+		return
+	}
+
+	families, err := gc.metrics.GetRegistry().Gather()
+	if err != nil {
+		logError("Failed to gather metrics for snapshot", err)
+		return
+	}
+
+	snapshot, err := state.NewSnapshot(families, time.Now())
+	if err != nil {
+		logError("Failed to encode metric snapshot", err)
+		return
+	}
+
+	saved := &state.State{
+		Snapshot:     snapshot,
+		WorkflowRuns: gc.window.prune(time.Now()),
+		Followers:    gc.followers.snapshot(),
+		OrgRepos:     gc.orgRepos.snapshot(),
+	}
+
+	if err := gc.state.Save(saved); err != nil {
+		logError("Failed to save state", err, "path", gc.state.Path())
+		return
+	}
+
+	slog.Debug("Saved metric snapshot", "path", gc.state.Path(), "families", len(families))
+}
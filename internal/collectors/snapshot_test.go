@@ -0,0 +1,43 @@
+package collectors
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/d0ugal/github-exporter/internal/state"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// TestSnapshotWarmup tests that a restarted collector serves the previous run's metrics until warm-up ends
+func TestSnapshotWarmup(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+
+	previous := createTestCollector()
+	previous.state = state.NewStore(path)
+	previous.metrics.GitHubReposStars.WithLabelValues("d0ugal", "github-exporter", "public").Set(42)
+	previous.saveSnapshot()
+
+	restarted := createTestCollector()
+	restarted.state = state.NewStore(path)
+	restarted.restoreSnapshot()
+
+	families, err := restarted.metrics.Gatherer().Gather()
+	if err != nil {
+		t.Fatalf("Failed to gather metrics: %v", err)
+	}
+
+	found := false
+	for _, family := range families {
+		if family.GetName() == "github_repo_stars" && len(family.GetMetric()) == 1 {
+			found = family.GetMetric()[0].GetGauge().GetValue() == 42
+		}
+	}
+
+	if !found {
+		t.Error("Expected restored github_repo_stars during warm-up")
+	}
+
+	if got := testutil.ToFloat64(restarted.metrics.GitHubExporterWarmup); got != 1 {
+		t.Errorf("Expected warm-up gauge 1, got %v", got)
+	}
+}
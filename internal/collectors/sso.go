@@ -0,0 +1,44 @@
+package collectors
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// orgSSOQuery reads an organization's SAML identity provider, which is only
+// visible to organization owners
+const orgSSOQuery = `query($org: String!) {
+  organization(login: $org) {
+    samlIdentityProvider { id }
+  }
+}`
+
+// orgSSOResponse is the data of an orgSSOQuery response
+type orgSSOResponse struct {
+	Organization *struct {
+		SAMLIdentityProvider *struct {
+			ID string `json:"id"`
+		} `json:"samlIdentityProvider"`
+	} `json:"organization"`
+}
+
+// setSSOMetrics exports whether SAML single sign-on is configured for an
+// organization. SSO enforced by an enterprise account is not visible on its
+// organizations and reports 0.
+func (gc *GitHubCollector) setSSOMetrics(ctx context.Context, org string) {
+	var data orgSSOResponse
+
+	if err := gc.graphql(ctx, "graphql_org_sso", orgSSOQuery, map[string]interface{}{"org": org}, &data); err != nil {
+		logError("Failed to get SAML SSO configuration", wrapAPIError("graphql_org_sso", target{Org: org}, err))
+		return
+	}
+
+	if data.Organization == nil {
+		return
+	}
+
+	gc.metrics.GitHubOrgSAMLSSOEnabled.With(prometheus.Labels{
+		"org": org,
+	}).Set(boolToFloat(data.Organization.SAMLIdentityProvider != nil))
+}
@@ -0,0 +1,55 @@
+package collectors
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/d0ugal/promexporter/app"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// TestSetSSOMetrics tests SAML SSO detection for organizations
+func TestSetSSOMetrics(t *testing.T) {
+	collector := newAPITestCollector(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/graphql" {
+			t.Errorf("Unexpected request path %s", r.URL.Path)
+		}
+
+		_, _ = w.Write([]byte(`{"data": {"organization": {"samlIdentityProvider": {"id": "MDI4"}}}}`))
+	})
+
+	collector.setSSOMetrics(t.Context(), "d0ugal")
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubOrgSAMLSSOEnabled.WithLabelValues("d0ugal")); got != 1 {
+		t.Errorf("Expected SAML SSO to be enabled, got %v", got)
+	}
+}
+
+// TestSetSSOMetricsDisabled tests organizations without a SAML identity provider
+func TestSetSSOMetricsDisabled(t *testing.T) {
+	collector := newAPITestCollector(t, func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"data": {"organization": {"samlIdentityProvider": null}}}`))
+	})
+
+	collector.setSSOMetrics(t.Context(), "d0ugal")
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubOrgSAMLSSOEnabled.WithLabelValues("d0ugal")); got != 0 {
+		t.Errorf("Expected SAML SSO to be disabled, got %v", got)
+	}
+}
+
+// TestCollectOrgInfoTwoFactor tests the two-factor requirement from organization info
+func TestCollectOrgInfoTwoFactor(t *testing.T) {
+	collector := newAPITestCollector(t, func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"login": "d0ugal", "two_factor_requirement_enabled": true}`))
+	})
+	collector.app = app.New("github-exporter")
+
+	if ok, err := collector.collectOrgInfo(t.Context(), nil, "d0ugal"); !ok || err != nil {
+		t.Fatalf("Expected organization info, got %v, %v", ok, err)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubOrgTwoFactorRequired.WithLabelValues("d0ugal")); got != 1 {
+		t.Errorf("Expected two-factor authentication to be required, got %v", got)
+	}
+}
@@ -0,0 +1,140 @@
+package collectors
+
+import (
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/d0ugal/github-exporter/internal/config"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// applyFailurePolicy handles the metrics of a repository that could not be
+// collected according to github.failure_policy. An empty repo applies the policy
+// to all repositories of the owner, e.g. when listing an organization failed.
+func (gc *GitHubCollector) applyFailurePolicy(owner, repo string) {
+	match := prometheus.Labels{"org": owner}
+	if repo != "" {
+		match["repo"] = repo
+	}
+
+	switch gc.config.GitHub.FailurePolicy {
+	case config.FailurePolicyDelete:
+		deleted := 0
+		for _, gauge := range gc.metrics.RepoGauges() {
+			deleted += gauge.DeletePartialMatch(match)
+		}
+
+		slog.Debug("Deleted metrics of failed target", "org", owner, "repo", repo, "series", deleted)
+	case config.FailurePolicyZero:
+		for _, gauge := range gc.metrics.RepoGauges() {
+			zeroMatching(gauge, match)
+		}
+	}
+}
+
+// zeroMatching sets every series of a gauge whose labels include match to 0
+func zeroMatching(gauge *prometheus.GaugeVec, match prometheus.Labels) {
+	for _, s := range matchingSeries(gauge, match) {
+		gauge.With(s.labels).Set(0)
+	}
+}
+
+// series is a single labelled value of a gauge or counter
+type series struct {
+	labels prometheus.Labels
+	value  float64
+}
+
+// matchingSeries returns every series of a gauge or counter whose labels include
+// match. The series are gathered before returning, so the caller may modify the
+// vector.
+func matchingSeries(vec prometheus.Collector, match prometheus.Labels) []series {
+	ch := make(chan prometheus.Metric)
+
+	go func() {
+		vec.Collect(ch)
+		close(ch)
+	}()
+
+	var matched []series
+
+	for metric := range ch {
+		var m dto.Metric
+		if err := metric.Write(&m); err != nil {
+			continue
+		}
+
+		labels := make(prometheus.Labels, len(m.GetLabel()))
+		for _, pair := range m.GetLabel() {
+			labels[pair.GetName()] = pair.GetValue()
+		}
+
+		if labelsMatch(labels, match) {
+			value := m.GetGauge().GetValue()
+			if m.Counter != nil {
+				value = m.GetCounter().GetValue()
+			}
+
+			matched = append(matched, series{labels: labels, value: value})
+		}
+	}
+
+	return matched
+}
+
+// labelsMatch reports whether labels contains every label in match
+func labelsMatch(labels, match prometheus.Labels) bool {
+	for name, value := range match {
+		if labels[name] != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+// forgetRepos stops tracking the status of repositories removed from the
+// inventory, and of their branches
+func (gc *GitHubCollector) forgetRepos(repos []string) {
+	gc.status.forget(func(status TargetStatus) bool {
+		switch status.Type {
+		case "repo":
+			return !slices.Contains(repos, status.Target)
+		case "branch":
+			repo, _, _ := strings.Cut(status.Target, "@")
+			return !slices.Contains(repos, repo)
+		default:
+			return true
+		}
+	})
+}
+
+// setStaleMetrics exports whether the last collection of each target failed,
+// deleting the series of targets no longer monitored
+func (gc *GitHubCollector) setStaleMetrics() {
+	targets, _, _ := gc.status.snapshot()
+
+	monitored := make(map[string]bool, len(targets))
+
+	for _, status := range targets {
+		stale := 0.0
+		if !status.Healthy() {
+			stale = 1
+		}
+
+		gc.metrics.GitHubTargetStale.With(prometheus.Labels{
+			"type":   status.Type,
+			"target": status.Target,
+		}).Set(stale)
+
+		monitored[status.Type+":"+status.Target] = true
+	}
+
+	for _, s := range matchingSeries(gc.metrics.GitHubTargetStale, prometheus.Labels{}) {
+		if !monitored[s.labels["type"]+":"+s.labels["target"]] {
+			gc.metrics.GitHubTargetStale.Delete(s.labels)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package collectors
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/d0ugal/github-exporter/internal/config"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// TestApplyFailurePolicy tests keeping, zeroing and deleting the metrics of a failed repository
+func TestApplyFailurePolicy(t *testing.T) {
+	for policy, expected := range map[string]int{
+		config.FailurePolicyKeep:   1,
+		config.FailurePolicyZero:   1,
+		config.FailurePolicyDelete: 0,
+	} {
+		t.Run(policy, func(t *testing.T) {
+			collector := createTestCollector()
+			collector.config.GitHub.FailurePolicy = policy
+
+			collector.metrics.GitHubReposStars.WithLabelValues("d0ugal", "broken", "public").Set(42)
+			collector.metrics.GitHubReposStars.WithLabelValues("d0ugal", "healthy", "public").Set(7)
+
+			collector.applyFailurePolicy("d0ugal", "broken")
+
+			if got := testutil.CollectAndCount(collector.metrics.GitHubReposStars); got != expected+1 {
+				t.Errorf("Expected %d series, got %d", expected+1, got)
+			}
+
+			if got := testutil.ToFloat64(collector.metrics.GitHubReposStars.WithLabelValues("d0ugal", "healthy", "public")); got != 7 {
+				t.Errorf("Expected other repositories to be untouched, got %v", got)
+			}
+
+			if policy == config.FailurePolicyDelete {
+				return
+			}
+
+			want := 42.0
+			if policy == config.FailurePolicyZero {
+				want = 0
+			}
+
+			if got := testutil.ToFloat64(collector.metrics.GitHubReposStars.WithLabelValues("d0ugal", "broken", "public")); got != want {
+				t.Errorf("Expected %v stars, got %v", want, got)
+			}
+		})
+	}
+}
+
+// TestSetStaleMetrics tests exporting whether each target's last collection failed
+func TestSetStaleMetrics(t *testing.T) {
+	collector := createTestCollector()
+	collector.status.record("repo", "d0ugal/broken", errors.New("boom"))
+	collector.status.record("repo", "d0ugal/healthy", nil)
+
+	collector.setStaleMetrics()
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubTargetStale.WithLabelValues("repo", "d0ugal/broken")); got != 1 {
+		t.Errorf("Expected failed target to be stale, got %v", got)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubTargetStale.WithLabelValues("repo", "d0ugal/healthy")); got != 0 {
+		t.Errorf("Expected healthy target not to be stale, got %v", got)
+	}
+}
+
+// TestStaleMetricsRemovedTargets tests that the stale series of repositories
+// removed from the inventory, and of their branches, are deleted
+func TestStaleMetricsRemovedTargets(t *testing.T) {
+	collector := createTestCollector()
+	collector.status.record("repo", "d0ugal/kept", nil)
+	collector.status.record("repo", "d0ugal/deleted", errors.New("boom"))
+	collector.status.record("branch", "d0ugal/deleted@main", errors.New("boom"))
+	collector.status.record("org", "d0ugal", nil)
+	collector.setStaleMetrics()
+
+	collector.forgetRepos([]string{"d0ugal/deleted"})
+	collector.setStaleMetrics()
+
+	if got := testutil.CollectAndCount(collector.metrics.GitHubTargetStale); got != 2 {
+		t.Errorf("Expected 2 stale series after removing a repository, got %d", got)
+	}
+
+	for _, labels := range [][]string{{"repo", "d0ugal/kept"}, {"org", "d0ugal"}} {
+		if len(matchingSeries(collector.metrics.GitHubTargetStale, prometheus.Labels{"type": labels[0], "target": labels[1]})) != 1 {
+			t.Errorf("Expected the %s %s series to be kept", labels[0], labels[1])
+		}
+	}
+
+	if targets, _, _ := collector.status.snapshot(); len(targets) != 2 {
+		t.Errorf("Expected 2 targets left on the status page, got %d", len(targets))
+	}
+}
@@ -0,0 +1,148 @@
+package collectors
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/d0ugal/github-exporter/internal/redact"
+)
+
+// TargetStatus describes the last collection of a single monitored target
+type TargetStatus struct {
+	Type          string    // "org", "repo", "team" or "branch"
+	Target        string    // e.g. "d0ugal", "d0ugal/repo" or "d0ugal/repo@main"
+	LastCollected time.Time // Time of the last collection attempt
+	LastSuccess   time.Time // Time of the last successful collection
+	LastError     string    // Error of the last attempt, empty on success
+	Failures      int       // Consecutive failed attempts
+}
+
+// Healthy reports whether the last collection of the target succeeded
+func (ts TargetStatus) Healthy() bool {
+	return ts.LastError == ""
+}
+
+// Status is a snapshot of the collector state for the status page
+type Status struct {
+	Orgs               []string
+	Repos              []string
+	Teams              []string
+	Branches           []string
+	RefreshInterval    time.Duration
+	LastCollection     time.Time
+	RateLimitTotal     int
+	RateLimitRemaining int
+	RateLimitReset     time.Time
+	Targets            []TargetStatus
+}
+
+// statusTracker records per-target collection results
+type statusTracker struct {
+	mu              sync.RWMutex
+	targets         map[string]*TargetStatus
+	refreshInterval time.Duration
+	lastCollection  time.Time
+}
+
+func newStatusTracker() *statusTracker {
+	return &statusTracker{
+		targets: make(map[string]*TargetStatus),
+	}
+}
+
+// record stores the result of collecting a target
+func (st *statusTracker) record(targetType, target string, err error) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	key := targetType + ":" + target
+
+	status, ok := st.targets[key]
+	if !ok {
+		status = &TargetStatus{Type: targetType, Target: target}
+		st.targets[key] = status
+	}
+
+	now := time.Now()
+	status.LastCollected = now
+
+	if err != nil {
+		status.LastError = redact.String(err.Error())
+		status.Failures++
+
+		return
+	}
+
+	status.LastError = ""
+	status.Failures = 0
+	status.LastSuccess = now
+}
+
+// forget removes the targets that are no longer monitored
+func (st *statusTracker) forget(monitored func(TargetStatus) bool) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	for key, status := range st.targets {
+		if !monitored(*status) {
+			delete(st.targets, key)
+		}
+	}
+}
+
+// setCycle records the effective refresh interval and completion of a collection cycle
+func (st *statusTracker) setCycle(interval time.Duration, completed time.Time) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	if interval > 0 {
+		st.refreshInterval = interval
+	}
+
+	if !completed.IsZero() {
+		st.lastCollection = completed
+	}
+}
+
+// snapshot returns a sorted copy of all target statuses
+func (st *statusTracker) snapshot() ([]TargetStatus, time.Duration, time.Time) {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+
+	targets := make([]TargetStatus, 0, len(st.targets))
+	for _, status := range st.targets {
+		targets = append(targets, *status)
+	}
+
+	sort.Slice(targets, func(i, j int) bool {
+		if targets[i].Type != targets[j].Type {
+			return targets[i].Type < targets[j].Type
+		}
+
+		return targets[i].Target < targets[j].Target
+	})
+
+	return targets, st.refreshInterval, st.lastCollection
+}
+
+// Status returns a snapshot of the collector state
+func (gc *GitHubCollector) Status() Status {
+	targets, interval, lastCollection := gc.status.snapshot()
+
+	gc.mu.RLock()
+	defer gc.mu.RUnlock()
+
+	return Status{
+		Orgs:               gc.targetOrgs(),
+		Repos:              gc.targetRepos(),
+		Teams:              gc.config.GitHub.Teams,
+		Branches:           gc.config.GitHub.Branches,
+		RefreshInterval:    interval,
+		LastCollection:     lastCollection,
+		RateLimitTotal:     gc.rateLimitTotal,
+		RateLimitRemaining: gc.rateLimitRemaining,
+		RateLimitReset:     gc.rateLimitReset,
+		Targets:            targets,
+	}
+}
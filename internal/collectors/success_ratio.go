@@ -0,0 +1,106 @@
+package collectors
+
+import (
+	"sync"
+	"time"
+
+	"github.com/d0ugal/github-exporter/internal/state"
+	"github.com/google/go-github/v76/github"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// successRatioWindow is the sliding window workflow success ratios are computed over
+const successRatioWindow = 24 * time.Hour
+
+// runWindow remembers the workflow runs that completed within the success ratio
+// window, keyed by run ID so runs seen again, e.g. when backfilling after a
+// restart, are only counted once
+type runWindow struct {
+	mu   sync.Mutex
+	runs map[int64]state.WorkflowRun
+}
+
+// add remembers a completed run unless it is older than the window
+func (w *runWindow) add(run state.WorkflowRun, now time.Time) {
+	if now.Sub(run.CompletedAt) > successRatioWindow {
+		return
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.runs == nil {
+		w.runs = make(map[int64]state.WorkflowRun)
+	}
+
+	w.runs[run.ID] = run
+}
+
+// prune forgets the runs that completed before the window and returns the others
+func (w *runWindow) prune(now time.Time) []state.WorkflowRun {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	runs := make([]state.WorkflowRun, 0, len(w.runs))
+
+	for id, run := range w.runs {
+		if now.Sub(run.CompletedAt) > successRatioWindow {
+			delete(w.runs, id)
+			continue
+		}
+
+		runs = append(runs, run)
+	}
+
+	return runs
+}
+
+// addToRunWindow remembers a completed run for the success ratio. Only runs that
+// succeeded or failed count; skipped, neutral and other conclusions don't.
+func (gc *GitHubCollector) addToRunWindow(owner, repo, branch string, run *github.WorkflowRun) {
+	status := gc.getStatusValue(run.GetConclusion())
+	if status != 0 && status != 1 {
+		return
+	}
+
+	gc.window.add(state.WorkflowRun{
+		Org:         owner,
+		Repo:        repo,
+		Workflow:    run.GetName(),
+		Branch:      branch,
+		ID:          run.GetID(),
+		CompletedAt: run.GetUpdatedAt().Time,
+		Success:     status == 1,
+	}, time.Now())
+}
+
+// setSuccessRatios exports the share of successful runs of each workflow and
+// branch within the window. Workflows without runs in the window have no ratio.
+func (gc *GitHubCollector) setSuccessRatios() {
+	type workflowBranch struct {
+		org, repo, workflow, branch string
+	}
+
+	succeeded := make(map[workflowBranch]int)
+	completed := make(map[workflowBranch]int)
+
+	for _, run := range gc.window.prune(time.Now()) {
+		key := workflowBranch{run.Org, run.Repo, run.Workflow, run.Branch}
+
+		completed[key]++
+		if run.Success {
+			succeeded[key]++
+		}
+	}
+
+	gc.metrics.GitHubWorkflowSuccessRatio.Reset()
+
+	for key, total := range completed {
+		gc.metrics.GitHubWorkflowSuccessRatio.With(prometheus.Labels{
+			"org":      key.org,
+			"repo":     key.repo,
+			"workflow": key.workflow,
+			"branch":   key.branch,
+		}).Set(float64(succeeded[key]) / float64(total))
+	}
+}
@@ -0,0 +1,70 @@
+package collectors
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/d0ugal/github-exporter/internal/state"
+	"github.com/google/go-github/v76/github"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// windowRun returns a run of the CI workflow on main that completed ago
+func windowRun(id int64, conclusion string, ago time.Duration) *github.WorkflowRun {
+	return &github.WorkflowRun{
+		ID:         github.Ptr(id),
+		Name:       github.Ptr("CI"),
+		Conclusion: github.Ptr(conclusion),
+		UpdatedAt:  &github.Timestamp{Time: time.Now().Add(-ago)},
+	}
+}
+
+// TestSetSuccessRatios tests that the ratio only covers succeeded and failed runs
+// within the window, and that runs seen twice count once
+func TestSetSuccessRatios(t *testing.T) {
+	collector := createTestCollector()
+
+	runs := []*github.WorkflowRun{
+		windowRun(1, "success", time.Hour),
+		windowRun(1, "success", time.Hour), // Seen again, e.g. when backfilling
+		windowRun(2, "success", 2*time.Hour),
+		windowRun(3, "failure", 3*time.Hour),
+		windowRun(4, "skipped", 4*time.Hour),
+		windowRun(5, "failure", 25*time.Hour), // Outside the window
+	}
+
+	for _, run := range runs {
+		collector.addToRunWindow("d0ugal", "github-exporter", "main", run)
+	}
+
+	collector.setSuccessRatios()
+
+	ratio := testutil.ToFloat64(collector.metrics.GitHubWorkflowSuccessRatio.WithLabelValues("d0ugal", "github-exporter", "CI", "main"))
+	if ratio < 0.66 || ratio > 0.67 {
+		t.Errorf("Expected a ratio of 2/3, got %v", ratio)
+	}
+}
+
+// TestSuccessRatioWindowPersisted tests that the runs in the window survive a restart
+func TestSuccessRatioWindowPersisted(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+
+	previous := createTestCollector()
+	previous.state = state.NewStore(path)
+	previous.addToRunWindow("d0ugal", "github-exporter", "main", windowRun(1, "failure", time.Hour))
+	previous.saveSnapshot()
+
+	restarted := createTestCollector()
+	restarted.state = state.NewStore(path)
+	restarted.restoreSnapshot()
+	restarted.setSuccessRatios()
+
+	if got := testutil.ToFloat64(restarted.metrics.GitHubWorkflowSuccessRatio.WithLabelValues("d0ugal", "github-exporter", "CI", "main")); got != 0 {
+		t.Errorf("Expected the restored failure to give a ratio of 0, got %v", got)
+	}
+
+	if got := testutil.CollectAndCount(restarted.metrics.GitHubWorkflowSuccessRatio); got != 1 {
+		t.Errorf("Expected 1 ratio, got %d", got)
+	}
+}
@@ -0,0 +1,206 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/d0ugal/github-exporter/internal/config"
+	"github.com/google/go-github/v76/github"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// syntheticPollInterval is how often the run of a dispatched synthetic check is looked up
+	syntheticPollInterval = 30 * time.Second
+
+	// syntheticClockSkew widens the search for the dispatched run to tolerate
+	// clock drift between the exporter and GitHub
+	syntheticClockSkew = time.Minute
+)
+
+// startSyntheticChecks runs each configured synthetic check in the background
+// until ctx is cancelled
+func (gc *GitHubCollector) startSyntheticChecks(ctx context.Context) {
+	for _, check := range gc.config.GitHub.SyntheticChecks {
+		go gc.runSyntheticCheck(ctx, check)
+	}
+}
+
+// runSyntheticCheck dispatches the check's workflow right away and then every
+// interval. Checks are skipped while collection is paused.
+func (gc *GitHubCollector) runSyntheticCheck(ctx context.Context, check config.SyntheticCheck) {
+	ticker := time.NewTicker(check.Interval.Duration)
+	defer ticker.Stop()
+
+	for {
+		if !gc.updatePaused(time.Now()) {
+			gc.syntheticCheck(ctx, check, syntheticPollInterval)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// syntheticCheck dispatches the check's workflow, waits for its run to complete
+// and records the time it took and its result
+func (gc *GitHubCollector) syntheticCheck(ctx context.Context, check config.SyntheticCheck, pollInterval time.Duration) {
+	owner, repo, _ := strings.Cut(check.Repo, "/")
+	labels := prometheus.Labels{"org": owner, "repo": repo, "workflow": check.Workflow}
+
+	record := func(result string) {
+		gc.metrics.GitHubSyntheticWorkflowRuns.With(prometheus.Labels{
+			"org":      owner,
+			"repo":     repo,
+			"workflow": check.Workflow,
+			"result":   result,
+		}).Inc()
+	}
+
+	dispatched := time.Now()
+
+	if err := gc.dispatchWorkflow(ctx, owner, repo, check); err != nil {
+		slog.Error("Failed to dispatch synthetic check", "repo", check.Repo, "workflow", check.Workflow, "error", err)
+		record("error")
+
+		return
+	}
+
+	run, err := gc.awaitSyntheticRun(ctx, owner, repo, check, dispatched, pollInterval)
+	if err != nil {
+		if ctx.Err() == nil {
+			slog.Warn("Synthetic check did not complete", "repo", check.Repo, "workflow", check.Workflow, "timeout", check.Timeout.Duration, "error", err)
+			record("timeout")
+		}
+
+		return
+	}
+
+	// The run's last update is its completion; fall back to when it was seen
+	// completed if the clocks disagree
+	duration := run.GetUpdatedAt().Sub(dispatched)
+	if duration <= 0 {
+		duration = time.Since(dispatched)
+	}
+
+	gc.metrics.GitHubSyntheticWorkflowDuration.With(labels).Set(duration.Seconds())
+	record(run.GetConclusion())
+
+	slog.Debug("Synthetic check completed",
+		"repo", check.Repo,
+		"workflow", check.Workflow,
+		"run_id", run.GetID(),
+		"conclusion", run.GetConclusion(),
+		"duration", duration,
+	)
+}
+
+// dispatchWorkflow triggers a workflow_dispatch event for the check's workflow
+func (gc *GitHubCollector) dispatchWorkflow(ctx context.Context, owner, repo string, check config.SyntheticCheck) error {
+	t := target{Org: owner, Repo: repo}
+
+	// Wait for rate limiter
+	if err := gc.limiter.Wait(ctx); err != nil {
+		return wrapAPIError("workflow_dispatch", t, fmt.Errorf("rate limiter error: %w", err))
+	}
+
+	reqCtx, cancel := gc.requestContext(ctx, "workflow_dispatch")
+	_, err := gc.api.CreateWorkflowDispatchEventByFileName(reqCtx, owner, repo, check.Workflow, github.CreateWorkflowDispatchEventRequest{
+		Ref: check.Ref,
+	})
+	cancel()
+	if err != nil {
+		gc.recordAPIError("workflow_dispatch", err)
+		return wrapAPIError("workflow_dispatch", t, err)
+	}
+
+	return nil
+}
+
+// awaitSyntheticRun polls the runs of the check's workflow until the run created
+// by a dispatch at the given time completes, or the check's timeout passes.
+// The dispatch API does not return the run it creates, so the earliest
+// workflow_dispatch run on the ref created after the dispatch is assumed to be it.
+func (gc *GitHubCollector) awaitSyntheticRun(ctx context.Context, owner, repo string, check config.SyntheticCheck, dispatched time.Time, pollInterval time.Duration) (*github.WorkflowRun, error) {
+	ctx, cancel := context.WithDeadline(ctx, dispatched.Add(check.Timeout.Duration))
+	defer cancel()
+
+	timer := time.NewTimer(pollInterval)
+	defer timer.Stop()
+
+	var runID int64
+
+	for {
+		select {
+		case <-ctx.Done():
+			if runID == 0 {
+				return nil, fmt.Errorf("no run was created: %w", ctx.Err())
+			}
+
+			return nil, fmt.Errorf("run %d: %w", runID, ctx.Err())
+		case <-timer.C:
+		}
+
+		run, err := gc.findSyntheticRun(ctx, owner, repo, check, dispatched, runID)
+		if err != nil {
+			slog.Debug("Failed to look up synthetic check run", "repo", check.Repo, "workflow", check.Workflow, "error", err)
+		} else if run != nil {
+			runID = run.GetID()
+
+			if run.GetStatus() == "completed" {
+				return run, nil
+			}
+		}
+
+		timer.Reset(pollInterval)
+	}
+}
+
+// findSyntheticRun returns the run with the given ID, or the earliest
+// workflow_dispatch run created after dispatched when runID is 0. It returns nil
+// if there is no such run yet.
+func (gc *GitHubCollector) findSyntheticRun(ctx context.Context, owner, repo string, check config.SyntheticCheck, dispatched time.Time, runID int64) (*github.WorkflowRun, error) {
+	t := target{Org: owner, Repo: repo}
+
+	// Wait for rate limiter
+	if err := gc.limiter.Wait(ctx); err != nil {
+		return nil, wrapAPIError("workflow_runs", t, fmt.Errorf("rate limiter error: %w", err))
+	}
+
+	reqCtx, cancel := gc.requestContext(ctx, "workflow_runs")
+	runs, _, err := gc.api.ListWorkflowRunsByFileName(reqCtx, owner, repo, check.Workflow, &github.ListWorkflowRunsOptions{
+		Event:       "workflow_dispatch",
+		Branch:      check.Ref,
+		Created:     ">=" + dispatched.Add(-syntheticClockSkew).UTC().Format(time.RFC3339),
+		ListOptions: github.ListOptions{PerPage: 20},
+	})
+	cancel()
+	if err != nil {
+		gc.recordAPIError("workflow_runs", err)
+		return nil, wrapAPIError("workflow_runs", t, err)
+	}
+
+	var found *github.WorkflowRun
+
+	for _, run := range runs.WorkflowRuns {
+		if runID != 0 {
+			if run.GetID() == runID {
+				return run, nil
+			}
+
+			continue
+		}
+
+		if found == nil || run.GetID() < found.GetID() {
+			found = run
+		}
+	}
+
+	return found, nil
+}
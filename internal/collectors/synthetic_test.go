@@ -0,0 +1,111 @@
+package collectors
+
+import (
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/d0ugal/github-exporter/internal/config"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// testSyntheticCheck returns a synthetic check of d0ugal/canary
+func testSyntheticCheck(timeout time.Duration) config.SyntheticCheck {
+	return config.SyntheticCheck{
+		Repo:     "d0ugal/canary",
+		Workflow: "canary.yml",
+		Ref:      "main",
+		Interval: config.Duration{Duration: time.Hour},
+		Timeout:  config.Duration{Duration: timeout},
+	}
+}
+
+// TestSyntheticCheck tests that the dispatched run is followed until it
+// completes and its duration and conclusion are recorded
+func TestSyntheticCheck(t *testing.T) {
+	var polls atomic.Int32
+
+	collector := newAPITestCollector(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/d0ugal/canary/actions/workflows/canary.yml/dispatches":
+			if r.Method != http.MethodPost {
+				t.Errorf("Expected a POST, got %s", r.Method)
+			}
+
+			w.WriteHeader(http.StatusNoContent)
+		case "/repos/d0ugal/canary/actions/workflows/canary.yml/runs":
+			if r.URL.Query().Get("event") != "workflow_dispatch" || r.URL.Query().Get("branch") != "main" {
+				t.Errorf("Unexpected query %s", r.URL.RawQuery)
+			}
+
+			status := "in_progress"
+			if polls.Add(1) > 1 {
+				status = "completed"
+			}
+
+			updated := time.Now().Add(90 * time.Second).UTC().Format(time.RFC3339)
+			_, _ = fmt.Fprintf(w, `{"total_count": 2, "workflow_runs": [
+				{"id": 8, "status": "queued", "updated_at": %q},
+				{"id": 7, "status": %q, "conclusion": "success", "updated_at": %q}
+			]}`, updated, status, updated)
+		default:
+			t.Errorf("Unexpected request path %s", r.URL.Path)
+		}
+	})
+
+	collector.syntheticCheck(t.Context(), testSyntheticCheck(5*time.Second), time.Millisecond)
+
+	if got := polls.Load(); got != 2 {
+		t.Errorf("Expected 2 polls, got %d", got)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubSyntheticWorkflowRuns.WithLabelValues("d0ugal", "canary", "canary.yml", "success")); got != 1 {
+		t.Errorf("Expected 1 successful run, got %v", got)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubSyntheticWorkflowDuration.WithLabelValues("d0ugal", "canary", "canary.yml")); got < 85 || got > 95 {
+		t.Errorf("Expected a duration of about 90s, got %v", got)
+	}
+}
+
+// TestSyntheticCheckTimeout tests that runs not completing in time are counted as timed out
+func TestSyntheticCheckTimeout(t *testing.T) {
+	collector := newAPITestCollector(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"total_count": 1, "workflow_runs": [{"id": 7, "status": "queued"}]}`))
+	})
+
+	collector.syntheticCheck(t.Context(), testSyntheticCheck(50*time.Millisecond), time.Millisecond)
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubSyntheticWorkflowRuns.WithLabelValues("d0ugal", "canary", "canary.yml", "timeout")); got != 1 {
+		t.Errorf("Expected 1 timed out run, got %v", got)
+	}
+
+	if got := testutil.CollectAndCount(collector.metrics.GitHubSyntheticWorkflowDuration); got != 0 {
+		t.Errorf("Expected no duration, got %d series", got)
+	}
+}
+
+// TestSyntheticCheckDispatchError tests that failed dispatches are counted as errors
+func TestSyntheticCheckDispatchError(t *testing.T) {
+	collector := newAPITestCollector(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected no runs to be listed after a failed dispatch, got %s %s", r.Method, r.URL.Path)
+		}
+
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"message": "Workflow does not have 'workflow_dispatch' trigger"}`))
+	})
+
+	collector.syntheticCheck(t.Context(), testSyntheticCheck(time.Second), time.Millisecond)
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubSyntheticWorkflowRuns.WithLabelValues("d0ugal", "canary", "canary.yml", "error")); got != 1 {
+		t.Errorf("Expected 1 failed dispatch, got %v", got)
+	}
+}
@@ -0,0 +1,142 @@
+package collectors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/go-github/v76/github"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// setTagProtectionMetrics exports the rulesets targeting a repository's tags and
+// the tag patterns the active ones cover. Legacy tag protection rules were
+// migrated to rulesets by GitHub, so rulesets are the only source.
+func (gc *GitHubCollector) setTagProtectionMetrics(ctx context.Context, owner, repo string) error {
+	rulesets, err := gc.listTagRulesets(ctx, owner, repo)
+	if err != nil {
+		logError("Failed to get tag rulesets", err)
+		return err
+	}
+
+	labels := prometheus.Labels{"org": owner, "repo": repo}
+
+	// Counts are rebuilt every cycle so removed enforcement levels disappear
+	gc.metrics.GitHubTagRulesets.DeletePartialMatch(labels)
+
+	counts := make(map[github.RulesetEnforcement]int)
+	patterns := make(map[string]bool)
+
+	var errs []error
+
+	for _, ruleset := range rulesets {
+		counts[ruleset.Enforcement]++
+
+		if ruleset.Enforcement != github.RulesetEnforcementActive {
+			continue
+		}
+
+		// The list endpoint omits conditions, so they are read per ruleset
+		full, err := gc.getRuleset(ctx, owner, repo, ruleset.GetID())
+		if err != nil {
+			logError("Failed to get tag ruleset", err, "ruleset", ruleset.Name)
+			errs = append(errs, err)
+			continue
+		}
+
+		if full.Conditions != nil && full.Conditions.RefName != nil {
+			for _, pattern := range full.Conditions.RefName.Include {
+				patterns[pattern] = true
+			}
+		}
+	}
+
+	for enforcement, count := range counts {
+		gc.metrics.GitHubTagRulesets.With(prometheus.Labels{
+			"org":         owner,
+			"repo":        repo,
+			"enforcement": string(enforcement),
+		}).Set(float64(count))
+	}
+
+	gc.metrics.GitHubTagProtected.With(labels).Set(boolToFloat(counts[github.RulesetEnforcementActive] > 0))
+	gc.metrics.GitHubTagProtectionPatterns.With(labels).Set(float64(len(patterns)))
+
+	return errors.Join(errs...)
+}
+
+// listTagRulesets lists the rulesets that apply to a repository's tags,
+// including those configured for its organization
+func (gc *GitHubCollector) listTagRulesets(ctx context.Context, owner, repo string) ([]*github.RepositoryRuleset, error) {
+	rulesets, err := gc.listRulesets(ctx, owner, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	var tagRulesets []*github.RepositoryRuleset
+
+	for _, ruleset := range rulesets {
+		if ruleset.Target != nil && *ruleset.Target == github.RulesetTargetTag {
+			tagRulesets = append(tagRulesets, ruleset)
+		}
+	}
+
+	return tagRulesets, nil
+}
+
+// listRulesets lists the rulesets that apply to a repository, including those
+// configured for its organization
+func (gc *GitHubCollector) listRulesets(ctx context.Context, owner, repo string) ([]*github.RepositoryRuleset, error) {
+	t := target{Org: owner, Repo: repo}
+	opts := &github.RepositoryListRulesetsOptions{
+		IncludesParents: github.Ptr(true),
+		ListOptions:     github.ListOptions{PerPage: 100},
+	}
+
+	var all []*github.RepositoryRuleset
+
+	for page := 0; page < maxActivityPages; page++ {
+		// Wait for rate limiter
+		if err := gc.limiter.Wait(ctx); err != nil {
+			return nil, wrapAPIError("rulesets", t, fmt.Errorf("rate limiter error: %w", err))
+		}
+
+		reqCtx, cancel := gc.requestContext(ctx, "rulesets")
+		rulesets, resp, err := gc.api.GetAllRulesets(reqCtx, owner, repo, opts)
+		cancel()
+		if err != nil {
+			gc.recordAPIError("rulesets", err)
+			return nil, wrapAPIError("rulesets", t, err)
+		}
+
+		all = append(all, rulesets...)
+
+		if resp == nil || resp.NextPage == 0 {
+			break
+		}
+
+		opts.Page = resp.NextPage
+	}
+
+	return all, nil
+}
+
+// getRuleset returns a ruleset that applies to a repository with its conditions
+func (gc *GitHubCollector) getRuleset(ctx context.Context, owner, repo string, id int64) (*github.RepositoryRuleset, error) {
+	t := target{Org: owner, Repo: repo}
+
+	// Wait for rate limiter
+	if err := gc.limiter.Wait(ctx); err != nil {
+		return nil, wrapAPIError("rulesets", t, fmt.Errorf("rate limiter error: %w", err))
+	}
+
+	reqCtx, cancel := gc.requestContext(ctx, "rulesets")
+	ruleset, _, err := gc.api.GetRuleset(reqCtx, owner, repo, id, true)
+	cancel()
+	if err != nil {
+		gc.recordAPIError("rulesets", err)
+		return nil, wrapAPIError("rulesets", t, err)
+	}
+
+	return ruleset, nil
+}
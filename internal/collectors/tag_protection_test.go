@@ -0,0 +1,72 @@
+package collectors
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// TestSetTagProtectionMetrics tests counting tag rulesets by enforcement and the
+// patterns covered by the active ones
+func TestSetTagProtectionMetrics(t *testing.T) {
+	collector := newAPITestCollector(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/d0ugal/private/rulesets":
+			if got := r.URL.Query().Get("includes_parents"); got != "true" {
+				t.Errorf("Expected organization rulesets to be included, got includes_parents=%q", got)
+			}
+
+			_, _ = w.Write([]byte(`[
+				{"id": 1, "name": "releases", "target": "tag", "source_type": "Repository", "enforcement": "active"},
+				{"id": 2, "name": "org tags", "target": "tag", "source_type": "Organization", "enforcement": "active"},
+				{"id": 3, "name": "trial", "target": "tag", "source_type": "Repository", "enforcement": "evaluate"},
+				{"id": 4, "name": "main", "target": "branch", "source_type": "Repository", "enforcement": "active"}
+			]`))
+		case "/repos/d0ugal/private/rulesets/1":
+			_, _ = w.Write([]byte(`{"id": 1, "name": "releases", "target": "tag", "enforcement": "active",
+				"conditions": {"ref_name": {"include": ["refs/tags/v*", "refs/tags/release-*"], "exclude": []}}}`))
+		case "/repos/d0ugal/private/rulesets/2":
+			_, _ = w.Write([]byte(`{"id": 2, "name": "org tags", "target": "tag", "enforcement": "active",
+				"conditions": {"ref_name": {"include": ["refs/tags/v*"], "exclude": []}}}`))
+		default:
+			t.Errorf("Unexpected request path %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	collector.setTagProtectionMetrics(t.Context(), "d0ugal", "private")
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubTagProtected.WithLabelValues("d0ugal", "private")); got != 1 {
+		t.Errorf("Expected tags to be protected, got %v", got)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubTagRulesets.WithLabelValues("d0ugal", "private", "active")); got != 2 {
+		t.Errorf("Expected 2 active tag rulesets, got %v", got)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubTagRulesets.WithLabelValues("d0ugal", "private", "evaluate")); got != 1 {
+		t.Errorf("Expected 1 evaluate tag ruleset, got %v", got)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubTagProtectionPatterns.WithLabelValues("d0ugal", "private")); got != 2 {
+		t.Errorf("Expected 2 distinct patterns, got %v", got)
+	}
+}
+
+// TestSetTagProtectionMetricsUnprotected tests repositories without tag rulesets
+func TestSetTagProtectionMetricsUnprotected(t *testing.T) {
+	collector := newAPITestCollector(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[{"id": 4, "name": "main", "target": "branch", "enforcement": "active"}]`))
+	})
+
+	collector.setTagProtectionMetrics(t.Context(), "d0ugal", "private")
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubTagProtected.WithLabelValues("d0ugal", "private")); got != 0 {
+		t.Errorf("Expected tags to be unprotected, got %v", got)
+	}
+
+	if got := testutil.CollectAndCount(collector.metrics.GitHubTagRulesets); got != 0 {
+		t.Errorf("Expected no tag ruleset series, got %d", got)
+	}
+}
@@ -0,0 +1,166 @@
+package collectors
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/v76/github"
+)
+
+// requestIDHeader is the response header GitHub uses to identify a request
+const requestIDHeader = "X-GitHub-Request-Id"
+
+// target identifies the GitHub object an API call was made for
+type target struct {
+	Org    string
+	Repo   string
+	Branch string
+}
+
+// String returns the target in "org/repo@branch" form, omitting empty parts
+func (t target) String() string {
+	s := t.Org
+	if t.Repo != "" {
+		s += "/" + t.Repo
+	}
+
+	if t.Branch != "" {
+		s += "@" + t.Branch
+	}
+
+	return s
+}
+
+// TargetError wraps an error from the GitHub API with the target and response
+// context needed to debug it from the logs alone
+type TargetError struct {
+	Org        string
+	Repo       string
+	Branch     string
+	Endpoint   string
+	StatusCode int
+	RequestID  string
+	Err        error
+}
+
+// Error implements the error interface
+func (e *TargetError) Error() string {
+	var b strings.Builder
+
+	b.WriteString(e.Endpoint)
+
+	if t := (target{Org: e.Org, Repo: e.Repo, Branch: e.Branch}).String(); t != "" {
+		b.WriteString(" ")
+		b.WriteString(t)
+	}
+
+	if e.StatusCode != 0 {
+		fmt.Fprintf(&b, " (status %d", e.StatusCode)
+
+		if e.RequestID != "" {
+			fmt.Fprintf(&b, ", request ID %s", e.RequestID)
+		}
+
+		b.WriteString(")")
+	}
+
+	b.WriteString(": ")
+	b.WriteString(e.Err.Error())
+
+	return b.String()
+}
+
+// Unwrap returns the underlying error
+func (e *TargetError) Unwrap() error {
+	return e.Err
+}
+
+// LogAttrs returns the structured logging attributes for the error, omitting empty fields
+func (e *TargetError) LogAttrs() []any {
+	attrs := make([]any, 0, 14)
+
+	for _, field := range []struct{ key, value string }{
+		{"org", e.Org},
+		{"repo", e.Repo},
+		{"branch", e.Branch},
+		{"endpoint", e.Endpoint},
+	} {
+		if field.value != "" {
+			attrs = append(attrs, field.key, field.value)
+		}
+	}
+
+	if e.StatusCode != 0 {
+		attrs = append(attrs, "status_code", e.StatusCode)
+	}
+
+	if e.RequestID != "" {
+		attrs = append(attrs, "request_id", e.RequestID)
+	}
+
+	return append(attrs, "error", e.Err)
+}
+
+// apiErrorResponse returns the HTTP response attached to a GitHub API error, if any
+func apiErrorResponse(err error) *http.Response {
+	var rateLimitErr *github.RateLimitError
+	if errors.As(err, &rateLimitErr) && rateLimitErr.Response != nil {
+		return rateLimitErr.Response
+	}
+
+	var abuseErr *github.AbuseRateLimitError
+	if errors.As(err, &abuseErr) && abuseErr.Response != nil {
+		return abuseErr.Response
+	}
+
+	var errorResponse *github.ErrorResponse
+	if errors.As(err, &errorResponse) && errorResponse.Response != nil {
+		return errorResponse.Response
+	}
+
+	return nil
+}
+
+// wrapAPIError wraps err with the target and endpoint it occurred for, along with
+// the status code and request ID of the GitHub response when one is available.
+// Errors that are already wrapped are returned unchanged.
+func wrapAPIError(endpoint string, t target, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var existing *TargetError
+	if errors.As(err, &existing) {
+		return err
+	}
+
+	targetErr := &TargetError{
+		Org:      t.Org,
+		Repo:     t.Repo,
+		Branch:   t.Branch,
+		Endpoint: endpoint,
+		Err:      err,
+	}
+
+	if resp := apiErrorResponse(err); resp != nil {
+		targetErr.StatusCode = resp.StatusCode
+		targetErr.RequestID = resp.Header.Get(requestIDHeader)
+	}
+
+	return targetErr
+}
+
+// logError logs err at error level, including the target context when err is a
+// TargetError. Additional attributes are appended after the target context.
+func logError(msg string, err error, attrs ...any) {
+	var targetErr *TargetError
+	if errors.As(err, &targetErr) {
+		slog.Error(msg, append(targetErr.LogAttrs(), attrs...)...)
+		return
+	}
+
+	slog.Error(msg, append(attrs, "error", err)...)
+}
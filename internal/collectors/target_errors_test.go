@@ -0,0 +1,69 @@
+package collectors
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-github/v76/github"
+)
+
+// TestWrapAPIError tests that API errors are wrapped with target and response context
+func TestWrapAPIError(t *testing.T) {
+	header := http.Header{}
+	header.Set(requestIDHeader, "ABCD:1234")
+
+	apiErr := &github.ErrorResponse{
+		Response: &http.Response{StatusCode: http.StatusNotFound, Header: header},
+		Message:  "Not Found",
+	}
+
+	err := wrapAPIError("workflow_runs", target{Org: "d0ugal", Repo: "repo", Branch: "main"}, fmt.Errorf("failed: %w", apiErr))
+
+	var targetErr *TargetError
+	if !errors.As(err, &targetErr) {
+		t.Fatalf("Expected TargetError, got %T", err)
+	}
+
+	if targetErr.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected status 404, got %d", targetErr.StatusCode)
+	}
+
+	if targetErr.RequestID != "ABCD:1234" {
+		t.Errorf("Expected request ID ABCD:1234, got %q", targetErr.RequestID)
+	}
+
+	if !errors.As(err, &apiErr) {
+		t.Error("Expected wrapped error to unwrap to the GitHub error")
+	}
+
+	want := "workflow_runs d0ugal/repo@main (status 404, request ID ABCD:1234): failed: "
+	if got := err.Error(); len(got) < len(want) || got[:len(want)] != want {
+		t.Errorf("Unexpected error message %q", got)
+	}
+
+	// Wrapping again keeps the original context
+	if again := wrapAPIError("repos", target{Org: "other"}, err); again != err {
+		t.Error("Expected already wrapped error to be returned unchanged")
+	}
+}
+
+// TestTargetErrorLogAttrs tests that empty fields are omitted from log attributes
+func TestTargetErrorLogAttrs(t *testing.T) {
+	err := wrapAPIError("orgs", target{Org: "d0ugal"}, errors.New("connection refused"))
+
+	var targetErr *TargetError
+	if !errors.As(err, &targetErr) {
+		t.Fatalf("Expected TargetError, got %T", err)
+	}
+
+	attrs := targetErr.LogAttrs()
+	if len(attrs) != 6 {
+		t.Fatalf("Expected org, endpoint and error attributes, got %v", attrs)
+	}
+
+	if attrs[0] != "org" || attrs[1] != "d0ugal" || attrs[2] != "endpoint" || attrs[3] != "orgs" {
+		t.Errorf("Unexpected attributes %v", attrs)
+	}
+}
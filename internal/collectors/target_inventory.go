@@ -0,0 +1,64 @@
+package collectors
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Target types reported by github_exporter_targets
+var inventoryTargetTypes = []string{"org", "repo", "branch", "workflow", "user"}
+
+// cycleTargets records the distinct targets monitored during a collection cycle,
+// after wildcard, team and starred expansion and collector switches are applied
+type cycleTargets struct {
+	mu      sync.Mutex
+	targets map[string]map[string]struct{} // type -> target
+}
+
+func newCycleTargets() *cycleTargets {
+	ct := &cycleTargets{}
+	ct.begin()
+
+	return ct
+}
+
+// begin starts recording a new collection cycle
+func (ct *cycleTargets) begin() {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+
+	ct.targets = make(map[string]map[string]struct{}, len(inventoryTargetTypes))
+}
+
+// add records a monitored target
+func (ct *cycleTargets) add(targetType, target string) {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+
+	if ct.targets[targetType] == nil {
+		ct.targets[targetType] = make(map[string]struct{})
+	}
+
+	ct.targets[targetType][target] = struct{}{}
+}
+
+// counts returns the number of distinct targets recorded per type
+func (ct *cycleTargets) counts() map[string]int {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+
+	counts := make(map[string]int, len(inventoryTargetTypes))
+	for _, targetType := range inventoryTargetTypes {
+		counts[targetType] = len(ct.targets[targetType])
+	}
+
+	return counts
+}
+
+// setTargetMetrics exports the number of targets monitored in the completed cycle
+func (gc *GitHubCollector) setTargetMetrics() {
+	for targetType, count := range gc.cycle.counts() {
+		gc.metrics.GitHubExporterTargets.With(prometheus.Labels{"type": targetType}).Set(float64(count))
+	}
+}
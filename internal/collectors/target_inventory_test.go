@@ -0,0 +1,53 @@
+package collectors
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// TestSetTargetMetrics tests exporting the distinct targets of a collection cycle
+func TestSetTargetMetrics(t *testing.T) {
+	collector := createTestCollector()
+
+	collector.cycle.add("org", "old-org")
+	collector.cycle.begin()
+
+	collector.cycle.add("org", "d0ugal")
+	collector.cycle.add("repo", "d0ugal/github-exporter")
+	collector.cycle.add("repo", "d0ugal/github-exporter")
+	collector.cycle.add("repo", "d0ugal/mqtt-exporter")
+	collector.cycle.add("branch", "d0ugal/github-exporter@main")
+
+	collector.setTargetMetrics()
+
+	for targetType, expected := range map[string]float64{"org": 1, "repo": 2, "branch": 1, "workflow": 0} {
+		if got := testutil.ToFloat64(collector.metrics.GitHubExporterTargets.WithLabelValues(targetType)); got != expected {
+			t.Errorf("Expected %v %s targets, got %v", expected, targetType, got)
+		}
+	}
+}
+
+// TestTargetMetricsDegradedCycle tests that a degraded cycle keeps the target counts of the last full cycle
+func TestTargetMetricsDegradedCycle(t *testing.T) {
+	collector := createTestCollector()
+
+	collector.cycle.begin()
+	collector.cycle.add("org", "d0ugal")
+	collector.cycle.add("repo", "d0ugal/github-exporter")
+	collector.cycle.add("branch", "d0ugal/github-exporter@main")
+	collector.cycle.add("branch", "d0ugal/github-exporter@develop")
+	collector.finishCycle(nil, time.Now())
+
+	collector.degraded = true
+	collector.cycle.begin()
+	collector.cycle.add("branch", "d0ugal/github-exporter@main")
+	collector.finishCycle(nil, time.Now())
+
+	for targetType, expected := range map[string]float64{"org": 1, "repo": 1, "branch": 2} {
+		if got := testutil.ToFloat64(collector.metrics.GitHubExporterTargets.WithLabelValues(targetType)); got != expected {
+			t.Errorf("Expected %v %s targets after a degraded cycle, got %v", expected, targetType, got)
+		}
+	}
+}
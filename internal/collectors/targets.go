@@ -0,0 +1,149 @@
+package collectors
+
+import (
+	"log/slog"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Sources of dynamic targets
+const (
+	TargetSourceKubernetes = "kubernetes"
+	TargetSourceApp        = "app"
+)
+
+// Names of GitHub accounts and repositories accepted from dynamic sources
+var (
+	accountNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9-]*$`)
+	repoNamePattern    = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+)
+
+// dynamicTargets holds targets discovered at runtime, in addition to the configured
+// ones, keyed by the source that discovered them
+type dynamicTargets struct {
+	mu       sync.RWMutex
+	orgs     map[string][]string
+	repos    map[string][]string
+	rejected map[string]bool // Rejected targets already logged, by source, kind and name
+}
+
+// SetDynamicTargets replaces the organizations and repositories discovered at
+// runtime by source. They are collected alongside the statically configured
+// targets from the next cycle.
+func (gc *GitHubCollector) SetDynamicTargets(source string, orgs, repos []string) {
+	gc.dynamic.mu.Lock()
+	defer gc.dynamic.mu.Unlock()
+
+	slog.Debug("Updating dynamic targets", "source", source, "orgs", len(orgs), "repos", len(repos))
+
+	if gc.dynamic.orgs == nil {
+		gc.dynamic.orgs = make(map[string][]string)
+		gc.dynamic.repos = make(map[string][]string)
+		gc.dynamic.rejected = make(map[string]bool)
+	}
+
+	gc.dynamic.orgs[source] = gc.dynamic.valid(source, "org", orgs, validOrgTarget)
+	gc.dynamic.repos[source] = gc.dynamic.valid(source, "repo", repos, validRepoTarget)
+}
+
+// valid returns the targets that pass validate. Dynamic targets come from
+// outside the configuration, e.g. namespace annotations, so they must not turn
+// on wildcard discovery or fail every cycle. Each rejection is logged once.
+func (dt *dynamicTargets) valid(source, kind string, targets []string, validate func(string) bool) []string {
+	accepted := make([]string, 0, len(targets))
+
+	for _, target := range targets {
+		if validate(target) {
+			accepted = append(accepted, target)
+			continue
+		}
+
+		key := source + "/" + kind + "/" + target
+		if !dt.rejected[key] {
+			dt.rejected[key] = true
+			slog.Warn("Ignoring invalid dynamic target", "source", source, "kind", kind, "target", target)
+		}
+	}
+
+	return accepted
+}
+
+// validOrgTarget reports whether an organization target is an account name
+func validOrgTarget(org string) bool {
+	return accountNamePattern.MatchString(org)
+}
+
+// validRepoTarget reports whether a repository target is in "owner/repo" form
+func validRepoTarget(fullName string) bool {
+	owner, repo, ok := strings.Cut(fullName, "/")
+
+	return ok && accountNamePattern.MatchString(owner) &&
+		repoNamePattern.MatchString(repo) && repo != "." && repo != ".."
+}
+
+// targetOrgs returns the configured organizations followed by any discovered
+// ones, without organizations skipped because they do not exist
+func (gc *GitHubCollector) targetOrgs() []string {
+	gc.dynamic.mu.RLock()
+	defer gc.dynamic.mu.RUnlock()
+
+	return gc.skipMissing("org", mergeTargets(gc.config.GitHub.Orgs, bySource(gc.dynamic.orgs)...))
+}
+
+// targetRepos returns the configured repositories followed by any discovered
+// ones, with repositories that were renamed or transferred under their new name
+// and without repositories skipped because they do not exist
+func (gc *GitHubCollector) targetRepos() []string {
+	gc.dynamic.mu.RLock()
+	defer gc.dynamic.mu.RUnlock()
+
+	return gc.skipMissing("repo", gc.renames.resolve(mergeTargets(gc.config.GitHub.Repos, bySource(gc.dynamic.repos)...)))
+}
+
+// bySource returns the discovered target lists ordered by source name, so the
+// merged order is stable
+func bySource(discovered map[string][]string) [][]string {
+	sources := make([]string, 0, len(discovered))
+	for source := range discovered {
+		sources = append(sources, source)
+	}
+
+	sort.Strings(sources)
+
+	lists := make([][]string, 0, len(sources))
+	for _, source := range sources {
+		lists = append(lists, discovered[source])
+	}
+
+	return lists
+}
+
+// mergeTargets appends discovered targets to configured ones, skipping duplicates
+func mergeTargets(configured []string, discovered ...[]string) []string {
+	total := len(configured)
+	for _, list := range discovered {
+		total += len(list)
+	}
+
+	if total == len(configured) {
+		return configured
+	}
+
+	seen := make(map[string]bool, total)
+	merged := make([]string, 0, total)
+
+	for _, list := range append([][]string{configured}, discovered...) {
+		for _, target := range list {
+			if seen[target] {
+				continue
+			}
+
+			seen[target] = true
+			merged = append(merged, target)
+		}
+	}
+
+	return merged
+}
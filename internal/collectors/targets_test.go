@@ -0,0 +1,52 @@
+package collectors
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestDynamicTargets tests that discovered targets are merged with configured ones
+func TestDynamicTargets(t *testing.T) {
+	collector := createTestCollector()
+	collector.config.GitHub.Orgs = []string{"d0ugal"}
+	collector.config.GitHub.Repos = []string{"d0ugal/a"}
+
+	if got := collector.targetRepos(); !reflect.DeepEqual(got, []string{"d0ugal/a"}) {
+		t.Errorf("Expected configured repos only, got %v", got)
+	}
+
+	collector.SetDynamicTargets(TargetSourceKubernetes, []string{"team-b", "d0ugal"}, []string{"team-a/api", "d0ugal/a"})
+	collector.SetDynamicTargets(TargetSourceApp, []string{"team-c"}, []string{"team-a/api"})
+
+	if got := collector.targetOrgs(); !reflect.DeepEqual(got, []string{"d0ugal", "team-c", "team-b"}) {
+		t.Errorf("Expected merged orgs, got %v", got)
+	}
+
+	if got := collector.targetRepos(); !reflect.DeepEqual(got, []string{"d0ugal/a", "team-a/api"}) {
+		t.Errorf("Expected merged repos, got %v", got)
+	}
+}
+
+// TestDynamicTargetsInvalid tests that wildcards and malformed targets from
+// dynamic sources are rejected
+func TestDynamicTargetsInvalid(t *testing.T) {
+	collector := createTestCollector()
+	collector.config.GitHub.Repos = []string{"d0ugal/a"}
+
+	collector.SetDynamicTargets(TargetSourceKubernetes,
+		[]string{"team-b", "*", "team/b", ""},
+		[]string{"team-a/api", "*", "team-a/*", "team-a", "team-a/api/x", "/api", "team-a/.."},
+	)
+
+	if got := collector.targetOrgs(); !reflect.DeepEqual(got, []string{"team-b"}) {
+		t.Errorf("Expected only the valid org, got %v", got)
+	}
+
+	if got := collector.targetRepos(); !reflect.DeepEqual(got, []string{"d0ugal/a", "team-a/api"}) {
+		t.Errorf("Expected only the valid repo, got %v", got)
+	}
+
+	if collector.hasWildcardRepos() {
+		t.Error("Expected a dynamic wildcard not to enable wildcard discovery")
+	}
+}
@@ -0,0 +1,142 @@
+package collectors
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/d0ugal/github-exporter/internal/metrics"
+	"github.com/google/go-github/v76/github"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// rateLimitResourceHeader is the response header naming the rate limit bucket a request counted against
+const rateLimitResourceHeader = "X-RateLimit-Resource"
+
+// endpointKey is the context key of the endpoint label of a request
+type endpointKey struct{}
+
+// withEndpoint returns a context labelling the requests made with it as endpoint
+func withEndpoint(ctx context.Context, endpoint string) context.Context {
+	return context.WithValue(ctx, endpointKey{}, endpoint)
+}
+
+// endpointOf returns the endpoint label of a request, "other" if it has none
+func endpointOf(req *http.Request) string {
+	if endpoint, ok := req.Context().Value(endpointKey{}).(string); ok {
+		return endpoint
+	}
+
+	return "other"
+}
+
+// instrumentedTransport records the outcome, latency and response details of
+// every GitHub API request, independent of which collector made the call. The
+// endpoint label is taken from the request context, see requestContext.
+type instrumentedTransport struct {
+	base    http.RoundTripper
+	metrics *metrics.GitHubRegistry
+
+	// onRateLimit receives the rate limit reported with each response, by resource
+	onRateLimit func(resource string, r github.Rate)
+
+	// onRequest is called with the endpoint of every request made
+	onRequest func(endpoint string)
+
+	// onTokenExpiration receives the expiration of the token, when it expires
+	onTokenExpiration func(time.Time)
+}
+
+// newInstrumentedTransport wraps base, falling back to http.DefaultTransport when nil
+func newInstrumentedTransport(base http.RoundTripper, metricsRegistry *metrics.GitHubRegistry) *instrumentedTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &instrumentedTransport{
+		base:    base,
+		metrics: metricsRegistry,
+	}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *instrumentedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	endpoint := endpointOf(req)
+
+	if t.onRequest != nil {
+		t.onRequest(endpoint)
+	}
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+
+	t.metrics.GitHubAPIRequestDuration.With(prometheus.Labels{
+		"endpoint": endpoint,
+	}).Observe(time.Since(start).Seconds())
+
+	status := "none"
+	if resp != nil {
+		status = strconv.Itoa(resp.StatusCode)
+	}
+
+	t.metrics.GitHubAPICallsTotal.With(prometheus.Labels{
+		"endpoint": endpoint,
+		"status":   status,
+	}).Inc()
+
+	if resp == nil {
+		return resp, err
+	}
+
+	requestID := resp.Header.Get(requestIDHeader)
+
+	if r, ok := parseRate(resp.Header); ok && t.onRateLimit != nil {
+		t.onRateLimit(resp.Header.Get(rateLimitResourceHeader), r)
+	}
+
+	if expiration, ok := parseTokenExpiration(resp.Header); ok {
+		t.metrics.GitHubTokenExpiration.With(prometheus.Labels{}).Set(float64(expiration.Unix()))
+
+		if t.onTokenExpiration != nil {
+			t.onTokenExpiration(expiration)
+		}
+	}
+
+	resource := resp.Header.Get(rateLimitResourceHeader)
+	if resource == "" {
+		resource = "none"
+	}
+
+	t.metrics.GitHubAPIResourceTotal.With(prometheus.Labels{
+		"resource": resource,
+	}).Inc()
+
+	// Attach the request ID to the active collector span so traces can be matched
+	// with GitHub's own records
+	if span := trace.SpanFromContext(req.Context()); span.IsRecording() {
+		span.AddEvent("github_api_response",
+			trace.WithAttributes(
+				attribute.String("http.method", req.Method),
+				attribute.String("http.path", req.URL.Path),
+				attribute.Int("http.status_code", resp.StatusCode),
+				attribute.String("github.request_id", requestID),
+				attribute.String("github.rate_limit_resource", resource),
+			),
+		)
+	}
+
+	slog.Debug("GitHub API response",
+		"endpoint", endpoint,
+		"method", req.Method,
+		"path", req.URL.Path,
+		"status_code", resp.StatusCode,
+		"request_id", requestID,
+		"resource", resource,
+	)
+
+	return resp, err
+}
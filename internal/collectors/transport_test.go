@@ -0,0 +1,93 @@
+package collectors
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/d0ugal/github-exporter/internal/metrics"
+	promexporter_metrics "github.com/d0ugal/promexporter/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// TestInstrumentedTransportCountsResources tests that responses are counted by rate limit resource
+func TestInstrumentedTransportCountsResources(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/search/issues" {
+			w.Header().Set(rateLimitResourceHeader, "search")
+		}
+
+		w.Header().Set(requestIDHeader, "ABCD:1234")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	registry := metrics.NewGitHubRegistry(promexporter_metrics.NewRegistry("github_exporter_info"))
+	client := &http.Client{Transport: newInstrumentedTransport(nil, registry)}
+
+	for _, path := range []string{"/search/issues", "/search/issues", "/repos/d0ugal/repo"} {
+		resp, err := client.Get(server.URL + path)
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+
+		_ = resp.Body.Close()
+	}
+
+	if got := testutil.ToFloat64(registry.GitHubAPIResourceTotal.WithLabelValues("search")); got != 2 {
+		t.Errorf("Expected 2 search requests, got %v", got)
+	}
+
+	if got := testutil.ToFloat64(registry.GitHubAPIResourceTotal.WithLabelValues("none")); got != 1 {
+		t.Errorf("Expected 1 request without resource header, got %v", got)
+	}
+}
+
+// TestInstrumentedTransportCountsCalls tests that every request is counted and
+// timed under the endpoint of its context, including failed ones
+func TestInstrumentedTransportCountsCalls(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/repos/d0ugal/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	registry := metrics.NewGitHubRegistry(promexporter_metrics.NewRegistry("github_exporter_info"))
+	client := &http.Client{Transport: newInstrumentedTransport(nil, registry)}
+
+	for _, path := range []string{"/repos/d0ugal/repo", "/repos/d0ugal/missing", "/rate_limit"} {
+		ctx := t.Context()
+		if path != "/rate_limit" {
+			ctx = withEndpoint(ctx, "repos")
+		}
+
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+path, nil)
+
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+
+		_ = resp.Body.Close()
+	}
+
+	for _, tt := range []struct {
+		endpoint, status string
+	}{
+		{"repos", "200"},
+		{"repos", "404"},
+		{"other", "200"},
+	} {
+		if got := testutil.ToFloat64(registry.GitHubAPICallsTotal.WithLabelValues(tt.endpoint, tt.status)); got != 1 {
+			t.Errorf("Expected 1 %s call with status %s, got %v", tt.endpoint, tt.status, got)
+		}
+	}
+
+	if got := testutil.CollectAndCount(registry.GitHubAPIRequestDuration); got != 2 {
+		t.Errorf("Expected request durations for 2 endpoints, got %d", got)
+	}
+}
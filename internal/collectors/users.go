@@ -0,0 +1,68 @@
+package collectors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// collectUserMetrics collects the public profile counts of the configured
+// users, counting the followers each gained and lost since the last collection
+func (gc *GitHubCollector) collectUserMetrics(ctx context.Context) error {
+	var errs []error
+
+	for _, user := range prioritize(gc.skipped, "user", gc.skipMissing("user", gc.config.GitHub.Users), identity) {
+		if gc.pastDeadline(ctx, "user", user) {
+			continue
+		}
+
+		gc.cycle.add("user", user)
+
+		err := gc.collectUser(ctx, user)
+		gc.status.record("user", user, err)
+
+		if err != nil {
+			gc.logTargetError("Failed to get user info", "user", user, err)
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// collectUser exports the follower, following and public repository counts of a user
+func (gc *GitHubCollector) collectUser(ctx context.Context, user string) error {
+	t := target{Org: user}
+
+	// Wait for rate limiter
+	if err := gc.limiter.Wait(ctx); err != nil {
+		return wrapAPIError("users", t, fmt.Errorf("rate limiter error: %w", err))
+	}
+
+	reqCtx, cancel := gc.requestContext(ctx, "users")
+	info, _, err := gc.api.GetUser(reqCtx, user)
+	cancel()
+	if err != nil {
+		gc.recordAPIError("users", err)
+		return wrapAPIError("users", t, err)
+	}
+
+	labels := prometheus.Labels{"user": user}
+
+	if info.Followers != nil && gc.plausibleCount("user_followers", t, *info.Followers) {
+		gc.metrics.GitHubUserFollowers.With(labels).Set(float64(*info.Followers))
+		gc.observeFollowers(followerKindUser, user, *info.Followers)
+	}
+
+	if info.Following != nil && gc.plausibleCount("user_following", t, *info.Following) {
+		gc.metrics.GitHubUserFollowing.With(labels).Set(float64(*info.Following))
+	}
+
+	if info.PublicRepos != nil && gc.plausibleCount("user_public_repos", t, *info.PublicRepos) {
+		gc.metrics.GitHubUserPublicRepos.With(labels).Set(float64(*info.PublicRepos))
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package collectors
+
+import (
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/d0ugal/github-exporter/internal/state"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// TestCollectUserMetrics tests that user profile counts are exported and
+// follower changes are counted, separately from organizations of the same name
+func TestCollectUserMetrics(t *testing.T) {
+	followers := 100
+	collector := newAPITestCollector(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/users/octocat" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		_, _ = fmt.Fprintf(w, `{"login": "octocat", "followers": %d, "following": 9, "public_repos": 8}`, followers)
+	})
+	collector.config.GitHub.Users = []string{"octocat", "ghost"}
+
+	for _, followers = range []int{100, 110, 104} {
+		if err := collector.collectUserMetrics(t.Context()); err == nil {
+			t.Error("Expected an error for the missing user")
+		}
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubUserFollowers.WithLabelValues("octocat")); got != 104 {
+		t.Errorf("Expected 104 followers, got %v", got)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubUserPublicRepos.WithLabelValues("octocat")); got != 8 {
+		t.Errorf("Expected 8 public repositories, got %v", got)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubUserFollowersGained.WithLabelValues("octocat")); got != 10 {
+		t.Errorf("Expected 10 followers gained, got %v", got)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubUserFollowersLost.WithLabelValues("octocat")); got != 6 {
+		t.Errorf("Expected 6 followers lost, got %v", got)
+	}
+
+	if got := testutil.CollectAndCount(collector.metrics.GitHubOrgFollowersGained); got != 0 {
+		t.Errorf("Expected no organization follower counters, got %d", got)
+	}
+}
+
+// TestUserFollowersPersisted tests that user follower counters are restored as
+// user counters after a restart
+func TestUserFollowersPersisted(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+
+	previous := createTestCollector()
+	previous.state = state.NewStore(path)
+	previous.observeFollowers(followerKindUser, "octocat", 100)
+	previous.observeFollowers(followerKindUser, "octocat", 105)
+	previous.observeFollowers(followerKindOrg, "d0ugal", 10)
+	previous.observeFollowers(followerKindOrg, "d0ugal", 12)
+	previous.saveSnapshot()
+
+	restarted := createTestCollector()
+	restarted.state = state.NewStore(path)
+	restarted.restoreSnapshot()
+
+	if got := testutil.ToFloat64(restarted.metrics.GitHubUserFollowersGained.WithLabelValues("octocat")); got != 5 {
+		t.Errorf("Expected the restored 5 followers gained by the user, got %v", got)
+	}
+
+	if got := testutil.ToFloat64(restarted.metrics.GitHubOrgFollowersGained.WithLabelValues("d0ugal")); got != 2 {
+		t.Errorf("Expected the restored 2 followers gained by the organization, got %v", got)
+	}
+}
@@ -0,0 +1,141 @@
+package collectors
+
+import (
+	"slices"
+
+	"github.com/google/go-github/v76/github"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// ApplyWorkflowRun updates the status of a workflow run on a monitored branch as
+// soon as a webhook delivery reports it. The branch build status is recomputed
+// from the branch's recent runs at the next poll.
+func (gc *GitHubCollector) ApplyWorkflowRun(event *github.WorkflowRunEvent) {
+	run := event.GetWorkflowRun()
+	branch := run.GetHeadBranch()
+
+	owner, repo, ok := gc.monitoredBranch(event.GetRepo(), branch)
+	if !ok || run.GetName() == "" || !gc.config.Collectors.BuildStatusEnabled() {
+		return
+	}
+
+	conclusion := run.GetConclusion()
+	if conclusion == "" {
+		conclusion = "unknown"
+	}
+
+	gc.metrics.GitHubWorkflowRunStatus.With(prometheus.Labels{
+		"org":        owner,
+		"repo":       repo,
+		"workflow":   run.GetName(),
+		"branch":     branch,
+		"conclusion": conclusion,
+	}).Set(gc.getStatusValue(conclusion))
+
+	gc.setLatestRunInfo(owner, repo, branch, run)
+}
+
+// ApplyCheckRun updates the status of a check run on a monitored branch as soon
+// as a webhook delivery reports it. When only required checks are reported,
+// deliveries are left to the next poll, which knows which checks are required.
+func (gc *GitHubCollector) ApplyCheckRun(event *github.CheckRunEvent) {
+	checkRun := event.GetCheckRun()
+	branch := checkRun.GetCheckSuite().GetHeadBranch()
+
+	owner, repo, ok := gc.monitoredBranch(event.GetRepo(), branch)
+	if !ok || checkRun.GetName() == "" || !gc.config.Collectors.CheckRunsEnabled() || gc.config.GitHub.BuildStatus.RequiredOnly {
+		return
+	}
+
+	conclusion := checkRun.GetConclusion()
+	if conclusion == "" {
+		conclusion = "unknown"
+	}
+
+	// The delivery supersedes the check's earlier runs
+	if gc.config.GitHub.BuildStatus.LatestCheckSuite {
+		gc.metrics.GitHubCheckRunStatus.DeletePartialMatch(prometheus.Labels{
+			"org":        owner,
+			"repo":       repo,
+			"check_name": checkRun.GetName(),
+			"branch":     branch,
+		})
+	}
+
+	gc.metrics.GitHubCheckRunStatus.With(prometheus.Labels{
+		"org":        owner,
+		"repo":       repo,
+		"check_name": checkRun.GetName(),
+		"branch":     branch,
+		"conclusion": conclusion,
+	}).Set(gc.getStatusValue(conclusion))
+}
+
+// ApplyIssues adjusts the open issue count of a monitored repository when an
+// issue is opened, closed, reopened or removed
+func (gc *GitHubCollector) ApplyIssues(event *github.IssuesEvent) {
+	delta := 0.0
+
+	switch event.GetAction() {
+	case "opened", "reopened":
+		delta = 1
+	case "closed":
+		delta = -1
+	case "deleted", "transferred":
+		if event.GetIssue().GetState() == "open" {
+			delta = -1
+		}
+	}
+
+	gc.adjustOpenCount(event.GetRepo(), delta, false)
+}
+
+// ApplyPullRequest adjusts the open pull request count of a monitored repository
+// when a pull request is opened, closed or reopened. GitHub counts pull requests
+// as issues, so the open issue count is adjusted too.
+func (gc *GitHubCollector) ApplyPullRequest(event *github.PullRequestEvent) {
+	delta := 0.0
+
+	switch event.GetAction() {
+	case "opened", "reopened":
+		delta = 1
+	case "closed":
+		delta = -1
+	}
+
+	gc.adjustOpenCount(event.GetRepo(), delta, true)
+}
+
+// adjustOpenCount adds delta to the open issue count of a monitored repository,
+// and to its open pull request count for pull requests
+func (gc *GitHubCollector) adjustOpenCount(repository *github.Repository, delta float64, pullRequest bool) {
+	owner, repo := repository.GetOwner().GetLogin(), repository.GetName()
+	if delta == 0 || !gc.inventory.has(owner, repo) {
+		return
+	}
+
+	visibility := "public"
+	if repository.GetPrivate() {
+		visibility = "private"
+	}
+
+	t := target{Org: owner, Repo: repo}
+	labels := prometheus.Labels{"org": owner, "repo": repo, "visibility": visibility}
+	gc.adjustCount(gc.metrics.GitHubReposOpenIssues.With(labels), "repo_open_issues", t, delta)
+
+	if pullRequest && gc.config.Collectors.PullRequestsEnabled() {
+		gc.adjustCount(gc.metrics.GitHubReposOpenPRs.With(labels), "repo_open_prs", t, delta)
+	}
+}
+
+// monitoredBranch returns the owner and name of a repository if it is monitored
+// and branch is one of the branches whose build status is collected
+func (gc *GitHubCollector) monitoredBranch(repository *github.Repository, branch string) (string, string, bool) {
+	owner, repo := repository.GetOwner().GetLogin(), repository.GetName()
+
+	if !gc.inventory.has(owner, repo) || !slices.Contains(gc.config.GitHub.Branches, branch) {
+		return "", "", false
+	}
+
+	return owner, repo, true
+}
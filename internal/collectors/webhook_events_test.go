@@ -0,0 +1,122 @@
+package collectors
+
+import (
+	"testing"
+
+	"github.com/google/go-github/v76/github"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// newWebhookTestCollector returns a collector monitoring the main branch of d0ugal/private
+func newWebhookTestCollector() *GitHubCollector {
+	collector := createTestCollector()
+	collector.config.GitHub.Branches = []string{"main"}
+	collector.inventory.add("d0ugal", "private", "private", &github.Repository{})
+
+	return collector
+}
+
+// testWebhookRepo returns the repository of webhook payloads
+func testWebhookRepo(name string) *github.Repository {
+	return &github.Repository{
+		Name:    github.Ptr(name),
+		Owner:   &github.User{Login: github.Ptr("d0ugal")},
+		Private: github.Ptr(true),
+	}
+}
+
+// TestApplyWorkflowRun tests that workflow runs on monitored branches update their status
+func TestApplyWorkflowRun(t *testing.T) {
+	collector := newWebhookTestCollector()
+
+	for _, branch := range []string{"main", "feature"} {
+		collector.ApplyWorkflowRun(&github.WorkflowRunEvent{
+			Action: github.Ptr("completed"),
+			Repo:   testWebhookRepo("private"),
+			WorkflowRun: &github.WorkflowRun{
+				ID:         github.Ptr(int64(7)),
+				Name:       github.Ptr("CI"),
+				HeadBranch: github.Ptr(branch),
+				Conclusion: github.Ptr("failure"),
+			},
+		})
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubWorkflowRunStatus.WithLabelValues("d0ugal", "private", "CI", "main", "failure")); got != 0 {
+		t.Errorf("Expected a failed run, got %v", got)
+	}
+
+	if got := testutil.CollectAndCount(collector.metrics.GitHubWorkflowRunStatus); got != 1 {
+		t.Errorf("Expected only the monitored branch, got %d series", got)
+	}
+
+	if got := testutil.CollectAndCount(collector.metrics.GitHubWorkflowLatestRunInfo); got != 1 {
+		t.Errorf("Expected the latest run to be updated, got %d series", got)
+	}
+}
+
+// TestApplyCheckRun tests that check runs on monitored branches update their status
+func TestApplyCheckRun(t *testing.T) {
+	collector := newWebhookTestCollector()
+
+	collector.ApplyCheckRun(&github.CheckRunEvent{
+		Action: github.Ptr("created"),
+		Repo:   testWebhookRepo("private"),
+		CheckRun: &github.CheckRun{
+			Name:       github.Ptr("lint"),
+			Status:     github.Ptr("in_progress"),
+			CheckSuite: &github.CheckSuite{HeadBranch: github.Ptr("main")},
+		},
+	})
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubCheckRunStatus.WithLabelValues("d0ugal", "private", "lint", "main", "unknown")); got != 2 {
+		t.Errorf("Expected a pending check run, got %v", got)
+	}
+
+	// Unmonitored repositories are ignored
+	collector.ApplyCheckRun(&github.CheckRunEvent{
+		Repo: testWebhookRepo("other"),
+		CheckRun: &github.CheckRun{
+			Name:       github.Ptr("lint"),
+			Conclusion: github.Ptr("success"),
+			CheckSuite: &github.CheckSuite{HeadBranch: github.Ptr("main")},
+		},
+	})
+
+	if got := testutil.CollectAndCount(collector.metrics.GitHubCheckRunStatus); got != 1 {
+		t.Errorf("Expected only the monitored repository, got %d series", got)
+	}
+}
+
+// TestApplyOpenCounts tests that opening and closing issues and pull requests
+// adjusts the open counts of monitored repositories
+func TestApplyOpenCounts(t *testing.T) {
+	collector := newWebhookTestCollector()
+	collector.metrics.GitHubReposOpenIssues.WithLabelValues("d0ugal", "private", "private").Set(10)
+	collector.metrics.GitHubReposOpenPRs.WithLabelValues("d0ugal", "private", "private").Set(3)
+
+	for _, action := range []string{"opened", "opened", "closed", "labeled"} {
+		collector.ApplyIssues(&github.IssuesEvent{Action: github.Ptr(action), Repo: testWebhookRepo("private")})
+	}
+
+	collector.ApplyIssues(&github.IssuesEvent{
+		Action: github.Ptr("deleted"),
+		Repo:   testWebhookRepo("private"),
+		Issue:  &github.Issue{State: github.Ptr("closed")},
+	})
+
+	collector.ApplyPullRequest(&github.PullRequestEvent{Action: github.Ptr("closed"), Repo: testWebhookRepo("private")})
+	collector.ApplyPullRequest(&github.PullRequestEvent{Action: github.Ptr("opened"), Repo: testWebhookRepo("other")})
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubReposOpenIssues.WithLabelValues("d0ugal", "private", "private")); got != 10 {
+		t.Errorf("Expected 10 open issues, got %v", got)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubReposOpenPRs.WithLabelValues("d0ugal", "private", "private")); got != 2 {
+		t.Errorf("Expected 2 open pull requests, got %v", got)
+	}
+
+	if got := testutil.CollectAndCount(collector.metrics.GitHubReposOpenPRs); got != 1 {
+		t.Errorf("Expected only the monitored repository, got %d series", got)
+	}
+}
@@ -0,0 +1,131 @@
+package collectors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/google/go-github/v76/github"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// webhookDeliveriesPerHook is the number of recent deliveries inspected per webhook
+const webhookDeliveriesPerHook = 100
+
+// webhookHost returns the host a webhook delivers to. Only the host is exported
+// because webhook URLs may carry credentials in their path or query.
+func webhookHost(hook *github.Hook) string {
+	if hook.Config == nil {
+		return ""
+	}
+
+	u, err := url.Parse(hook.Config.GetURL())
+	if err != nil {
+		return ""
+	}
+
+	return u.Host
+}
+
+// deliverySucceeded returns true if a webhook delivery received a 2xx response
+func deliverySucceeded(delivery *github.HookDelivery) bool {
+	code := delivery.GetStatusCode()
+	return code >= 200 && code < 300
+}
+
+// setWebhookMetrics exports the delivery health of each webhook configured on a repository
+func (gc *GitHubCollector) setWebhookMetrics(ctx context.Context, owner, repo string) error {
+	t := target{Org: owner, Repo: repo}
+
+	// Wait for rate limiter
+	if err := gc.limiter.Wait(ctx); err != nil {
+		err = wrapAPIError("hooks", t, fmt.Errorf("rate limiter error: %w", err))
+		logError("Rate limiter error while listing webhooks", err)
+		return err
+	}
+
+	reqCtx, cancel := gc.requestContext(ctx, "hooks")
+	hooks, _, err := gc.api.ListHooks(reqCtx, owner, repo, &github.ListOptions{PerPage: 100})
+	cancel()
+	if err != nil {
+		err = wrapAPIError("hooks", t, err)
+		logError("Failed to list webhooks", err)
+		gc.recordAPIError("hooks", err)
+		return err
+	}
+
+	var errs []error
+
+	for _, hook := range hooks {
+		if !hook.GetActive() {
+			continue
+		}
+
+		if err := gc.setWebhookDeliveryMetrics(ctx, owner, repo, hook); err != nil {
+			logError("Failed to list webhook deliveries", err, "hook_id", hook.GetID())
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// setWebhookDeliveryMetrics exports the last delivery status and recent failure
+// count of a single webhook
+func (gc *GitHubCollector) setWebhookDeliveryMetrics(ctx context.Context, owner, repo string, hook *github.Hook) error {
+	t := target{Org: owner, Repo: repo}
+
+	// Wait for rate limiter
+	if err := gc.limiter.Wait(ctx); err != nil {
+		return wrapAPIError("hook_deliveries", t, fmt.Errorf("rate limiter error: %w", err))
+	}
+
+	reqCtx, cancel := gc.requestContext(ctx, "hook_deliveries")
+	deliveries, _, err := gc.api.ListHookDeliveries(reqCtx, owner, repo, hook.GetID(), &github.ListCursorOptions{
+		PerPage: webhookDeliveriesPerHook,
+	})
+	cancel()
+	if err != nil {
+		gc.recordAPIError("hook_deliveries", err)
+		return wrapAPIError("hook_deliveries", t, err)
+	}
+
+	labels := prometheus.Labels{
+		"org":     owner,
+		"repo":    repo,
+		"hook_id": strconv.FormatInt(hook.GetID(), 10),
+		"host":    webhookHost(hook),
+	}
+
+	failures := 0
+
+	for _, delivery := range deliveries {
+		if !deliverySucceeded(delivery) {
+			failures++
+		}
+	}
+
+	gc.metrics.GitHubWebhookDeliveryFailures.With(labels).Set(float64(failures))
+
+	// Deliveries are returned newest first
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	latest := deliveries[0]
+
+	success := 0.0
+	if deliverySucceeded(latest) {
+		success = 1
+	}
+
+	gc.metrics.GitHubWebhookLastDeliverySuccess.With(labels).Set(success)
+
+	if latest.DeliveredAt != nil {
+		gc.metrics.GitHubWebhookLastDeliveryTimestamp.With(labels).Set(float64(latest.DeliveredAt.Unix()))
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package collectors
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// TestSetWebhookMetrics tests exporting webhook delivery health
+func TestSetWebhookMetrics(t *testing.T) {
+	collector := newAPITestCollector(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/d0ugal/private/hooks":
+			_, _ = w.Write([]byte(`[
+				{"id": 1, "active": true, "config": {"url": "https://deploy.example.com/hook?token=secret"}},
+				{"id": 2, "active": false, "config": {"url": "https://old.example.com/hook"}}
+			]`))
+		case "/repos/d0ugal/private/hooks/1/deliveries":
+			_, _ = w.Write([]byte(`[
+				{"id": 12, "status_code": 502, "delivered_at": "2026-01-02T10:00:00Z"},
+				{"id": 11, "status_code": 200, "delivered_at": "2026-01-01T10:00:00Z"},
+				{"id": 10, "status_code": 0, "delivered_at": "2026-01-01T09:00:00Z"}
+			]`))
+		default:
+			t.Errorf("Unexpected request path %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	collector.setWebhookMetrics(t.Context(), "d0ugal", "private")
+
+	labels := []string{"d0ugal", "private", "1", "deploy.example.com"}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubWebhookLastDeliverySuccess.WithLabelValues(labels...)); got != 0 {
+		t.Errorf("Expected last delivery to have failed, got %v", got)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubWebhookDeliveryFailures.WithLabelValues(labels...)); got != 2 {
+		t.Errorf("Expected 2 failed deliveries, got %v", got)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubWebhookLastDeliveryTimestamp.WithLabelValues(labels...)); got != 1767348000 {
+		t.Errorf("Expected last delivery timestamp 1767348000, got %v", got)
+	}
+
+	if got := testutil.CollectAndCount(collector.metrics.GitHubWebhookLastDeliverySuccess); got != 1 {
+		t.Errorf("Expected inactive webhooks to be skipped, got %d series", got)
+	}
+}
@@ -0,0 +1,188 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/google/go-github/v76/github"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// runTracker remembers the completed workflow runs listed on each branch in the
+// previous cycle, so every completed run is counted once
+type runTracker struct {
+	mu        sync.Mutex
+	completed map[string]map[int64]bool // "org/repo@branch" -> run IDs
+}
+
+// seen reports whether a branch was observed before
+func (rt *runTracker) seen(branch string) bool {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	_, ok := rt.completed[branch]
+
+	return ok
+}
+
+// observe records the completed runs currently listed on a branch and returns the
+// ones that were not listed before. The first observation of a branch only seeds
+// the tracker. Runs are forgotten once they are no longer listed, so the tracker
+// stays bounded by the size of the listing.
+func (rt *runTracker) observe(branch string, runs []*github.WorkflowRun) []*github.WorkflowRun {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	if rt.completed == nil {
+		rt.completed = make(map[string]map[int64]bool)
+	}
+
+	previous, seen := rt.completed[branch]
+	current := make(map[int64]bool, len(runs))
+
+	var completed []*github.WorkflowRun
+
+	for _, run := range runs {
+		if run.GetStatus() != "completed" {
+			continue
+		}
+
+		current[run.GetID()] = true
+
+		if seen && !previous[run.GetID()] {
+			completed = append(completed, run)
+		}
+	}
+
+	rt.completed[branch] = current
+
+	return completed
+}
+
+// rename moves the completed runs of a repository that was renamed or transferred
+func (rt *runTracker) rename(from, to string) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	renameRepoKeys(rt.completed, from, to)
+}
+
+// backfillBudget limits the API calls spent on backfilling per collection cycle
+type backfillBudget struct {
+	mu        sync.Mutex
+	remaining int
+}
+
+// reset restores the budget at the start of a cycle
+func (b *backfillBudget) reset(calls int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.remaining = calls
+}
+
+// take spends one call, returning false when the budget is exhausted
+func (b *backfillBudget) take() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.remaining <= 0 {
+		return false
+	}
+
+	b.remaining--
+
+	return true
+}
+
+// countWorkflowRuns counts the runs on a branch that completed since the previous
+// cycle. When a branch is first seen and backfilling is enabled, the most recent
+// completed runs are counted instead, so rate() queries work from the start.
+func (gc *GitHubCollector) countWorkflowRuns(ctx context.Context, owner, repo, branch string, runs []*github.WorkflowRun) {
+	key := target{Org: owner, Repo: repo, Branch: branch}.String()
+
+	if backfill := gc.config.GitHub.BuildStatus.BackfillRuns; backfill > 0 && !gc.runs.seen(key) {
+		// Wait for a later cycle rather than seeding the branch without history
+		if !gc.backfill.take() {
+			slog.Debug("Backfill budget exhausted, deferring branch", "org", owner, "repo", repo, "branch", branch)
+			return
+		}
+
+		history, err := gc.listCompletedRuns(ctx, owner, repo, branch, backfill)
+		if err != nil {
+			logError("Failed to backfill workflow runs", err)
+			return
+		}
+
+		gc.runs.observe(key, append(history, runs...))
+
+		for _, run := range history {
+			gc.observeWorkflowRun(owner, repo, branch, run)
+		}
+
+		slog.Debug("Backfilled workflow runs", "org", owner, "repo", repo, "branch", branch, "runs", len(history))
+
+		return
+	}
+
+	for _, run := range gc.runs.observe(key, runs) {
+		gc.observeWorkflowRun(owner, repo, branch, run)
+	}
+}
+
+// listCompletedRuns lists the most recent completed runs on a branch
+func (gc *GitHubCollector) listCompletedRuns(ctx context.Context, owner, repo, branch string, count int) ([]*github.WorkflowRun, error) {
+	t := target{Org: owner, Repo: repo, Branch: branch}
+
+	// Wait for rate limiter
+	if err := gc.limiter.Wait(ctx); err != nil {
+		return nil, wrapAPIError("workflow_runs", t, fmt.Errorf("rate limiter error: %w", err))
+	}
+
+	reqCtx, cancel := gc.requestContext(ctx, "workflow_runs")
+	workflowRuns, _, err := gc.api.ListWorkflowRuns(reqCtx, owner, repo, &github.ListWorkflowRunsOptions{
+		Branch: branch,
+		Status: "completed",
+		ListOptions: github.ListOptions{
+			PerPage: count,
+		},
+	})
+	cancel()
+	if err != nil {
+		gc.recordAPIError("workflow_runs", err)
+		return nil, wrapAPIError("workflow_runs", t, fmt.Errorf("failed to list completed workflow runs: %w", err))
+	}
+
+	return workflowRuns.WorkflowRuns, nil
+}
+
+// observeWorkflowRun counts a completed run, observes its duration and adds it
+// to the success ratio window and the organization totals
+func (gc *GitHubCollector) observeWorkflowRun(owner, repo, branch string, run *github.WorkflowRun) {
+	gc.addToRunWindow(owner, repo, branch, run)
+	gc.addRunToRollups(owner, run)
+
+	conclusion := run.GetConclusion()
+	if conclusion == "" {
+		conclusion = "unknown"
+	}
+
+	gc.metrics.GitHubWorkflowRunsTotal.With(prometheus.Labels{
+		"org":        owner,
+		"repo":       repo,
+		"workflow":   run.GetName(),
+		"branch":     branch,
+		"conclusion": conclusion,
+	}).Inc()
+
+	if run.RunStartedAt != nil && run.UpdatedAt != nil {
+		gc.metrics.GitHubWorkflowRunTime.With(prometheus.Labels{
+			"org":      owner,
+			"repo":     repo,
+			"workflow": run.GetName(),
+			"branch":   branch,
+		}).Observe(run.UpdatedAt.Sub(run.RunStartedAt.Time).Seconds())
+	}
+}
@@ -0,0 +1,106 @@
+package collectors
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v76/github"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// completedRun returns a completed run of the CI workflow on main
+func completedRun(id int64, conclusion string) *github.WorkflowRun {
+	started := time.Unix(1767225600, 0)
+
+	return &github.WorkflowRun{
+		ID:           github.Ptr(id),
+		WorkflowID:   github.Ptr(int64(1)),
+		Name:         github.Ptr("CI"),
+		HeadBranch:   github.Ptr("main"),
+		Status:       github.Ptr("completed"),
+		Conclusion:   github.Ptr(conclusion),
+		RunStartedAt: &github.Timestamp{Time: started},
+		UpdatedAt:    &github.Timestamp{Time: started.Add(90 * time.Second)},
+	}
+}
+
+// TestCountWorkflowRuns tests that completed runs are counted once, starting
+// from the second cycle a branch is seen in
+func TestCountWorkflowRuns(t *testing.T) {
+	collector, api := newFakeCollector()
+	collector.config.Collectors.WorkflowRuns = github.Ptr(true)
+
+	inProgress := &github.WorkflowRun{
+		ID: github.Ptr(int64(3)), WorkflowID: github.Ptr(int64(1)), Name: github.Ptr("CI"),
+		HeadBranch: github.Ptr("main"), Status: github.Ptr("in_progress"),
+	}
+	api.workflowRuns["d0ugal/github-exporter"] = []*github.WorkflowRun{inProgress, completedRun(2, "success"), completedRun(1, "failure")}
+
+	collect := func() {
+		t.Helper()
+
+		if err := collector.collectBranchBuildStatus(t.Context(), "d0ugal", "github-exporter", "main"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	collect()
+
+	if got := testutil.CollectAndCount(collector.metrics.GitHubWorkflowRunsTotal); got != 0 {
+		t.Errorf("Expected the first cycle to only seed the tracker, got %d series", got)
+	}
+
+	// Run 3 completes and a new run starts
+	api.workflowRuns["d0ugal/github-exporter"] = []*github.WorkflowRun{completedRun(3, "failure"), completedRun(2, "success"), completedRun(1, "failure")}
+	collect()
+	collect()
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubWorkflowRunsTotal.WithLabelValues("d0ugal", "github-exporter", "CI", "main", "failure")); got != 1 {
+		t.Errorf("Expected 1 failed run, got %v", got)
+	}
+
+	if got := testutil.CollectAndCount(collector.metrics.GitHubWorkflowRunsTotal); got != 1 {
+		t.Errorf("Expected only the newly completed run to be counted, got %d series", got)
+	}
+}
+
+// TestBackfillWorkflowRuns tests that the most recent completed runs are counted
+// when a branch is first seen, within the backfill budget
+func TestBackfillWorkflowRuns(t *testing.T) {
+	collector, api := newFakeCollector()
+	collector.config.Collectors.WorkflowRuns = github.Ptr(true)
+	collector.config.GitHub.BuildStatus.BackfillRuns = 2
+	collector.backfill.reset(1)
+
+	api.workflowRuns["d0ugal/github-exporter"] = []*github.WorkflowRun{completedRun(3, "success"), completedRun(2, "success"), completedRun(1, "failure")}
+	api.workflowRuns["d0ugal/other"] = []*github.WorkflowRun{completedRun(4, "success")}
+
+	for _, repo := range []string{"github-exporter", "other"} {
+		if err := collector.collectBranchBuildStatus(t.Context(), "d0ugal", repo, "main"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubWorkflowRunsTotal.WithLabelValues("d0ugal", "github-exporter", "CI", "main", "success")); got != 2 {
+		t.Errorf("Expected the 2 most recent runs to be backfilled, got %v", got)
+	}
+
+	if got := testutil.CollectAndCount(collector.metrics.GitHubWorkflowRunTime); got != 1 {
+		t.Errorf("Expected durations of one branch, got %d", got)
+	}
+
+	// The budget ran out before d0ugal/other, which is backfilled in the next cycle
+	if collector.runs.seen("d0ugal/other@main") {
+		t.Error("Expected the branch over budget to be deferred")
+	}
+
+	collector.backfill.reset(1)
+
+	if err := collector.collectBranchBuildStatus(t.Context(), "d0ugal", "other", "main"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubWorkflowRunsTotal.WithLabelValues("d0ugal", "other", "CI", "main", "success")); got != 1 {
+		t.Errorf("Expected the deferred branch to be backfilled, got %v", got)
+	}
+}
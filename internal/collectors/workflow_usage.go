@@ -0,0 +1,99 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/google/go-github/v76/github"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// usageCache remembers the billable time of the latest completed run of each
+// workflow. The usage of a completed run doesn't change, so it is only fetched
+// again once a newer run completes.
+type usageCache struct {
+	mu      sync.Mutex
+	entries map[string]usageEntry // "org/repo@branch/workflow ID" -> latest run
+}
+
+type usageEntry struct {
+	runID    int64
+	billable github.WorkflowRunBillMap
+}
+
+func newUsageCache() *usageCache {
+	return &usageCache{
+		entries: make(map[string]usageEntry),
+	}
+}
+
+// get returns the cached billable time for a workflow if it belongs to runID
+func (uc *usageCache) get(key string, runID int64) (github.WorkflowRunBillMap, bool) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	entry, ok := uc.entries[key]
+	if !ok || entry.runID != runID {
+		return nil, false
+	}
+
+	return entry.billable, true
+}
+
+func (uc *usageCache) set(key string, runID int64, billable github.WorkflowRunBillMap) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	uc.entries[key] = usageEntry{runID: runID, billable: billable}
+}
+
+// collectWorkflowRunUsage exports the billable time of a completed workflow run
+func (gc *GitHubCollector) collectWorkflowRunUsage(ctx context.Context, owner, repo, branch string, run *github.WorkflowRun) error {
+	t := target{Org: owner, Repo: repo, Branch: branch}
+	key := fmt.Sprintf("%s/%d", t.String(), run.GetWorkflowID())
+
+	billable, ok := gc.usage.get(key, run.GetID())
+	if !ok {
+		// Wait for rate limiter
+		if err := gc.limiter.Wait(ctx); err != nil {
+			return wrapAPIError("workflow_run_usage", t, fmt.Errorf("rate limiter error: %w", err))
+		}
+
+		reqCtx, cancel := gc.requestContext(ctx, "workflow_run_usage")
+		usage, _, err := gc.api.GetWorkflowRunUsage(reqCtx, owner, repo, run.GetID())
+		cancel()
+		if err != nil {
+			gc.recordAPIError("workflow_run_usage", err)
+			return wrapAPIError("workflow_run_usage", t, fmt.Errorf("failed to get workflow run usage: %w", err))
+		}
+
+		if usage.Billable != nil {
+			billable = *usage.Billable
+		}
+
+		gc.usage.set(key, run.GetID(), billable)
+		gc.addUsageToRollups(owner, run, billable)
+	}
+
+	// Drop series for runners only an earlier run of the workflow used
+	gc.metrics.GitHubWorkflowRunBillable.DeletePartialMatch(prometheus.Labels{
+		"org":      owner,
+		"repo":     repo,
+		"workflow": run.GetName(),
+		"branch":   branch,
+	})
+
+	for runner, bill := range billable {
+		gc.metrics.GitHubWorkflowRunBillable.With(prometheus.Labels{
+			"org":      owner,
+			"repo":     repo,
+			"workflow": run.GetName(),
+			"branch":   branch,
+			"os":       strings.ToLower(runner),
+		}).Set(float64(bill.GetTotalMS()) / 1000)
+	}
+
+	return nil
+}
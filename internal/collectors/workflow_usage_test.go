@@ -0,0 +1,73 @@
+package collectors
+
+import (
+	"testing"
+
+	"github.com/google/go-github/v76/github"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// TestCollectWorkflowRunUsage tests billable time of the latest completed run of each workflow
+func TestCollectWorkflowRunUsage(t *testing.T) {
+	collector, api := newFakeCollector()
+	enabled := true
+	collector.config.Collectors.WorkflowUsage = &enabled
+
+	api.workflowRuns["d0ugal/github-exporter"] = []*github.WorkflowRun{
+		{ID: github.Ptr(int64(30)), WorkflowID: github.Ptr(int64(1)), Name: github.Ptr("CI"), HeadBranch: github.Ptr("main"), Status: github.Ptr("in_progress")},
+		{ID: github.Ptr(int64(20)), WorkflowID: github.Ptr(int64(1)), Name: github.Ptr("CI"), HeadBranch: github.Ptr("main"), Status: github.Ptr("completed"), Conclusion: github.Ptr("success")},
+		{ID: github.Ptr(int64(10)), WorkflowID: github.Ptr(int64(1)), Name: github.Ptr("CI"), HeadBranch: github.Ptr("main"), Status: github.Ptr("completed"), Conclusion: github.Ptr("failure")},
+	}
+	api.runUsage[20] = &github.WorkflowRunUsage{Billable: &github.WorkflowRunBillMap{
+		"UBUNTU": {TotalMS: github.Ptr(int64(120000))},
+		"MACOS":  {TotalMS: github.Ptr(int64(600000))},
+	}}
+
+	for range 2 {
+		if err := collector.collectBranchBuildStatus(t.Context(), "d0ugal", "github-exporter", "main"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubWorkflowRunBillable.WithLabelValues("d0ugal", "github-exporter", "CI", "main", "ubuntu")); got != 120 {
+		t.Errorf("Expected 120 billable seconds on ubuntu, got %v", got)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubWorkflowRunBillable.WithLabelValues("d0ugal", "github-exporter", "CI", "main", "macos")); got != 600 {
+		t.Errorf("Expected 600 billable seconds on macos, got %v", got)
+	}
+
+	// The usage of a completed run is only fetched once
+	if api.calls["GetWorkflowRunUsage"] != 1 {
+		t.Errorf("Expected usage to be fetched once, got %d", api.calls["GetWorkflowRunUsage"])
+	}
+}
+
+// TestCollectWorkflowRunUsageNewRun tests that runners of an earlier run are dropped
+func TestCollectWorkflowRunUsageNewRun(t *testing.T) {
+	collector, api := newFakeCollector()
+
+	first := &github.WorkflowRun{ID: github.Ptr(int64(1)), WorkflowID: github.Ptr(int64(1)), Name: github.Ptr("CI")}
+	second := &github.WorkflowRun{ID: github.Ptr(int64(2)), WorkflowID: github.Ptr(int64(1)), Name: github.Ptr("CI")}
+
+	api.runUsage[1] = &github.WorkflowRunUsage{Billable: &github.WorkflowRunBillMap{
+		"WINDOWS": {TotalMS: github.Ptr(int64(1000))},
+	}}
+	api.runUsage[2] = &github.WorkflowRunUsage{Billable: &github.WorkflowRunBillMap{
+		"UBUNTU": {TotalMS: github.Ptr(int64(2000))},
+	}}
+
+	for _, run := range []*github.WorkflowRun{first, second} {
+		if err := collector.collectWorkflowRunUsage(t.Context(), "d0ugal", "github-exporter", "main", run); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	if got := testutil.CollectAndCount(collector.metrics.GitHubWorkflowRunBillable); got != 1 {
+		t.Errorf("Expected 1 billable series, got %d", got)
+	}
+
+	if got := testutil.ToFloat64(collector.metrics.GitHubWorkflowRunBillable.WithLabelValues("d0ugal", "github-exporter", "CI", "main", "ubuntu")); got != 2 {
+		t.Errorf("Expected 2 billable seconds on ubuntu, got %v", got)
+	}
+}
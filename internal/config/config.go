@@ -2,41 +2,699 @@ package config
 
 import (
 	"fmt"
+	"net/netip"
+	"net/url"
 	"os"
+	"path"
+	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/d0ugal/github-exporter/internal/cron"
 	promexporter_config "github.com/d0ugal/promexporter/config"
 	"gopkg.in/yaml.v3"
 )
 
+// metricNamespacePattern matches valid Prometheus metric name prefixes, and is
+// also used for metric and label names defined in configuration
+var metricNamespacePattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
 // Duration uses promexporter Duration type
 type Duration = promexporter_config.Duration
 
 type Config struct {
-	promexporter_config.BaseConfig
+	promexporter_config.BaseConfig `yaml:",inline"`
 
 	GitHub GitHubConfig `yaml:"github"`
+
+	Kubernetes KubernetesConfig `yaml:"kubernetes"`
+
+	Collectors CollectorsConfig `yaml:"collectors"`
+
+	State StateConfig `yaml:"state"`
+
+	Webhook WebhookConfig `yaml:"webhook"`
+
+	Admin AdminConfig `yaml:"admin"`
+
+	Notifications NotificationsConfig `yaml:"notifications"`
+
+	// MetricsOptions holds exporter-specific options from the metrics section.
+	// They are decoded separately because the section is owned by BaseConfig.
+	MetricsOptions MetricsOptions `yaml:"-"`
+
+	// ServerOptions holds exporter-specific options from the server section,
+	// decoded separately for the same reason
+	ServerOptions ServerOptions `yaml:"-"`
+}
+
+// ServerOptions holds exporter-specific HTTP server configuration
+type ServerOptions struct {
+	TLS  TLSConfig  `yaml:"tls"`
+	Auth AuthConfig `yaml:"auth"`
+
+	// AllowedNetworks restricts the metrics, status, debug and admin endpoints
+	// to clients in these CIDR ranges or addresses (empty = all clients)
+	AllowedNetworks []string `yaml:"allowed_networks"`
+}
+
+// Networks parses AllowedNetworks, accepting single addresses as well as ranges
+func (o *ServerOptions) Networks() ([]netip.Prefix, error) {
+	networks := make([]netip.Prefix, 0, len(o.AllowedNetworks))
+
+	for _, network := range o.AllowedNetworks {
+		network = strings.TrimSpace(network)
+
+		if addr, err := netip.ParseAddr(network); err == nil {
+			networks = append(networks, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+
+		prefix, err := netip.ParsePrefix(network)
+		if err != nil {
+			return nil, fmt.Errorf("invalid network %q: %w", network, err)
+		}
+
+		networks = append(networks, prefix.Masked())
+	}
+
+	return networks, nil
+}
+
+// TLSConfig enables HTTPS when a certificate and key are configured
+type TLSConfig struct {
+	CertFile string `yaml:"cert_file"` // PEM certificate, including any intermediates
+	KeyFile  string `yaml:"key_file"`  // PEM private key
+}
+
+// Enabled reports whether the server is served over TLS
+func (t *TLSConfig) Enabled() bool {
+	return t.CertFile != ""
+}
+
+// AuthConfig protects the metrics, status and debug endpoints. Requests are
+// accepted with either the basic auth credentials or the bearer token; without
+// both the endpoints are open. /health stays open for liveness probes.
+type AuthConfig struct {
+	Username    string `yaml:"username"`
+	Password    string `yaml:"password"`
+	BearerToken string `yaml:"bearer_token"`
+}
+
+// Enabled reports whether requests need to authenticate
+func (a *AuthConfig) Enabled() bool {
+	return a.Username != "" || a.BearerToken != ""
+}
+
+// MetricsOptions holds exporter-specific metrics configuration
+type MetricsOptions struct {
+	Namespace         string `yaml:"namespace"`             // Prefix for all metric names (default: "github")
+	OpenMetrics       *bool  `yaml:"openmetrics,omitempty"` // Serve the OpenMetrics format when requested (default: true)
+	CreatedTimestamps bool   `yaml:"created_timestamps"`    // Emit _created series for counters in OpenMetrics output
+	CompatibilityMode bool   `yaml:"compatibility_mode"`    // Also expose metrics under githubexporter/github-exporter names
+
+	// RepoLabels adds repository attributes as labels to every series labelled
+	// with org and repo, keyed by label name. Attributes are "language",
+	// "default_branch" and "topic", the first of the repository's topics.
+	RepoLabels map[string]string `yaml:"repo_labels"`
+}
+
+// Repository attributes that can be added as labels with metrics.repo_labels
+const (
+	RepoAttributeLanguage      = "language"
+	RepoAttributeDefaultBranch = "default_branch"
+	RepoAttributeTopic         = "topic"
+)
+
+// IsOpenMetricsEnabled returns true if OpenMetrics negotiation is enabled (defaults to true)
+func (m *MetricsOptions) IsOpenMetricsEnabled() bool {
+	if m.OpenMetrics == nil {
+		return true
+	}
+
+	return *m.OpenMetrics
 }
 
 type GitHubConfig struct {
-	Token           string   `yaml:"token"`
-	Orgs            []string `yaml:"orgs"`
-	Repos           []string `yaml:"repos"`
-	Branches        []string `yaml:"branches"`  // Branches to monitor for build status
-	Workflows       []string `yaml:"workflows"` // Specific workflows to monitor (empty = all)
-	Timeout         Duration `yaml:"timeout"`
-	RefreshInterval Duration `yaml:"refresh_interval"`
-	RateLimitBuffer float64  `yaml:"rate_limit_buffer"` // Percentage to stay under limit (0.8 = 80%)
-}
-
-// LoadConfig loads configuration from either a YAML file or environment variables
-func LoadConfig(path string, configFromEnv bool) (*Config, error) {
+	Token     string   `yaml:"token"`
+	Tokens    []string `yaml:"tokens"` // Tokens rotated between as each approaches its rate limit, instead of token
+	Orgs      []string `yaml:"orgs"`
+	Repos     []string `yaml:"repos"`
+	Teams     []string `yaml:"teams"`     // Teams ("org/team-slug") whose repositories are monitored
+	Starred   bool     `yaml:"starred"`   // Monitor all repositories starred by the authenticated user
+	Users     []string `yaml:"users"`     // Users whose public profile and followers are monitored
+	Branches  []string `yaml:"branches"`  // Branches to monitor for build status
+	Workflows []string `yaml:"workflows"` // Specific workflows to monitor (empty = all)
+	Timeout   Duration `yaml:"timeout"`
+	// Per-endpoint timeouts keyed by endpoint (e.g. "repos", "search_issues"), falling back to Timeout
+	Timeouts        map[string]Duration `yaml:"timeouts"`
+	RefreshInterval Duration            `yaml:"refresh_interval"`
+	RateLimitBuffer float64             `yaml:"rate_limit_buffer"` // Percentage to stay under limit (0.8 = 80%)
+
+	// DiscoveryInterval controls how often wildcard and organization repository
+	// listings are refreshed (0 = rediscover on every collection cycle)
+	DiscoveryInterval Duration `yaml:"discovery_interval"`
+
+	// RevalidateInterval lists the repositories of wildcard, organization, team
+	// and starred targets again on its own schedule (0 = disabled) and warns when
+	// more than DriftThreshold of them appeared or disappeared since the last time
+	RevalidateInterval Duration `yaml:"revalidate_interval"`
+	DriftThreshold     float64  `yaml:"drift_threshold"` // Default: 0.1
+
+	// DegradedModeFloor switches to degraded mode when fewer rate limit requests
+	// remain (0 = disabled). Until the rate limit resets only the rate limit and the
+	// build status of PriorityBranches are refreshed.
+	DegradedModeFloor int      `yaml:"degraded_mode_floor"`
+	PriorityBranches  []string `yaml:"priority_branches"`
+
+	// RunAttribution adds the short head SHA and the triggering actor to the
+	// latest workflow run metrics. Off by default as every run creates new series.
+	RunAttribution bool `yaml:"run_attribution"`
+
+	// BuildStatus tunes the build status collected for monitored branches
+	BuildStatus BuildStatusConfig `yaml:"build_status"`
+
+	// CollectionDeadline bounds a full collection cycle (0 = no deadline). Targets
+	// not reached before the deadline are collected first in the next cycle.
+	CollectionDeadline Duration `yaml:"collection_deadline"`
+
+	// StaggerTargets spreads per-target collection evenly across the refresh interval
+	// instead of collecting all targets back-to-back at each tick
+	StaggerTargets bool    `yaml:"stagger_targets"`
+	StaggerJitter  float64 `yaml:"stagger_jitter"` // Random jitter as a fraction of each target's slot (0.1 = ±10%)
+
+	// MaxRepos caps the number of repositories a single wildcard or organization
+	// discovery may return (0 = unlimited). MaxReposPolicy decides what happens
+	// when the cap is exceeded: "abort" skips the discovery, "truncate" keeps the
+	// first MaxRepos repositories.
+	MaxRepos       int    `yaml:"max_repos"`
+	MaxReposPolicy string `yaml:"max_repos_policy"`
+
+	// FailurePolicy decides what happens to a repository's metrics when it can no
+	// longer be collected: "keep" the last values, "zero" them or "delete" the series
+	FailurePolicy string `yaml:"failure_policy"`
+
+	// NotFoundPolicy decides what happens when a monitored repository or
+	// organization does not exist: "warn" every cycle, "warn_once" or "skip" it
+	// until the exporter restarts
+	NotFoundPolicy string `yaml:"not_found_policy"`
+
+	// Projects lists organization projects (Projects v2, "org/number") to export
+	// item metrics for. Items are grouped by the single-select field named
+	// ProjectStatusField and by the iteration field named ProjectIterationField.
+	Projects              []string `yaml:"projects"`
+	ProjectStatusField    string   `yaml:"project_status_field"`    // Default: "Status"
+	ProjectIterationField string   `yaml:"project_iteration_field"` // Default: "Iteration"
+
+	// PackageTypes lists the GitHub Packages ecosystems collected for monitored
+	// organizations when the packages collector is enabled
+	PackageTypes []string `yaml:"package_types"` // Default: container, npm, maven
+
+	// CustomSearches lists issue and pull request search queries whose result
+	// counts are exported, labelled by name
+	CustomSearches []CustomSearch `yaml:"custom_searches"`
+
+	// CustomQueries lists GraphQL queries whose results are exported as gauges,
+	// one metric per query
+	CustomQueries []CustomQuery `yaml:"custom_queries"`
+
+	// Blackouts lists recurring windows during which no API calls are made, e.g.
+	// while bulk migrations would skew the metrics. The last metrics stay exported.
+	Blackouts []BlackoutWindow `yaml:"blackouts"`
+
+	// ExcludeRepos lists patterns of repositories ("owner/repo") skipped by
+	// wildcard, organization, team and starred discovery: globs such as
+	// "*/test-*", or regular expressions enclosed in slashes such as "/-mirror$/"
+	ExcludeRepos []string `yaml:"exclude_repos"`
+
+	// SkipArchived and SkipForks skip archived and forked repositories found by
+	// wildcard and organization discovery, whose series rarely change
+	SkipArchived bool `yaml:"skip_archived"`
+	SkipForks    bool `yaml:"skip_forks"`
+
+	// ForkActivity lists upstream repositories ("owner/repo") whose forks pushed
+	// to in the last 30 days are counted
+	ForkActivity []string `yaml:"fork_activity"`
+
+	// SyntheticChecks lists workflows that are dispatched periodically to measure
+	// the time until their run completes, as a canary for Actions health
+	SyntheticChecks []SyntheticCheck `yaml:"synthetic_checks"`
+
+	// App authenticates as a GitHub App instead of with Token
+	App GitHubAppConfig `yaml:"app"`
+}
+
+// GitHubAppConfig configures authentication as a GitHub App. All installations
+// of the app are enumerated and the organizations and repositories they grant
+// are monitored, each with a token of its own installation.
+type GitHubAppConfig struct {
+	AppID          int64    `yaml:"app_id"`
+	PrivateKeyPath string   `yaml:"private_key_path"` // PEM private key of the app
+	PollInterval   Duration `yaml:"poll_interval"`    // How often installations are enumerated (default: 1h)
+	InstallationID int64    `yaml:"installation_id"`  // Only use this installation (0 = all installations)
+}
+
+// BuildStatusConfig tunes the build status collected for monitored branches
+type BuildStatusConfig struct {
+	// LatestCheckSuite only reports the most recent run of each check, ignoring
+	// runs of check suites that were superseded, e.g. by a re-run
+	LatestCheckSuite bool `yaml:"latest_check_suite"`
+
+	// RequiredOnly only reports the check runs branch protection requires, and
+	// derives the branch build status from them. Branches without required
+	// status checks are reported as usual.
+	RequiredOnly bool `yaml:"required_only"`
+
+	// BackfillRuns is the number of completed workflow runs counted when the
+	// workflow_runs collector first sees a branch (0 = disabled, at most 100), so
+	// the run counters don't start from zero. BackfillBudget caps the API calls
+	// spent on backfilling per cycle; branches over budget are backfilled later.
+	BackfillRuns   int `yaml:"backfill_runs"`
+	BackfillBudget int `yaml:"backfill_budget"` // Default: 10
+}
+
+// Enabled returns true if the exporter authenticates as a GitHub App
+func (a GitHubAppConfig) Enabled() bool {
+	return a.AppID != 0
+}
+
+// CustomQuery is a user-defined GraphQL query exported as the gauge
+// custom_query_<name>. Paths are JSONPath-style ("$.organization.repositories.totalCount")
+// and are resolved against the data of the response, or against each element of
+// Items when it is set.
+type CustomQuery struct {
+	Name      string                 `yaml:"name"`      // Metric name suffix
+	Help      string                 `yaml:"help"`      // Metric help text
+	Query     string                 `yaml:"query"`     // GraphQL query
+	Variables map[string]interface{} `yaml:"variables"` // GraphQL variables
+	Items     string                 `yaml:"items"`     // Path to a list, each element becomes a series (optional)
+	Value     string                 `yaml:"value"`     // Path to the numeric or boolean value
+	Labels    map[string]string      `yaml:"labels"`    // Label name -> path to its value
+}
+
+// CustomSearch is a user-defined issue and pull request search query
+type CustomSearch struct {
+	Name  string `yaml:"name"`  // Value of the name label
+	Query string `yaml:"query"` // Search query, e.g. "org:myorg label:security state:open"
+}
+
+// BlackoutWindow is a recurring period without collection, starting whenever the
+// cron schedule fires (in UTC) and lasting for Duration
+type BlackoutWindow struct {
+	Name     string   `yaml:"name"`     // Shown in logs
+	Schedule string   `yaml:"schedule"` // Five-field cron expression, e.g. "0 2 * * 6"
+	Duration Duration `yaml:"duration"`
+}
+
+// SyntheticCheck is a workflow with a workflow_dispatch trigger that is run
+// every Interval. Runs not completed within Timeout are counted as timed out.
+type SyntheticCheck struct {
+	Repo     string   `yaml:"repo"`     // Repository in the form owner/repo
+	Workflow string   `yaml:"workflow"` // Workflow file name, e.g. "canary.yml"
+	Ref      string   `yaml:"ref"`      // Branch or tag to run the workflow on (default: "main")
+	Interval Duration `yaml:"interval"` // Default: 1h
+	Timeout  Duration `yaml:"timeout"`  // Default: 30m
+}
+
+// validPackageTypes lists the package types supported by the GitHub Packages API
+var validPackageTypes = map[string]bool{
+	"container": true,
+	"docker":    true,
+	"maven":     true,
+	"npm":       true,
+	"nuget":     true,
+	"rubygems":  true,
+}
+
+// validTimeoutEndpoints lists the API endpoints accepted as github.timeouts keys,
+// the same names as the endpoint label of the API metrics
+var validTimeoutEndpoints = map[string]bool{
+	"actions_fork_pr_approval":        true,
+	"actions_permissions":             true,
+	"actions_private_fork_pr":         true,
+	"actions_runner_permissions":      true,
+	"actions_secrets":                 true,
+	"actions_variables":               true,
+	"actions_workflow_permissions":    true,
+	"branch_protection":               true,
+	"check_runs":                      true,
+	"codeowners_errors":               true,
+	"commits":                         true,
+	"compare":                         true,
+	"contents":                        true,
+	"environment_secrets":             true,
+	"environments":                    true,
+	"forks":                           true,
+	"graphql_custom":                  true,
+	"graphql_org_sso":                 true,
+	"graphql_project_items":           true,
+	"hook_deliveries":                 true,
+	"hooks":                           true,
+	"issue_comments":                  true,
+	"issues":                          true,
+	"oidc_subject_claim":              true,
+	"orgs":                            true,
+	"package_versions":                true,
+	"packages":                        true,
+	"pr_review_comments":              true,
+	"private_vulnerability_reporting": true,
+	"releases":                        true,
+	"repos":                           true,
+	"required_status_checks":          true,
+	"rulesets":                        true,
+	"search_issues":                   true,
+	"starred":                         true,
+	"team_repos":                      true,
+	"user":                            true,
+	"users":                           true,
+	"workflow_dispatch":               true,
+	"workflow_run_usage":              true,
+	"workflow_runs":                   true,
+	"workflows":                       true,
+}
+
+// Policies for repository discovery exceeding MaxRepos
+const (
+	MaxReposPolicyAbort    = "abort"
+	MaxReposPolicyTruncate = "truncate"
+)
+
+// Policies for the metrics of repositories that fail to be collected
+const (
+	FailurePolicyKeep   = "keep"
+	FailurePolicyZero   = "zero"
+	FailurePolicyDelete = "delete"
+)
+
+// Policies for monitored targets the GitHub API returns 404 for
+const (
+	NotFoundPolicyWarn     = "warn"
+	NotFoundPolicyWarnOnce = "warn_once"
+	NotFoundPolicySkip     = "skip"
+)
+
+// CollectorsConfig enables or disables individual collectors. Unset collectors
+// use their default, which is enabled except for collectors that need extra
+// permissions or API calls beyond the basic repository metrics.
+type CollectorsConfig struct {
+	RepoStats            *bool `yaml:"repo_stats,omitempty"`            // Repository info, stars, forks, issues, size
+	OrgStats             *bool `yaml:"org_stats,omitempty"`             // Organization info, public repos, followers
+	ActionsPolicy        *bool `yaml:"actions_policy,omitempty"`        // Organization Actions and runner permission settings
+	SSO                  *bool `yaml:"sso,omitempty"`                   // Organization SAML single sign-on configuration
+	Secrets              *bool `yaml:"secrets,omitempty"`               // Actions secret and variable counts of organizations and repositories
+	OIDC                 *bool `yaml:"oidc,omitempty"`                  // Actions OIDC subject claims and deployment environment secrets
+	PullRequests         *bool `yaml:"prs,omitempty"`                   // Open pull request counts (uses the search API)
+	BuildStatus          *bool `yaml:"build_status,omitempty"`          // Workflow run and branch build status
+	CheckRuns            *bool `yaml:"check_runs,omitempty"`            // Check run status (requires build_status)
+	WorkflowUsage        *bool `yaml:"workflow_usage,omitempty"`        // Billable time of workflow runs (requires build_status)
+	Webhooks             *bool `yaml:"webhooks,omitempty"`              // Webhook delivery health for repositories with admin access
+	Commits              *bool `yaml:"commits,omitempty"`               // New commit counts for configured branches (requires build_status)
+	WorkflowRuns         *bool `yaml:"workflow_runs,omitempty"`         // Completed workflow run counts and durations (requires build_status)
+	Comments             *bool `yaml:"comments,omitempty"`              // Issue and pull request review comment counts
+	FirstResponse        *bool `yaml:"first_response,omitempty"`        // Time to first response for newly opened issues
+	IssueActivity        *bool `yaml:"issue_activity,omitempty"`        // Issues opened and closed counts
+	Codeowners           *bool `yaml:"codeowners,omitempty"`            // CODEOWNERS presence and rule counts
+	CodeownersErrors     *bool `yaml:"codeowners_errors,omitempty"`     // CODEOWNERS validation errors (requires codeowners)
+	SecurityPolicy       *bool `yaml:"security_policy,omitempty"`       // Private vulnerability reporting and SECURITY.md presence
+	CommunityFiles       *bool `yaml:"community_files,omitempty"`       // Issue template, pull request template and CONTRIBUTING presence
+	DependencyAutomation *bool `yaml:"dependency_automation,omitempty"` // Renovate and Dependabot configuration presence
+	TagProtection        *bool `yaml:"tag_protection,omitempty"`        // Rulesets protecting tags
+	BypassActors         *bool `yaml:"bypass_actors,omitempty"`         // Actors allowed to bypass rulesets and branch protection
+	Releases             *bool `yaml:"releases,omitempty"`              // Release cadence
+	ReleaseAssets        *bool `yaml:"release_assets,omitempty"`        // Assets of the latest release (requires releases)
+	UnreleasedCommits    *bool `yaml:"unreleased_commits,omitempty"`    // Commits on the default branch since the latest release (requires releases)
+	Schedules            *bool `yaml:"schedules,omitempty"`             // Scheduled workflow inventory and last scheduled runs
+	WorkflowState        *bool `yaml:"workflow_state,omitempty"`        // Workflow inventory and state, e.g. disabled due to inactivity
+	Packages             *bool `yaml:"packages,omitempty"`              // GitHub Packages owned by monitored organizations
+}
+
+// isEnabled returns the value of an optional switch, or def when it is unset
+func isEnabled(value *bool, def bool) bool {
+	if value == nil {
+		return def
+	}
+
+	return *value
+}
+
+// RepoStatsEnabled returns true if repository metrics are collected (default: true)
+func (c *CollectorsConfig) RepoStatsEnabled() bool {
+	return isEnabled(c.RepoStats, true)
+}
+
+// OrgStatsEnabled returns true if organization metrics are collected (default: true)
+func (c *CollectorsConfig) OrgStatsEnabled() bool {
+	return isEnabled(c.OrgStats, true)
+}
+
+// ActionsPolicyEnabled returns true if organization Actions settings are collected (default: false)
+func (c *CollectorsConfig) ActionsPolicyEnabled() bool {
+	return isEnabled(c.ActionsPolicy, false)
+}
+
+// SSOEnabled returns true if organization SAML SSO configuration is collected (default: false)
+func (c *CollectorsConfig) SSOEnabled() bool {
+	return isEnabled(c.SSO, false)
+}
+
+// PullRequestsEnabled returns true if open pull request counts are collected (default: true)
+func (c *CollectorsConfig) PullRequestsEnabled() bool {
+	return isEnabled(c.PullRequests, true)
+}
+
+// BuildStatusEnabled returns true if build status is collected for configured branches (default: true)
+func (c *CollectorsConfig) BuildStatusEnabled() bool {
+	return isEnabled(c.BuildStatus, true)
+}
+
+// CheckRunsEnabled returns true if check runs are collected alongside build status (default: true)
+func (c *CollectorsConfig) CheckRunsEnabled() bool {
+	return c.BuildStatusEnabled() && isEnabled(c.CheckRuns, true)
+}
+
+// WorkflowUsageEnabled returns true if billable time is collected for workflow runs (default: false)
+func (c *CollectorsConfig) WorkflowUsageEnabled() bool {
+	return c.BuildStatusEnabled() && isEnabled(c.WorkflowUsage, false)
+}
+
+// CommitsEnabled returns true if new commits are counted for configured branches (default: false)
+func (c *CollectorsConfig) CommitsEnabled() bool {
+	return c.BuildStatusEnabled() && isEnabled(c.Commits, false)
+}
+
+// WorkflowRunsEnabled returns true if completed workflow runs are counted for configured branches (default: false)
+func (c *CollectorsConfig) WorkflowRunsEnabled() bool {
+	return c.BuildStatusEnabled() && isEnabled(c.WorkflowRuns, false)
+}
+
+// CommentsEnabled returns true if new issue and review comments are counted (default: false)
+func (c *CollectorsConfig) CommentsEnabled() bool {
+	return isEnabled(c.Comments, false)
+}
+
+// FirstResponseEnabled returns true if time to first response is observed for new issues (default: false)
+func (c *CollectorsConfig) FirstResponseEnabled() bool {
+	return isEnabled(c.FirstResponse, false)
+}
+
+// IssueActivityEnabled returns true if opened and closed issues are counted (default: false)
+func (c *CollectorsConfig) IssueActivityEnabled() bool {
+	return isEnabled(c.IssueActivity, false)
+}
+
+// CodeownersEnabled returns true if CODEOWNERS files are inspected (default: false)
+func (c *CollectorsConfig) CodeownersEnabled() bool {
+	return isEnabled(c.Codeowners, false)
+}
+
+// CodeownersErrorsEnabled returns true if CODEOWNERS files are validated by GitHub (default: false)
+func (c *CollectorsConfig) CodeownersErrorsEnabled() bool {
+	return c.CodeownersEnabled() && isEnabled(c.CodeownersErrors, false)
+}
+
+// SecurityPolicyEnabled returns true if repository security policies are inspected (default: false)
+func (c *CollectorsConfig) SecurityPolicyEnabled() bool {
+	return isEnabled(c.SecurityPolicy, false)
+}
+
+// SecretsEnabled returns true if Actions secrets and variables are counted (default: false)
+func (c *CollectorsConfig) SecretsEnabled() bool {
+	return isEnabled(c.Secrets, false)
+}
+
+// OIDCEnabled returns true if OIDC usage of repositories and their environments is exported (default: false)
+func (c *CollectorsConfig) OIDCEnabled() bool {
+	return isEnabled(c.OIDC, false)
+}
+
+// CommunityFilesEnabled returns true if community health file presence is exported (default: false)
+func (c *CollectorsConfig) CommunityFilesEnabled() bool {
+	return isEnabled(c.CommunityFiles, false)
+}
+
+// DependencyAutomationEnabled returns true if Renovate and Dependabot configuration is detected (default: false)
+func (c *CollectorsConfig) DependencyAutomationEnabled() bool {
+	return isEnabled(c.DependencyAutomation, false)
+}
+
+// TagProtectionEnabled returns true if tag rulesets are inspected (default: false)
+func (c *CollectorsConfig) TagProtectionEnabled() bool {
+	return isEnabled(c.TagProtection, false)
+}
+
+// BypassActorsEnabled returns true if actors allowed to bypass rulesets and branch protection are counted (default: false)
+func (c *CollectorsConfig) BypassActorsEnabled() bool {
+	return isEnabled(c.BypassActors, false)
+}
+
+// ReleasesEnabled returns true if release cadence metrics are collected (default: false)
+func (c *CollectorsConfig) ReleasesEnabled() bool {
+	return isEnabled(c.Releases, false)
+}
+
+// ReleaseAssetsEnabled returns true if assets of the latest release are exported (default: false)
+func (c *CollectorsConfig) ReleaseAssetsEnabled() bool {
+	return c.ReleasesEnabled() && isEnabled(c.ReleaseAssets, false)
+}
+
+// UnreleasedCommitsEnabled returns true if commits since the latest release are counted (default: false)
+func (c *CollectorsConfig) UnreleasedCommitsEnabled() bool {
+	return c.ReleasesEnabled() && isEnabled(c.UnreleasedCommits, false)
+}
+
+// SchedulesEnabled returns true if scheduled workflows are inventoried (default: false)
+func (c *CollectorsConfig) SchedulesEnabled() bool {
+	return isEnabled(c.Schedules, false)
+}
+
+// WorkflowStateEnabled returns true if the inventory and state of workflows is collected (default: false)
+func (c *CollectorsConfig) WorkflowStateEnabled() bool {
+	return isEnabled(c.WorkflowState, false)
+}
+
+// PackagesEnabled returns true if GitHub Packages metrics are collected (default: false)
+func (c *CollectorsConfig) PackagesEnabled() bool {
+	return isEnabled(c.Packages, false)
+}
+
+// WebhooksEnabled returns true if webhook delivery health is collected (default: false)
+func (c *CollectorsConfig) WebhooksEnabled() bool {
+	return isEnabled(c.Webhooks, false)
+}
+
+// KubernetesConfig configures discovery of additional targets from Kubernetes
+type KubernetesConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Namespaces to search for target ConfigMaps (empty = the exporter's own namespace)
+	Namespaces    []string `yaml:"namespaces"`
+	LabelSelector string   `yaml:"label_selector"` // Label selector for target ConfigMaps
+	// NamespaceAnnotations also reads targets from annotations on namespaces
+	NamespaceAnnotations bool     `yaml:"namespace_annotations"`
+	PollInterval         Duration `yaml:"poll_interval"` // How often to check for changes (default: 1m)
+}
+
+// DefaultKubernetesLabelSelector selects ConfigMaps that contain exporter targets
+const DefaultKubernetesLabelSelector = "github-exporter.d0ugal.com/targets=true"
+
+// StateConfig configures the on-disk state store that survives restarts
+type StateConfig struct {
+	Enabled bool   `yaml:"enabled"`
+	Path    string `yaml:"path"` // State file location (default: github-exporter-state.json)
+}
+
+// DefaultStatePath is the state file used when state.path is not set
+const DefaultStatePath = "github-exporter-state.json"
+
+// WebhookConfig configures the receiver for GitHub webhook deliveries
+type WebhookConfig struct {
+	Enabled bool   `yaml:"enabled"`
+	Path    string `yaml:"path"`   // Endpoint deliveries are posted to (default: /webhook)
+	Secret  string `yaml:"secret"` // Secret of the webhook, used to validate delivery signatures
+	// ReconcileInterval forces a full poll of all targets, including repository
+	// rediscovery, to correct for missed deliveries (0 = disabled)
+	ReconcileInterval Duration `yaml:"reconcile_interval"`
+}
+
+// AdminConfig configures the endpoints controlling the exporter at runtime
+type AdminConfig struct {
+	// Token authenticates requests to the admin endpoints as a bearer token.
+	// The endpoints are disabled without a token.
+	Token string `yaml:"token"`
+
+	// Host and Port bind the admin and resync endpoints to a listener of their
+	// own, so they can be kept internal while metrics are exposed widely. They
+	// are served alongside the metrics while Port is 0.
+	Host string `yaml:"host"` // Default: 127.0.0.1
+	Port int    `yaml:"port"`
+}
+
+// NotificationsConfig configures messages posted to a Slack-compatible incoming
+// webhook when targets keep failing or the token is about to expire
+type NotificationsConfig struct {
+	WebhookURL string `yaml:"webhook_url"` // Notifications are disabled without a URL
+	// FailureThreshold is the number of consecutive failed collections of a
+	// target before a notification is sent (default: 3)
+	FailureThreshold int `yaml:"failure_threshold"`
+	// TokenExpiryWarning notifies once the token expires within this duration
+	// (default: 168h, 0 = no expiry notifications)
+	TokenExpiryWarning *Duration `yaml:"token_expiry_warning,omitempty"`
+}
+
+// Enabled reports whether notifications are sent
+func (n *NotificationsConfig) Enabled() bool {
+	return n.WebhookURL != ""
+}
+
+// ExpiryWarning returns how long before the token expires a notification is sent,
+// 0 when expiry notifications are disabled
+func (n *NotificationsConfig) ExpiryWarning() time.Duration {
+	if n.TokenExpiryWarning == nil {
+		return DefaultTokenExpiryWarning
+	}
+
+	return n.TokenExpiryWarning.Duration
+}
+
+// DefaultFailureThreshold is the number of consecutive failures notified about when
+// notifications.failure_threshold is not set
+const DefaultFailureThreshold = 3
+
+// DefaultTokenExpiryWarning is how long before the token expires a notification is
+// sent when notifications.token_expiry_warning is not set
+const DefaultTokenExpiryWarning = 7 * 24 * time.Hour
+
+// DefaultAdminHost is the interface the admin listener binds to when admin.host is not set
+const DefaultAdminHost = "127.0.0.1"
+
+// SeparateListener reports whether the admin endpoints have a listener of their own
+func (a *AdminConfig) SeparateListener() bool {
+	return a.Port != 0
+}
+
+// DefaultWebhookPath is the endpoint of the webhook receiver when webhook.path is not set
+const DefaultWebhookPath = "/webhook"
+
+// ResyncPathPrefix is the prefix of the endpoint forcing a resync of a repository
+// in webhook mode
+const ResyncPathPrefix = "/api/v1/resync/"
+
+// LoadConfig loads configuration from either YAML files or environment variables.
+// When configDir is set, the YAML fragments it contains are merged over the config file.
+func LoadConfig(path, configDir string, configFromEnv bool) (*Config, error) {
 	if configFromEnv {
+		if configDir != "" {
+			return nil, fmt.Errorf("a config directory cannot be combined with configuration from environment variables")
+		}
+
 		return loadFromEnv()
 	}
 
+	if configDir != "" {
+		return LoadWithDir(path, configDir)
+	}
+
 	return Load(path)
 }
 
@@ -47,11 +705,74 @@ func Load(path string) (*Config, error) {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
+	return parse(data)
+}
+
+// LoadWithDir loads configuration from a base YAML file merged with every *.yaml
+// and *.yml fragment in dir, applied in lexical order. The base file is optional.
+func LoadWithDir(path, dir string) (*Config, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config directory: %w", err)
+	}
+
+	if !info.IsDir() {
+		return nil, fmt.Errorf("config directory %s is not a directory", dir)
+	}
+
+	var paths []string
+
+	if path != "" {
+		if _, err := os.Stat(path); err == nil {
+			paths = append(paths, path)
+		} else if !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to read config file: %w", err)
+		}
+	}
+
+	fragments, err := readConfigDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	paths = append(paths, fragments...)
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no configuration files found in %s", dir)
+	}
+
+	data, err := mergeConfigFiles(paths)
+	if err != nil {
+		return nil, err
+	}
+
+	return parse(data)
+}
+
+// parse decodes, defaults and validates a YAML configuration document
+func parse(data []byte) (*Config, error) {
 	var config Config
 	if err := yaml.Unmarshal(data, &config); err != nil {
 		return nil, fmt.Errorf("failed to parse config file: %w", err)
 	}
 
+	var metricsSection struct {
+		Metrics MetricsOptions `yaml:"metrics"`
+	}
+	if err := yaml.Unmarshal(data, &metricsSection); err != nil {
+		return nil, fmt.Errorf("failed to parse metrics config: %w", err)
+	}
+
+	config.MetricsOptions = metricsSection.Metrics
+
+	var serverSection struct {
+		Server ServerOptions `yaml:"server"`
+	}
+	if err := yaml.Unmarshal(data, &serverSection); err != nil {
+		return nil, fmt.Errorf("failed to parse server config: %w", err)
+	}
+
+	config.ServerOptions = serverSection.Server
+
 	// Set defaults
 	setDefaults(&config)
 
@@ -87,6 +808,30 @@ func loadFromEnv() (*Config, error) {
 		baseConfig.Server.Port = 8080
 	}
 
+	if certFile := os.Getenv("GITHUB_EXPORTER_SERVER_TLS_CERT_FILE"); certFile != "" {
+		config.ServerOptions.TLS.CertFile = certFile
+	}
+
+	if keyFile := os.Getenv("GITHUB_EXPORTER_SERVER_TLS_KEY_FILE"); keyFile != "" {
+		config.ServerOptions.TLS.KeyFile = keyFile
+	}
+
+	if username := os.Getenv("GITHUB_EXPORTER_SERVER_AUTH_USERNAME"); username != "" {
+		config.ServerOptions.Auth.Username = username
+	}
+
+	if password := os.Getenv("GITHUB_EXPORTER_SERVER_AUTH_PASSWORD"); password != "" {
+		config.ServerOptions.Auth.Password = password
+	}
+
+	if token := os.Getenv("GITHUB_EXPORTER_SERVER_AUTH_BEARER_TOKEN"); token != "" {
+		config.ServerOptions.Auth.BearerToken = token
+	}
+
+	if networksStr := os.Getenv("GITHUB_EXPORTER_SERVER_ALLOWED_NETWORKS"); networksStr != "" {
+		config.ServerOptions.AllowedNetworks = strings.Split(networksStr, ",")
+	}
+
 	// Logging configuration
 	if level := os.Getenv("GITHUB_EXPORTER_LOG_LEVEL"); level != "" {
 		baseConfig.Logging.Level = level
@@ -112,6 +857,43 @@ func loadFromEnv() (*Config, error) {
 		baseConfig.Metrics.Collection.DefaultInterval = promexporter_config.Duration{Duration: time.Second * 30}
 	}
 
+	if namespace := os.Getenv("GITHUB_EXPORTER_METRICS_NAMESPACE"); namespace != "" {
+		config.MetricsOptions.Namespace = namespace
+	}
+
+	if openMetricsStr := os.Getenv("GITHUB_EXPORTER_METRICS_OPENMETRICS"); openMetricsStr != "" {
+		if openMetrics, err := ParseBool(openMetricsStr); err != nil {
+			return nil, fmt.Errorf("invalid metrics openmetrics value: %w", err)
+		} else {
+			config.MetricsOptions.OpenMetrics = &openMetrics
+		}
+	}
+
+	if compatStr := os.Getenv("GITHUB_EXPORTER_METRICS_COMPATIBILITY_MODE"); compatStr != "" {
+		if compat, err := ParseBool(compatStr); err != nil {
+			return nil, fmt.Errorf("invalid metrics compatibility mode value: %w", err)
+		} else {
+			config.MetricsOptions.CompatibilityMode = compat
+		}
+	}
+
+	if repoLabelsStr := os.Getenv("GITHUB_EXPORTER_METRICS_REPO_LABELS"); repoLabelsStr != "" {
+		repoLabels, err := ParseStringMap(repoLabelsStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid metrics repo labels: %w", err)
+		}
+
+		config.MetricsOptions.RepoLabels = repoLabels
+	}
+
+	if createdStr := os.Getenv("GITHUB_EXPORTER_METRICS_CREATED_TIMESTAMPS"); createdStr != "" {
+		if created, err := ParseBool(createdStr); err != nil {
+			return nil, fmt.Errorf("invalid metrics created timestamps value: %w", err)
+		} else {
+			config.MetricsOptions.CreatedTimestamps = created
+		}
+	}
+
 	// Tracing configuration
 	if enabledStr := os.Getenv("TRACING_ENABLED"); enabledStr != "" {
 		enabled := enabledStr == "true"
@@ -133,6 +915,38 @@ func loadFromEnv() (*Config, error) {
 		config.GitHub.Token = token
 	}
 
+	if tokensStr := os.Getenv("GITHUB_EXPORTER_GITHUB_TOKENS"); tokensStr != "" {
+		config.GitHub.Tokens = strings.Split(tokensStr, ",")
+	}
+
+	if appIDStr := os.Getenv("GITHUB_EXPORTER_GITHUB_APP_ID"); appIDStr != "" {
+		if appID, err := strconv.ParseInt(appIDStr, 10, 64); err != nil {
+			return nil, fmt.Errorf("invalid GitHub App ID: %w", err)
+		} else {
+			config.GitHub.App.AppID = appID
+		}
+	}
+
+	if keyPath := os.Getenv("GITHUB_EXPORTER_GITHUB_APP_PRIVATE_KEY_PATH"); keyPath != "" {
+		config.GitHub.App.PrivateKeyPath = keyPath
+	}
+
+	if installationIDStr := os.Getenv("GITHUB_EXPORTER_GITHUB_APP_INSTALLATION_ID"); installationIDStr != "" {
+		if installationID, err := strconv.ParseInt(installationIDStr, 10, 64); err != nil {
+			return nil, fmt.Errorf("invalid GitHub App installation ID: %w", err)
+		} else {
+			config.GitHub.App.InstallationID = installationID
+		}
+	}
+
+	if pollIntervalStr := os.Getenv("GITHUB_EXPORTER_GITHUB_APP_POLL_INTERVAL"); pollIntervalStr != "" {
+		if pollInterval, err := time.ParseDuration(pollIntervalStr); err != nil {
+			return nil, fmt.Errorf("invalid GitHub App poll interval: %w", err)
+		} else {
+			config.GitHub.App.PollInterval = Duration{Duration: pollInterval}
+		}
+	}
+
 	if orgsStr := os.Getenv("GITHUB_EXPORTER_GITHUB_ORGS"); orgsStr != "" {
 		config.GitHub.Orgs = strings.Split(orgsStr, ",")
 	}
@@ -141,6 +955,80 @@ func loadFromEnv() (*Config, error) {
 		config.GitHub.Repos = strings.Split(reposStr, ",")
 	}
 
+	if teamsStr := os.Getenv("GITHUB_EXPORTER_GITHUB_TEAMS"); teamsStr != "" {
+		config.GitHub.Teams = ParseStringList(teamsStr)
+	}
+
+	if starredStr := os.Getenv("GITHUB_EXPORTER_GITHUB_STARRED"); starredStr != "" {
+		if starred, err := ParseBool(starredStr); err != nil {
+			return nil, fmt.Errorf("invalid GitHub starred value: %w", err)
+		} else {
+			config.GitHub.Starred = starred
+		}
+	}
+
+	if usersStr := os.Getenv("GITHUB_EXPORTER_GITHUB_USERS"); usersStr != "" {
+		config.GitHub.Users = ParseStringList(usersStr)
+	}
+
+	if projectsStr := os.Getenv("GITHUB_EXPORTER_GITHUB_PROJECTS"); projectsStr != "" {
+		config.GitHub.Projects = ParseStringList(projectsStr)
+	}
+
+	if excludeReposStr := os.Getenv("GITHUB_EXPORTER_GITHUB_EXCLUDE_REPOS"); excludeReposStr != "" {
+		config.GitHub.ExcludeRepos = ParseStringList(excludeReposStr)
+	}
+
+	if skipArchivedStr := os.Getenv("GITHUB_EXPORTER_GITHUB_SKIP_ARCHIVED"); skipArchivedStr != "" {
+		if skipArchived, err := ParseBool(skipArchivedStr); err != nil {
+			return nil, fmt.Errorf("invalid GitHub skip archived value: %w", err)
+		} else {
+			config.GitHub.SkipArchived = skipArchived
+		}
+	}
+
+	if skipForksStr := os.Getenv("GITHUB_EXPORTER_GITHUB_SKIP_FORKS"); skipForksStr != "" {
+		if skipForks, err := ParseBool(skipForksStr); err != nil {
+			return nil, fmt.Errorf("invalid GitHub skip forks value: %w", err)
+		} else {
+			config.GitHub.SkipForks = skipForks
+		}
+	}
+
+	if forkActivityStr := os.Getenv("GITHUB_EXPORTER_GITHUB_FORK_ACTIVITY"); forkActivityStr != "" {
+		config.GitHub.ForkActivity = ParseStringList(forkActivityStr)
+	}
+
+	if searchesStr := os.Getenv("GITHUB_EXPORTER_GITHUB_CUSTOM_SEARCHES"); searchesStr != "" {
+		searches, err := ParseCustomSearches(searchesStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid GitHub custom searches: %w", err)
+		}
+
+		config.GitHub.CustomSearches = searches
+	}
+
+	if field := os.Getenv("GITHUB_EXPORTER_GITHUB_PROJECT_STATUS_FIELD"); field != "" {
+		config.GitHub.ProjectStatusField = field
+	}
+
+	if field := os.Getenv("GITHUB_EXPORTER_GITHUB_PROJECT_ITERATION_FIELD"); field != "" {
+		config.GitHub.ProjectIterationField = field
+	}
+
+	if blackoutsStr := os.Getenv("GITHUB_EXPORTER_GITHUB_BLACKOUTS"); blackoutsStr != "" {
+		blackouts, err := ParseBlackouts(blackoutsStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid GitHub blackouts: %w", err)
+		}
+
+		config.GitHub.Blackouts = blackouts
+	}
+
+	if packageTypesStr := os.Getenv("GITHUB_EXPORTER_GITHUB_PACKAGE_TYPES"); packageTypesStr != "" {
+		config.GitHub.PackageTypes = ParseStringList(packageTypesStr)
+	}
+
 	if branchesStr := os.Getenv("GITHUB_EXPORTER_GITHUB_BRANCHES"); branchesStr != "" {
 		config.GitHub.Branches = strings.Split(branchesStr, ",")
 	}
@@ -149,6 +1037,46 @@ func loadFromEnv() (*Config, error) {
 		config.GitHub.Workflows = strings.Split(workflowsStr, ",")
 	}
 
+	if attributionStr := os.Getenv("GITHUB_EXPORTER_GITHUB_RUN_ATTRIBUTION"); attributionStr != "" {
+		if attribution, err := ParseBool(attributionStr); err != nil {
+			return nil, fmt.Errorf("invalid GitHub run attribution value: %w", err)
+		} else {
+			config.GitHub.RunAttribution = attribution
+		}
+	}
+
+	if latestStr := os.Getenv("GITHUB_EXPORTER_GITHUB_BUILD_STATUS_LATEST_CHECK_SUITE"); latestStr != "" {
+		if latest, err := ParseBool(latestStr); err != nil {
+			return nil, fmt.Errorf("invalid GitHub build status latest check suite value: %w", err)
+		} else {
+			config.GitHub.BuildStatus.LatestCheckSuite = latest
+		}
+	}
+
+	if requiredStr := os.Getenv("GITHUB_EXPORTER_GITHUB_BUILD_STATUS_REQUIRED_ONLY"); requiredStr != "" {
+		if required, err := ParseBool(requiredStr); err != nil {
+			return nil, fmt.Errorf("invalid GitHub build status required only value: %w", err)
+		} else {
+			config.GitHub.BuildStatus.RequiredOnly = required
+		}
+	}
+
+	if backfillStr := os.Getenv("GITHUB_EXPORTER_GITHUB_BUILD_STATUS_BACKFILL_RUNS"); backfillStr != "" {
+		if backfill, err := ParseInt(backfillStr); err != nil {
+			return nil, fmt.Errorf("invalid GitHub build status backfill runs: %w", err)
+		} else {
+			config.GitHub.BuildStatus.BackfillRuns = backfill
+		}
+	}
+
+	if budgetStr := os.Getenv("GITHUB_EXPORTER_GITHUB_BUILD_STATUS_BACKFILL_BUDGET"); budgetStr != "" {
+		if budget, err := ParseInt(budgetStr); err != nil {
+			return nil, fmt.Errorf("invalid GitHub build status backfill budget: %w", err)
+		} else {
+			config.GitHub.BuildStatus.BackfillBudget = budget
+		}
+	}
+
 	if timeoutStr := os.Getenv("GITHUB_EXPORTER_GITHUB_TIMEOUT"); timeoutStr != "" {
 		if timeout, err := time.ParseDuration(timeoutStr); err != nil {
 			return nil, fmt.Errorf("invalid GitHub timeout: %w", err)
@@ -159,6 +1087,15 @@ func loadFromEnv() (*Config, error) {
 		config.GitHub.Timeout = Duration{Duration: time.Second * 30}
 	}
 
+	if timeoutsStr := os.Getenv("GITHUB_EXPORTER_GITHUB_TIMEOUTS"); timeoutsStr != "" {
+		timeouts, err := ParseDurationMap(timeoutsStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid GitHub timeouts: %w", err)
+		}
+
+		config.GitHub.Timeouts = timeouts
+	}
+
 	if refreshIntervalStr := os.Getenv("GITHUB_EXPORTER_GITHUB_REFRESH_INTERVAL"); refreshIntervalStr != "" {
 		if refreshInterval, err := time.ParseDuration(refreshIntervalStr); err != nil {
 			return nil, fmt.Errorf("invalid GitHub refresh interval: %w", err)
@@ -170,6 +1107,86 @@ func loadFromEnv() (*Config, error) {
 		config.GitHub.RefreshInterval = Duration{Duration: 0}
 	}
 
+	if discoveryIntervalStr := os.Getenv("GITHUB_EXPORTER_GITHUB_DISCOVERY_INTERVAL"); discoveryIntervalStr != "" {
+		if discoveryInterval, err := time.ParseDuration(discoveryIntervalStr); err != nil {
+			return nil, fmt.Errorf("invalid GitHub discovery interval: %w", err)
+		} else {
+			config.GitHub.DiscoveryInterval = Duration{Duration: discoveryInterval}
+		}
+	}
+
+	if revalidateIntervalStr := os.Getenv("GITHUB_EXPORTER_GITHUB_REVALIDATE_INTERVAL"); revalidateIntervalStr != "" {
+		if revalidateInterval, err := time.ParseDuration(revalidateIntervalStr); err != nil {
+			return nil, fmt.Errorf("invalid GitHub revalidate interval: %w", err)
+		} else {
+			config.GitHub.RevalidateInterval = Duration{Duration: revalidateInterval}
+		}
+	}
+
+	if thresholdStr := os.Getenv("GITHUB_EXPORTER_GITHUB_DRIFT_THRESHOLD"); thresholdStr != "" {
+		if threshold, err := strconv.ParseFloat(thresholdStr, 64); err != nil {
+			return nil, fmt.Errorf("invalid GitHub drift threshold: %w", err)
+		} else {
+			config.GitHub.DriftThreshold = threshold
+		}
+	}
+
+	if floorStr := os.Getenv("GITHUB_EXPORTER_GITHUB_DEGRADED_MODE_FLOOR"); floorStr != "" {
+		if floor, err := strconv.Atoi(floorStr); err != nil {
+			return nil, fmt.Errorf("invalid GitHub degraded mode floor: %w", err)
+		} else {
+			config.GitHub.DegradedModeFloor = floor
+		}
+	}
+
+	if branchesStr := os.Getenv("GITHUB_EXPORTER_GITHUB_PRIORITY_BRANCHES"); branchesStr != "" {
+		config.GitHub.PriorityBranches = ParseStringList(branchesStr)
+	}
+
+	if deadlineStr := os.Getenv("GITHUB_EXPORTER_GITHUB_COLLECTION_DEADLINE"); deadlineStr != "" {
+		if deadline, err := time.ParseDuration(deadlineStr); err != nil {
+			return nil, fmt.Errorf("invalid GitHub collection deadline: %w", err)
+		} else {
+			config.GitHub.CollectionDeadline = Duration{Duration: deadline}
+		}
+	}
+
+	if staggerStr := os.Getenv("GITHUB_EXPORTER_GITHUB_STAGGER_TARGETS"); staggerStr != "" {
+		if stagger, err := ParseBool(staggerStr); err != nil {
+			return nil, fmt.Errorf("invalid GitHub stagger targets: %w", err)
+		} else {
+			config.GitHub.StaggerTargets = stagger
+		}
+	}
+
+	if jitterStr := os.Getenv("GITHUB_EXPORTER_GITHUB_STAGGER_JITTER"); jitterStr != "" {
+		if jitter, err := strconv.ParseFloat(jitterStr, 64); err != nil {
+			return nil, fmt.Errorf("invalid GitHub stagger jitter: %w", err)
+		} else {
+			config.GitHub.StaggerJitter = jitter
+		}
+	}
+
+	if maxReposStr := os.Getenv("GITHUB_EXPORTER_GITHUB_MAX_REPOS"); maxReposStr != "" {
+		if maxRepos, err := ParseInt(maxReposStr); err != nil {
+			return nil, fmt.Errorf("invalid GitHub max repos: %w", err)
+		} else {
+			config.GitHub.MaxRepos = maxRepos
+		}
+	}
+
+	if policy := os.Getenv("GITHUB_EXPORTER_GITHUB_MAX_REPOS_POLICY"); policy != "" {
+		config.GitHub.MaxReposPolicy = policy
+	}
+
+	if policy := os.Getenv("GITHUB_EXPORTER_GITHUB_FAILURE_POLICY"); policy != "" {
+		config.GitHub.FailurePolicy = policy
+	}
+
+	if policy := os.Getenv("GITHUB_EXPORTER_GITHUB_NOT_FOUND_POLICY"); policy != "" {
+		config.GitHub.NotFoundPolicy = policy
+	}
+
 	if bufferStr := os.Getenv("GITHUB_EXPORTER_GITHUB_RATE_LIMIT_BUFFER"); bufferStr != "" {
 		if buffer, err := strconv.ParseFloat(bufferStr, 64); err != nil {
 			return nil, fmt.Errorf("invalid GitHub rate limit buffer: %w", err)
@@ -180,50 +1197,282 @@ func loadFromEnv() (*Config, error) {
 		config.GitHub.RateLimitBuffer = 0.8 // Default to 80% of rate limit
 	}
 
-	// Set defaults for any missing values
-	setDefaults(config)
+	// Collector switches
+	for _, collector := range []struct {
+		env   string
+		value **bool
+	}{
+		{"GITHUB_EXPORTER_COLLECTORS_REPO_STATS", &config.Collectors.RepoStats},
+		{"GITHUB_EXPORTER_COLLECTORS_ORG_STATS", &config.Collectors.OrgStats},
+		{"GITHUB_EXPORTER_COLLECTORS_ACTIONS_POLICY", &config.Collectors.ActionsPolicy},
+		{"GITHUB_EXPORTER_COLLECTORS_SSO", &config.Collectors.SSO},
+		{"GITHUB_EXPORTER_COLLECTORS_PRS", &config.Collectors.PullRequests},
+		{"GITHUB_EXPORTER_COLLECTORS_BUILD_STATUS", &config.Collectors.BuildStatus},
+		{"GITHUB_EXPORTER_COLLECTORS_CHECK_RUNS", &config.Collectors.CheckRuns},
+		{"GITHUB_EXPORTER_COLLECTORS_WORKFLOW_USAGE", &config.Collectors.WorkflowUsage},
+		{"GITHUB_EXPORTER_COLLECTORS_WEBHOOKS", &config.Collectors.Webhooks},
+		{"GITHUB_EXPORTER_COLLECTORS_COMMITS", &config.Collectors.Commits},
+		{"GITHUB_EXPORTER_COLLECTORS_WORKFLOW_RUNS", &config.Collectors.WorkflowRuns},
+		{"GITHUB_EXPORTER_COLLECTORS_COMMENTS", &config.Collectors.Comments},
+		{"GITHUB_EXPORTER_COLLECTORS_FIRST_RESPONSE", &config.Collectors.FirstResponse},
+		{"GITHUB_EXPORTER_COLLECTORS_ISSUE_ACTIVITY", &config.Collectors.IssueActivity},
+		{"GITHUB_EXPORTER_COLLECTORS_CODEOWNERS", &config.Collectors.Codeowners},
+		{"GITHUB_EXPORTER_COLLECTORS_CODEOWNERS_ERRORS", &config.Collectors.CodeownersErrors},
+		{"GITHUB_EXPORTER_COLLECTORS_SECURITY_POLICY", &config.Collectors.SecurityPolicy},
+		{"GITHUB_EXPORTER_COLLECTORS_COMMUNITY_FILES", &config.Collectors.CommunityFiles},
+		{"GITHUB_EXPORTER_COLLECTORS_DEPENDENCY_AUTOMATION", &config.Collectors.DependencyAutomation},
+		{"GITHUB_EXPORTER_COLLECTORS_TAG_PROTECTION", &config.Collectors.TagProtection},
+		{"GITHUB_EXPORTER_COLLECTORS_BYPASS_ACTORS", &config.Collectors.BypassActors},
+		{"GITHUB_EXPORTER_COLLECTORS_SECRETS", &config.Collectors.Secrets},
+		{"GITHUB_EXPORTER_COLLECTORS_OIDC", &config.Collectors.OIDC},
+		{"GITHUB_EXPORTER_COLLECTORS_RELEASES", &config.Collectors.Releases},
+		{"GITHUB_EXPORTER_COLLECTORS_RELEASE_ASSETS", &config.Collectors.ReleaseAssets},
+		{"GITHUB_EXPORTER_COLLECTORS_UNRELEASED_COMMITS", &config.Collectors.UnreleasedCommits},
+		{"GITHUB_EXPORTER_COLLECTORS_SCHEDULES", &config.Collectors.Schedules},
+		{"GITHUB_EXPORTER_COLLECTORS_WORKFLOW_STATE", &config.Collectors.WorkflowState},
+		{"GITHUB_EXPORTER_COLLECTORS_PACKAGES", &config.Collectors.Packages},
+	} {
+		if enabledStr := os.Getenv(collector.env); enabledStr != "" {
+			enabled, err := ParseBool(enabledStr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s value: %w", collector.env, err)
+			}
+
+			*collector.value = &enabled
+		}
+	}
+
+	// Kubernetes discovery configuration
+	if enabledStr := os.Getenv("GITHUB_EXPORTER_KUBERNETES_ENABLED"); enabledStr != "" {
+		if enabled, err := ParseBool(enabledStr); err != nil {
+			return nil, fmt.Errorf("invalid Kubernetes enabled value: %w", err)
+		} else {
+			config.Kubernetes.Enabled = enabled
+		}
+	}
+
+	if namespacesStr := os.Getenv("GITHUB_EXPORTER_KUBERNETES_NAMESPACES"); namespacesStr != "" {
+		config.Kubernetes.Namespaces = ParseStringList(namespacesStr)
+	}
+
+	if selector := os.Getenv("GITHUB_EXPORTER_KUBERNETES_LABEL_SELECTOR"); selector != "" {
+		config.Kubernetes.LabelSelector = selector
+	}
+
+	if annotationsStr := os.Getenv("GITHUB_EXPORTER_KUBERNETES_NAMESPACE_ANNOTATIONS"); annotationsStr != "" {
+		if annotations, err := ParseBool(annotationsStr); err != nil {
+			return nil, fmt.Errorf("invalid Kubernetes namespace annotations value: %w", err)
+		} else {
+			config.Kubernetes.NamespaceAnnotations = annotations
+		}
+	}
+
+	if pollIntervalStr := os.Getenv("GITHUB_EXPORTER_KUBERNETES_POLL_INTERVAL"); pollIntervalStr != "" {
+		if pollInterval, err := time.ParseDuration(pollIntervalStr); err != nil {
+			return nil, fmt.Errorf("invalid Kubernetes poll interval: %w", err)
+		} else {
+			config.Kubernetes.PollInterval = Duration{Duration: pollInterval}
+		}
+	}
+
+	// State store configuration
+	if enabledStr := os.Getenv("GITHUB_EXPORTER_STATE_ENABLED"); enabledStr != "" {
+		if enabled, err := ParseBool(enabledStr); err != nil {
+			return nil, fmt.Errorf("invalid state enabled value: %w", err)
+		} else {
+			config.State.Enabled = enabled
+		}
+	}
+
+	if path := os.Getenv("GITHUB_EXPORTER_STATE_PATH"); path != "" {
+		config.State.Path = path
+	}
+
+	// Webhook receiver configuration
+	if enabledStr := os.Getenv("GITHUB_EXPORTER_WEBHOOK_ENABLED"); enabledStr != "" {
+		if enabled, err := ParseBool(enabledStr); err != nil {
+			return nil, fmt.Errorf("invalid webhook enabled value: %w", err)
+		} else {
+			config.Webhook.Enabled = enabled
+		}
+	}
+
+	if path := os.Getenv("GITHUB_EXPORTER_WEBHOOK_PATH"); path != "" {
+		config.Webhook.Path = path
+	}
+
+	if secret := os.Getenv("GITHUB_EXPORTER_WEBHOOK_SECRET"); secret != "" {
+		config.Webhook.Secret = secret
+	}
+
+	if intervalStr := os.Getenv("GITHUB_EXPORTER_WEBHOOK_RECONCILE_INTERVAL"); intervalStr != "" {
+		if interval, err := time.ParseDuration(intervalStr); err != nil {
+			return nil, fmt.Errorf("invalid webhook reconcile interval: %w", err)
+		} else {
+			config.Webhook.ReconcileInterval = Duration{Duration: interval}
+		}
+	}
+
+	if token := os.Getenv("GITHUB_EXPORTER_ADMIN_TOKEN"); token != "" {
+		config.Admin.Token = token
+	}
+
+	if webhookURL := os.Getenv("GITHUB_EXPORTER_NOTIFICATIONS_WEBHOOK_URL"); webhookURL != "" {
+		config.Notifications.WebhookURL = webhookURL
+	}
+
+	if thresholdStr := os.Getenv("GITHUB_EXPORTER_NOTIFICATIONS_FAILURE_THRESHOLD"); thresholdStr != "" {
+		if threshold, err := strconv.Atoi(thresholdStr); err != nil {
+			return nil, fmt.Errorf("invalid notifications failure threshold: %w", err)
+		} else {
+			config.Notifications.FailureThreshold = threshold
+		}
+	}
+
+	if warningStr := os.Getenv("GITHUB_EXPORTER_NOTIFICATIONS_TOKEN_EXPIRY_WARNING"); warningStr != "" {
+		if warning, err := time.ParseDuration(warningStr); err != nil {
+			return nil, fmt.Errorf("invalid notifications token expiry warning: %w", err)
+		} else {
+			config.Notifications.TokenExpiryWarning = &Duration{Duration: warning}
+		}
+	}
+
+	if host := os.Getenv("GITHUB_EXPORTER_ADMIN_HOST"); host != "" {
+		config.Admin.Host = host
+	}
+
+	if portStr := os.Getenv("GITHUB_EXPORTER_ADMIN_PORT"); portStr != "" {
+		if port, err := strconv.Atoi(portStr); err != nil {
+			return nil, fmt.Errorf("invalid admin port: %w", err)
+		} else {
+			config.Admin.Port = port
+		}
+	}
+
+	// Set defaults for any missing values
+	setDefaults(config)
+
+	// Validate configuration
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("configuration validation failed: %w", err)
+	}
+
+	return config, nil
+}
+
+// setDefaults sets default values for configuration
+func setDefaults(config *Config) {
+	if config.Server.Host == "" {
+		config.Server.Host = "0.0.0.0"
+	}
+
+	if config.Server.Port == 0 {
+		config.Server.Port = 8080
+	}
+
+	if config.Admin.Host == "" {
+		config.Admin.Host = DefaultAdminHost
+	}
+
+	if config.Notifications.FailureThreshold == 0 {
+		config.Notifications.FailureThreshold = DefaultFailureThreshold
+	}
+
+	if config.Logging.Level == "" {
+		config.Logging.Level = "info"
+	}
+
+	if config.Logging.Format == "" {
+		config.Logging.Format = "json"
+	}
+
+	if !config.Metrics.Collection.DefaultIntervalSet {
+		config.Metrics.Collection.DefaultInterval = promexporter_config.Duration{Duration: time.Second * 30}
+	}
+
+	if config.MetricsOptions.Namespace == "" {
+		config.MetricsOptions.Namespace = "github"
+	}
+
+	if config.GitHub.Timeout.Duration == 0 {
+		config.GitHub.Timeout = Duration{Duration: time.Second * 30}
+	}
+
+	if config.GitHub.RefreshInterval.Duration == 0 {
+		// Will be calculated dynamically based on rate limits
+		config.GitHub.RefreshInterval = Duration{Duration: 0}
+	}
+
+	if config.GitHub.RateLimitBuffer == 0 {
+		config.GitHub.RateLimitBuffer = 0.8
+	}
+
+	if config.GitHub.DriftThreshold == 0 {
+		config.GitHub.DriftThreshold = 0.1
+	}
+
+	if config.GitHub.MaxReposPolicy == "" {
+		config.GitHub.MaxReposPolicy = MaxReposPolicyAbort
+	}
+
+	if config.GitHub.FailurePolicy == "" {
+		config.GitHub.FailurePolicy = FailurePolicyKeep
+	}
+
+	if config.GitHub.NotFoundPolicy == "" {
+		config.GitHub.NotFoundPolicy = NotFoundPolicyWarn
+	}
 
-	// Validate configuration
-	if err := config.Validate(); err != nil {
-		return nil, fmt.Errorf("configuration validation failed: %w", err)
+	if config.GitHub.BuildStatus.BackfillBudget == 0 {
+		config.GitHub.BuildStatus.BackfillBudget = 10
 	}
 
-	return config, nil
-}
+	if config.GitHub.ProjectStatusField == "" {
+		config.GitHub.ProjectStatusField = "Status"
+	}
 
-// setDefaults sets default values for configuration
-func setDefaults(config *Config) {
-	if config.Server.Host == "" {
-		config.Server.Host = "0.0.0.0"
+	if config.GitHub.ProjectIterationField == "" {
+		config.GitHub.ProjectIterationField = "Iteration"
 	}
 
-	if config.Server.Port == 0 {
-		config.Server.Port = 8080
+	if len(config.GitHub.PackageTypes) == 0 {
+		config.GitHub.PackageTypes = []string{"container", "npm", "maven"}
 	}
 
-	if config.Logging.Level == "" {
-		config.Logging.Level = "info"
+	for i := range config.GitHub.SyntheticChecks {
+		check := &config.GitHub.SyntheticChecks[i]
+
+		if check.Ref == "" {
+			check.Ref = "main"
+		}
+
+		if check.Interval.Duration == 0 {
+			check.Interval = Duration{Duration: time.Hour}
+		}
+
+		if check.Timeout.Duration == 0 {
+			check.Timeout = Duration{Duration: 30 * time.Minute}
+		}
 	}
 
-	if config.Logging.Format == "" {
-		config.Logging.Format = "json"
+	if config.GitHub.App.Enabled() && config.GitHub.App.PollInterval.Duration == 0 {
+		config.GitHub.App.PollInterval = Duration{Duration: time.Hour}
 	}
 
-	if !config.Metrics.Collection.DefaultIntervalSet {
-		config.Metrics.Collection.DefaultInterval = promexporter_config.Duration{Duration: time.Second * 30}
+	if config.Kubernetes.LabelSelector == "" {
+		config.Kubernetes.LabelSelector = DefaultKubernetesLabelSelector
 	}
 
-	if config.GitHub.Timeout.Duration == 0 {
-		config.GitHub.Timeout = Duration{Duration: time.Second * 30}
+	if config.Kubernetes.PollInterval.Duration == 0 {
+		config.Kubernetes.PollInterval = Duration{Duration: time.Minute}
 	}
 
-	if config.GitHub.RefreshInterval.Duration == 0 {
-		// Will be calculated dynamically based on rate limits
-		config.GitHub.RefreshInterval = Duration{Duration: 0}
+	if config.State.Path == "" {
+		config.State.Path = DefaultStatePath
 	}
 
-	if config.GitHub.RateLimitBuffer == 0 {
-		config.GitHub.RateLimitBuffer = 0.8
+	if config.Webhook.Path == "" {
+		config.Webhook.Path = DefaultWebhookPath
 	}
 }
 
@@ -249,6 +1498,59 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("github config: %w", err)
 	}
 
+	// Validate Kubernetes configuration
+	if err := c.validateKubernetesConfig(); err != nil {
+		return fmt.Errorf("kubernetes config: %w", err)
+	}
+
+	// Validate webhook receiver configuration
+	if err := c.validateWebhookConfig(); err != nil {
+		return fmt.Errorf("webhook config: %w", err)
+	}
+
+	// Validate admin configuration
+	if err := c.validateAdminConfig(); err != nil {
+		return fmt.Errorf("admin config: %w", err)
+	}
+
+	// Validate notifications configuration
+	if err := c.validateNotificationsConfig(); err != nil {
+		return fmt.Errorf("notifications config: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Config) validateNotificationsConfig() error {
+	if !c.Notifications.Enabled() {
+		return nil
+	}
+
+	webhookURL, err := url.Parse(c.Notifications.WebhookURL)
+	if err != nil || (webhookURL.Scheme != "https" && webhookURL.Scheme != "http") || webhookURL.Host == "" {
+		return fmt.Errorf("webhook_url must be an http or https URL")
+	}
+
+	if c.Notifications.FailureThreshold < 1 {
+		return fmt.Errorf("failure_threshold must be at least 1, got %d", c.Notifications.FailureThreshold)
+	}
+
+	if warning := c.Notifications.ExpiryWarning(); warning < 0 {
+		return fmt.Errorf("token_expiry_warning must not be negative, got %s", warning)
+	}
+
+	return nil
+}
+
+func (c *Config) validateAdminConfig() error {
+	if c.Admin.Port < 0 || c.Admin.Port > 65535 {
+		return fmt.Errorf("port must be between 1 and 65535, got %d", c.Admin.Port)
+	}
+
+	if c.Admin.Port == c.Server.Port {
+		return fmt.Errorf("port %d is already used by the server", c.Admin.Port)
+	}
+
 	return nil
 }
 
@@ -257,6 +1559,20 @@ func (c *Config) validateServerConfig() error {
 		return fmt.Errorf("port must be between 1 and 65535, got %d", c.Server.Port)
 	}
 
+	tls := c.ServerOptions.TLS
+	if (tls.CertFile == "") != (tls.KeyFile == "") {
+		return fmt.Errorf("tls needs both cert_file and key_file")
+	}
+
+	auth := c.ServerOptions.Auth
+	if (auth.Username == "") != (auth.Password == "") {
+		return fmt.Errorf("auth needs both username and password")
+	}
+
+	if _, err := c.ServerOptions.Networks(); err != nil {
+		return fmt.Errorf("allowed_networks: %w", err)
+	}
+
 	return nil
 }
 
@@ -283,6 +1599,27 @@ func (c *Config) validateLoggingConfig() error {
 }
 
 func (c *Config) validateMetricsConfig() error {
+	if !metricNamespacePattern.MatchString(c.MetricsOptions.Namespace) {
+		return fmt.Errorf("invalid metrics namespace: %q", c.MetricsOptions.Namespace)
+	}
+
+	if c.MetricsOptions.CreatedTimestamps && !c.MetricsOptions.IsOpenMetricsEnabled() {
+		return fmt.Errorf("created timestamps require openmetrics to be enabled")
+	}
+
+	for label, attribute := range c.MetricsOptions.RepoLabels {
+		if !metricNamespacePattern.MatchString(label) || strings.HasPrefix(label, "__") || label == "org" || label == "repo" {
+			return fmt.Errorf("invalid repo label name: %q", label)
+		}
+
+		switch attribute {
+		case RepoAttributeLanguage, RepoAttributeDefaultBranch, RepoAttributeTopic:
+		default:
+			return fmt.Errorf("repo label %q: unknown repository attribute %q, expected %s, %s or %s",
+				label, attribute, RepoAttributeLanguage, RepoAttributeDefaultBranch, RepoAttributeTopic)
+		}
+	}
+
 	if c.Metrics.Collection.DefaultInterval.Seconds() < 1 {
 		return fmt.Errorf("default interval must be at least 1 second, got %d", c.Metrics.Collection.DefaultInterval.Seconds())
 	}
@@ -295,12 +1632,159 @@ func (c *Config) validateMetricsConfig() error {
 }
 
 func (c *Config) validateGitHubConfig() error {
-	if c.GitHub.Token == "" {
-		return fmt.Errorf("github token is required")
+	if len(c.GitHub.Tokens) > 0 {
+		if c.GitHub.Token != "" || c.GitHub.App.Enabled() {
+			return fmt.Errorf("github tokens cannot be configured with a token or app")
+		}
+
+		for i, token := range c.GitHub.Tokens {
+			if strings.TrimSpace(token) == "" {
+				return fmt.Errorf("github tokens[%d] cannot be empty", i)
+			}
+		}
+	} else if c.GitHub.App.Enabled() {
+		if c.GitHub.Token != "" {
+			return fmt.Errorf("github token and app cannot both be configured")
+		}
+
+		if c.GitHub.App.PrivateKeyPath == "" {
+			return fmt.Errorf("app private_key_path is required")
+		}
+
+		if c.GitHub.App.InstallationID < 0 {
+			return fmt.Errorf("app installation_id cannot be negative, got %d", c.GitHub.App.InstallationID)
+		}
+
+		// Installation tokens cannot list the repositories of a user
+		if c.GitHub.Starred || slices.Contains(c.GitHub.Repos, "*") {
+			return fmt.Errorf("starred and wildcard repositories cannot be used with app authentication")
+		}
+	} else if c.GitHub.Token == "" {
+		return fmt.Errorf("github token, tokens or app is required")
+	}
+
+	// Targets may be discovered entirely from Kubernetes or the app's installations
+	if len(c.GitHub.Orgs) == 0 && len(c.GitHub.Repos) == 0 && len(c.GitHub.Teams) == 0 && len(c.GitHub.Users) == 0 && !c.GitHub.Starred && !c.Kubernetes.Enabled && !c.GitHub.App.Enabled() {
+		return fmt.Errorf("at least one GitHub organization, repository, team or user must be specified, or starred enabled")
+	}
+
+	for _, user := range c.GitHub.Users {
+		if user == "" || strings.ContainsAny(user, "/* ") {
+			return fmt.Errorf("invalid user %q, expected a login", user)
+		}
+	}
+
+	// Validate teams configuration
+	for _, team := range c.GitHub.Teams {
+		parts := strings.Split(team, "/")
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" || strings.TrimSpace(parts[1]) == "" {
+			return fmt.Errorf("team %q must be in the form org/team-slug", team)
+		}
+	}
+
+	// Validate projects configuration
+	for _, project := range c.GitHub.Projects {
+		org, number, ok := strings.Cut(project, "/")
+		if n, err := strconv.Atoi(number); !ok || strings.TrimSpace(org) == "" || err != nil || n < 1 {
+			return fmt.Errorf("project %q must be in the form org/number", project)
+		}
+	}
+
+	// Validate repository exclusions
+	for _, pattern := range c.GitHub.ExcludeRepos {
+		if _, err := ParseRepoPattern(pattern); err != nil {
+			return fmt.Errorf("exclude repository pattern %q: %w", pattern, err)
+		}
+	}
+
+	// Validate fork activity configuration
+	for _, upstream := range c.GitHub.ForkActivity {
+		owner, repo, ok := strings.Cut(upstream, "/")
+		if !ok || strings.TrimSpace(owner) == "" || strings.TrimSpace(repo) == "" || strings.Contains(repo, "/") {
+			return fmt.Errorf("fork activity repository %q must be in the form owner/repo", upstream)
+		}
+	}
+
+	// Validate custom searches
+	searchNames := make(map[string]bool)
+
+	for _, search := range c.GitHub.CustomSearches {
+		if strings.TrimSpace(search.Name) == "" {
+			return fmt.Errorf("custom search names cannot be empty")
+		}
+
+		if searchNames[search.Name] {
+			return fmt.Errorf("duplicate custom search name %q", search.Name)
+		}
+
+		searchNames[search.Name] = true
+
+		if strings.TrimSpace(search.Query) == "" {
+			return fmt.Errorf("custom search %q must have a query", search.Name)
+		}
+	}
+
+	// Validate blackout windows
+	for _, blackout := range c.GitHub.Blackouts {
+		if _, err := cron.Parse(blackout.Schedule); err != nil {
+			return fmt.Errorf("blackout %q: %w", blackout.Name, err)
+		}
+
+		if blackout.Duration.Duration <= 0 {
+			return fmt.Errorf("blackout %q must have a positive duration", blackout.Name)
+		}
+	}
+
+	// Validate synthetic checks
+	for _, check := range c.GitHub.SyntheticChecks {
+		owner, repo, ok := strings.Cut(check.Repo, "/")
+		if !ok || owner == "" || repo == "" || strings.Contains(repo, "/") {
+			return fmt.Errorf("synthetic check repository %q must be in the form owner/repo", check.Repo)
+		}
+
+		if strings.TrimSpace(check.Workflow) == "" {
+			return fmt.Errorf("synthetic check of %s must have a workflow", check.Repo)
+		}
+
+		if check.Interval.Duration < time.Minute {
+			return fmt.Errorf("synthetic check of %s must have an interval of at least 1 minute, got %s", check.Repo, check.Interval.Duration)
+		}
+
+		if check.Timeout.Duration <= 0 {
+			return fmt.Errorf("synthetic check of %s must have a positive timeout", check.Repo)
+		}
+	}
+
+	// Validate custom queries
+	queryNames := make(map[string]bool)
+
+	for _, query := range c.GitHub.CustomQueries {
+		if !metricNamespacePattern.MatchString(query.Name) {
+			return fmt.Errorf("custom query name %q must be a valid metric name", query.Name)
+		}
+
+		if queryNames[query.Name] {
+			return fmt.Errorf("duplicate custom query name %q", query.Name)
+		}
+
+		queryNames[query.Name] = true
+
+		if strings.TrimSpace(query.Query) == "" || strings.TrimSpace(query.Value) == "" {
+			return fmt.Errorf("custom query %q must have a query and a value path", query.Name)
+		}
+
+		for label := range query.Labels {
+			if !metricNamespacePattern.MatchString(label) || strings.HasPrefix(label, "__") {
+				return fmt.Errorf("custom query %q has an invalid label name %q", query.Name, label)
+			}
+		}
 	}
 
-	if len(c.GitHub.Orgs) == 0 && len(c.GitHub.Repos) == 0 {
-		return fmt.Errorf("at least one GitHub organization or repository must be specified")
+	// Validate package types
+	for _, packageType := range c.GitHub.PackageTypes {
+		if !validPackageTypes[packageType] {
+			return fmt.Errorf("invalid package type %q", packageType)
+		}
 	}
 
 	// Validate branches configuration
@@ -321,6 +1805,78 @@ func (c *Config) validateGitHubConfig() error {
 		return fmt.Errorf("github timeout must be at least 1 second, got %d", c.GitHub.Timeout.Seconds())
 	}
 
+	for endpoint, timeout := range c.GitHub.Timeouts {
+		if strings.TrimSpace(endpoint) == "" {
+			return fmt.Errorf("github timeout endpoint names cannot be empty")
+		}
+
+		if !validTimeoutEndpoints[endpoint] {
+			return fmt.Errorf("github timeout for unknown endpoint %q", endpoint)
+		}
+
+		if timeout.Seconds() < 1 {
+			return fmt.Errorf("github timeout for %s must be at least 1 second, got %d", endpoint, timeout.Seconds())
+		}
+	}
+
+	if c.GitHub.DiscoveryInterval.Duration < 0 {
+		return fmt.Errorf("github discovery interval cannot be negative, got %s", c.GitHub.DiscoveryInterval.Duration)
+	}
+
+	if c.GitHub.RevalidateInterval.Duration < 0 {
+		return fmt.Errorf("github revalidate interval cannot be negative, got %s", c.GitHub.RevalidateInterval.Duration)
+	}
+
+	if c.GitHub.DriftThreshold < 0 || c.GitHub.DriftThreshold > 1 {
+		return fmt.Errorf("github drift threshold must be between 0 and 1, got %f", c.GitHub.DriftThreshold)
+	}
+
+	if c.GitHub.DegradedModeFloor < 0 {
+		return fmt.Errorf("github degraded mode floor cannot be negative, got %d", c.GitHub.DegradedModeFloor)
+	}
+
+	for _, branch := range c.GitHub.PriorityBranches {
+		if !slices.Contains(c.GitHub.Branches, branch) {
+			return fmt.Errorf("github priority branch %q must also be listed in branches", branch)
+		}
+	}
+
+	if c.GitHub.CollectionDeadline.Duration < 0 {
+		return fmt.Errorf("github collection deadline cannot be negative, got %s", c.GitHub.CollectionDeadline.Duration)
+	}
+
+	if c.GitHub.StaggerJitter < 0 || c.GitHub.StaggerJitter > 1 {
+		return fmt.Errorf("github stagger jitter must be between 0 and 1, got %f", c.GitHub.StaggerJitter)
+	}
+
+	if c.GitHub.MaxRepos < 0 {
+		return fmt.Errorf("github max repos cannot be negative, got %d", c.GitHub.MaxRepos)
+	}
+
+	if c.GitHub.MaxReposPolicy != MaxReposPolicyAbort && c.GitHub.MaxReposPolicy != MaxReposPolicyTruncate {
+		return fmt.Errorf("github max repos policy must be %q or %q, got %q", MaxReposPolicyAbort, MaxReposPolicyTruncate, c.GitHub.MaxReposPolicy)
+	}
+
+	switch c.GitHub.FailurePolicy {
+	case FailurePolicyKeep, FailurePolicyZero, FailurePolicyDelete:
+	default:
+		return fmt.Errorf("github failure policy must be %q, %q or %q, got %q", FailurePolicyKeep, FailurePolicyZero, FailurePolicyDelete, c.GitHub.FailurePolicy)
+	}
+
+	switch c.GitHub.NotFoundPolicy {
+	case NotFoundPolicyWarn, NotFoundPolicyWarnOnce, NotFoundPolicySkip:
+	default:
+		return fmt.Errorf("github not found policy must be %q, %q or %q, got %q", NotFoundPolicyWarn, NotFoundPolicyWarnOnce, NotFoundPolicySkip, c.GitHub.NotFoundPolicy)
+	}
+
+	if c.GitHub.BuildStatus.BackfillRuns < 0 || c.GitHub.BuildStatus.BackfillRuns > 100 {
+		return fmt.Errorf("github build status backfill runs must be between 0 and 100, got %d", c.GitHub.BuildStatus.BackfillRuns)
+	}
+
+	if c.GitHub.BuildStatus.BackfillBudget < 0 {
+		return fmt.Errorf("github build status backfill budget cannot be negative, got %d", c.GitHub.BuildStatus.BackfillBudget)
+	}
+
 	if c.GitHub.RateLimitBuffer <= 0 || c.GitHub.RateLimitBuffer > 1 {
 		return fmt.Errorf("github rate limit buffer must be between 0 and 1, got %f", c.GitHub.RateLimitBuffer)
 	}
@@ -328,11 +1884,109 @@ func (c *Config) validateGitHubConfig() error {
 	return nil
 }
 
+func (c *Config) validateKubernetesConfig() error {
+	if !c.Kubernetes.Enabled {
+		return nil
+	}
+
+	if c.Kubernetes.PollInterval.Duration < time.Second {
+		return fmt.Errorf("poll interval must be at least 1 second, got %s", c.Kubernetes.PollInterval.Duration)
+	}
+
+	for _, namespace := range c.Kubernetes.Namespaces {
+		if strings.TrimSpace(namespace) == "" {
+			return fmt.Errorf("namespace names cannot be empty")
+		}
+	}
+
+	return nil
+}
+
+// reservedPaths are served by the exporter itself and cannot receive webhooks
+var reservedPaths = []string{"/", "/metrics", "/health", "/status", "/http_sd"}
+
+func (c *Config) validateWebhookConfig() error {
+	if !c.Webhook.Enabled {
+		return nil
+	}
+
+	// Unsigned deliveries could be forged by anyone who can reach the endpoint
+	if c.Webhook.Secret == "" {
+		return fmt.Errorf("a secret is required when the webhook receiver is enabled")
+	}
+
+	if !strings.HasPrefix(c.Webhook.Path, "/") {
+		return fmt.Errorf("path must start with /, got %q", c.Webhook.Path)
+	}
+
+	if slices.Contains(reservedPaths, c.Webhook.Path) {
+		return fmt.Errorf("path %q is already served by the exporter", c.Webhook.Path)
+	}
+
+	if strings.HasPrefix(c.Webhook.Path, ResyncPathPrefix) {
+		return fmt.Errorf("path %q conflicts with the resync endpoint", c.Webhook.Path)
+	}
+
+	if c.Webhook.ReconcileInterval.Duration < 0 {
+		return fmt.Errorf("reconcile_interval must not be negative, got %s", c.Webhook.ReconcileInterval.Duration)
+	}
+
+	return nil
+}
+
+// TimeoutFor returns the request timeout for an API endpoint, falling back to the global timeout
+func (g *GitHubConfig) TimeoutFor(endpoint string) time.Duration {
+	if timeout, ok := g.Timeouts[endpoint]; ok && timeout.Duration > 0 {
+		return timeout.Duration
+	}
+
+	return g.Timeout.Duration
+}
+
 // GetDefaultInterval returns the default collection interval
 func (c *Config) GetDefaultInterval() int {
 	return c.Metrics.Collection.DefaultInterval.Seconds()
 }
 
+// RepoPattern matches repository full names ("owner/repo") against a glob,
+// case-insensitively, or against a regular expression enclosed in slashes
+type RepoPattern struct {
+	glob   string
+	regexp *regexp.Regexp
+}
+
+// ParseRepoPattern parses a glob in path.Match syntax, where "*" does not match
+// the slash between owner and repository, or a regular expression enclosed in
+// slashes, which matches anywhere in the full name unless anchored
+func ParseRepoPattern(pattern string) (RepoPattern, error) {
+	if len(pattern) > 2 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/") {
+		re, err := regexp.Compile(pattern[1 : len(pattern)-1])
+		if err != nil {
+			return RepoPattern{}, err
+		}
+
+		return RepoPattern{regexp: re}, nil
+	}
+
+	glob := strings.ToLower(pattern)
+	if _, err := path.Match(glob, ""); err != nil {
+		return RepoPattern{}, err
+	}
+
+	return RepoPattern{glob: glob}, nil
+}
+
+// Match reports whether a repository full name matches the pattern
+func (p RepoPattern) Match(fullName string) bool {
+	if p.regexp != nil {
+		return p.regexp.MatchString(fullName)
+	}
+
+	matched, _ := path.Match(p.glob, strings.ToLower(fullName))
+
+	return matched
+}
+
 // ParseStringList parses a comma-separated string into a slice of strings
 func ParseStringList(input string) []string {
 	if input == "" {
@@ -352,6 +2006,100 @@ func ParseStringList(input string) []string {
 	return result
 }
 
+// ParseDurationMap parses a comma-separated list of key=duration pairs
+func ParseDurationMap(input string) (map[string]Duration, error) {
+	result := make(map[string]Duration)
+
+	for _, part := range ParseStringList(input) {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("expected key=duration, got %s", part)
+		}
+
+		duration, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("invalid duration for %s: %w", key, err)
+		}
+
+		result[strings.TrimSpace(key)] = Duration{Duration: duration}
+	}
+
+	return result, nil
+}
+
+// ParseStringMap parses a comma-separated list of key=value pairs
+func ParseStringMap(input string) (map[string]string, error) {
+	result := make(map[string]string)
+
+	for _, part := range ParseStringList(input) {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("expected key=value, got %s", part)
+		}
+
+		result[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+
+	return result, nil
+}
+
+// ParseCustomSearches parses semicolon separated name=query pairs. Semicolons
+// are used because search queries may contain commas.
+func ParseCustomSearches(input string) ([]CustomSearch, error) {
+	var searches []CustomSearch
+
+	for _, part := range strings.Split(input, ";") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+
+		name, query, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("expected name=query, got %s", part)
+		}
+
+		searches = append(searches, CustomSearch{Name: strings.TrimSpace(name), Query: strings.TrimSpace(query)})
+	}
+
+	return searches, nil
+}
+
+// ParseBlackouts parses semicolon separated name=schedule@duration blackout
+// windows, e.g. "migrations=0 2 * * 6@4h". Semicolons are used because cron
+// expressions may contain commas.
+func ParseBlackouts(input string) ([]BlackoutWindow, error) {
+	var blackouts []BlackoutWindow
+
+	for _, part := range strings.Split(input, ";") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+
+		name, window, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("expected name=schedule@duration, got %s", part)
+		}
+
+		schedule, durationStr, ok := strings.Cut(window, "@")
+		if !ok {
+			return nil, fmt.Errorf("expected name=schedule@duration, got %s", part)
+		}
+
+		duration, err := time.ParseDuration(strings.TrimSpace(durationStr))
+		if err != nil {
+			return nil, fmt.Errorf("invalid duration of blackout %s: %w", strings.TrimSpace(name), err)
+		}
+
+		blackouts = append(blackouts, BlackoutWindow{
+			Name:     strings.TrimSpace(name),
+			Schedule: strings.TrimSpace(schedule),
+			Duration: Duration{Duration: duration},
+		})
+	}
+
+	return blackouts, nil
+}
+
 // ParseBool parses a string to boolean
 func ParseBool(input string) (bool, error) {
 	switch strings.ToLower(strings.TrimSpace(input)) {
@@ -0,0 +1,523 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestTimeoutFor tests per-endpoint timeout lookup with fallback to the global timeout
+func TestTimeoutFor(t *testing.T) {
+	cfg := GitHubConfig{
+		Timeout: Duration{Duration: 30 * time.Second},
+		Timeouts: map[string]Duration{
+			"search_issues": {Duration: 2 * time.Minute},
+		},
+	}
+
+	if got := cfg.TimeoutFor("search_issues"); got != 2*time.Minute {
+		t.Errorf("Expected 2m for search_issues, got %s", got)
+	}
+
+	if got := cfg.TimeoutFor("repos"); got != 30*time.Second {
+		t.Errorf("Expected fallback to 30s for repos, got %s", got)
+	}
+}
+
+// TestTimeoutsValidation tests that per-endpoint timeouts only accept known endpoints
+func TestTimeoutsValidation(t *testing.T) {
+	base := "github:\n  token: token\n  orgs: [d0ugal]\n  timeouts:\n"
+
+	if _, err := parse([]byte(base + "    search_issues: 60s\n    graphql_custom: 10s\n")); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	if _, err := parse([]byte(base + "    rate_limit: 10s\n")); err == nil {
+		t.Error("Expected error for an unknown endpoint")
+	}
+}
+
+// TestParseDurationMap tests parsing of key=duration lists
+func TestParseDurationMap(t *testing.T) {
+	result, err := ParseDurationMap("repos=10s, search_issues=1m")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if result["repos"].Duration != 10*time.Second {
+		t.Errorf("Expected 10s for repos, got %s", result["repos"].Duration)
+	}
+
+	if result["search_issues"].Duration != time.Minute {
+		t.Errorf("Expected 1m for search_issues, got %s", result["search_issues"].Duration)
+	}
+
+	if _, err := ParseDurationMap("repos"); err == nil {
+		t.Error("Expected error for missing duration")
+	}
+
+	if _, err := ParseDurationMap("repos=soon"); err == nil {
+		t.Error("Expected error for invalid duration")
+	}
+}
+
+// TestParseCustomSearches tests parsing of semicolon separated name=query lists
+func TestParseCustomSearches(t *testing.T) {
+	searches, err := ParseCustomSearches(`security_issues=org:d0ugal label:security state:open; stale=org:d0ugal label:"needs info,stale";`)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(searches) != 2 {
+		t.Fatalf("Expected 2 searches, got %d", len(searches))
+	}
+
+	if searches[0].Name != "security_issues" || searches[0].Query != "org:d0ugal label:security state:open" {
+		t.Errorf("Unexpected first search %+v", searches[0])
+	}
+
+	if searches[1].Query != `org:d0ugal label:"needs info,stale"` {
+		t.Errorf("Expected commas to be kept in queries, got %q", searches[1].Query)
+	}
+
+	if _, err := ParseCustomSearches("security_issues"); err == nil {
+		t.Error("Expected error for missing query")
+	}
+}
+
+// TestCollectorsConfigDefaults tests collector defaults and that collectors can be switched off
+func TestCollectorsConfigDefaults(t *testing.T) {
+	var collectors CollectorsConfig
+
+	if !collectors.RepoStatsEnabled() || !collectors.OrgStatsEnabled() || !collectors.PullRequestsEnabled() ||
+		!collectors.BuildStatusEnabled() || !collectors.CheckRunsEnabled() {
+		t.Error("Expected all collectors to be enabled by default")
+	}
+
+	if collectors.WebhooksEnabled() {
+		t.Error("Expected webhooks collector to be disabled by default")
+	}
+
+	disabled := false
+	collectors.BuildStatus = &disabled
+
+	if collectors.BuildStatusEnabled() {
+		t.Error("Expected build status to be disabled")
+	}
+
+	if collectors.CheckRunsEnabled() {
+		t.Error("Expected check runs to be disabled along with build status")
+	}
+}
+
+// TestWebhookConfigValidation tests that the webhook receiver requires a secret and a free path
+func TestWebhookConfigValidation(t *testing.T) {
+	base := "github:\n  token: token\n  orgs: [d0ugal]\nwebhook:\n  enabled: true\n"
+
+	cfg, err := parse([]byte(base + "  secret: s3cret\n"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if cfg.Webhook.Path != DefaultWebhookPath {
+		t.Errorf("Expected default path %s, got %s", DefaultWebhookPath, cfg.Webhook.Path)
+	}
+
+	if _, err := parse([]byte(base)); err == nil {
+		t.Error("Expected error for missing secret")
+	}
+
+	if _, err := parse([]byte(base + "  secret: s3cret\n  path: /metrics\n")); err == nil {
+		t.Error("Expected error for a path already served by the exporter")
+	}
+
+	if _, err := parse([]byte(base + "  secret: s3cret\n  path: /api/v1/resync/hooks\n")); err == nil {
+		t.Error("Expected error for a path overlapping the resync endpoint")
+	}
+
+	cfg, err = parse([]byte(base + "  secret: s3cret\n  reconcile_interval: 6h\n"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if cfg.Webhook.ReconcileInterval.Duration != 6*time.Hour {
+		t.Errorf("Expected reconcile interval 6h, got %s", cfg.Webhook.ReconcileInterval.Duration)
+	}
+}
+
+// TestGitHubAppConfigValidation tests that app authentication replaces the token
+// and needs no configured targets
+func TestGitHubAppConfigValidation(t *testing.T) {
+	base := "github:\n  app:\n    app_id: 42\n"
+
+	cfg, err := parse([]byte(base + "    private_key_path: /etc/github-exporter/app.pem\n"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if cfg.GitHub.App.PollInterval.Duration != time.Hour {
+		t.Errorf("Expected default poll interval 1h, got %s", cfg.GitHub.App.PollInterval.Duration)
+	}
+
+	if _, err := parse([]byte(base)); err == nil {
+		t.Error("Expected error for missing private key path")
+	}
+
+	if _, err := parse([]byte(base + "    private_key_path: app.pem\n  token: token\n")); err == nil {
+		t.Error("Expected error for both a token and an app")
+	}
+
+	if _, err := parse([]byte(base + "    private_key_path: app.pem\n  repos: [\"*\"]\n")); err == nil {
+		t.Error("Expected error for wildcard repositories with an app")
+	}
+
+	if _, err := parse([]byte(base + "    private_key_path: app.pem\n    installation_id: -1\n")); err == nil {
+		t.Error("Expected error for a negative installation ID")
+	}
+
+	if _, err := parse([]byte("github:\n  orgs: [d0ugal]\n")); err == nil {
+		t.Error("Expected error for neither a token nor an app")
+	}
+}
+
+// TestGitHubTokensConfigValidation tests that a pool of tokens replaces the
+// token and cannot be combined with other authentication
+func TestGitHubTokensConfigValidation(t *testing.T) {
+	base := "github:\n  orgs: [d0ugal]\n  tokens: [one, two]\n"
+
+	cfg, err := parse([]byte(base))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(cfg.GitHub.Tokens) != 2 {
+		t.Errorf("Expected 2 tokens, got %v", cfg.GitHub.Tokens)
+	}
+
+	if _, err := parse([]byte(base + "  token: token\n")); err == nil {
+		t.Error("Expected error for both a token and tokens")
+	}
+
+	if _, err := parse([]byte(base + "  app:\n    app_id: 42\n    private_key_path: app.pem\n")); err == nil {
+		t.Error("Expected error for both tokens and an app")
+	}
+
+	if _, err := parse([]byte("github:\n  orgs: [d0ugal]\n  tokens: [one, \"\"]\n")); err == nil {
+		t.Error("Expected error for an empty token")
+	}
+}
+
+// TestForkActivityValidation tests that fork activity repositories must be owner/repo
+func TestForkActivityValidation(t *testing.T) {
+	base := "github:\n  token: token\n  orgs: [d0ugal]\n"
+
+	if _, err := parse([]byte(base + "  fork_activity: [d0ugal/github-exporter]\n")); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for _, upstream := range []string{"d0ugal", "d0ugal/", "/github-exporter", "d0ugal/github-exporter/forks"} {
+		if _, err := parse([]byte(base + "  fork_activity: [\"" + upstream + "\"]\n")); err == nil {
+			t.Errorf("Expected error for fork activity repository %q", upstream)
+		}
+	}
+}
+
+// TestUsers tests configuring user targets alone and rejecting invalid users
+func TestUsers(t *testing.T) {
+	cfg, err := parse([]byte("github:\n  token: token\n  users: [octocat]\n"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(cfg.GitHub.Users) != 1 || cfg.GitHub.Users[0] != "octocat" {
+		t.Errorf("Expected users [octocat], got %v", cfg.GitHub.Users)
+	}
+
+	for _, user := range []string{"", "d0ugal/github-exporter", "*"} {
+		if _, err := parse([]byte("github:\n  token: token\n  users: [\"" + user + "\"]\n")); err == nil {
+			t.Errorf("Expected error for user %q", user)
+		}
+	}
+}
+
+// TestExcludeRepos tests matching repository exclusions and rejecting invalid patterns
+func TestExcludeRepos(t *testing.T) {
+	base := "github:\n  token: token\n  orgs: [d0ugal]\n"
+
+	if _, err := parse([]byte(base + "  exclude_repos: [\"*/test-*\", \"/-mirror$/\"]\n")); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for _, pattern := range []string{"d0ugal/[", "/(/"} {
+		if _, err := parse([]byte(base + "  exclude_repos: [\"" + pattern + "\"]\n")); err == nil {
+			t.Errorf("Expected error for exclude pattern %q", pattern)
+		}
+	}
+
+	for _, tc := range []struct {
+		pattern  string
+		fullName string
+		want     bool
+	}{
+		{"*/test-*", "d0ugal/test-repo", true},
+		{"*/test-*", "d0ugal/repo-test", false},
+		{"d0ugal/*", "D0ugal/Github-Exporter", true},
+		{"*", "d0ugal/github-exporter", false},
+		{"/-mirror$/", "d0ugal/linux-mirror", true},
+		{"/-mirror$/", "d0ugal/mirror-tools", false},
+		{"/^d0ugal/", "d0ugal/github-exporter", true},
+	} {
+		pattern, err := ParseRepoPattern(tc.pattern)
+		if err != nil {
+			t.Fatalf("Unexpected error for pattern %q: %v", tc.pattern, err)
+		}
+
+		if got := pattern.Match(tc.fullName); got != tc.want {
+			t.Errorf("Expected %q matching %q to be %v, got %v", tc.pattern, tc.fullName, tc.want, got)
+		}
+	}
+}
+
+// TestRevalidationValidation tests the default drift threshold and rejecting invalid values
+func TestRevalidationValidation(t *testing.T) {
+	base := "github:\n  token: token\n  orgs: [d0ugal]\n"
+
+	cfg, err := parse([]byte(base + "  revalidate_interval: 1h\n"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if cfg.GitHub.DriftThreshold != 0.1 {
+		t.Errorf("Expected default drift threshold 0.1, got %v", cfg.GitHub.DriftThreshold)
+	}
+
+	for _, option := range []string{"revalidate_interval: -1h", "drift_threshold: 1.5", "drift_threshold: -0.1"} {
+		if _, err := parse([]byte(base + "  " + option + "\n")); err == nil {
+			t.Errorf("Expected error for %q", option)
+		}
+	}
+}
+
+// TestRepoLabelsValidation tests that repository labels need valid names and known attributes
+func TestRepoLabelsValidation(t *testing.T) {
+	base := "github:\n  token: token\n  orgs: [d0ugal]\nmetrics:\n  repo_labels:\n"
+
+	if _, err := parse([]byte(base + "    lang: language\n    team: topic\n    branch: default_branch\n")); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for _, mapping := range []string{"lang: stars", "repo: language", "org: topic", "__lang: language", "\"lang-name\": language"} {
+		if _, err := parse([]byte(base + "    " + mapping + "\n")); err == nil {
+			t.Errorf("Expected error for repo label %q", mapping)
+		}
+	}
+}
+
+// TestNotFoundPolicyValidation tests the default and accepted values of not_found_policy
+func TestNotFoundPolicyValidation(t *testing.T) {
+	base := "github:\n  token: token\n  orgs: [d0ugal]\n"
+
+	cfg, err := parse([]byte(base))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if cfg.GitHub.NotFoundPolicy != NotFoundPolicyWarn {
+		t.Errorf("Expected default policy %s, got %s", NotFoundPolicyWarn, cfg.GitHub.NotFoundPolicy)
+	}
+
+	if _, err := parse([]byte(base + "  not_found_policy: skip\n")); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	if _, err := parse([]byte(base + "  not_found_policy: ignore\n")); err == nil {
+		t.Error("Expected error for an unknown policy")
+	}
+}
+
+// TestBackfillValidation tests the default backfill budget and the bounds of backfill_runs
+func TestBackfillValidation(t *testing.T) {
+	base := "github:\n  token: token\n  orgs: [d0ugal]\n  build_status:\n"
+
+	cfg, err := parse([]byte(base + "    backfill_runs: 50\n"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if cfg.GitHub.BuildStatus.BackfillBudget != 10 {
+		t.Errorf("Expected default budget 10, got %d", cfg.GitHub.BuildStatus.BackfillBudget)
+	}
+
+	if _, err := parse([]byte(base + "    backfill_runs: 101\n")); err == nil {
+		t.Error("Expected error for more than 100 backfill runs")
+	}
+}
+
+// TestBlackoutValidation tests that blackout windows need a valid schedule and a duration
+func TestBlackoutValidation(t *testing.T) {
+	base := "github:\n  token: token\n  orgs: [d0ugal]\n  blackouts:\n"
+
+	cfg, err := parse([]byte(base + "    - name: migrations\n      schedule: \"0 2 * * 6\"\n      duration: 4h\n"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(cfg.GitHub.Blackouts) != 1 || cfg.GitHub.Blackouts[0].Duration.Duration != 4*time.Hour {
+		t.Errorf("Unexpected blackouts %+v", cfg.GitHub.Blackouts)
+	}
+
+	if _, err := parse([]byte(base + "    - name: migrations\n      schedule: \"0 2 * *\"\n      duration: 4h\n")); err == nil {
+		t.Error("Expected error for an invalid schedule")
+	}
+
+	if _, err := parse([]byte(base + "    - name: migrations\n      schedule: \"0 2 * * 6\"\n")); err == nil {
+		t.Error("Expected error for a missing duration")
+	}
+}
+
+// TestSyntheticCheckDefaults tests the defaults and validation of synthetic checks
+func TestSyntheticCheckDefaults(t *testing.T) {
+	base := "github:\n  token: token\n  orgs: [d0ugal]\n  synthetic_checks:\n"
+
+	cfg, err := parse([]byte(base + "    - repo: d0ugal/canary\n      workflow: canary.yml\n"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := SyntheticCheck{
+		Repo:     "d0ugal/canary",
+		Workflow: "canary.yml",
+		Ref:      "main",
+		Interval: Duration{Duration: time.Hour},
+		Timeout:  Duration{Duration: 30 * time.Minute},
+	}
+	if len(cfg.GitHub.SyntheticChecks) != 1 || cfg.GitHub.SyntheticChecks[0] != expected {
+		t.Errorf("Expected %+v, got %+v", expected, cfg.GitHub.SyntheticChecks)
+	}
+
+	if _, err := parse([]byte(base + "    - repo: canary\n      workflow: canary.yml\n")); err == nil {
+		t.Error("Expected error for a repository without owner")
+	}
+
+	if _, err := parse([]byte(base + "    - repo: d0ugal/canary\n")); err == nil {
+		t.Error("Expected error for a missing workflow")
+	}
+
+	if _, err := parse([]byte(base + "    - repo: d0ugal/canary\n      workflow: canary.yml\n      interval: 10s\n")); err == nil {
+		t.Error("Expected error for an interval shorter than a minute")
+	}
+}
+
+// TestParseBlackouts tests parsing of semicolon separated name=schedule@duration lists
+func TestParseBlackouts(t *testing.T) {
+	blackouts, err := ParseBlackouts("migrations=0 2 * * 6@4h; releases=30 9 1,15 * *@90m")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []BlackoutWindow{
+		{Name: "migrations", Schedule: "0 2 * * 6", Duration: Duration{Duration: 4 * time.Hour}},
+		{Name: "releases", Schedule: "30 9 1,15 * *", Duration: Duration{Duration: 90 * time.Minute}},
+	}
+
+	if !reflect.DeepEqual(blackouts, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, blackouts)
+	}
+
+	if _, err := ParseBlackouts("migrations=0 2 * * 6"); err == nil {
+		t.Error("Expected error for a missing duration")
+	}
+
+	if _, err := ParseBlackouts("migrations=0 2 * * 6@soon"); err == nil {
+		t.Error("Expected error for an invalid duration")
+	}
+}
+
+// TestServerOptionsValidation tests that TLS and basic auth settings come in pairs
+func TestServerOptionsValidation(t *testing.T) {
+	base := "github:\n  token: token\n  orgs: [d0ugal]\n"
+
+	cfg, err := parse([]byte(base + "server:\n  port: 8080\n  tls:\n    cert_file: tls.crt\n    key_file: tls.key\n  auth:\n    bearer_token: s3cret\n"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !cfg.ServerOptions.TLS.Enabled() || cfg.ServerOptions.Auth.BearerToken != "s3cret" {
+		t.Errorf("Unexpected server options %+v", cfg.ServerOptions)
+	}
+
+	if _, err := parse([]byte(base + "server:\n  port: 8080\n  tls:\n    cert_file: tls.crt\n")); err == nil {
+		t.Error("Expected error for a certificate without a key")
+	}
+
+	if _, err := parse([]byte(base + "server:\n  port: 8080\n  auth:\n    username: prometheus\n")); err == nil {
+		t.Error("Expected error for a username without a password")
+	}
+}
+
+// TestAdminConfigValidation tests the admin listener defaults and port conflicts
+func TestAdminConfigValidation(t *testing.T) {
+	base := "github:\n  token: token\n  orgs: [d0ugal]\n"
+
+	cfg, err := parse([]byte(base + "admin:\n  port: 9090\n"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !cfg.Admin.SeparateListener() || cfg.Admin.Host != DefaultAdminHost {
+		t.Errorf("Expected a separate listener on %s, got %+v", DefaultAdminHost, cfg.Admin)
+	}
+
+	if _, err := parse([]byte(base + "admin:\n  port: 8080\n")); err == nil {
+		t.Error("Expected error for the port of the server")
+	}
+}
+
+// TestServerOptionsNetworks tests parsing of allowed networks and addresses
+func TestServerOptionsNetworks(t *testing.T) {
+	options := ServerOptions{AllowedNetworks: []string{"10.1.2.3/8", " 192.168.1.10", "fd00::/8"}}
+
+	networks, err := options.Networks()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []string{"10.0.0.0/8", "192.168.1.10/32", "fd00::/8"}
+	for i, network := range networks {
+		if network.String() != expected[i] {
+			t.Errorf("Expected %s, got %s", expected[i], network)
+		}
+	}
+
+	options.AllowedNetworks = []string{"10.0.0.0/33"}
+	if _, err := options.Networks(); err == nil {
+		t.Error("Expected error for an invalid network")
+	}
+}
+
+// TestNotificationsValidation tests the notification defaults and that the webhook URL must be http(s)
+func TestNotificationsValidation(t *testing.T) {
+	base := "github:\n  token: token\n  orgs: [d0ugal]\nnotifications:\n"
+
+	cfg, err := parse([]byte(base + "  webhook_url: https://hooks.slack.com/services/T000/B000/XXXX\n"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if cfg.Notifications.FailureThreshold != DefaultFailureThreshold || cfg.Notifications.ExpiryWarning() != DefaultTokenExpiryWarning {
+		t.Errorf("Expected default threshold and expiry warning, got %+v", cfg.Notifications)
+	}
+
+	cfg, err = parse([]byte(base + "  webhook_url: https://hooks.slack.com/services/T000/B000/XXXX\n  token_expiry_warning: 0s\n"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if cfg.Notifications.ExpiryWarning() != 0 {
+		t.Errorf("Expected expiry notifications to be disabled, got %s", cfg.Notifications.ExpiryWarning())
+	}
+
+	if _, err := parse([]byte(base + "  webhook_url: hooks.slack.com/services\n")); err == nil {
+		t.Error("Expected error for a URL without a scheme")
+	}
+}
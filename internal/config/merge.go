@@ -0,0 +1,120 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// configFragmentPatterns are the file patterns loaded from a config directory
+var configFragmentPatterns = []string{"*.yaml", "*.yml"}
+
+// readConfigDir returns the paths of all YAML fragments in dir, in lexical order
+func readConfigDir(dir string) ([]string, error) {
+	var paths []string
+
+	for _, pattern := range configFragmentPatterns {
+		matches, err := filepath.Glob(filepath.Join(dir, pattern))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list config directory: %w", err)
+		}
+
+		paths = append(paths, matches...)
+	}
+
+	sort.Strings(paths)
+
+	return paths, nil
+}
+
+// mergeConfigFiles reads the given YAML files and merges them in order into a
+// single document. Later files override scalar values of earlier ones, nested
+// sections are merged key by key and lists are concatenated without duplicates.
+func mergeConfigFiles(paths []string) ([]byte, error) {
+	merged := map[string]interface{}{}
+
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read config file %s: %w", path, err)
+		}
+
+		var fragment map[string]interface{}
+		if err := yaml.Unmarshal(data, &fragment); err != nil {
+			return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+		}
+
+		mergeMaps(merged, fragment)
+	}
+
+	data, err := yaml.Marshal(merged)
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge config files: %w", err)
+	}
+
+	return data, nil
+}
+
+// mergeMaps merges src into dst
+func mergeMaps(dst, src map[string]interface{}) {
+	for key, srcValue := range src {
+		dstValue, exists := dst[key]
+		if !exists {
+			dst[key] = srcValue
+			continue
+		}
+
+		switch srcTyped := srcValue.(type) {
+		case map[string]interface{}:
+			if dstMap, ok := dstValue.(map[string]interface{}); ok {
+				mergeMaps(dstMap, srcTyped)
+				continue
+			}
+		case []interface{}:
+			if dstList, ok := dstValue.([]interface{}); ok {
+				dst[key] = mergeLists(dstList, srcTyped)
+				continue
+			}
+		}
+
+		dst[key] = srcValue
+	}
+}
+
+// mergeLists appends the items of src to dst, skipping scalar items already present
+func mergeLists(dst, src []interface{}) []interface{} {
+	seen := make(map[interface{}]bool, len(dst))
+
+	for _, item := range dst {
+		if isScalar(item) {
+			seen[item] = true
+		}
+	}
+
+	for _, item := range src {
+		if isScalar(item) {
+			if seen[item] {
+				continue
+			}
+
+			seen[item] = true
+		}
+
+		dst = append(dst, item)
+	}
+
+	return dst
+}
+
+// isScalar reports whether a decoded YAML value can be compared for equality
+func isScalar(value interface{}) bool {
+	switch value.(type) {
+	case map[string]interface{}, []interface{}:
+		return false
+	default:
+		return true
+	}
+}
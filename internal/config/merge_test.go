@@ -0,0 +1,96 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// writeFile writes a test config file
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("Failed to write %s: %v", path, err)
+	}
+}
+
+// TestLoadWithDir tests merging of a base config with config directory fragments
+func TestLoadWithDir(t *testing.T) {
+	tmp := t.TempDir()
+	confDir := filepath.Join(tmp, "conf.d")
+
+	if err := os.Mkdir(confDir, 0o700); err != nil {
+		t.Fatalf("Failed to create config dir: %v", err)
+	}
+
+	base := filepath.Join(tmp, "config.yaml")
+	writeFile(t, base, `
+server:
+  port: 8080
+github:
+  token: base-token
+  repos:
+    - d0ugal/shared
+  timeout: 30s
+`)
+	writeFile(t, filepath.Join(confDir, "10-team-a.yaml"), `
+github:
+  repos:
+    - team-a/service
+    - d0ugal/shared
+`)
+	writeFile(t, filepath.Join(confDir, "20-team-b.yml"), `
+github:
+  repos:
+    - team-b/api
+  timeout: 1m
+`)
+	writeFile(t, filepath.Join(confDir, "README.md"), "not yaml: [")
+
+	cfg, err := LoadWithDir(base, confDir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	wantRepos := []string{"d0ugal/shared", "team-a/service", "team-b/api"}
+	if !reflect.DeepEqual(cfg.GitHub.Repos, wantRepos) {
+		t.Errorf("Expected repos %v, got %v", wantRepos, cfg.GitHub.Repos)
+	}
+
+	if cfg.GitHub.Token != "base-token" {
+		t.Errorf("Expected token from base config, got %q", cfg.GitHub.Token)
+	}
+
+	if cfg.GitHub.Timeout.Duration.String() != "1m0s" {
+		t.Errorf("Expected later fragment to override timeout, got %s", cfg.GitHub.Timeout.Duration)
+	}
+}
+
+// TestLoadWithDirWithoutBase tests that the base config file is optional
+func TestLoadWithDirWithoutBase(t *testing.T) {
+	confDir := t.TempDir()
+	writeFile(t, filepath.Join(confDir, "base.yaml"), `
+github:
+  token: token
+  orgs:
+    - d0ugal
+`)
+
+	cfg, err := LoadWithDir(filepath.Join(confDir, "missing.yaml"), confDir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(cfg.GitHub.Orgs) != 1 || cfg.GitHub.Orgs[0] != "d0ugal" {
+		t.Errorf("Expected orgs from fragment, got %v", cfg.GitHub.Orgs)
+	}
+}
+
+// TestLoadConfigDirWithEnv tests that a config directory cannot be used with env configuration
+func TestLoadConfigDirWithEnv(t *testing.T) {
+	if _, err := LoadConfig("", t.TempDir(), true); err == nil {
+		t.Error("Expected error combining config directory with environment configuration")
+	}
+}
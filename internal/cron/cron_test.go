@@ -0,0 +1,85 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+// TestParseCron tests parsing of cron expressions
+func TestParseCron(t *testing.T) {
+	valid := []string{
+		"0 0 * * *",
+		"*/15 * * * *",
+		"30 4 1,15 * MON-FRI",
+		"0 9 * jan-mar 1/2",
+		"0 0 * * 7",
+	}
+
+	for _, expr := range valid {
+		if _, err := Parse(expr); err != nil {
+			t.Errorf("Expected %q to parse, got %v", expr, err)
+		}
+	}
+
+	invalid := []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"@daily",
+	}
+
+	for _, expr := range invalid {
+		if _, err := Parse(expr); err == nil {
+			t.Errorf("Expected %q to be rejected", expr)
+		}
+	}
+}
+
+// TestCronNext tests the next run of cron schedules
+func TestCronNext(t *testing.T) {
+	// Wednesday
+	now := time.Date(2025, time.January, 15, 10, 20, 30, 0, time.UTC)
+
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"*/15 * * * *", time.Date(2025, time.January, 15, 10, 30, 0, 0, time.UTC)},
+		{"0 0 * * *", time.Date(2025, time.January, 16, 0, 0, 0, 0, time.UTC)},
+		{"20 10 * * *", time.Date(2025, time.January, 16, 10, 20, 0, 0, time.UTC)},
+		{"0 6 * * MON", time.Date(2025, time.January, 20, 6, 0, 0, 0, time.UTC)},
+		{"0 6 * * 7", time.Date(2025, time.January, 19, 6, 0, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC)},
+		// Day of month and day of week are ORed when both are restricted
+		{"0 0 20 * 5", time.Date(2025, time.January, 17, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		schedule, err := Parse(tt.expr)
+		if err != nil {
+			t.Fatalf("Failed to parse %q: %v", tt.expr, err)
+		}
+
+		if got := schedule.Next(now); !got.Equal(tt.want) {
+			t.Errorf("%q: expected next run %s, got %s", tt.expr, tt.want, got)
+		}
+	}
+}
+
+// TestCronNextNever tests schedules that can never fire
+func TestCronNextNever(t *testing.T) {
+	schedule, err := Parse("0 0 31 2 *")
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	if got := schedule.Next(time.Now()); !got.IsZero() {
+		t.Errorf("Expected no next run, got %s", got)
+	}
+}
@@ -0,0 +1,239 @@
+package dashboard
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	promexporter_metrics "github.com/d0ugal/promexporter/metrics"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// Options controls how the dashboard is generated
+type Options struct {
+	Title     string
+	UID       string
+	Namespace string
+}
+
+// Dashboard is the subset of the Grafana dashboard JSON model we generate
+type Dashboard struct {
+	Title         string     `json:"title"`
+	UID           string     `json:"uid"`
+	Tags          []string   `json:"tags"`
+	Timezone      string     `json:"timezone"`
+	SchemaVersion int        `json:"schemaVersion"`
+	Refresh       string     `json:"refresh"`
+	Time          TimeRange  `json:"time"`
+	Templating    Templating `json:"templating"`
+	Panels        []Panel    `json:"panels"`
+}
+
+// TimeRange is the default dashboard time range
+type TimeRange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Templating holds the dashboard template variables
+type Templating struct {
+	List []Variable `json:"list"`
+}
+
+// Variable is a Grafana template variable
+type Variable struct {
+	Name       string      `json:"name"`
+	Label      string      `json:"label"`
+	Type       string      `json:"type"`
+	Query      interface{} `json:"query"`
+	Datasource *Datasource `json:"datasource,omitempty"`
+	Refresh    int         `json:"refresh,omitempty"`
+	Multi      bool        `json:"multi"`
+	IncludeAll bool        `json:"includeAll"`
+	AllValue   string      `json:"allValue,omitempty"`
+}
+
+// Datasource references the Prometheus datasource variable
+type Datasource struct {
+	Type string `json:"type"`
+	UID  string `json:"uid"`
+}
+
+// GridPos is the position of a panel on the dashboard grid
+type GridPos struct {
+	H int `json:"h"`
+	W int `json:"w"`
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+// Target is a Prometheus query of a panel
+type Target struct {
+	Expr         string `json:"expr"`
+	LegendFormat string `json:"legendFormat"`
+	RefID        string `json:"refId"`
+}
+
+// Panel is a Grafana panel
+type Panel struct {
+	ID          int         `json:"id"`
+	Type        string      `json:"type"`
+	Title       string      `json:"title"`
+	Description string      `json:"description,omitempty"`
+	GridPos     GridPos     `json:"gridPos"`
+	Datasource  *Datasource `json:"datasource,omitempty"`
+	Targets     []Target    `json:"targets,omitempty"`
+}
+
+var prometheusDatasource = &Datasource{Type: "prometheus", UID: "${datasource}"}
+
+// Generate builds a Grafana dashboard with one panel per metric, graphing each
+// metric according to its type in types, keyed by metric name
+func Generate(metrics []promexporter_metrics.MetricInfo, types map[string]dto.MetricType, opts Options) *Dashboard {
+	if opts.Namespace == "" {
+		opts.Namespace = "github"
+	}
+
+	if opts.Title == "" {
+		opts.Title = "GitHub Exporter"
+	}
+
+	if opts.UID == "" {
+		opts.UID = opts.Namespace + "-exporter"
+	}
+
+	infoMetric := opts.Namespace + "_repo_info"
+
+	dashboard := &Dashboard{
+		Title:         opts.Title,
+		UID:           opts.UID,
+		Tags:          []string{"github", "prometheus"},
+		Timezone:      "browser",
+		SchemaVersion: 39,
+		Refresh:       "5m",
+		Time:          TimeRange{From: "now-24h", To: "now"},
+		Templating: Templating{List: []Variable{
+			{
+				Name:  "datasource",
+				Label: "Datasource",
+				Type:  "datasource",
+				Query: "prometheus",
+			},
+			{
+				Name:       "org",
+				Label:      "Organization",
+				Type:       "query",
+				Query:      fmt.Sprintf("label_values(%s, org)", infoMetric),
+				Datasource: prometheusDatasource,
+				Refresh:    2,
+				Multi:      true,
+				IncludeAll: true,
+				AllValue:   ".*",
+			},
+			{
+				Name:       "repo",
+				Label:      "Repository",
+				Type:       "query",
+				Query:      fmt.Sprintf(`label_values(%s{org=~"$org"}, repo)`, infoMetric),
+				Datasource: prometheusDatasource,
+				Refresh:    2,
+				Multi:      true,
+				IncludeAll: true,
+				AllValue:   ".*",
+			},
+		}},
+	}
+
+	id := 1
+	y := 0
+	x := 0
+
+	for _, metric := range metrics {
+		// Info metrics carry labels rather than values and are not useful as graphs
+		if strings.HasSuffix(metric.Name, "_info") {
+			continue
+		}
+
+		dashboard.Panels = append(dashboard.Panels, Panel{
+			ID:          id,
+			Type:        "timeseries",
+			Title:       panelTitle(metric.Name, opts.Namespace),
+			Description: metric.Help,
+			GridPos:     GridPos{H: 8, W: 12, X: x, Y: y},
+			Datasource:  prometheusDatasource,
+			Targets: []Target{{
+				Expr:         Query(metric, types[metric.Name]),
+				LegendFormat: legendFormat(metric.Labels),
+				RefID:        "A",
+			}},
+		})
+
+		id++
+
+		if x == 0 {
+			x = 12
+		} else {
+			x = 0
+			y += 8
+		}
+	}
+
+	return dashboard
+}
+
+// JSON renders the dashboard as indented JSON
+func (d *Dashboard) JSON() ([]byte, error) {
+	return json.MarshalIndent(d, "", "  ")
+}
+
+// Query returns the PromQL expression for a metric of the given type, filtered
+// by the org/repo variables
+func Query(metric promexporter_metrics.MetricInfo, metricType dto.MetricType) string {
+	var matchers []string
+	if slices.Contains(metric.Labels, "org") {
+		matchers = append(matchers, `org=~"$org"`)
+	}
+
+	if slices.Contains(metric.Labels, "repo") {
+		matchers = append(matchers, `repo=~"$repo"`)
+	}
+
+	selector := metric.Name
+	if len(matchers) > 0 {
+		selector += "{" + strings.Join(matchers, ", ") + "}"
+	}
+
+	// Counters are graphed as per-second rates
+	if metricType == dto.MetricType_COUNTER {
+		return fmt.Sprintf("sum by (%s) (rate(%s[$__rate_interval]))", strings.Join(metric.Labels, ", "), selector)
+	}
+
+	return selector
+}
+
+// legendFormat builds a legend from all labels of a metric
+func legendFormat(labels []string) string {
+	if len(labels) == 0 {
+		return "{{__name__}}"
+	}
+
+	parts := make([]string, 0, len(labels))
+	for _, label := range labels {
+		parts = append(parts, "{{"+label+"}}")
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// panelTitle derives a human readable title from a metric name
+func panelTitle(name, namespace string) string {
+	title := strings.TrimPrefix(name, namespace+"_")
+	title = strings.ReplaceAll(title, "_", " ")
+
+	if title == "" {
+		return name
+	}
+
+	return strings.ToUpper(title[:1]) + title[1:]
+}
@@ -0,0 +1,69 @@
+package dashboard
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	promexporter_metrics "github.com/d0ugal/promexporter/metrics"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// TestGenerate tests that a panel is generated for each non-info metric
+func TestGenerate(t *testing.T) {
+	metrics := []promexporter_metrics.MetricInfo{
+		{Name: "github_repo_info", Help: "Info", Labels: []string{"org", "repo"}},
+		{Name: "github_repo_stars", Help: "Stars", Labels: []string{"org", "repo", "visibility"}},
+		{Name: "github_api_calls_total", Help: "Calls", Labels: []string{"endpoint", "status"}},
+	}
+
+	types := map[string]dto.MetricType{
+		"github_repo_stars":      dto.MetricType_GAUGE,
+		"github_api_calls_total": dto.MetricType_COUNTER,
+	}
+
+	dashboard := Generate(metrics, types, Options{Namespace: "github"})
+
+	if len(dashboard.Panels) != 2 {
+		t.Fatalf("Expected 2 panels, got %d", len(dashboard.Panels))
+	}
+
+	if got := dashboard.Panels[0].Targets[0].Expr; got != `github_repo_stars{org=~"$org", repo=~"$repo"}` {
+		t.Errorf("Unexpected stars query: %s", got)
+	}
+
+	if got := dashboard.Panels[1].Targets[0].Expr; got != "sum by (endpoint, status) (rate(github_api_calls_total[$__rate_interval]))" {
+		t.Errorf("Unexpected counter query: %s", got)
+	}
+
+	if len(dashboard.Templating.List) != 3 {
+		t.Errorf("Expected datasource, org and repo variables, got %d", len(dashboard.Templating.List))
+	}
+
+	data, err := dashboard.JSON()
+	if err != nil {
+		t.Fatalf("Failed to render JSON: %v", err)
+	}
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Generated dashboard is not valid JSON: %v", err)
+	}
+}
+
+// TestQueryGaugeTotal tests that gauges named like counters are not wrapped in rate()
+func TestQueryGaugeTotal(t *testing.T) {
+	for _, metric := range []promexporter_metrics.MetricInfo{
+		{Name: "github_repos_total", Labels: []string{"org", "visibility"}},
+		{Name: "github_actions_secrets_total", Labels: []string{"org", "repo"}},
+	} {
+		if got := Query(metric, dto.MetricType_GAUGE); strings.Contains(got, "rate(") {
+			t.Errorf("Unexpected rate() in gauge query: %s", got)
+		}
+	}
+
+	got := Query(promexporter_metrics.MetricInfo{Name: "github_repos_total", Labels: []string{"org", "visibility"}}, dto.MetricType_GAUGE)
+	if got != `github_repos_total{org=~"$org"}` {
+		t.Errorf("Unexpected query: %s", got)
+	}
+}
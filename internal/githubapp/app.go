@@ -0,0 +1,238 @@
+// Package githubapp authenticates as a GitHub App: it signs app JWTs, enumerates
+// the app's installations and mints installation tokens for API requests.
+package githubapp
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/d0ugal/github-exporter/internal/config"
+	"github.com/google/go-github/v76/github"
+)
+
+const (
+	// jwtLifetime is how long app JWTs are valid; GitHub accepts at most 10 minutes
+	jwtLifetime = 9 * time.Minute
+
+	// clockSkew backdates JWTs to tolerate clock drift between the exporter and GitHub
+	clockSkew = time.Minute
+)
+
+// Installation is an installation of the app on an account
+type Installation struct {
+	ID                  int64
+	Account             string
+	AccountType         string // "Organization" or "User"
+	RepositorySelection string // "all" or "selected"
+}
+
+// App authenticates as a GitHub App
+type App struct {
+	id      int64
+	only    int64 // Installation to use, 0 for all
+	key     *rsa.PrivateKey
+	base    http.RoundTripper
+	baseURL *url.URL
+
+	// client authenticates with app JWTs
+	client *github.Client
+
+	mu         sync.Mutex
+	jwt        string
+	jwtExpires time.Time
+}
+
+// New creates an app from its configuration, reading the private key from disk.
+// Requests are made through base, or http.DefaultTransport when nil.
+func New(cfg config.GitHubAppConfig, base http.RoundTripper) (*App, error) {
+	keyPEM, err := os.ReadFile(cfg.PrivateKeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read app private key: %w", err)
+	}
+
+	key, err := parsePrivateKey(keyPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	app := &App{id: cfg.AppID, only: cfg.InstallationID, key: key, base: base}
+	app.setBaseURL(github.NewClient(nil).BaseURL)
+
+	return app, nil
+}
+
+// setBaseURL points the app at an API server, used by tests
+func (a *App) setBaseURL(baseURL *url.URL) {
+	a.baseURL = baseURL
+	a.client = github.NewClient(&http.Client{Transport: &jwtTransport{app: a}})
+	a.client.BaseURL = baseURL
+}
+
+// newInstallationClient returns a client authenticating with the given token
+func (a *App) newInstallationClient(token string) *github.Client {
+	client := github.NewClient(&http.Client{Transport: a.base}).WithAuthToken(token)
+	client.BaseURL = a.baseURL
+
+	return client
+}
+
+// parsePrivateKey parses a PEM-encoded RSA key in PKCS#1 form, as downloaded
+// from GitHub, or PKCS#8 form
+func parsePrivateKey(keyPEM []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(keyPEM)
+	if block == nil {
+		return nil, fmt.Errorf("app private key is not PEM encoded")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse app private key: %w", err)
+	}
+
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("app private key is not an RSA key")
+	}
+
+	return key, nil
+}
+
+// token returns a JWT authenticating as the app, reusing it until shortly before it expires
+func (a *App) token() (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := time.Now()
+	if a.jwt != "" && now.Add(clockSkew).Before(a.jwtExpires) {
+		return a.jwt, nil
+	}
+
+	expires := now.Add(jwtLifetime)
+
+	token, err := a.sign(map[string]interface{}{
+		"iat": now.Add(-clockSkew).Unix(),
+		"exp": expires.Unix(),
+		"iss": strconv.FormatInt(a.id, 10),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	a.jwt, a.jwtExpires = token, expires
+
+	return token, nil
+}
+
+// sign creates an RS256 JWT with the given claims
+func (a *App) sign(claims map[string]interface{}) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(unsigned))
+
+	signature, err := rsa.SignPKCS1v15(rand.Reader, a.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign app JWT: %w", err)
+	}
+
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// Installations lists the app's installations, skipping suspended ones. When
+// the app is restricted to one installation, only that one is returned and it
+// is an error if it is missing.
+func (a *App) Installations(ctx context.Context) ([]Installation, error) {
+	var installations []Installation
+
+	opts := &github.ListOptions{PerPage: 100}
+
+	for {
+		page, resp, err := a.client.Apps.ListInstallations(ctx, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list app installations: %w", err)
+		}
+
+		for _, installation := range page {
+			if installation.SuspendedAt != nil || (a.only != 0 && installation.GetID() != a.only) {
+				continue
+			}
+
+			installations = append(installations, Installation{
+				ID:                  installation.GetID(),
+				Account:             installation.GetAccount().GetLogin(),
+				AccountType:         installation.GetAccount().GetType(),
+				RepositorySelection: installation.GetRepositorySelection(),
+			})
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		opts.Page = resp.NextPage
+	}
+
+	if a.only != 0 && len(installations) == 0 {
+		return nil, fmt.Errorf("app installation %d does not exist or is suspended", a.only)
+	}
+
+	return installations, nil
+}
+
+// installationToken mints a token for an installation
+func (a *App) installationToken(ctx context.Context, installationID int64) (string, time.Time, error) {
+	token, _, err := a.client.Apps.CreateInstallationToken(ctx, installationID, nil)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to create token for installation %d: %w", installationID, err)
+	}
+
+	return token.GetToken(), token.GetExpiresAt().Time, nil
+}
+
+// jwtTransport authenticates requests as the app
+type jwtTransport struct {
+	app *App
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *jwtTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.app.token()
+	if err != nil {
+		return nil, err
+	}
+
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	return t.app.base.RoundTrip(req)
+}
@@ -0,0 +1,172 @@
+package githubapp
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/d0ugal/github-exporter/internal/config"
+)
+
+// newTestApp creates an app with a freshly generated key, pointed at a test server
+func newTestApp(t *testing.T, handler http.HandlerFunc) (*App, *rsa.PrivateKey) {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+
+	keyPath := filepath.Join(t.TempDir(), "app.pem")
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	if err := os.WriteFile(keyPath, keyPEM, 0o600); err != nil {
+		t.Fatalf("Failed to write key: %v", err)
+	}
+
+	app, err := New(config.GitHubAppConfig{AppID: 42, PrivateKeyPath: keyPath}, nil)
+	if err != nil {
+		t.Fatalf("Failed to create app: %v", err)
+	}
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	baseURL, _ := url.Parse(server.URL + "/")
+	app.setBaseURL(baseURL)
+
+	return app, key
+}
+
+// TestAppJWT tests that app JWTs are signed with the app's key and identify the app
+func TestAppJWT(t *testing.T) {
+	app, key := newTestApp(t, func(w http.ResponseWriter, r *http.Request) {})
+
+	token, err := app.token()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		t.Fatalf("Expected a JWT with 3 parts, got %d", len(parts))
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		t.Fatalf("Failed to decode signature: %v", err)
+	}
+
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], signature); err != nil {
+		t.Errorf("Invalid signature: %v", err)
+	}
+
+	payload, _ := base64.RawURLEncoding.DecodeString(parts[1])
+
+	var claims struct {
+		Issuer    string `json:"iss"`
+		IssuedAt  int64  `json:"iat"`
+		ExpiresAt int64  `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		t.Fatalf("Failed to decode claims: %v", err)
+	}
+
+	if claims.Issuer != "42" {
+		t.Errorf("Expected issuer 42, got %q", claims.Issuer)
+	}
+
+	if lifetime := claims.ExpiresAt - claims.IssuedAt; lifetime > 600 {
+		t.Errorf("Expected a lifetime of at most 10 minutes, got %ds", lifetime)
+	}
+
+	if again, _ := app.token(); again != token {
+		t.Error("Expected the JWT to be reused until it expires")
+	}
+}
+
+// TestAppInstallations tests listing installations with a JWT, skipping suspended ones
+func TestAppInstallations(t *testing.T) {
+	app, _ := newTestApp(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/app/installations" {
+			t.Errorf("Unexpected request path %s", r.URL.Path)
+		}
+
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ey") {
+			t.Errorf("Expected a JWT, got %q", r.Header.Get("Authorization"))
+		}
+
+		_, _ = w.Write([]byte(`[
+			{"id": 1, "account": {"login": "d0ugal", "type": "User"}, "repository_selection": "selected"},
+			{"id": 2, "account": {"login": "acme", "type": "Organization"}, "repository_selection": "all"},
+			{"id": 3, "account": {"login": "gone", "type": "Organization"}, "suspended_at": "2024-01-01T00:00:00Z"}
+		]`))
+	})
+
+	installations, err := app.Installations(t.Context())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(installations) != 2 {
+		t.Fatalf("Expected 2 active installations, got %d", len(installations))
+	}
+
+	expected := Installation{ID: 2, Account: "acme", AccountType: "Organization", RepositorySelection: "all"}
+	if installations[1] != expected {
+		t.Errorf("Expected %+v, got %+v", expected, installations[1])
+	}
+}
+
+// TestAppInstallationsRestricted tests that an app restricted to one
+// installation ignores the others and fails when it is missing
+func TestAppInstallationsRestricted(t *testing.T) {
+	app, _ := newTestApp(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[
+			{"id": 1, "account": {"login": "d0ugal", "type": "User"}, "repository_selection": "selected"},
+			{"id": 2, "account": {"login": "acme", "type": "Organization"}, "repository_selection": "all"}
+		]`))
+	})
+
+	app.only = 2
+
+	installations, err := app.Installations(t.Context())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(installations) != 1 || installations[0].Account != "acme" {
+		t.Errorf("Expected only the acme installation, got %+v", installations)
+	}
+
+	app.only = 3
+
+	if _, err := app.Installations(t.Context()); err == nil {
+		t.Error("Expected an error for a missing installation")
+	}
+}
+
+// TestParsePrivateKeyInvalid tests that keys which are not PEM-encoded RSA keys are rejected
+func TestParsePrivateKeyInvalid(t *testing.T) {
+	if _, err := parsePrivateKey([]byte("not a key")); err == nil {
+		t.Error("Expected an error for a key that is not PEM encoded")
+	}
+
+	block := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("garbage")})
+	if _, err := parsePrivateKey(block); err == nil {
+		t.Error("Expected an error for an unparseable key")
+	}
+}
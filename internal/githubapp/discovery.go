@@ -0,0 +1,169 @@
+package githubapp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/d0ugal/github-exporter/internal/metrics"
+	"github.com/google/go-github/v76/github"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// errNoInstallations is returned for requests made before any installation was discovered
+var errNoInstallations = errors.New("the GitHub App has no installations")
+
+// Targets holds the organizations and repositories granted to the app
+type Targets struct {
+	Orgs  []string
+	Repos []string
+}
+
+// Equal reports whether two target sets are identical
+func (t Targets) Equal(other Targets) bool {
+	return slices.Equal(t.Orgs, other.Orgs) && slices.Equal(t.Repos, other.Repos)
+}
+
+// Discoverer periodically enumerates the app's installations and the
+// organizations and repositories they grant
+type Discoverer struct {
+	app          *App
+	transport    *Transport
+	metrics      *metrics.GitHubRegistry
+	pollInterval time.Duration
+}
+
+// NewDiscoverer creates a discoverer registering the installations it finds with transport
+func NewDiscoverer(app *App, transport *Transport, metricsRegistry *metrics.GitHubRegistry, pollInterval time.Duration) *Discoverer {
+	return &Discoverer{
+		app:          app,
+		transport:    transport,
+		metrics:      metricsRegistry,
+		pollInterval: pollInterval,
+	}
+}
+
+// Discover enumerates the installations and returns the targets they grant.
+// Organizations that granted access to all their repositories are returned as
+// organization targets, so organization metrics are collected too; for other
+// installations the granted repositories are listed. Lists are sorted.
+func (d *Discoverer) Discover(ctx context.Context) (Targets, error) {
+	installations, err := d.app.Installations(ctx)
+	if err != nil {
+		return Targets{}, err
+	}
+
+	d.transport.SetInstallations(installations)
+
+	d.metrics.GitHubAppInstallationInfo.Reset()
+
+	var targets Targets
+
+	for _, installation := range installations {
+		d.metrics.GitHubAppInstallationInfo.With(prometheus.Labels{
+			"installation":         strconv.FormatInt(installation.ID, 10),
+			"account":              installation.Account,
+			"account_type":         installation.AccountType,
+			"repository_selection": installation.RepositorySelection,
+		}).Set(1)
+
+		if installation.AccountType == "Organization" && installation.RepositorySelection == "all" {
+			targets.Orgs = append(targets.Orgs, installation.Account)
+			continue
+		}
+
+		repos, err := d.repositories(ctx, installation)
+		if err != nil {
+			return Targets{}, err
+		}
+
+		targets.Repos = append(targets.Repos, repos...)
+	}
+
+	sort.Strings(targets.Orgs)
+	sort.Strings(targets.Repos)
+
+	return targets, nil
+}
+
+// repositories lists the full names of the repositories an installation grants
+func (d *Discoverer) repositories(ctx context.Context, installation Installation) ([]string, error) {
+	inst := d.transport.route(installation.Account)
+
+	token, err := d.transport.token(ctx, inst)
+	if err != nil {
+		return nil, err
+	}
+
+	client := d.app.newInstallationClient(token)
+
+	var repos []string
+
+	opts := &github.ListOptions{PerPage: 100}
+
+	for {
+		page, resp, err := client.Apps.ListRepos(ctx, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list repositories of installation %d: %w", installation.ID, err)
+		}
+
+		for _, repo := range page.Repositories {
+			repos = append(repos, repo.GetFullName())
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		opts.Page = resp.NextPage
+	}
+
+	return repos, nil
+}
+
+// Start performs an initial discovery and then polls for changes in the background
+// until ctx is cancelled. onChange is called with the initial targets and whenever
+// they change afterwards. Discovery errors are logged and the last known targets kept.
+func (d *Discoverer) Start(ctx context.Context, onChange func(Targets)) {
+	current, err := d.Discover(ctx)
+	if err != nil {
+		slog.Error("Failed to discover GitHub App installations", "error", err)
+	} else {
+		slog.Info("Discovered GitHub App installations", "orgs", len(current.Orgs), "repos", len(current.Repos))
+		onChange(current)
+	}
+
+	go d.poll(ctx, current, onChange)
+}
+
+func (d *Discoverer) poll(ctx context.Context, current Targets, onChange func(Targets)) {
+	ticker := time.NewTicker(d.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			targets, err := d.Discover(ctx)
+			if err != nil {
+				slog.Error("Failed to discover GitHub App installations", "error", err)
+				continue
+			}
+
+			if targets.Equal(current) {
+				continue
+			}
+
+			slog.Info("GitHub App targets changed", "orgs", len(targets.Orgs), "repos", len(targets.Repos))
+
+			current = targets
+			onChange(targets)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package githubapp
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/d0ugal/github-exporter/internal/metrics"
+	promexporter_metrics "github.com/d0ugal/promexporter/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// fakeAPI serves installations, installation tokens and the repositories
+// granted to each token
+func fakeAPI(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		expires := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+
+		switch {
+		case r.URL.Path == "/app/installations":
+			_, _ = w.Write([]byte(`[
+				{"id": 1, "account": {"login": "d0ugal", "type": "User"}, "repository_selection": "selected"},
+				{"id": 2, "account": {"login": "acme", "type": "Organization"}, "repository_selection": "all"}
+			]`))
+		case strings.HasPrefix(r.URL.Path, "/app/installations/") && strings.HasSuffix(r.URL.Path, "/access_tokens"):
+			id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/app/installations/"), "/access_tokens")
+			w.WriteHeader(http.StatusCreated)
+			_, _ = fmt.Fprintf(w, `{"token": "token-%s", "expires_at": %q}`, id, expires)
+		case r.URL.Path == "/installation/repositories":
+			if r.Header.Get("Authorization") != "Bearer token-1" {
+				t.Errorf("Expected the token of installation 1, got %q", r.Header.Get("Authorization"))
+			}
+
+			_, _ = w.Write([]byte(`{"total_count": 2, "repositories": [{"full_name": "d0ugal/b"}, {"full_name": "d0ugal/a"}]}`))
+		default:
+			w.Header().Set("X-RateLimit-Limit", "15000")
+			w.Header().Set("X-RateLimit-Remaining", "14999")
+			w.Header().Set("X-RateLimit-Reset", fmt.Sprint(time.Now().Add(time.Hour).Unix()))
+			w.Header().Set("X-RateLimit-Resource", "core")
+			_, _ = fmt.Fprintf(w, `{"authorization": %q}`, r.Header.Get("Authorization"))
+		}
+	}
+}
+
+// newTestDiscoverer creates a discoverer and transport backed by fakeAPI
+func newTestDiscoverer(t *testing.T) (*Discoverer, *Transport, *metrics.GitHubRegistry) {
+	t.Helper()
+
+	app, _ := newTestApp(t, fakeAPI(t))
+	registry := metrics.NewGitHubRegistry(promexporter_metrics.NewRegistry("github-exporter-test"))
+	transport := NewTransport(app, registry, 0.8)
+
+	return NewDiscoverer(app, transport, registry, time.Hour), transport, registry
+}
+
+// TestDiscover tests that organizations granting all repositories become
+// organization targets and selected repositories are listed
+func TestDiscover(t *testing.T) {
+	discoverer, _, registry := newTestDiscoverer(t)
+
+	targets, err := discoverer.Discover(t.Context())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := Targets{Orgs: []string{"acme"}, Repos: []string{"d0ugal/a", "d0ugal/b"}}
+	if !reflect.DeepEqual(targets, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, targets)
+	}
+
+	if got := testutil.CollectAndCount(registry.GitHubAppInstallationInfo); got != 2 {
+		t.Errorf("Expected 2 installations, got %d", got)
+	}
+}
+
+// TestTransportRouting tests that requests use the token of the installation on
+// the account they are about, falling back to the first installation
+func TestTransportRouting(t *testing.T) {
+	discoverer, transport, registry := newTestDiscoverer(t)
+
+	if _, err := discoverer.Discover(t.Context()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Pace nothing in tests
+	for _, inst := range transport.installations {
+		inst.limiter.SetLimit(1000)
+		inst.limiter.SetBurst(10)
+	}
+
+	client := &http.Client{Transport: transport}
+
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{"/repos/acme/api", "token-2"},
+		{"/orgs/ACME", "token-2"},
+		{"/search/issues?q=repo:acme/api+type:pr", "token-2"},
+		{"/repos/d0ugal/a/actions/runs", "token-1"},
+		{"/rate_limit", "token-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			resp, err := client.Get(transport.app.baseURL.String() + strings.TrimPrefix(tt.path, "/"))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			body, _ := io.ReadAll(resp.Body)
+			if !strings.Contains(string(body), "Bearer "+tt.expected) {
+				t.Errorf("Expected %s, got %s", tt.expected, body)
+			}
+		})
+	}
+
+	if got := testutil.ToFloat64(registry.GitHubAppInstallationRateLimitRemaining.WithLabelValues("2", "acme")); got != 14999 {
+		t.Errorf("Expected 14999 requests remaining for installation 2, got %v", got)
+	}
+}
+
+// TestTransportNoInstallations tests that requests fail before any installation is known
+func TestTransportNoInstallations(t *testing.T) {
+	_, transport, _ := newTestDiscoverer(t)
+
+	req := httptest.NewRequest(http.MethodGet, "https://api.github.com/repos/acme/api", nil)
+	if _, err := transport.RoundTrip(req); err == nil {
+		t.Error("Expected an error without installations")
+	}
+}
+
+// TestTransportRateLimitRecovery tests that an installation that ran out of
+// requests is never stopped entirely and is no longer paced after the reset
+func TestTransportRateLimitRecovery(t *testing.T) {
+	discoverer, transport, _ := newTestDiscoverer(t)
+
+	if _, err := discoverer.Discover(t.Context()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	inst := transport.route("acme")
+
+	rateLimited := func(remaining int) *http.Response {
+		resp := &http.Response{Header: http.Header{}}
+		resp.Header.Set("X-RateLimit-Limit", "15000")
+		resp.Header.Set("X-RateLimit-Remaining", fmt.Sprint(remaining))
+		resp.Header.Set("X-RateLimit-Reset", fmt.Sprint(time.Now().Add(time.Hour).Unix()))
+
+		return resp
+	}
+
+	transport.observeRateLimit(inst, rateLimited(0))
+
+	if limit := inst.limiter.Limit(); limit <= 0 {
+		t.Fatalf("Expected the installation to keep a positive limit, got %v", limit)
+	}
+
+	transport.observeRateLimit(inst, rateLimited(1))
+	inst.reset = time.Now().Add(-time.Minute)
+
+	ctx, cancel := context.WithTimeout(t.Context(), time.Second)
+	defer cancel()
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, transport.app.baseURL.String()+"repos/acme/api", nil)
+
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("Expected the request to be sent after the reset, got %v", err)
+	}
+	_ = resp.Body.Close()
+}
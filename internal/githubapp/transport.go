@@ -0,0 +1,246 @@
+package githubapp
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/d0ugal/github-exporter/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+)
+
+// tokenRefreshMargin is how long before expiry installation tokens are replaced
+const tokenRefreshMargin = 5 * time.Minute
+
+// installation holds the token and rate limiter of an installation
+type installation struct {
+	Installation
+
+	mu      sync.Mutex
+	token   string
+	expires time.Time
+	limiter *rate.Limiter
+	reset   time.Time // When the rate limit of the latest response resets
+}
+
+// Transport authenticates each request with a token of the installation on the
+// account the request is about, and paces requests per installation based on
+// the installation's own rate limit. Requests that are not about an account,
+// such as /rate_limit or GraphQL queries, use the first installation.
+type Transport struct {
+	app     *App
+	metrics *metrics.GitHubRegistry
+	buffer  float64 // Fraction of each installation's rate limit to use
+
+	mu            sync.RWMutex
+	installations map[string]*installation // By lower-cased account login
+	fallback      *installation
+}
+
+// NewTransport creates a transport for the app's installations. Installations
+// are registered with SetInstallations.
+func NewTransport(app *App, metricsRegistry *metrics.GitHubRegistry, rateLimitBuffer float64) *Transport {
+	return &Transport{
+		app:           app,
+		metrics:       metricsRegistry,
+		buffer:        rateLimitBuffer,
+		installations: make(map[string]*installation),
+	}
+}
+
+// SetInstallations replaces the installations requests are routed to, keeping
+// the tokens and rate limiters of installations that are still present
+func (t *Transport) SetInstallations(installations []Installation) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	current := make(map[int64]*installation, len(t.installations))
+	for _, inst := range t.installations {
+		current[inst.ID] = inst
+	}
+
+	t.installations = make(map[string]*installation, len(installations))
+	t.fallback = nil
+
+	for _, i := range installations {
+		inst, ok := current[i.ID]
+		if !ok {
+			// Start conservatively until the first response reports the rate limit
+			inst = &installation{Installation: i, limiter: rate.NewLimiter(1, 1)}
+		}
+
+		t.installations[strings.ToLower(i.Account)] = inst
+
+		if t.fallback == nil {
+			t.fallback = inst
+		}
+	}
+}
+
+// route returns the installation a request about account is made with
+func (t *Transport) route(account string) *installation {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if inst, ok := t.installations[strings.ToLower(account)]; ok {
+		return inst
+	}
+
+	return t.fallback
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	inst := t.route(accountOf(req))
+	if inst == nil {
+		return nil, errNoInstallations
+	}
+
+	inst.liftPace(time.Now())
+
+	if err := inst.limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+
+	token, err := t.token(req.Context(), inst)
+	if err != nil {
+		return nil, err
+	}
+
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := t.app.base.RoundTrip(req)
+	if resp != nil {
+		t.observeRateLimit(inst, resp)
+	}
+
+	return resp, err
+}
+
+// token returns a valid token of the installation, minting a new one shortly before expiry
+func (t *Transport) token(ctx context.Context, inst *installation) (string, error) {
+	inst.mu.Lock()
+	defer inst.mu.Unlock()
+
+	if inst.token != "" && time.Until(inst.expires) > tokenRefreshMargin {
+		return inst.token, nil
+	}
+
+	token, expires, err := t.app.installationToken(ctx, inst.ID)
+	if err != nil {
+		return "", err
+	}
+
+	inst.token, inst.expires = token, expires
+
+	t.metrics.GitHubAppInstallationTokenExpiration.With(inst.labels()).Set(float64(expires.Unix()))
+
+	return token, nil
+}
+
+// observeRateLimit exports the rate limit reported with a response and paces
+// the installation's requests so the remaining ones last until it resets
+func (t *Transport) observeRateLimit(inst *installation, resp *http.Response) {
+	limit, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Limit"))
+	if err != nil {
+		return
+	}
+
+	remaining, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return
+	}
+
+	// GraphQL points and search requests have rate limits of their own
+	if resource := resp.Header.Get("X-RateLimit-Resource"); resource != "" && resource != "core" {
+		return
+	}
+
+	t.metrics.GitHubAppInstallationRateLimitTotal.With(inst.labels()).Set(float64(limit))
+	t.metrics.GitHubAppInstallationRateLimitRemaining.With(inst.labels()).Set(float64(remaining))
+
+	reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil {
+		return
+	}
+
+	inst.mu.Lock()
+	inst.reset = time.Unix(reset, 0)
+	inst.mu.Unlock()
+
+	// A limit of 0 would fail every request until restarted, keep the pace and
+	// let the reset lift it instead
+	if remaining <= 0 {
+		return
+	}
+
+	timeUntilReset := time.Until(time.Unix(reset, 0))
+	if timeUntilReset <= 0 {
+		timeUntilReset = time.Hour
+	}
+
+	inst.limiter.SetLimit(rate.Limit(float64(remaining) * t.buffer / timeUntilReset.Seconds()))
+}
+
+// liftPace stops pacing the installation's requests once its rate limit has
+// reset, until the next response reports the new rate limit
+func (inst *installation) liftPace(now time.Time) {
+	inst.mu.Lock()
+	reset := inst.reset
+	inst.mu.Unlock()
+
+	if !reset.IsZero() && !now.Before(reset) {
+		inst.limiter.SetLimit(rate.Inf)
+	}
+}
+
+// labels returns the metric labels of the installation
+func (inst *installation) labels() prometheus.Labels {
+	return prometheus.Labels{
+		"installation": strconv.FormatInt(inst.ID, 10),
+		"account":      inst.Account,
+	}
+}
+
+// accountOf returns the organization or user a request is about, taken from
+// the /repos/{owner}, /orgs/{org} or /users/{user} path prefix or the repo:,
+// org: and user: qualifiers of a search query. It returns "" for other requests.
+func accountOf(req *http.Request) string {
+	segments := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+
+	for i, segment := range segments[:max(len(segments)-1, 0)] {
+		switch segment {
+		case "repos", "orgs", "users":
+			return segments[i+1]
+		case "search":
+			return accountOfQuery(req.URL.Query().Get("q"))
+		}
+	}
+
+	return ""
+}
+
+// accountOfQuery returns the account of the first repo:, org: or user: qualifier of a search query
+func accountOfQuery(query string) string {
+	for _, term := range strings.Fields(query) {
+		qualifier, value, ok := strings.Cut(term, ":")
+		if !ok {
+			continue
+		}
+
+		switch qualifier {
+		case "repo":
+			owner, _, _ := strings.Cut(value, "/")
+			return owner
+		case "org", "user":
+			return value
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,172 @@
+package kubernetes
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// Paths of the service account credentials mounted into every pod
+const (
+	serviceAccountDir       = "/var/run/secrets/kubernetes.io/serviceaccount"
+	serviceAccountToken     = serviceAccountDir + "/token"
+	serviceAccountCA        = serviceAccountDir + "/ca.crt"
+	serviceAccountNamespace = serviceAccountDir + "/namespace"
+)
+
+// Client is a minimal read-only client for the Kubernetes API using in-cluster credentials
+type Client struct {
+	baseURL   string
+	tokenPath string
+	namespace string
+	http      *http.Client
+}
+
+// NewInClusterClient creates a client from the pod's service account
+func NewInClusterClient() (*Client, error) {
+	host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+	if host == "" || port == "" {
+		return nil, fmt.Errorf("not running in a Kubernetes cluster: KUBERNETES_SERVICE_HOST and KUBERNETES_SERVICE_PORT must be set")
+	}
+
+	caData, err := os.ReadFile(serviceAccountCA)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read service account CA: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caData) {
+		return nil, fmt.Errorf("failed to parse service account CA")
+	}
+
+	namespace, err := os.ReadFile(serviceAccountNamespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read service account namespace: %w", err)
+	}
+
+	httpClient := &http.Client{
+		Timeout: 30 * time.Second,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				RootCAs:    pool,
+				MinVersion: tls.VersionTLS12,
+			},
+		},
+	}
+
+	return newClient("https://"+net.JoinHostPort(host, port), serviceAccountToken, strings.TrimSpace(string(namespace)), httpClient), nil
+}
+
+func newClient(baseURL, tokenPath, namespace string, httpClient *http.Client) *Client {
+	return &Client{
+		baseURL:   strings.TrimSuffix(baseURL, "/"),
+		tokenPath: tokenPath,
+		namespace: namespace,
+		http:      httpClient,
+	}
+}
+
+// Namespace returns the namespace the exporter is running in
+func (c *Client) Namespace() string {
+	return c.namespace
+}
+
+// get performs a GET request against the API and decodes the JSON response into out.
+// The token is read on every request because projected service account tokens rotate.
+func (c *Client) get(ctx context.Context, path string, query url.Values, out interface{}) error {
+	u := c.baseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	if c.tokenPath != "" {
+		token, err := os.ReadFile(c.tokenPath)
+		if err != nil {
+			return fmt.Errorf("failed to read service account token: %w", err)
+		}
+
+		req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("request to %s failed: %w", path, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("request to %s failed with status %d: %s", path, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response from %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// objectMeta holds the metadata fields used for discovery
+type objectMeta struct {
+	Name        string            `json:"name"`
+	Namespace   string            `json:"namespace"`
+	Annotations map[string]string `json:"annotations"`
+}
+
+// configMapList is the subset of a ConfigMapList used for discovery
+type configMapList struct {
+	Items []struct {
+		Metadata objectMeta        `json:"metadata"`
+		Data     map[string]string `json:"data"`
+	} `json:"items"`
+}
+
+// namespaceList is the subset of a NamespaceList used for discovery
+type namespaceList struct {
+	Items []struct {
+		Metadata objectMeta `json:"metadata"`
+	} `json:"items"`
+}
+
+// listConfigMaps lists the ConfigMaps in namespace matching labelSelector
+func (c *Client) listConfigMaps(ctx context.Context, namespace, labelSelector string) (*configMapList, error) {
+	var list configMapList
+
+	query := url.Values{}
+	if labelSelector != "" {
+		query.Set("labelSelector", labelSelector)
+	}
+
+	if err := c.get(ctx, "/api/v1/namespaces/"+url.PathEscape(namespace)+"/configmaps", query, &list); err != nil {
+		return nil, err
+	}
+
+	return &list, nil
+}
+
+// listNamespaces lists all namespaces in the cluster
+func (c *Client) listNamespaces(ctx context.Context) (*namespaceList, error) {
+	var list namespaceList
+
+	if err := c.get(ctx, "/api/v1/namespaces", nil, &list); err != nil {
+		return nil, err
+	}
+
+	return &list, nil
+}
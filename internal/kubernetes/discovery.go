@@ -0,0 +1,150 @@
+package kubernetes
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/d0ugal/github-exporter/internal/config"
+)
+
+// Keys under which targets are listed in ConfigMap data and namespace annotations
+const (
+	OrgsKey  = "orgs"
+	ReposKey = "repos"
+
+	// AnnotationPrefix prefixes the namespace annotations listing targets
+	AnnotationPrefix = "github-exporter.d0ugal.com/"
+)
+
+// Targets holds the organizations and repositories discovered from Kubernetes
+type Targets struct {
+	Orgs  []string
+	Repos []string
+}
+
+// Equal reports whether two target sets are identical
+func (t Targets) Equal(other Targets) bool {
+	return slices.Equal(t.Orgs, other.Orgs) && slices.Equal(t.Repos, other.Repos)
+}
+
+// Discoverer periodically discovers targets from ConfigMaps and namespace annotations
+type Discoverer struct {
+	client *Client
+	config config.KubernetesConfig
+}
+
+// NewDiscoverer creates a discoverer using in-cluster credentials
+func NewDiscoverer(cfg config.KubernetesConfig) (*Discoverer, error) {
+	client, err := NewInClusterClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Discoverer{client: client, config: cfg}, nil
+}
+
+// Discover returns the current set of targets. Lists are sorted and de-duplicated.
+func (d *Discoverer) Discover(ctx context.Context) (Targets, error) {
+	orgs := map[string]bool{}
+	repos := map[string]bool{}
+
+	namespaces := d.config.Namespaces
+	if len(namespaces) == 0 {
+		namespaces = []string{d.client.Namespace()}
+	}
+
+	for _, namespace := range namespaces {
+		list, err := d.client.listConfigMaps(ctx, namespace, d.config.LabelSelector)
+		if err != nil {
+			return Targets{}, err
+		}
+
+		for _, item := range list.Items {
+			addAll(orgs, item.Data[OrgsKey])
+			addAll(repos, item.Data[ReposKey])
+		}
+	}
+
+	if d.config.NamespaceAnnotations {
+		list, err := d.client.listNamespaces(ctx)
+		if err != nil {
+			return Targets{}, err
+		}
+
+		for _, item := range list.Items {
+			addAll(orgs, item.Metadata.Annotations[AnnotationPrefix+OrgsKey])
+			addAll(repos, item.Metadata.Annotations[AnnotationPrefix+ReposKey])
+		}
+	}
+
+	return Targets{Orgs: sortedKeys(orgs), Repos: sortedKeys(repos)}, nil
+}
+
+// Start performs an initial discovery and then polls for changes in the background
+// until ctx is cancelled. onChange is called with the initial targets and whenever
+// they change afterwards. Discovery errors are logged and the last known targets kept.
+func (d *Discoverer) Start(ctx context.Context, onChange func(Targets)) {
+	current, err := d.Discover(ctx)
+	if err != nil {
+		slog.Error("Failed to discover Kubernetes targets", "error", err)
+	} else {
+		slog.Info("Discovered Kubernetes targets", "orgs", len(current.Orgs), "repos", len(current.Repos))
+		onChange(current)
+	}
+
+	go d.poll(ctx, current, onChange)
+}
+
+func (d *Discoverer) poll(ctx context.Context, current Targets, onChange func(Targets)) {
+	ticker := time.NewTicker(d.config.PollInterval.Duration)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			targets, err := d.Discover(ctx)
+			if err != nil {
+				slog.Error("Failed to discover Kubernetes targets", "error", err)
+				continue
+			}
+
+			if targets.Equal(current) {
+				continue
+			}
+
+			slog.Info("Kubernetes targets changed", "orgs", len(targets.Orgs), "repos", len(targets.Repos))
+
+			current = targets
+			onChange(targets)
+		}
+	}
+}
+
+// addAll adds the entries of a comma or newline separated list to set
+func addAll(set map[string]bool, list string) {
+	for _, entry := range strings.FieldsFunc(list, func(r rune) bool {
+		return r == ',' || r == '\n'
+	}) {
+		entry = strings.TrimSpace(entry)
+		if entry != "" && !strings.HasPrefix(entry, "#") {
+			set[entry] = true
+		}
+	}
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
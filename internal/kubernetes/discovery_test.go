@@ -0,0 +1,90 @@
+package kubernetes
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/d0ugal/github-exporter/internal/config"
+)
+
+// newTestDiscoverer creates a discoverer backed by a fake API server
+func newTestDiscoverer(t *testing.T, handler http.HandlerFunc, cfg config.KubernetesConfig) *Discoverer {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	tokenPath := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenPath, []byte("test-token\n"), 0o600); err != nil {
+		t.Fatalf("Failed to write token: %v", err)
+	}
+
+	return &Discoverer{
+		client: newClient(server.URL, tokenPath, "monitoring", server.Client()),
+		config: cfg,
+	}
+}
+
+// TestDiscover tests discovery from ConfigMaps and namespace annotations
+func TestDiscover(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/api/v1/namespaces/monitoring/configmaps":
+			if r.URL.Query().Get("labelSelector") != config.DefaultKubernetesLabelSelector {
+				t.Errorf("Unexpected label selector %q", r.URL.Query().Get("labelSelector"))
+			}
+
+			_, _ = w.Write([]byte(`{"items": [
+				{"metadata": {"name": "team-a"}, "data": {"repos": "team-a/api\nteam-a/web\n# team-a/old\n"}},
+				{"metadata": {"name": "team-b"}, "data": {"repos": "team-b/api, team-a/api", "orgs": "team-b"}}
+			]}`))
+		case "/api/v1/namespaces":
+			_, _ = w.Write([]byte(`{"items": [
+				{"metadata": {"name": "payments", "annotations": {"github-exporter.d0ugal.com/repos": "payments/ledger"}}},
+				{"metadata": {"name": "default"}}
+			]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	d := newTestDiscoverer(t, handler, config.KubernetesConfig{
+		LabelSelector:        config.DefaultKubernetesLabelSelector,
+		NamespaceAnnotations: true,
+	})
+
+	targets, err := d.Discover(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	wantRepos := []string{"payments/ledger", "team-a/api", "team-a/web", "team-b/api"}
+	if !reflect.DeepEqual(targets.Repos, wantRepos) {
+		t.Errorf("Expected repos %v, got %v", wantRepos, targets.Repos)
+	}
+
+	if !reflect.DeepEqual(targets.Orgs, []string{"team-b"}) {
+		t.Errorf("Expected orgs [team-b], got %v", targets.Orgs)
+	}
+}
+
+// TestDiscoverError tests that API errors are returned
+func TestDiscoverError(t *testing.T) {
+	d := newTestDiscoverer(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "forbidden", http.StatusForbidden)
+	}, config.KubernetesConfig{})
+
+	if _, err := d.Discover(context.Background()); err == nil {
+		t.Error("Expected error for forbidden response")
+	}
+}
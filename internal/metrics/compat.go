@@ -0,0 +1,166 @@
+package metrics
+
+import (
+	"sort"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// metricAlias maps a metric of this exporter to the name used by the
+// githubexporter/github-exporter container
+type metricAlias struct {
+	source string            // Metric name without namespace
+	target string            // Fully qualified compatibility name
+	labels map[string]string // Label renames (source -> target)
+}
+
+// compatAliases lists the metrics exposed under compatibility names. Metrics whose
+// names already match (stars, forks, watchers, open issues) are not aliased since
+// the same name cannot be exposed twice with different labels.
+var compatAliases = []metricAlias{
+	{source: "repo_open_prs", target: "github_repo_pull_request_count", labels: repoCompatLabels},
+	{source: "repo_size_bytes", target: "github_repo_size_kb", labels: repoCompatLabels},
+	{source: "rate_limit_total", target: "github_rate_limit"},
+	{source: "rate_limit_remaining", target: "github_rate_remaining"},
+	{source: "rate_limit_reset_timestamp", target: "github_rate_reset"},
+}
+
+// repoCompatLabels renames repository labels to the githubexporter naming
+var repoCompatLabels = map[string]string{
+	"org": "user",
+}
+
+// aliasGatherer exposes copies of selected metric families under alias names
+type aliasGatherer struct {
+	gatherer prometheus.Gatherer
+	aliases  map[string]metricAlias
+}
+
+// EnableCompatibilityAliases additionally exposes metrics under the names used by
+// the githubexporter/github-exporter container so dashboards can be migrated gradually
+func (g *GitHubRegistry) EnableCompatibilityAliases() {
+	aliases := make(map[string]metricAlias, len(compatAliases))
+
+	for _, alias := range compatAliases {
+		sourceName := g.metricName(alias.source)
+		if sourceName == alias.target {
+			continue
+		}
+
+		aliases[sourceName] = alias
+
+		for _, info := range g.GetMetricsInfo() {
+			if info.Name == sourceName {
+				g.AddMetricInfo(alias.target, info.Help+" (compatibility alias)", renameLabelNames(info.Labels, alias.labels))
+				g.types[alias.target] = g.types[sourceName]
+				break
+			}
+		}
+	}
+
+	g.compat = &aliasGatherer{
+		gatherer: g.GetRegistry(),
+		aliases:  aliases,
+	}
+}
+
+// Gatherer returns the gatherer used to expose metrics, including compatibility
+// aliases and repository labels when enabled and the warm-up snapshot while it
+// is served
+func (g *GitHubRegistry) Gatherer() prometheus.Gatherer {
+	var live prometheus.Gatherer = g.GetRegistry()
+	if g.repoLabels != nil {
+		live = g.repoLabels
+	}
+
+	var gatherer prometheus.Gatherer = &warmupGatherer{
+		gatherer: live,
+		snapshot: &g.snapshot,
+	}
+
+	if g.compat == nil {
+		return gatherer
+	}
+
+	return prometheus.Gatherers{gatherer, g.compat}
+}
+
+// Gather implements prometheus.Gatherer
+func (a *aliasGatherer) Gather() ([]*dto.MetricFamily, error) {
+	families, err := a.gatherer.Gather()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*dto.MetricFamily, 0, len(a.aliases))
+
+	for _, family := range families {
+		alias, ok := a.aliases[family.GetName()]
+		if !ok {
+			continue
+		}
+
+		name := alias.target
+		aliased := &dto.MetricFamily{
+			Name:   &name,
+			Help:   family.Help,
+			Type:   family.Type,
+			Metric: make([]*dto.Metric, 0, len(family.Metric)),
+		}
+
+		for _, metric := range family.Metric {
+			aliased.Metric = append(aliased.Metric, &dto.Metric{
+				Label:       renameLabelPairs(metric.Label, alias.labels),
+				Gauge:       metric.Gauge,
+				Counter:     metric.Counter,
+				Untyped:     metric.Untyped,
+				Histogram:   metric.Histogram,
+				Summary:     metric.Summary,
+				TimestampMs: metric.TimestampMs,
+			})
+		}
+
+		result = append(result, aliased)
+	}
+
+	return result, nil
+}
+
+// renameLabelPairs returns a sorted copy of labels with names renamed
+func renameLabelPairs(labels []*dto.LabelPair, renames map[string]string) []*dto.LabelPair {
+	result := make([]*dto.LabelPair, 0, len(labels))
+
+	for _, label := range labels {
+		name := label.GetName()
+		if renamed, ok := renames[name]; ok {
+			name = renamed
+		}
+
+		result = append(result, &dto.LabelPair{
+			Name:  &name,
+			Value: label.Value,
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].GetName() < result[j].GetName()
+	})
+
+	return result
+}
+
+// renameLabelNames returns a copy of label names with renames applied
+func renameLabelNames(labels []string, renames map[string]string) []string {
+	result := make([]string, 0, len(labels))
+
+	for _, label := range labels {
+		if renamed, ok := renames[label]; ok {
+			label = renamed
+		}
+
+		result = append(result, label)
+	}
+
+	return result
+}
@@ -1,15 +1,29 @@
 package metrics
 
 import (
+	"sync/atomic"
+
 	promexporter_metrics "github.com/d0ugal/promexporter/metrics"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	dto "github.com/prometheus/client_model/go"
 )
 
 // GitHubRegistry wraps the promexporter registry with GitHub-specific metrics
 type GitHubRegistry struct {
 	*promexporter_metrics.Registry
 
+	namespace  string
+	factory    promauto.Factory
+	compat     *aliasGatherer
+	repoLabels *repoLabelGatherer
+	snapshot   atomic.Pointer[[]*dto.MetricFamily]
+	types      map[string]dto.MetricType // By fully qualified metric name
+
+	// Counters and histograms labelled by org and repo, in registration order
+	repoCounters   []*prometheus.CounterVec
+	repoHistograms []*prometheus.HistogramVec
+
 	// GitHub repository metrics
 	GitHubReposTotal       *prometheus.GaugeVec
 	GitHubReposInfo        *prometheus.GaugeVec
@@ -22,246 +36,616 @@ type GitHubRegistry struct {
 	GitHubReposLastUpdated *prometheus.GaugeVec
 	GitHubReposCreatedAt   *prometheus.GaugeVec
 
+	// GitHub repository activity metrics
+	GitHubIssueCommentsTotal    *prometheus.CounterVec
+	GitHubIssuesOpenedTotal     *prometheus.CounterVec
+	GitHubIssuesClosedTotal     *prometheus.CounterVec
+	GitHubPRReviewCommentsTotal *prometheus.CounterVec
+	GitHubIssueFirstResponse    *prometheus.HistogramVec
+
+	// GitHub repository CODEOWNERS metrics
+	GitHubCodeownersExists *prometheus.GaugeVec
+	GitHubCodeownersRules  *prometheus.GaugeVec
+	GitHubCodeownersErrors *prometheus.GaugeVec
+
+	// GitHub repository security metrics
+	GitHubPrivateVulnReportingEnabled *prometheus.GaugeVec
+	GitHubSecurityPolicyExists        *prometheus.GaugeVec
+	GitHubCommunityFilePresent        *prometheus.GaugeVec
+	GitHubDependencyAutomation        *prometheus.GaugeVec
+	GitHubTagProtected                *prometheus.GaugeVec
+	GitHubTagRulesets                 *prometheus.GaugeVec
+	GitHubTagProtectionPatterns       *prometheus.GaugeVec
+	GitHubRulesetBypassActors         *prometheus.GaugeVec
+	GitHubBranchBypassActors          *prometheus.GaugeVec
+
+	// GitHub Actions secret and variable metrics
+	GitHubActionsSecrets      *prometheus.GaugeVec
+	GitHubActionsSecretMaxAge *prometheus.GaugeVec
+	GitHubActionsVariables    *prometheus.GaugeVec
+
+	// GitHub Actions OIDC metrics
+	GitHubOIDCSubjectClaimCustomized *prometheus.GaugeVec
+	GitHubEnvironmentSecrets         *prometheus.GaugeVec
+	GitHubEnvironmentOIDC            *prometheus.GaugeVec
+
+	// GitHub repository release metrics
+	GitHubLastReleaseTimestamp *prometheus.GaugeVec
+	GitHubDaysSinceLastRelease *prometheus.GaugeVec
+	GitHubReleasesInWindow     *prometheus.GaugeVec
+	GitHubDraftReleases        *prometheus.GaugeVec
+	GitHubReleaseAssetInfo     *prometheus.GaugeVec
+	GitHubReleaseAssetSize     *prometheus.GaugeVec
+	GitHubReleaseAssets        *prometheus.GaugeVec
+	GitHubUnreleasedCommits    *prometheus.GaugeVec
+	GitHubRepoActiveForks      *prometheus.GaugeVec
+
+	// GitHub repository webhook metrics
+	GitHubWebhookLastDeliverySuccess   *prometheus.GaugeVec
+	GitHubWebhookLastDeliveryTimestamp *prometheus.GaugeVec
+	GitHubWebhookDeliveryFailures      *prometheus.GaugeVec
+
+	// GitHub webhook event metrics
+	GitHubWebhookRepositoryEvents *prometheus.CounterVec
+	GitHubPushEvents              *prometheus.CounterVec
+	GitHubPullRequestEvents       *prometheus.CounterVec
+
+	// Webhook receiver metrics
+	GitHubWebhookDeliveries      *prometheus.CounterVec
+	GitHubWebhookHandlerDuration *prometheus.HistogramVec
+	GitHubWebhookEventLag        *prometheus.HistogramVec
+	GitHubResyncs                *prometheus.CounterVec
+
 	// GitHub organization metrics
 	GitHubOrgsTotal       *prometheus.GaugeVec
+	GitHubOrgInfo         *prometheus.GaugeVec
 	GitHubOrgsPublicRepos *prometheus.GaugeVec
 	GitHubOrgsFollowers   *prometheus.GaugeVec
 	GitHubOrgsFollowing   *prometheus.GaugeVec
 
+	// GitHub organization follower change metrics
+	GitHubOrgFollowersGained *prometheus.CounterVec
+	GitHubOrgFollowersLost   *prometheus.CounterVec
+
+	// GitHub user metrics
+	GitHubUserFollowers       *prometheus.GaugeVec
+	GitHubUserFollowing       *prometheus.GaugeVec
+	GitHubUserPublicRepos     *prometheus.GaugeVec
+	GitHubUserFollowersGained *prometheus.CounterVec
+	GitHubUserFollowersLost   *prometheus.CounterVec
+
+	// GitHub organization repository change metrics
+	GitHubOrgReposCreated  *prometheus.CounterVec
+	GitHubOrgReposArchived *prometheus.CounterVec
+
+	// GitHub organization security metrics
+	GitHubOrgTwoFactorRequired *prometheus.GaugeVec
+	GitHubOrgSAMLSSOEnabled    *prometheus.GaugeVec
+
+	// GitHub organization Actions policy metrics
+	GitHubOrgActionsPolicy             *prometheus.GaugeVec
+	GitHubOrgDefaultWorkflowPermission *prometheus.GaugeVec
+	GitHubOrgWorkflowsCanApprovePRs    *prometheus.GaugeVec
+	GitHubOrgSelfHostedRunnersPolicy   *prometheus.GaugeVec
+	GitHubOrgForkPRApprovalPolicy      *prometheus.GaugeVec
+	GitHubOrgPrivateForkPRApproval     *prometheus.GaugeVec
+
+	// GitHub organization project metrics
+	GitHubProjectItems                  *prometheus.GaugeVec
+	GitHubProjectItemsAdded             *prometheus.CounterVec
+	GitHubProjectItemsClosed            *prometheus.CounterVec
+	GitHubProjectIterationItems         *prometheus.GaugeVec
+	GitHubProjectIterationProgressRatio *prometheus.GaugeVec
+
+	// GitHub Packages metrics
+	GitHubPackageVersions               *prometheus.GaugeVec
+	GitHubPackageLatestVersionTimestamp *prometheus.GaugeVec
+	GitHubPackageUntaggedVersions       *prometheus.GaugeVec
+
+	// Custom search metrics
+	GitHubCustomSearchResults *prometheus.GaugeVec
+
 	// GitHub build status metrics
-	GitHubBranchBuildStatus   *prometheus.GaugeVec
-	GitHubWorkflowRunStatus   *prometheus.GaugeVec
-	GitHubCheckRunStatus      *prometheus.GaugeVec
-	GitHubWorkflowRunDuration *prometheus.GaugeVec
+	GitHubBranchBuildStatus     *prometheus.GaugeVec
+	GitHubRepoBuildStatus       *prometheus.GaugeVec
+	GitHubOrgFailingBuilds      *prometheus.GaugeVec
+	GitHubWorkflowRunStatus     *prometheus.GaugeVec
+	GitHubWorkflowLatestRunInfo *prometheus.GaugeVec
+	GitHubCheckRunStatus        *prometheus.GaugeVec
+	GitHubWorkflowRunDuration   *prometheus.GaugeVec
+	GitHubWorkflowRunBillable   *prometheus.GaugeVec
+	GitHubBranchCommitsTotal    *prometheus.CounterVec
+	GitHubWorkflowRunsTotal     *prometheus.CounterVec
+	GitHubWorkflowRunTime       *prometheus.HistogramVec
+	GitHubWorkflowSuccessRatio  *prometheus.GaugeVec
+
+	// GitHub organization workflow rollups
+	GitHubOrgWorkflowRuns            *prometheus.GaugeVec
+	GitHubOrgWorkflowFailedRuns      *prometheus.GaugeVec
+	GitHubOrgWorkflowBillableMinutes *prometheus.GaugeVec
+
+	// GitHub workflow inventory metrics
+	GitHubWorkflowInfo                     *prometheus.GaugeVec
+	GitHubWorkflowState                    *prometheus.GaugeVec
+	GitHubWorkflowScheduleInfo             *prometheus.GaugeVec
+	GitHubWorkflowScheduleNextRun          *prometheus.GaugeVec
+	GitHubWorkflowLastScheduledRun         *prometheus.GaugeVec
+	GitHubWorkflowSecondsSinceScheduledRun *prometheus.GaugeVec
+
+	// Synthetic check metrics
+	GitHubSyntheticWorkflowDuration *prometheus.GaugeVec
+	GitHubSyntheticWorkflowRuns     *prometheus.CounterVec
 
 	// GitHub API metrics
 	GitHubAPICallsTotal      *prometheus.CounterVec
 	GitHubAPIErrorsTotal     *prometheus.CounterVec
+	GitHubAPIResourceTotal   *prometheus.CounterVec
+	GitHubAPIQuotaUsedRatio  *prometheus.GaugeVec
+	GitHubAPIRequestDuration *prometheus.HistogramVec
 	GitHubRateLimitTotal     *prometheus.GaugeVec
 	GitHubRateLimitRemaining *prometheus.GaugeVec
 	GitHubRateLimitReset     *prometheus.GaugeVec
+	GitHubTokenExpiration    *prometheus.GaugeVec
+
+	// GitHub App installation metrics
+	GitHubAppInstallationInfo               *prometheus.GaugeVec
+	GitHubAppInstallationRateLimitTotal     *prometheus.GaugeVec
+	GitHubAppInstallationRateLimitRemaining *prometheus.GaugeVec
+	GitHubAppInstallationTokenExpiration    *prometheus.GaugeVec
+
+	// Token pool metrics
+	GitHubTokenPoolRateLimitTotal     *prometheus.GaugeVec
+	GitHubTokenPoolRateLimitRemaining *prometheus.GaugeVec
+	GitHubTokenPoolRateLimitReset     *prometheus.GaugeVec
+	GitHubTokenPoolRotations          *prometheus.CounterVec
+
+	// GitHub GraphQL API rate limit metrics
+	GitHubGraphQLRateLimitTotal     *prometheus.GaugeVec
+	GitHubGraphQLRateLimitRemaining *prometheus.GaugeVec
+	GitHubGraphQLRateLimitReset     *prometheus.GaugeVec
+	GitHubGraphQLQueryCost          *prometheus.CounterVec
+
+	// Discovery metrics
+	GitHubDiscoveryMaxReposExceeded *prometheus.GaugeVec
+	GitHubTargetDrift               *prometheus.GaugeVec
+	GitHubTargetDriftRepos          *prometheus.GaugeVec
+	GitHubTargetRevalidations       *prometheus.CounterVec
+
+	// Collector metrics
+	GitHubCollectorPermissionOK     *prometheus.GaugeVec
+	GitHubExporterTargets           *prometheus.GaugeVec
+	GitHubRepoLabelValues           *prometheus.GaugeVec
+	GitHubExporterAnomalies         *prometheus.CounterVec
+	GitHubTargetStale               *prometheus.GaugeVec
+	GitHubRepoRenamed               *prometheus.GaugeVec
+	GitHubTargetNotFound            *prometheus.GaugeVec
+	GitHubCollectionSkipped         *prometheus.CounterVec
+	GitHubCollectionPhaseTargets    *prometheus.GaugeVec
+	GitHubExporterDegradedMode      *prometheus.GaugeVec
+	GitHubExporterPaused            *prometheus.GaugeVec
+	GitHubNotifications             *prometheus.CounterVec
+	GitHubExporterWarmup            *prometheus.GaugeVec
+	GitHubExporterSnapshotTimestamp *prometheus.GaugeVec
+	GitHubExporterRefreshInterval   *prometheus.GaugeVec
+	GitHubRefreshIntervalUpdates    *prometheus.CounterVec
 }
 
-// NewGitHubRegistry creates a new GitHub metrics registry
+// workflowRunBuckets range from 30 seconds to four hours, covering quick checks
+// up to long release pipelines
+var workflowRunBuckets = []float64{30, 60, 120, 300, 600, 900, 1800, 3600, 7200, 14400}
+
+// firstResponseBuckets range from one hour to four weeks, covering typical
+// response time SLAs measured in business days
+var firstResponseBuckets = []float64{
+	3600, 4 * 3600, 8 * 3600,
+	86400, 2 * 86400, 3 * 86400, 5 * 86400, 7 * 86400, 14 * 86400, 28 * 86400,
+}
+
+// webhookHandlerBuckets range from one millisecond to five seconds; handlers only
+// update in-memory metrics
+var webhookHandlerBuckets = []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 5}
+
+// webhookLagBuckets range from one second to one hour, from deliveries made right
+// away to deliveries redelivered after an outage
+var webhookLagBuckets = []float64{1, 2, 5, 10, 30, 60, 120, 300, 900, 3600}
+
+// apiRequestBuckets range from 50 milliseconds to the default request timeout
+var apiRequestBuckets = []float64{.05, .1, .25, .5, 1, 2.5, 5, 10, 30}
+
+// DefaultNamespace is the default prefix for all GitHub metric names
+const DefaultNamespace = "github"
+
+// NewGitHubRegistry creates a new GitHub metrics registry using the default namespace
 func NewGitHubRegistry(baseRegistry *promexporter_metrics.Registry) *GitHubRegistry {
-	// Get the underlying Prometheus registry
-	promRegistry := baseRegistry.GetRegistry()
-	factory := promauto.With(promRegistry)
+	return NewGitHubRegistryWithNamespace(baseRegistry, DefaultNamespace)
+}
+
+// NewGitHubRegistryWithNamespace creates a new GitHub metrics registry with all metric
+// names prefixed by namespace (e.g. "ghe" exports ghe_repo_stars)
+func NewGitHubRegistryWithNamespace(baseRegistry *promexporter_metrics.Registry, namespace string) *GitHubRegistry {
+	if namespace == "" {
+		namespace = DefaultNamespace
+	}
 
 	github := &GitHubRegistry{
-		Registry: baseRegistry,
+		Registry:  baseRegistry,
+		namespace: namespace,
+		factory:   promauto.With(baseRegistry.GetRegistry()),
+		types:     make(map[string]dto.MetricType),
 	}
 
 	// GitHub repository metrics
-	github.GitHubReposTotal = factory.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Name: "github_repos_total",
-			Help: "Total number of GitHub repositories",
-		},
-		[]string{"org", "visibility"},
-	)
-	baseRegistry.AddMetricInfo("github_repos_total", "Total number of GitHub repositories", []string{"org", "visibility"})
+	github.GitHubReposTotal = github.newGaugeVec("repos_total", "Total number of GitHub repositories", []string{"org", "visibility"})
+	github.GitHubReposInfo = github.newGaugeVec("repo_info", "Information about GitHub repositories", []string{"org", "repo", "visibility", "archived", "fork", "language"})
+	github.GitHubReposStars = github.newGaugeVec("repo_stars", "Number of stars for a GitHub repository", []string{"org", "repo", "visibility"})
+	github.GitHubReposForks = github.newGaugeVec("repo_forks", "Number of forks for a GitHub repository", []string{"org", "repo", "visibility"})
+	github.GitHubReposWatchers = github.newGaugeVec("repo_watchers", "Number of watchers for a GitHub repository", []string{"org", "repo", "visibility"})
+	github.GitHubReposOpenIssues = github.newGaugeVec("repo_open_issues", "Number of open issues for a GitHub repository", []string{"org", "repo", "visibility"})
+	github.GitHubReposOpenPRs = github.newGaugeVec("repo_open_prs", "Number of open pull requests for a GitHub repository", []string{"org", "repo", "visibility"})
+	github.GitHubReposSize = github.newGaugeVec("repo_size_bytes", "Size of a GitHub repository in bytes", []string{"org", "repo", "visibility"})
+	github.GitHubReposLastUpdated = github.newGaugeVec("repo_last_updated_timestamp", "Unix timestamp of the last update for a GitHub repository", []string{"org", "repo", "visibility"})
+	github.GitHubReposCreatedAt = github.newGaugeVec("repo_created_timestamp", "Unix timestamp of the creation date for a GitHub repository", []string{"org", "repo", "visibility"})
+
+	// GitHub repository activity metrics
+	github.GitHubIssueCommentsTotal = github.newCounterVec("repo_issue_comments_total", "Total number of issue and pull request conversation comments created on a GitHub repository since the exporter started", []string{"org", "repo"})
+	github.GitHubIssuesOpenedTotal = github.newCounterVec("repo_issues_opened_total", "Total number of issues opened on a GitHub repository since the exporter started", []string{"org", "repo"})
+	github.GitHubIssuesClosedTotal = github.newCounterVec("repo_issues_closed_total", "Total number of issues closed on a GitHub repository since the exporter started", []string{"org", "repo"})
+	github.GitHubPRReviewCommentsTotal = github.newCounterVec("repo_pr_review_comments_total", "Total number of pull request review comments created on a GitHub repository since the exporter started", []string{"org", "repo"})
+
+	github.GitHubIssueFirstResponse = github.newHistogramVec("repo_issue_first_response_seconds", "Time from opening a GitHub issue to its first comment by someone other than the author", firstResponseBuckets, []string{"org", "repo"})
+
+	// GitHub repository CODEOWNERS metrics
+	github.GitHubCodeownersExists = github.newGaugeVec("repo_codeowners_exists", "Whether a GitHub repository has a CODEOWNERS file (1=exists, 0=missing)", []string{"org", "repo"})
+	github.GitHubCodeownersRules = github.newGaugeVec("repo_codeowners_rules_total", "Number of rules in the CODEOWNERS file of a GitHub repository", []string{"org", "repo"})
+	github.GitHubCodeownersErrors = github.newGaugeVec("repo_codeowners_errors", "Number of errors GitHub reports for the CODEOWNERS file of a GitHub repository", []string{"org", "repo"})
+
+	// GitHub repository security metrics
+	github.GitHubPrivateVulnReportingEnabled = github.newGaugeVec("repo_private_vulnerability_reporting_enabled", "Whether private vulnerability reporting is enabled for a GitHub repository (1=enabled, 0=disabled)", []string{"org", "repo"})
+	github.GitHubSecurityPolicyExists = github.newGaugeVec("repo_security_policy_exists", "Whether a GitHub repository has a SECURITY.md security policy (1=exists, 0=missing)", []string{"org", "repo"})
+	github.GitHubCommunityFilePresent = github.newGaugeVec("repo_community_file_present", "Whether a GitHub repository has a community health file (issue_template, pull_request_template, contributing) in its root, .github or docs directory (1=present, 0=missing)", []string{"org", "repo", "file"})
+	github.GitHubDependencyAutomation = github.newGaugeVec("repo_dependency_automation", "Whether a GitHub repository has a configuration file of a dependency update tool (renovate, dependabot) (1=configured, 0=missing)", []string{"org", "repo", "tool"})
+	github.GitHubTagProtected = github.newGaugeVec("repo_tag_protected", "Whether an active ruleset protects tags of a GitHub repository (1=protected, 0=unprotected)", []string{"org", "repo"})
+	github.GitHubTagRulesets = github.newGaugeVec("repo_tag_rulesets", "Number of rulesets targeting tags of a GitHub repository, including organization rulesets", []string{"org", "repo", "enforcement"})
+	github.GitHubTagProtectionPatterns = github.newGaugeVec("repo_tag_protection_patterns", "Number of distinct tag patterns covered by the active tag rulesets of a GitHub repository", []string{"org", "repo"})
+	github.GitHubRulesetBypassActors = github.newGaugeVec("repo_ruleset_bypass_actors", "Number of distinct actors allowed to bypass the active rulesets of a GitHub repository, including organization rulesets, by actor type (Integration, OrganizationAdmin, RepositoryRole, Team, DeployKey)", []string{"org", "repo", "actor_type"})
+	github.GitHubBranchBypassActors = github.newGaugeVec("branch_protection_bypass_actors", "Number of actors allowed to bypass the pull request requirements of a GitHub branch's protection, by actor type (User, Team, Integration)", []string{"org", "repo", "branch", "actor_type"})
+
+	// GitHub Actions secret and variable metrics
+	github.GitHubActionsSecrets = github.newGaugeVec("actions_secrets_total", "Number of Actions secrets of a GitHub repository, or of an organization when repo is empty", []string{"org", "repo"})
+	github.GitHubActionsSecretMaxAge = github.newGaugeVec("actions_secret_max_age_days", "Days since the least recently updated Actions secret of a GitHub repository, or of an organization when repo is empty, was updated", []string{"org", "repo"})
+	github.GitHubActionsVariables = github.newGaugeVec("actions_variables_total", "Number of Actions variables of a GitHub repository, or of an organization when repo is empty", []string{"org", "repo"})
+
+	// GitHub Actions OIDC metrics
+	github.GitHubOIDCSubjectClaimCustomized = github.newGaugeVec("repo_oidc_subject_claim_customized", "Whether a GitHub repository customizes the subject claim of its Actions OIDC tokens (1=customized, 0=default)", []string{"org", "repo"})
+	github.GitHubEnvironmentSecrets = github.newGaugeVec("repo_environment_secrets", "Number of Actions secrets of a deployment environment of a GitHub repository", []string{"org", "repo", "environment"})
+	github.GitHubEnvironmentOIDC = github.newGaugeVec("repo_environment_oidc", "Best-effort guess whether a deployment environment of a GitHub repository deploys with OIDC instead of long-lived credentials, assumed when it has no secrets (1=OIDC, 0=secrets)", []string{"org", "repo", "environment"})
+
+	// GitHub repository release metrics
+	github.GitHubLastReleaseTimestamp = github.newGaugeVec("repo_last_release_timestamp", "Unix timestamp of the most recently published release (or pre-release) of a GitHub repository", []string{"org", "repo", "prerelease"})
+	github.GitHubDaysSinceLastRelease = github.newGaugeVec("repo_days_since_last_release", "Number of whole days since the most recently published release (or pre-release) of a GitHub repository", []string{"org", "repo", "prerelease"})
+	github.GitHubReleasesInWindow = github.newGaugeVec("repo_releases", "Number of releases of a GitHub repository published within a rolling window (30d, 90d)", []string{"org", "repo", "window", "prerelease"})
+	github.GitHubDraftReleases = github.newGaugeVec("repo_draft_releases", "Number of unpublished draft releases of a GitHub repository", []string{"org", "repo"})
+	github.GitHubReleaseAssetInfo = github.newGaugeVec("release_asset_info", "Asset of the latest stable release of a GitHub repository with its guessed platform (always 1)", []string{"org", "repo", "tag", "asset", "os", "arch"})
+	github.GitHubReleaseAssetSize = github.newGaugeVec("release_asset_size_bytes", "Size of an asset of the latest stable release of a GitHub repository in bytes", []string{"org", "repo", "tag", "asset"})
+	github.GitHubReleaseAssets = github.newGaugeVec("release_assets", "Number of assets of the latest stable release of a GitHub repository", []string{"org", "repo", "tag"})
+	github.GitHubUnreleasedCommits = github.newGaugeVec("repo_unreleased_commits", "Number of commits on the default branch of a GitHub repository that are not in its latest stable release", []string{"org", "repo"})
+	github.GitHubRepoActiveForks = github.newGaugeVec("repo_active_forks_30d", "Number of forks of a GitHub repository pushed to in the last 30 days", []string{"org", "repo"})
+
+	// GitHub repository webhook metrics
+	github.GitHubWebhookLastDeliverySuccess = github.newGaugeVec("repo_webhook_last_delivery_success", "Whether the most recent delivery of a repository webhook succeeded (1=2xx response, 0=failed)", []string{"org", "repo", "hook_id", "host"})
+	github.GitHubWebhookLastDeliveryTimestamp = github.newGaugeVec("repo_webhook_last_delivery_timestamp", "Unix timestamp of the most recent delivery of a repository webhook", []string{"org", "repo", "hook_id", "host"})
+	github.GitHubWebhookDeliveryFailures = github.newGaugeVec("repo_webhook_delivery_failures", "Number of failed deliveries among the most recent deliveries (up to 100) of a repository webhook", []string{"org", "repo", "hook_id", "host"})
+
+	// GitHub webhook event metrics
+	github.GitHubWebhookRepositoryEvents = github.newCounterVec("webhook_repository_events_total", "Total number of repository webhook events received per organization and action, e.g. created, deleted, archived or publicized", []string{"org", "action"})
+	github.GitHubPushEvents = github.newCounterVec("push_events_total", "Total number of push webhook events received per repository branch", []string{"org", "repo", "branch"})
+	github.GitHubPullRequestEvents = github.newCounterVec("pull_request_events_total", "Total number of pull request webhook events received per repository and action, e.g. opened, closed or synchronize", []string{"org", "repo", "action"})
+
+	// Webhook receiver metrics
+	github.GitHubWebhookDeliveries = github.newCounterVec("webhook_deliveries_total", "Total number of webhook deliveries received per event type and result (processed, ignored, invalid_signature, invalid_payload)", []string{"event", "result"})
+	github.GitHubWebhookHandlerDuration = github.newHistogramVec("webhook_handler_duration_seconds", "Time taken to validate and process a webhook delivery", webhookHandlerBuckets, []string{"event"})
+	github.GitHubWebhookEventLag = github.newHistogramVec("webhook_event_lag_seconds", "Time from the event a webhook delivery describes happening to the delivery being processed", webhookLagBuckets, []string{"event"})
+	github.GitHubResyncs = github.newCounterVec("resyncs_total", "Total number of polls run outside the regular collection cycle to correct webhook data, per trigger (manual, reconcile)", []string{"trigger"})
 
-	github.GitHubReposInfo = factory.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Name: "github_repo_info",
-			Help: "Information about GitHub repositories",
-		},
-		[]string{"org", "repo", "visibility", "archived", "fork", "language"},
-	)
-	baseRegistry.AddMetricInfo("github_repo_info", "Information about GitHub repositories", []string{"org", "repo", "visibility", "archived", "fork", "language"})
+	// GitHub organization metrics
+	github.GitHubOrgsTotal = github.newGaugeVec("orgs_total", "Total number of GitHub organizations", []string{})
+	github.GitHubOrgInfo = github.newGaugeVec("org_info", "Plan and domain verification of GitHub organizations (always 1)", []string{"org", "plan", "verified"})
+	github.GitHubOrgsPublicRepos = github.newGaugeVec("org_public_repos", "Number of public repositories for a GitHub organization", []string{"org"})
+	github.GitHubOrgsFollowers = github.newGaugeVec("org_followers", "Number of followers for a GitHub organization", []string{"org"})
+	github.GitHubOrgsFollowing = github.newGaugeVec("org_following", "Number of organizations that a GitHub organization is following", []string{"org"})
+
+	// GitHub organization follower change metrics
+	github.GitHubOrgFollowersGained = github.newCounterVec("org_followers_gained_total", "Total number of followers a GitHub organization gained, from increases of its follower count between collections", []string{"org"})
+	github.GitHubOrgFollowersLost = github.newCounterVec("org_followers_lost_total", "Total number of followers a GitHub organization lost, from decreases of its follower count between collections", []string{"org"})
+
+	// GitHub user metrics
+	github.GitHubUserFollowers = github.newGaugeVec("user_followers", "Number of followers of a GitHub user", []string{"user"})
+	github.GitHubUserFollowing = github.newGaugeVec("user_following", "Number of accounts a GitHub user follows", []string{"user"})
+	github.GitHubUserPublicRepos = github.newGaugeVec("user_public_repos", "Number of public repositories of a GitHub user", []string{"user"})
+	github.GitHubUserFollowersGained = github.newCounterVec("user_followers_gained_total", "Total number of followers a GitHub user gained, from increases of its follower count between collections", []string{"user"})
+	github.GitHubUserFollowersLost = github.newCounterVec("user_followers_lost_total", "Total number of followers a GitHub user lost, from decreases of its follower count between collections", []string{"user"})
+
+	// GitHub organization repository change metrics
+	github.GitHubOrgReposCreated = github.newCounterVec("org_repos_created_total", "Total number of repositories created in a GitHub organization, seen as repositories newer than any before in its repository discovery", []string{"org"})
+	github.GitHubOrgReposArchived = github.newCounterVec("org_repos_archived_total", "Total number of repositories of a GitHub organization that were archived between two repository discoveries", []string{"org"})
+
+	// GitHub organization security metrics
+	github.GitHubOrgTwoFactorRequired = github.newGaugeVec("org_two_factor_required", "Whether a GitHub organization requires two-factor authentication for its members (1=yes, 0=no)", []string{"org"})
+	github.GitHubOrgSAMLSSOEnabled = github.newGaugeVec("org_saml_sso_enabled", "Whether SAML single sign-on is configured for a GitHub organization (1=yes, 0=no)", []string{"org"})
+
+	// GitHub organization Actions policy metrics
+	github.GitHubOrgActionsPolicy = github.newGaugeVec("org_actions_policy_info", "Repositories GitHub Actions is enabled for (all, none, selected) and actions that are allowed (all, local_only, selected) in a GitHub organization (always 1)", []string{"org", "enabled_repositories", "allowed_actions"})
+	github.GitHubOrgDefaultWorkflowPermission = github.newGaugeVec("org_default_workflow_permissions_info", "Default GITHUB_TOKEN permissions (read, write) of workflows in a GitHub organization (always 1)", []string{"org", "permissions"})
+	github.GitHubOrgWorkflowsCanApprovePRs = github.newGaugeVec("org_workflows_can_approve_pull_requests", "Whether GitHub Actions workflows in a GitHub organization can approve pull requests (1=yes, 0=no)", []string{"org"})
+	github.GitHubOrgSelfHostedRunnersPolicy = github.newGaugeVec("org_self_hosted_runners_policy_info", "Repositories allowed to use self-hosted runners (all, selected, none) in a GitHub organization (always 1)", []string{"org", "enabled_repositories"})
+	github.GitHubOrgForkPRApprovalPolicy = github.newGaugeVec("org_fork_pr_approval_policy_info", "Which outside contributors need approval before workflows run on their fork pull requests in a GitHub organization (always 1)", []string{"org", "policy"})
+	github.GitHubOrgPrivateForkPRApproval = github.newGaugeVec("org_private_fork_pr_workflows_require_approval", "Whether workflows from fork pull requests to private repositories require approval in a GitHub organization (1=yes, 0=no)", []string{"org"})
+
+	// GitHub organization project metrics
+	github.GitHubProjectItems = github.newGaugeVec("org_project_items", "Number of items in an organization project by status field value", []string{"org", "project", "status"})
+	github.GitHubProjectItemsAdded = github.newCounterVec("org_project_items_added_total", "Total number of items added to an organization project since the exporter started", []string{"org", "project"})
+	github.GitHubProjectItemsClosed = github.newCounterVec("org_project_items_closed_total", "Total number of organization project items whose issue or pull request was closed since the exporter started", []string{"org", "project"})
+	github.GitHubProjectIterationItems = github.newGaugeVec("org_project_iteration_items", "Number of items in the current iteration of an organization project", []string{"org", "project", "iteration"})
+	github.GitHubProjectIterationProgressRatio = github.newGaugeVec("org_project_iteration_progress_ratio", "Fraction of items in the current iteration of an organization project whose issue or pull request is closed", []string{"org", "project", "iteration"})
+
+	// GitHub Packages metrics
+	github.GitHubPackageVersions = github.newGaugeVec("package_versions_total", "Number of versions of a GitHub Packages package owned by an organization", []string{"org", "package", "ecosystem", "visibility"})
+	github.GitHubPackageLatestVersionTimestamp = github.newGaugeVec("package_latest_version_timestamp", "Unix timestamp of the most recently published version of a GitHub container package", []string{"org", "package"})
+	github.GitHubPackageUntaggedVersions = github.newGaugeVec("package_untagged_versions", "Number of untagged versions of a GitHub container package, which are eligible for cleanup", []string{"org", "package"})
+
+	// Custom search metrics
+	github.GitHubCustomSearchResults = github.newGaugeVec("custom_search_results", "Number of issues and pull requests matching a user-defined search query", []string{"name"})
 
-	github.GitHubReposStars = factory.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Name: "github_repo_stars",
-			Help: "Number of stars for a GitHub repository",
-		},
-		[]string{"org", "repo", "visibility"},
-	)
-	baseRegistry.AddMetricInfo("github_repo_stars", "Number of stars for a GitHub repository", []string{"org", "repo", "visibility"})
+	// GitHub build status metrics
+	github.GitHubBranchBuildStatus = github.newGaugeVec("branch_build_status", "Build status for GitHub repository branches (0=failed, 1=success, 2=pending, 3=skipped)", []string{"org", "repo", "branch"})
+	github.GitHubRepoBuildStatus = github.newGaugeVec("repo_build_status", "Worst build status across the monitored branches of a GitHub repository (0=failed, 1=success, 2=pending, 3=skipped)", []string{"org", "repo"})
+	github.GitHubOrgFailingBuilds = github.newGaugeVec("org_failing_builds_total", "Number of repositories of a GitHub organization with a failed build on a monitored branch", []string{"org"})
+	github.GitHubWorkflowRunStatus = github.newGaugeVec("workflow_run_status", "Status of GitHub workflow runs (0=failed, 1=success, 2=pending, 3=skipped)", []string{"org", "repo", "workflow", "branch", "conclusion"})
+	github.GitHubWorkflowLatestRunInfo = github.newGaugeVec("workflow_latest_run_info", "Latest run of a GitHub workflow on a branch with its ID, URL, conclusion and optionally its head SHA and actor (always 1)", []string{"org", "repo", "workflow", "branch", "run_id", "html_url", "conclusion", "head_sha", "actor"})
+	github.GitHubCheckRunStatus = github.newGaugeVec("check_run_status", "Status of GitHub check runs (0=failed, 1=success, 2=pending, 3=skipped)", []string{"org", "repo", "check_name", "branch", "conclusion"})
+	github.GitHubWorkflowRunsTotal = github.newCounterVec("workflow_runs_total", "Total number of completed GitHub workflow runs seen on a monitored branch by conclusion, including backfilled runs", []string{"org", "repo", "workflow", "branch", "conclusion"})
+	github.GitHubWorkflowRunTime = github.newHistogramVec("workflow_completed_run_duration_seconds", "Duration of completed GitHub workflow runs seen on a monitored branch, including backfilled runs", workflowRunBuckets, []string{"org", "repo", "workflow", "branch"})
+	github.GitHubWorkflowSuccessRatio = github.newGaugeVec("workflow_success_ratio_24h", "Share of the GitHub workflow runs on a monitored branch completed in the last 24 hours that succeeded, ignoring skipped and neutral runs", []string{"org", "repo", "workflow", "branch"})
+	github.GitHubBranchCommitsTotal = github.newCounterVec("branch_commits_total", "Total number of new commits seen on a GitHub repository branch since the exporter started", []string{"org", "repo", "branch"})
+	github.GitHubWorkflowRunDuration = github.newGaugeVec("workflow_run_duration_seconds", "Duration of GitHub workflow runs in seconds", []string{"org", "repo", "workflow", "branch", "conclusion"})
+	github.GitHubWorkflowRunBillable = github.newGaugeVec("workflow_run_billable_seconds", "Billable time of the latest completed GitHub workflow run by runner operating system", []string{"org", "repo", "workflow", "branch", "os"})
+
+	// GitHub organization workflow rollups
+	github.GitHubOrgWorkflowRuns = github.newGaugeVec("org_workflow_runs", "Number of GitHub workflow runs on monitored branches of an organization that completed in the current UTC day or week", []string{"org", "period"})
+	github.GitHubOrgWorkflowFailedRuns = github.newGaugeVec("org_workflow_failed_runs", "Number of failed GitHub workflow runs on monitored branches of an organization that completed in the current UTC day or week", []string{"org", "period"})
+	github.GitHubOrgWorkflowBillableMinutes = github.newGaugeVec("org_workflow_billable_minutes", "Billable minutes of the GitHub workflow runs of an organization whose usage was fetched, for runs completed in the current UTC day or week", []string{"org", "period"})
+
+	// GitHub workflow inventory metrics
+	github.GitHubWorkflowInfo = github.newGaugeVec("workflow_info", "GitHub Actions workflow of a repository with its file path and state (always 1)", []string{"org", "repo", "workflow", "path", "state"})
+	github.GitHubWorkflowState = github.newGaugeVec("workflow_state", "State of a GitHub Actions workflow, e.g. active, disabled_manually or disabled_inactivity (always 1)", []string{"org", "repo", "workflow", "state"})
+	github.GitHubWorkflowScheduleInfo = github.newGaugeVec("workflow_schedule_info", "Cron schedule of a GitHub Actions workflow (always 1)", []string{"org", "repo", "workflow", "cron"})
+	github.GitHubWorkflowScheduleNextRun = github.newGaugeVec("workflow_schedule_next_run_timestamp", "Estimated Unix timestamp of the next run of a GitHub Actions workflow schedule", []string{"org", "repo", "workflow", "cron"})
+	github.GitHubWorkflowLastScheduledRun = github.newGaugeVec("workflow_last_scheduled_run_timestamp", "Unix timestamp of the most recent scheduled run of a GitHub Actions workflow", []string{"org", "repo", "workflow"})
+	github.GitHubWorkflowSecondsSinceScheduledRun = github.newGaugeVec("workflow_seconds_since_last_scheduled_run", "Seconds since the most recent scheduled run of a GitHub Actions workflow", []string{"org", "repo", "workflow"})
+
+	// Synthetic check metrics
+	github.GitHubSyntheticWorkflowDuration = github.newGaugeVec("synthetic_workflow_duration_seconds", "Time from dispatching a synthetic check workflow until its run completed, for the latest completed check", []string{"org", "repo", "workflow"})
+	github.GitHubSyntheticWorkflowRuns = github.newCounterVec("synthetic_workflow_runs_total", "Total number of synthetic check workflow dispatches by result: the run's conclusion, timeout if it did not complete in time or error if it could not be dispatched", []string{"org", "repo", "workflow", "result"})
 
-	github.GitHubReposForks = factory.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Name: "github_repo_forks",
-			Help: "Number of forks for a GitHub repository",
-		},
-		[]string{"org", "repo", "visibility"},
-	)
-	baseRegistry.AddMetricInfo("github_repo_forks", "Number of forks for a GitHub repository", []string{"org", "repo", "visibility"})
+	// GitHub API metrics
+	github.GitHubAPICallsTotal = github.newCounterVec("api_calls_total", "Total number of GitHub API calls made, by response status (none if no response was received)", []string{"endpoint", "status"})
+	github.GitHubAPIRequestDuration = github.newHistogramVec("api_request_duration_seconds", "Time taken by GitHub API requests, until the response headers were received", apiRequestBuckets, []string{"endpoint"})
+	github.GitHubAPIErrorsTotal = github.newCounterVec("api_errors_total", "Total number of GitHub API errors", []string{"endpoint", "error_type", "status_code"})
+	github.GitHubAPIResourceTotal = github.newCounterVec("api_requests_by_resource_total", "Total number of GitHub API responses by rate limit resource (X-RateLimit-Resource header)", []string{"resource"})
+	github.GitHubAPIQuotaUsedRatio = github.newGaugeVec("api_quota_used_ratio", "Share of the API calls of the last collection cycle made by each endpoint, to find the collectors using most of the rate limit", []string{"endpoint"})
+	github.GitHubRateLimitTotal = github.newGaugeVec("rate_limit_total", "Total number of GitHub API requests allowed in the current rate limit window", []string{})
+	github.GitHubRateLimitRemaining = github.newGaugeVec("rate_limit_remaining", "Number of GitHub API requests remaining in the current rate limit window", []string{})
+	github.GitHubRateLimitReset = github.newGaugeVec("rate_limit_reset_timestamp", "Unix timestamp when the GitHub API rate limit resets", []string{})
+	github.GitHubTokenExpiration = github.newGaugeVec("token_expiration_timestamp", "Unix timestamp when the GitHub token expires (only set for tokens with an expiration)", []string{})
+
+	// GitHub App installation metrics
+	github.GitHubAppInstallationInfo = github.newGaugeVec("app_installation_info", "Installations of the GitHub App the exporter authenticates as (always 1)", []string{"installation", "account", "account_type", "repository_selection"})
+	github.GitHubAppInstallationRateLimitTotal = github.newGaugeVec("app_installation_rate_limit_total", "Total number of GitHub API requests allowed in the current rate limit window of a GitHub App installation", []string{"installation", "account"})
+	github.GitHubAppInstallationRateLimitRemaining = github.newGaugeVec("app_installation_rate_limit_remaining", "Number of GitHub API requests remaining in the current rate limit window of a GitHub App installation", []string{"installation", "account"})
+	github.GitHubAppInstallationTokenExpiration = github.newGaugeVec("app_installation_token_expiration_timestamp", "Unix timestamp when the current token of a GitHub App installation expires", []string{"installation", "account"})
+
+	// Token pool metrics
+	github.GitHubTokenPoolRateLimitTotal = github.newGaugeVec("token_pool_rate_limit_total", "Total number of GitHub API requests allowed in the current rate limit window of a token in the pool, by its position in github.tokens", []string{"token_index"})
+	github.GitHubTokenPoolRateLimitRemaining = github.newGaugeVec("token_pool_rate_limit_remaining", "Number of GitHub API requests remaining in the current rate limit window of a token in the pool", []string{"token_index"})
+	github.GitHubTokenPoolRateLimitReset = github.newGaugeVec("token_pool_rate_limit_reset_timestamp", "Unix timestamp when the GitHub API rate limit of a token in the pool resets", []string{"token_index"})
+	github.GitHubTokenPoolRotations = github.newCounterVec("token_pool_rotations_total", "Total number of times requests moved on to another token in the pool because the one in use approached its rate limit", []string{})
+
+	// GitHub GraphQL API rate limit metrics
+	github.GitHubGraphQLRateLimitTotal = github.newGaugeVec("graphql_rate_limit_total", "Total number of GitHub GraphQL API points allowed in the current rate limit window", []string{})
+	github.GitHubGraphQLRateLimitRemaining = github.newGaugeVec("graphql_rate_limit_remaining", "Number of GitHub GraphQL API points remaining in the current rate limit window", []string{})
+	github.GitHubGraphQLRateLimitReset = github.newGaugeVec("graphql_rate_limit_reset_timestamp", "Unix timestamp when the GitHub GraphQL API rate limit resets", []string{})
+	github.GitHubGraphQLQueryCost = github.newCounterVec("graphql_query_cost_total", "Total number of GitHub GraphQL API rate limit points spent per endpoint", []string{"endpoint"})
+
+	// Discovery metrics
+	github.GitHubDiscoveryMaxReposExceeded = github.newGaugeVec("discovery_max_repos_exceeded", "Whether the last repository discovery for a scope (an organization or \"*\") returned more repositories than github.max_repos (1=exceeded, 0=within limit)", []string{"scope"})
+	github.GitHubTargetDrift = github.newGaugeVec("target_drift", "Whether more than github.drift_threshold of the discovered repositories appeared or disappeared at the last revalidation (1=drifted, 0=stable)", []string{})
+	github.GitHubTargetDriftRepos = github.newGaugeVec("target_drift_repos", "Number of discovered repositories that appeared or disappeared since the previous revalidation, by change (appeared, disappeared)", []string{"change"})
+	github.GitHubTargetRevalidations = github.newCounterVec("target_revalidations_total", "Total number of revalidations of the discovered repositories, by result (success, error)", []string{"result"})
+
+	// Collector metrics
+	github.GitHubCollectorPermissionOK = github.newGaugeVec("collector_permission_ok", "Whether the token has the access an enabled collector needs, checked at startup (1=ok, 0=missing)", []string{"collector"})
+	github.GitHubTargetStale = github.newGaugeVec("target_stale", "Whether the last collection of a monitored target failed, so its metrics may be outdated (1=stale, 0=fresh)", []string{"type", "target"})
+	github.GitHubRepoRenamed = github.newGaugeVec("repo_renamed", "Set to 1 for each monitored repository GitHub redirected to a new owner or name, so the configuration can be updated", []string{"org", "repo", "new_org", "new_repo"})
+	github.GitHubTargetNotFound = github.newGaugeVec("target_not_found", "Set to 1 for each monitored organization, repository or branch that does not exist and is no longer requested (github.not_found_policy=skip)", []string{"target"})
+	github.GitHubCollectionSkipped = github.newCounterVec("collection_skipped_total", "Total number of targets skipped during a collection cycle, by reason (deadline = not reached before github.collection_deadline)", []string{"reason"})
+	github.GitHubCollectionPhaseTargets = github.newGaugeVec("collection_phase_targets", "Number of targets a collection phase succeeded or failed for in the last cycle", []string{"phase", "result"})
+	github.GitHubExporterDegradedMode = github.newGaugeVec("exporter_degraded_mode", "Whether only high-priority metrics are refreshed because the remaining rate limit dropped below github.degraded_mode_floor (1=degraded, 0=normal)", []string{})
+	github.GitHubExporterPaused = github.newGaugeVec("exporter_paused", "Whether collection is paused because a blackout window is active or it was paused through /-/pause, so no API calls are made and metrics keep their last values (1=paused, 0=collecting)", []string{})
+	github.GitHubNotifications = github.newCounterVec("notifications_total", "Total number of notifications posted to notifications.webhook_url, by kind (target_failing, target_recovered, token_expiring) and result (success, error)", []string{"kind", "result"})
+	github.GitHubExporterWarmup = github.newGaugeVec("exporter_warmup", "Whether metrics restored from the persisted snapshot are served because the first collection has not completed yet (1=warming up, 0=live)", []string{})
+	github.GitHubExporterSnapshotTimestamp = github.newGaugeVec("exporter_snapshot_timestamp", "Unix timestamp when the metric snapshot served during warm-up was saved", []string{})
+	github.GitHubExporterRefreshInterval = github.newGaugeVec("exporter_refresh_interval_seconds", "Effective interval between collection cycles, either configured or adapted to the remaining rate limit", []string{})
+	github.GitHubRefreshIntervalUpdates = github.newCounterVec("exporter_refresh_interval_recalculations_total", "Total number of refresh interval recalculations after a collection cycle, by whether the interval changed (changed, unchanged)", []string{"result"})
+	github.GitHubRepoLabelValues = github.newGaugeVec("exporter_repo_label_values", "Number of distinct values of each label added to repository series with metrics.repo_labels", []string{"label"})
+	github.GitHubExporterAnomalies = github.newCounterVec("exporter_anomalies_total", "Total number of implausible values from GitHub API responses that were rejected instead of exported, by metric and reason (negative, future_timestamp, past_timestamp)", []string{"metric", "reason"})
+	github.GitHubExporterTargets = github.newGaugeVec("exporter_targets", "Number of distinct targets monitored in the last collection cycle, after wildcard expansion and filters", []string{"type"})
 
-	github.GitHubReposWatchers = factory.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Name: "github_repo_watchers",
-			Help: "Number of watchers for a GitHub repository",
-		},
-		[]string{"org", "repo", "visibility"},
-	)
-	baseRegistry.AddMetricInfo("github_repo_watchers", "Number of watchers for a GitHub repository", []string{"org", "repo", "visibility"})
+	return github
+}
 
-	github.GitHubReposOpenIssues = factory.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Name: "github_repo_open_issues",
-			Help: "Number of open issues for a GitHub repository",
-		},
-		[]string{"org", "repo", "visibility"},
-	)
-	baseRegistry.AddMetricInfo("github_repo_open_issues", "Number of open issues for a GitHub repository", []string{"org", "repo", "visibility"})
+// RepoGauges returns the gauges labelled by org and repo, which describe the state
+// of a single repository and go stale when it can no longer be collected
+func (g *GitHubRegistry) RepoGauges() []*prometheus.GaugeVec {
+	return []*prometheus.GaugeVec{
+		g.GitHubReposInfo,
+		g.GitHubReposStars,
+		g.GitHubReposForks,
+		g.GitHubReposWatchers,
+		g.GitHubReposOpenIssues,
+		g.GitHubReposOpenPRs,
+		g.GitHubReposSize,
+		g.GitHubReposLastUpdated,
+		g.GitHubReposCreatedAt,
+		g.GitHubCodeownersExists,
+		g.GitHubCodeownersRules,
+		g.GitHubCodeownersErrors,
+		g.GitHubPrivateVulnReportingEnabled,
+		g.GitHubSecurityPolicyExists,
+		g.GitHubCommunityFilePresent,
+		g.GitHubDependencyAutomation,
+		g.GitHubTagProtected,
+		g.GitHubTagRulesets,
+		g.GitHubTagProtectionPatterns,
+		g.GitHubRulesetBypassActors,
+		g.GitHubBranchBypassActors,
+		g.GitHubActionsSecrets,
+		g.GitHubActionsSecretMaxAge,
+		g.GitHubActionsVariables,
+		g.GitHubOIDCSubjectClaimCustomized,
+		g.GitHubEnvironmentSecrets,
+		g.GitHubEnvironmentOIDC,
+		g.GitHubLastReleaseTimestamp,
+		g.GitHubDaysSinceLastRelease,
+		g.GitHubReleasesInWindow,
+		g.GitHubDraftReleases,
+		g.GitHubReleaseAssetInfo,
+		g.GitHubReleaseAssetSize,
+		g.GitHubReleaseAssets,
+		g.GitHubUnreleasedCommits,
+		g.GitHubWebhookLastDeliverySuccess,
+		g.GitHubWebhookLastDeliveryTimestamp,
+		g.GitHubWebhookDeliveryFailures,
+		g.GitHubBranchBuildStatus,
+		g.GitHubWorkflowRunStatus,
+		g.GitHubWorkflowLatestRunInfo,
+		g.GitHubCheckRunStatus,
+		g.GitHubWorkflowRunDuration,
+		g.GitHubWorkflowRunBillable,
+		g.GitHubWorkflowInfo,
+		g.GitHubWorkflowState,
+		g.GitHubWorkflowScheduleInfo,
+		g.GitHubWorkflowScheduleNextRun,
+		g.GitHubWorkflowLastScheduledRun,
+		g.GitHubWorkflowSecondsSinceScheduledRun,
+	}
+}
 
-	github.GitHubReposOpenPRs = factory.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Name: "github_repo_open_prs",
-			Help: "Number of open pull requests for a GitHub repository",
-		},
-		[]string{"org", "repo", "visibility"},
-	)
-	baseRegistry.AddMetricInfo("github_repo_open_prs", "Number of open pull requests for a GitHub repository", []string{"org", "repo", "visibility"})
+// RepoCounters returns the counters labelled by org and repo, which accumulate
+// over the lifetime of a repository rather than describing its current state
+func (g *GitHubRegistry) RepoCounters() []*prometheus.CounterVec {
+	return g.repoCounters
+}
 
-	github.GitHubReposSize = factory.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Name: "github_repo_size_bytes",
-			Help: "Size of a GitHub repository in bytes",
-		},
-		[]string{"org", "repo", "visibility"},
-	)
-	baseRegistry.AddMetricInfo("github_repo_size_bytes", "Size of a GitHub repository in bytes", []string{"org", "repo", "visibility"})
+// RepoHistograms returns the histograms labelled by org and repo
+func (g *GitHubRegistry) RepoHistograms() []*prometheus.HistogramVec {
+	return g.repoHistograms
+}
 
-	github.GitHubReposLastUpdated = factory.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Name: "github_repo_last_updated_timestamp",
-			Help: "Unix timestamp of the last update for a GitHub repository",
-		},
-		[]string{"org", "repo", "visibility"},
-	)
-	baseRegistry.AddMetricInfo("github_repo_last_updated_timestamp", "Unix timestamp of the last update for a GitHub repository", []string{"org", "repo", "visibility"})
+// hasRepoLabels reports whether labels include both org and repo
+func hasRepoLabels(labels []string) bool {
+	var org, repo bool
 
-	github.GitHubReposCreatedAt = factory.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Name: "github_repo_created_timestamp",
-			Help: "Unix timestamp of the creation date for a GitHub repository",
-		},
-		[]string{"org", "repo", "visibility"},
-	)
-	baseRegistry.AddMetricInfo("github_repo_created_timestamp", "Unix timestamp of the creation date for a GitHub repository", []string{"org", "repo", "visibility"})
+	for _, label := range labels {
+		org = org || label == "org"
+		repo = repo || label == "repo"
+	}
 
-	// GitHub organization metrics
-	github.GitHubOrgsTotal = factory.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Name: "github_orgs_total",
-			Help: "Total number of GitHub organizations",
-		},
-		[]string{},
-	)
-	baseRegistry.AddMetricInfo("github_orgs_total", "Total number of GitHub organizations", []string{})
+	return org && repo
+}
 
-	github.GitHubOrgsPublicRepos = factory.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Name: "github_org_public_repos",
-			Help: "Number of public repositories for a GitHub organization",
-		},
-		[]string{"org"},
-	)
-	baseRegistry.AddMetricInfo("github_org_public_repos", "Number of public repositories for a GitHub organization", []string{"org"})
+// Namespace returns the prefix applied to all metric names
+func (g *GitHubRegistry) Namespace() string {
+	return g.namespace
+}
 
-	github.GitHubOrgsFollowers = factory.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Name: "github_org_followers",
-			Help: "Number of followers for a GitHub organization",
-		},
-		[]string{"org"},
-	)
-	baseRegistry.AddMetricInfo("github_org_followers", "Number of followers for a GitHub organization", []string{"org"})
+// MetricTypes returns the type of each registered metric by fully qualified name
+func (g *GitHubRegistry) MetricTypes() map[string]dto.MetricType {
+	types := make(map[string]dto.MetricType, len(g.types))
+	for name, metricType := range g.types {
+		types[name] = metricType
+	}
 
-	github.GitHubOrgsFollowing = factory.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Name: "github_org_following",
-			Help: "Number of organizations that a GitHub organization is following",
-		},
-		[]string{"org"},
-	)
-	baseRegistry.AddMetricInfo("github_org_following", "Number of organizations that a GitHub organization is following", []string{"org"})
+	return types
+}
 
-	// GitHub build status metrics
-	github.GitHubBranchBuildStatus = factory.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Name: "github_branch_build_status",
-			Help: "Build status for GitHub repository branches (0=failed, 1=success, 2=pending, 3=skipped)",
-		},
-		[]string{"org", "repo", "branch"},
-	)
-	baseRegistry.AddMetricInfo("github_branch_build_status", "Build status for GitHub repository branches (0=failed, 1=success, 2=pending, 3=skipped)", []string{"org", "repo", "branch"})
+// metricName returns the fully qualified name for a metric
+func (g *GitHubRegistry) metricName(name string) string {
+	return prometheus.BuildFQName(g.namespace, "", name)
+}
 
-	github.GitHubWorkflowRunStatus = factory.NewGaugeVec(
+// newGaugeVec registers a namespaced gauge vector and its metric info
+func (g *GitHubRegistry) newGaugeVec(name, help string, labels []string) *prometheus.GaugeVec {
+	fullName := g.metricName(name)
+	gauge := g.factory.NewGaugeVec(
 		prometheus.GaugeOpts{
-			Name: "github_workflow_run_status",
-			Help: "Status of GitHub workflow runs (0=failed, 1=success, 2=pending, 3=skipped)",
+			Name: fullName,
+			Help: help,
 		},
-		[]string{"org", "repo", "workflow", "branch", "conclusion"},
+		labels,
 	)
-	baseRegistry.AddMetricInfo("github_workflow_run_status", "Status of GitHub workflow runs (0=failed, 1=success, 2=pending, 3=skipped)", []string{"org", "repo", "workflow", "branch", "conclusion"})
+	g.AddMetricInfo(fullName, help, labels)
+	g.types[fullName] = dto.MetricType_GAUGE
 
-	github.GitHubCheckRunStatus = factory.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Name: "github_check_run_status",
-			Help: "Status of GitHub check runs (0=failed, 1=success, 2=pending, 3=skipped)",
-		},
-		[]string{"org", "repo", "check_name", "branch", "conclusion"},
-	)
-	baseRegistry.AddMetricInfo("github_check_run_status", "Status of GitHub check runs (0=failed, 1=success, 2=pending, 3=skipped)", []string{"org", "repo", "check_name", "branch", "conclusion"})
+	return gauge
+}
 
-	github.GitHubWorkflowRunDuration = factory.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Name: "github_workflow_run_duration_seconds",
-			Help: "Duration of GitHub workflow runs in seconds",
-		},
-		[]string{"org", "repo", "workflow", "branch", "conclusion"},
-	)
-	baseRegistry.AddMetricInfo("github_workflow_run_duration_seconds", "Duration of GitHub workflow runs in seconds", []string{"org", "repo", "workflow", "branch", "conclusion"})
+// NewCustomQueryGaugeVec registers the gauge vector of a GraphQL query defined in
+// configuration, named custom_query_<name>
+func (g *GitHubRegistry) NewCustomQueryGaugeVec(name, help string, labels []string) *prometheus.GaugeVec {
+	if help == "" {
+		help = "Value extracted from the custom GraphQL query " + name
+	}
 
-	// GitHub API metrics
-	github.GitHubAPICallsTotal = factory.NewCounterVec(
-		prometheus.CounterOpts{
-			Name: "github_api_calls_total",
-			Help: "Total number of GitHub API calls made",
-		},
-		[]string{"endpoint", "status"},
-	)
-	baseRegistry.AddMetricInfo("github_api_calls_total", "Total number of GitHub API calls made", []string{"endpoint", "status"})
+	return g.newGaugeVec("custom_query_"+name, help, labels)
+}
 
-	github.GitHubAPIErrorsTotal = factory.NewCounterVec(
+// newCounterVec registers a namespaced counter vector and its metric info
+func (g *GitHubRegistry) newCounterVec(name, help string, labels []string) *prometheus.CounterVec {
+	fullName := g.metricName(name)
+	counter := g.factory.NewCounterVec(
 		prometheus.CounterOpts{
-			Name: "github_api_errors_total",
-			Help: "Total number of GitHub API errors",
+			Name: fullName,
+			Help: help,
 		},
-		[]string{"endpoint", "error_type"},
+		labels,
 	)
-	baseRegistry.AddMetricInfo("github_api_errors_total", "Total number of GitHub API errors", []string{"endpoint", "error_type"})
+	g.AddMetricInfo(fullName, help, labels)
+	g.types[fullName] = dto.MetricType_COUNTER
 
-	github.GitHubRateLimitTotal = factory.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Name: "github_rate_limit_total",
-			Help: "Total number of GitHub API requests allowed in the current rate limit window",
-		},
-		[]string{},
-	)
-	baseRegistry.AddMetricInfo("github_rate_limit_total", "Total number of GitHub API requests allowed in the current rate limit window", []string{})
+	if hasRepoLabels(labels) {
+		g.repoCounters = append(g.repoCounters, counter)
+	}
 
-	github.GitHubRateLimitRemaining = factory.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Name: "github_rate_limit_remaining",
-			Help: "Number of GitHub API requests remaining in the current rate limit window",
-		},
-		[]string{},
-	)
-	baseRegistry.AddMetricInfo("github_rate_limit_remaining", "Number of GitHub API requests remaining in the current rate limit window", []string{})
+	return counter
+}
 
-	github.GitHubRateLimitReset = factory.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Name: "github_rate_limit_reset_timestamp",
-			Help: "Unix timestamp when the GitHub API rate limit resets",
+// newHistogramVec registers a namespaced histogram vector and its metric info
+func (g *GitHubRegistry) newHistogramVec(name, help string, buckets []float64, labels []string) *prometheus.HistogramVec {
+	fullName := g.metricName(name)
+	histogram := g.factory.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    fullName,
+			Help:    help,
+			Buckets: buckets,
 		},
-		[]string{},
+		labels,
 	)
-	baseRegistry.AddMetricInfo("github_rate_limit_reset_timestamp", "Unix timestamp when the GitHub API rate limit resets", []string{})
+	g.AddMetricInfo(fullName, help, labels)
+	g.types[fullName] = dto.MetricType_HISTOGRAM
 
-	return github
+	if hasRepoLabels(labels) {
+		g.repoHistograms = append(g.repoHistograms, histogram)
+	}
+
+	return histogram
 }
@@ -0,0 +1,119 @@
+package metrics
+
+import (
+	"testing"
+
+	promexporter_metrics "github.com/d0ugal/promexporter/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// TestNamespace tests that all metrics are registered under the configured namespace
+func TestNamespace(t *testing.T) {
+	baseRegistry := promexporter_metrics.NewRegistry("ghe_exporter_info")
+	registry := NewGitHubRegistryWithNamespace(baseRegistry, "ghe")
+
+	registry.GitHubReposStars.With(prometheus.Labels{"org": "d0ugal", "repo": "test", "visibility": "public"}).Set(1)
+
+	families, err := baseRegistry.GetRegistry().Gather()
+	if err != nil {
+		t.Fatalf("Failed to gather metrics: %v", err)
+	}
+
+	found := false
+	for _, family := range families {
+		if family.GetName() == "ghe_repo_stars" {
+			found = true
+		}
+
+		if family.GetName() == "github_repo_stars" {
+			t.Error("Expected no metrics with the default namespace")
+		}
+	}
+
+	if !found {
+		t.Error("Expected ghe_repo_stars to be registered")
+	}
+
+	for _, info := range baseRegistry.GetMetricsInfo() {
+		if info.Name == "github_repo_stars" {
+			t.Error("Expected metric info to use the configured namespace")
+		}
+	}
+}
+
+// TestDefaultNamespace tests that the default registry keeps the github prefix
+func TestDefaultNamespace(t *testing.T) {
+	registry := NewGitHubRegistry(promexporter_metrics.NewRegistry("github_exporter_info"))
+
+	if registry.Namespace() != DefaultNamespace {
+		t.Errorf("Expected namespace %s, got %s", DefaultNamespace, registry.Namespace())
+	}
+}
+
+// TestMetricTypes tests that every metric with info has its registered type
+func TestMetricTypes(t *testing.T) {
+	registry := NewGitHubRegistry(promexporter_metrics.NewRegistry("github_exporter_info"))
+	types := registry.MetricTypes()
+
+	for name, expected := range map[string]dto.MetricType{
+		"github_rate_limit_total":      dto.MetricType_GAUGE,
+		"github_actions_secrets_total": dto.MetricType_GAUGE,
+		"github_api_calls_total":       dto.MetricType_COUNTER,
+	} {
+		if got, ok := types[name]; !ok || got != expected {
+			t.Errorf("Expected %s to be a %v, got %v", name, expected, got)
+		}
+	}
+
+	for _, info := range registry.GetMetricsInfo() {
+		if _, ok := types[info.Name]; !ok && info.Name != "github_exporter_info" {
+			t.Errorf("Expected a type for %s", info.Name)
+		}
+	}
+}
+
+// TestCompatibilityAliases tests that aliased metrics are exposed with renamed labels
+func TestCompatibilityAliases(t *testing.T) {
+	registry := NewGitHubRegistry(promexporter_metrics.NewRegistry("github_exporter_info"))
+	registry.EnableCompatibilityAliases()
+
+	registry.GitHubReposOpenPRs.With(prometheus.Labels{"org": "d0ugal", "repo": "test", "visibility": "public"}).Set(3)
+	registry.GitHubReposStars.With(prometheus.Labels{"org": "d0ugal", "repo": "test", "visibility": "public"}).Set(5)
+
+	families, err := registry.Gatherer().Gather()
+	if err != nil {
+		t.Fatalf("Failed to gather metrics: %v", err)
+	}
+
+	names := make(map[string]int)
+	for _, family := range families {
+		names[family.GetName()]++
+
+		if family.GetName() != "github_repo_pull_request_count" {
+			continue
+		}
+
+		metric := family.GetMetric()[0]
+		if metric.GetGauge().GetValue() != 3 {
+			t.Errorf("Expected aliased value 3, got %f", metric.GetGauge().GetValue())
+		}
+
+		labels := make(map[string]string)
+		for _, label := range metric.GetLabel() {
+			labels[label.GetName()] = label.GetValue()
+		}
+
+		if labels["user"] != "d0ugal" || labels["repo"] != "test" {
+			t.Errorf("Expected renamed labels, got %v", labels)
+		}
+	}
+
+	if names["github_repo_pull_request_count"] != 1 {
+		t.Error("Expected github_repo_pull_request_count alias to be exposed")
+	}
+
+	if names["github_repo_open_prs"] != 1 || names["github_repo_stars"] != 1 {
+		t.Error("Expected original metrics to be exposed exactly once")
+	}
+}
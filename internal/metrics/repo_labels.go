@@ -0,0 +1,158 @@
+package metrics
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// repoLabelGatherer adds repository attributes as labels to every series
+// labelled with org and repo. Labels are added when metrics are gathered, so
+// series follow attribute changes without being deleted and recreated.
+type repoLabelGatherer struct {
+	gatherer prometheus.Gatherer
+
+	mu     sync.RWMutex
+	values map[string]map[string]string // By "org/repo", label values by name
+}
+
+// EnableRepoLabels adds the label values set with SetRepoLabels to all
+// repository series
+func (g *GitHubRegistry) EnableRepoLabels() {
+	g.repoLabels = &repoLabelGatherer{
+		gatherer: g.GetRegistry(),
+		values:   make(map[string]map[string]string),
+	}
+}
+
+// SetRepoLabels sets the labels added to the series of a repository. It does
+// nothing unless EnableRepoLabels was called.
+func (g *GitHubRegistry) SetRepoLabels(org, repo string, labels map[string]string) {
+	if g.repoLabels == nil {
+		return
+	}
+
+	g.repoLabels.mu.Lock()
+	defer g.repoLabels.mu.Unlock()
+
+	g.repoLabels.values[org+"/"+repo] = labels
+}
+
+// MoveRepoLabels moves the labels of a repository that was renamed or
+// transferred to its new name
+func (g *GitHubRegistry) MoveRepoLabels(fromOrg, fromRepo, toOrg, toRepo string) {
+	if g.repoLabels == nil {
+		return
+	}
+
+	g.repoLabels.mu.Lock()
+	defer g.repoLabels.mu.Unlock()
+
+	labels, ok := g.repoLabels.values[fromOrg+"/"+fromRepo]
+	if !ok {
+		return
+	}
+
+	delete(g.repoLabels.values, fromOrg+"/"+fromRepo)
+
+	if _, ok := g.repoLabels.values[toOrg+"/"+toRepo]; !ok {
+		g.repoLabels.values[toOrg+"/"+toRepo] = labels
+	}
+}
+
+// RepoLabelCardinality returns the number of distinct values of each label
+// added to repository series
+func (g *GitHubRegistry) RepoLabelCardinality() map[string]int {
+	if g.repoLabels == nil {
+		return nil
+	}
+
+	g.repoLabels.mu.RLock()
+	defer g.repoLabels.mu.RUnlock()
+
+	distinct := make(map[string]map[string]bool)
+
+	for _, labels := range g.repoLabels.values {
+		for name, value := range labels {
+			if distinct[name] == nil {
+				distinct[name] = make(map[string]bool)
+			}
+
+			distinct[name][value] = true
+		}
+	}
+
+	cardinality := make(map[string]int, len(distinct))
+	for name, values := range distinct {
+		cardinality[name] = len(values)
+	}
+
+	return cardinality
+}
+
+// Gather implements prometheus.Gatherer
+func (r *repoLabelGatherer) Gather() ([]*dto.MetricFamily, error) {
+	families, err := r.gatherer.Gather()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, family := range families {
+		for _, metric := range family.Metric {
+			if labels := r.values[repoKey(metric.Label)]; len(labels) > 0 {
+				metric.Label = addLabelPairs(metric.Label, labels)
+			}
+		}
+	}
+
+	return families, err
+}
+
+// repoKey returns "org/repo" for series with non-empty org and repo labels,
+// otherwise an empty string
+func repoKey(labels []*dto.LabelPair) string {
+	var org, repo string
+
+	for _, label := range labels {
+		switch label.GetName() {
+		case "org":
+			org = label.GetValue()
+		case "repo":
+			repo = label.GetValue()
+		}
+	}
+
+	if org == "" || repo == "" {
+		return ""
+	}
+
+	return org + "/" + repo
+}
+
+// addLabelPairs returns a sorted copy of labels with extra labels added. Labels
+// the series already has keep their value.
+func addLabelPairs(labels []*dto.LabelPair, extra map[string]string) []*dto.LabelPair {
+	result := make([]*dto.LabelPair, 0, len(labels)+len(extra))
+	present := make(map[string]bool, len(labels))
+
+	for _, label := range labels {
+		result = append(result, label)
+		present[label.GetName()] = true
+	}
+
+	for name, value := range extra {
+		if present[name] {
+			continue
+		}
+
+		result = append(result, &dto.LabelPair{Name: &name, Value: &value})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].GetName() < result[j].GetName()
+	})
+
+	return result
+}
@@ -0,0 +1,59 @@
+package metrics
+
+import (
+	"testing"
+
+	promexporter_metrics "github.com/d0ugal/promexporter/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// TestRepoLabels tests adding repository attributes to repository series only,
+// keeping labels the series already has
+func TestRepoLabels(t *testing.T) {
+	registry := NewGitHubRegistry(promexporter_metrics.NewRegistry("github_exporter_info"))
+	registry.EnableRepoLabels()
+
+	registry.SetRepoLabels("d0ugal", "test", map[string]string{"language": "Python", "topic": "prometheus"})
+	registry.SetRepoLabels("d0ugal", "other", map[string]string{"language": "Go", "topic": "prometheus"})
+
+	registry.GitHubReposStars.With(prometheus.Labels{"org": "d0ugal", "repo": "test", "visibility": "public"}).Set(5)
+	registry.GitHubReposInfo.With(prometheus.Labels{
+		"org": "d0ugal", "repo": "test", "visibility": "public", "archived": "false", "fork": "false", "language": "Go",
+	}).Set(1)
+	registry.GitHubActionsSecrets.With(prometheus.Labels{"org": "d0ugal", "repo": ""}).Set(2)
+
+	families, err := registry.Gatherer().Gather()
+	if err != nil {
+		t.Fatalf("Failed to gather metrics: %v", err)
+	}
+
+	labels := make(map[string]map[string]string)
+
+	for _, family := range families {
+		for _, metric := range family.GetMetric() {
+			pairs := make(map[string]string)
+			for _, label := range metric.GetLabel() {
+				pairs[label.GetName()] = label.GetValue()
+			}
+
+			labels[family.GetName()] = pairs
+		}
+	}
+
+	if got := labels["github_repo_stars"]; got["language"] != "Python" || got["topic"] != "prometheus" {
+		t.Errorf("Expected repository labels on github_repo_stars, got %v", got)
+	}
+
+	if got := labels["github_repo_info"]; got["language"] != "Go" || got["topic"] != "prometheus" {
+		t.Errorf("Expected github_repo_info to keep its own language label, got %v", got)
+	}
+
+	if got := labels["github_actions_secrets_total"]; got["topic"] != "" {
+		t.Errorf("Expected no repository labels on organization series, got %v", got)
+	}
+
+	cardinality := registry.RepoLabelCardinality()
+	if cardinality["language"] != 2 || cardinality["topic"] != 1 {
+		t.Errorf("Expected 2 languages and 1 topic, got %v", cardinality)
+	}
+}
@@ -0,0 +1,105 @@
+package metrics
+
+import (
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// warmupGatherer serves the metrics of a persisted snapshot alongside the live
+// metrics until the first collection completes. Live series take precedence over
+// snapshot series with the same labels.
+type warmupGatherer struct {
+	gatherer prometheus.Gatherer
+	snapshot *atomic.Pointer[[]*dto.MetricFamily]
+}
+
+// ServeSnapshot serves the given metric families, typically restored from the state
+// store, until EndWarmup is called. github_exporter_warmup is set to 1 meanwhile so
+// the restored values can be told apart from live ones.
+func (g *GitHubRegistry) ServeSnapshot(families []*dto.MetricFamily, savedAt time.Time) {
+	g.snapshot.Store(&families)
+	g.GitHubExporterWarmup.WithLabelValues().Set(1)
+	g.GitHubExporterSnapshotTimestamp.WithLabelValues().Set(float64(savedAt.Unix()))
+}
+
+// EndWarmup stops serving the snapshot passed to ServeSnapshot
+func (g *GitHubRegistry) EndWarmup() {
+	g.snapshot.Store(nil)
+	g.GitHubExporterWarmup.WithLabelValues().Set(0)
+}
+
+// Gather implements prometheus.Gatherer
+func (w *warmupGatherer) Gather() ([]*dto.MetricFamily, error) {
+	families, err := w.gatherer.Gather()
+
+	snapshot := w.snapshot.Load()
+	if snapshot == nil {
+		return families, err
+	}
+
+	live := make(map[string]*dto.MetricFamily, len(families))
+	for _, family := range families {
+		live[family.GetName()] = family
+	}
+
+	for _, restored := range *snapshot {
+		family, ok := live[restored.GetName()]
+		if !ok || family.GetType() != restored.GetType() {
+			continue
+		}
+
+		seen := make(map[string]bool, len(family.Metric))
+		for _, metric := range family.Metric {
+			seen[labelSignature(metric)] = true
+		}
+
+		merged := &dto.MetricFamily{
+			Name:   family.Name,
+			Help:   family.Help,
+			Type:   family.Type,
+			Metric: append([]*dto.Metric{}, family.Metric...),
+		}
+
+		for _, metric := range restored.Metric {
+			if !seen[labelSignature(metric)] {
+				merged.Metric = append(merged.Metric, metric)
+			}
+		}
+
+		live[family.GetName()] = merged
+	}
+
+	result := make([]*dto.MetricFamily, 0, len(live))
+	for _, family := range live {
+		result = append(result, family)
+	}
+
+	for _, restored := range *snapshot {
+		if _, ok := live[restored.GetName()]; !ok {
+			result = append(result, restored)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].GetName() < result[j].GetName()
+	})
+
+	return result, err
+}
+
+// labelSignature returns a key identifying a metric by its label values
+func labelSignature(metric *dto.Metric) string {
+	pairs := make([]string, 0, len(metric.Label))
+	for _, label := range metric.Label {
+		pairs = append(pairs, label.GetName()+"="+label.GetValue())
+	}
+
+	sort.Strings(pairs)
+
+	return strings.Join(pairs, "\xff")
+}
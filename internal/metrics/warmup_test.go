@@ -0,0 +1,91 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	promexporter_metrics "github.com/d0ugal/promexporter/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// gatherStars returns the repo_stars values by repository from a gatherer
+func gatherStars(t *testing.T, gatherer prometheus.Gatherer) map[string]float64 {
+	t.Helper()
+
+	families, err := gatherer.Gather()
+	if err != nil {
+		t.Fatalf("Failed to gather metrics: %v", err)
+	}
+
+	stars := make(map[string]float64)
+
+	for _, family := range families {
+		if family.GetName() != "github_repo_stars" {
+			continue
+		}
+
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "repo" {
+					stars[label.GetValue()] = metric.GetGauge().GetValue()
+				}
+			}
+		}
+	}
+
+	return stars
+}
+
+// TestServeSnapshot tests serving snapshot series until the warm-up ends, with live series taking precedence
+func TestServeSnapshot(t *testing.T) {
+	registry := NewGitHubRegistry(promexporter_metrics.NewRegistry("github_exporter_info"))
+
+	snapshot := NewGitHubRegistry(promexporter_metrics.NewRegistry("github_exporter_info"))
+	snapshot.GitHubReposStars.WithLabelValues("d0ugal", "restored", "public").Set(5)
+	snapshot.GitHubReposStars.WithLabelValues("d0ugal", "live", "public").Set(1)
+
+	families, err := snapshot.GetRegistry().Gather()
+	if err != nil {
+		t.Fatalf("Failed to gather snapshot: %v", err)
+	}
+
+	registry.ServeSnapshot(families, time.Now())
+	registry.GitHubReposStars.WithLabelValues("d0ugal", "live", "public").Set(10)
+
+	stars := gatherStars(t, registry.Gatherer())
+	if stars["restored"] != 5 {
+		t.Errorf("Expected restored series during warm-up, got %v", stars)
+	}
+
+	if stars["live"] != 10 {
+		t.Errorf("Expected live series to take precedence, got %v", stars["live"])
+	}
+
+	if got := gaugeValue(t, registry.GitHubExporterWarmup); got != 1 {
+		t.Errorf("Expected warm-up gauge 1, got %v", got)
+	}
+
+	registry.EndWarmup()
+
+	stars = gatherStars(t, registry.Gatherer())
+	if _, ok := stars["restored"]; ok {
+		t.Errorf("Expected snapshot series to be dropped after warm-up, got %v", stars)
+	}
+
+	if got := gaugeValue(t, registry.GitHubExporterWarmup); got != 0 {
+		t.Errorf("Expected warm-up gauge 0, got %v", got)
+	}
+}
+
+// gaugeValue returns the value of an unlabelled gauge vector
+func gaugeValue(t *testing.T, gauge *prometheus.GaugeVec) float64 {
+	t.Helper()
+
+	var metric dto.Metric
+	if err := gauge.WithLabelValues().Write(&metric); err != nil {
+		t.Fatalf("Failed to read gauge: %v", err)
+	}
+
+	return metric.GetGauge().GetValue()
+}
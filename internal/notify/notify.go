@@ -0,0 +1,77 @@
+// Package notify posts messages to Slack-compatible incoming webhooks.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// requestTimeout bounds each post so a slow webhook cannot hold up collection
+const requestTimeout = 10 * time.Second
+
+// Notifier posts messages to an incoming webhook
+type Notifier struct {
+	url    string
+	client *http.Client
+}
+
+// New creates a notifier posting to url
+func New(url string) *Notifier {
+	return &Notifier{
+		url:    url,
+		client: &http.Client{Timeout: requestTimeout},
+	}
+}
+
+// message is the payload of an incoming webhook. Slack, Mattermost, Rocket.Chat
+// and Discord's Slack-compatible endpoint all accept the text field.
+type message struct {
+	Text string `json:"text"`
+}
+
+// Send posts text to the webhook
+func (n *Notifier) Send(ctx context.Context, text string) error {
+	body, err := json.Marshal(message{Text: text})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create notification request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		// The URL of an incoming webhook is a credential, so it is not included
+		return fmt.Errorf("failed to post notification: %w", errorWithoutURL(err))
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("notification webhook returned %s", resp.Status)
+	}
+
+	return nil
+}
+
+// errorWithoutURL strips the request URL from client errors
+func errorWithoutURL(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return urlErr.Err
+	}
+
+	return err
+}
@@ -0,0 +1,56 @@
+package notify
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestSend tests that messages are posted as the text of a JSON payload
+func TestSend(t *testing.T) {
+	var received message
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("Expected a JSON payload, got %q", r.Header.Get("Content-Type"))
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("Failed to decode payload: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	if err := New(server.URL).Send(t.Context(), "collection failed"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if received.Text != "collection failed" {
+		t.Errorf("Expected the message text, got %q", received.Text)
+	}
+}
+
+// TestSendErrors tests that failed posts are reported without the webhook URL
+func TestSendErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "invalid_token", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	if err := New(server.URL+"/services/T000/B000/XXXX").Send(t.Context(), "text"); err == nil || !strings.Contains(err.Error(), "403") {
+		t.Errorf("Expected an error with the status, got %v", err)
+	}
+
+	server.Close()
+
+	err := New(server.URL+"/services/T000/B000/XXXX").Send(t.Context(), "text")
+	if err == nil {
+		t.Fatal("Expected an error for an unreachable webhook")
+	}
+
+	if strings.Contains(err.Error(), "XXXX") {
+		t.Errorf("Expected the webhook URL to be left out, got %v", err)
+	}
+}
@@ -0,0 +1,116 @@
+package redact
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+// sensitiveKeys are attribute keys whose values are always redacted
+var sensitiveKeys = map[string]bool{
+	"authorization": true,
+	"password":      true,
+	"secret":        true,
+	"token":         true,
+}
+
+// handler redacts credentials from log records before passing them on
+type handler struct {
+	next slog.Handler
+}
+
+// NewHandler wraps next so credentials are redacted from the messages and
+// attributes of all records, including errors and nested groups
+func NewHandler(next slog.Handler) slog.Handler {
+	return &handler{next: next}
+}
+
+// Enabled implements slog.Handler
+func (h *handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle implements slog.Handler
+func (h *handler) Handle(ctx context.Context, r slog.Record) error {
+	redacted := slog.NewRecord(r.Time, r.Level, String(r.Message), r.PC)
+
+	r.Attrs(func(a slog.Attr) bool {
+		redacted.AddAttrs(Attr(a))
+		return true
+	})
+
+	return h.next.Handle(ctx, redacted)
+}
+
+// WithAttrs implements slog.Handler
+func (h *handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	redacted := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		redacted[i] = Attr(a)
+	}
+
+	return &handler{next: h.next.WithAttrs(redacted)}
+}
+
+// WithGroup implements slog.Handler
+func (h *handler) WithGroup(name string) slog.Handler {
+	return &handler{next: h.next.WithGroup(name)}
+}
+
+// Attr returns a with credentials redacted from its value
+func Attr(a slog.Attr) slog.Attr {
+	value := a.Value.Resolve()
+
+	if sensitiveKeys[strings.ToLower(a.Key)] {
+		return slog.String(a.Key, Placeholder)
+	}
+
+	switch value.Kind() {
+	case slog.KindString:
+		return slog.String(a.Key, String(value.String()))
+	case slog.KindGroup:
+		group := value.Group()
+
+		redacted := make([]any, len(group))
+		for i, member := range group {
+			redacted[i] = Attr(member)
+		}
+
+		return slog.Group(a.Key, redacted...)
+	case slog.KindAny:
+		switch v := value.Any().(type) {
+		case error:
+			return slog.Any(a.Key, Error(v))
+		case http.Header:
+			return slog.Any(a.Key, header(v))
+		default:
+			// Only replace structured values when their formatting reveals a credential
+			formatted := fmt.Sprintf("%+v", v)
+			if redacted := String(formatted); redacted != formatted {
+				return slog.String(a.Key, redacted)
+			}
+		}
+	}
+
+	return slog.Attr{Key: a.Key, Value: value}
+}
+
+// header returns a copy of h with Authorization and cookie values redacted
+func header(h http.Header) http.Header {
+	redacted := h.Clone()
+
+	for key, values := range redacted {
+		for i, value := range values {
+			switch http.CanonicalHeaderKey(key) {
+			case "Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie":
+				values[i] = Placeholder
+			default:
+				values[i] = String(value)
+			}
+		}
+	}
+
+	return redacted
+}
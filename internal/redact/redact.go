@@ -0,0 +1,102 @@
+// Package redact keeps credentials out of logs, error messages and traces. It
+// replaces registered secrets, such as the configured token, as well as
+// anything shaped like a GitHub token, JWT or Authorization header value.
+package redact
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Placeholder replaces redacted credentials
+const Placeholder = "[REDACTED]"
+
+// minSecretLength keeps short values, which would match unrelated text, from being registered
+const minSecretLength = 8
+
+var (
+	mu      sync.RWMutex
+	secrets []string
+
+	// tokenPatterns match credentials that were never registered, e.g.
+	// installation tokens minted at runtime
+	tokenPatterns = []*regexp.Regexp{
+		// Classic, fine-grained, OAuth, installation and refresh tokens
+		regexp.MustCompile(`\b(gh[opsur]_[A-Za-z0-9]{20,}|github_pat_[A-Za-z0-9_]{20,})\b`),
+		// JWTs, e.g. GitHub App and Kubernetes service account tokens
+		regexp.MustCompile(`\beyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+`),
+	}
+
+	// credentialsPattern matches Authorization header values. Only values that
+	// contain a digit or symbol are redacted, so prose like "basic authentication" is kept.
+	credentialsPattern = regexp.MustCompile(`(?i)\b(bearer|basic)\s+([A-Za-z0-9_.~+/=-]{8,})`)
+)
+
+// Register adds secrets that must never be logged. Empty and short values are ignored.
+func Register(values ...string) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if len(value) < minSecretLength {
+			continue
+		}
+
+		secrets = append(secrets, value)
+	}
+}
+
+// String returns s with all credentials replaced by Placeholder
+func String(s string) string {
+	mu.RLock()
+	for _, secret := range secrets {
+		s = strings.ReplaceAll(s, secret, Placeholder)
+	}
+	mu.RUnlock()
+
+	for _, pattern := range tokenPatterns {
+		s = pattern.ReplaceAllString(s, Placeholder)
+	}
+
+	return credentialsPattern.ReplaceAllStringFunc(s, func(match string) string {
+		fields := strings.Fields(match)
+		scheme, credentials := fields[0], fields[1]
+
+		if strings.Trim(credentials, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ") == "" {
+			return match
+		}
+
+		return scheme + " " + Placeholder
+	})
+}
+
+// Error returns err with credentials redacted from its message. The original
+// error stays available to errors.Is and errors.As.
+func Error(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	msg := err.Error()
+	if redacted := String(msg); redacted != msg {
+		return &redactedError{msg: redacted, err: err}
+	}
+
+	return err
+}
+
+// redactedError hides the message of the error it wraps
+type redactedError struct {
+	msg string
+	err error
+}
+
+func (e *redactedError) Error() string {
+	return e.msg
+}
+
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
@@ -0,0 +1,149 @@
+package rules
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/d0ugal/github-exporter/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+// RuleFile is a Prometheus rule file
+type RuleFile struct {
+	Groups []RuleGroup `yaml:"groups"`
+}
+
+// RuleGroup is a named group of alerting rules
+type RuleGroup struct {
+	Name  string `yaml:"name"`
+	Rules []Rule `yaml:"rules"`
+}
+
+// Rule is a Prometheus alerting rule
+type Rule struct {
+	Alert       string            `yaml:"alert"`
+	Expr        string            `yaml:"expr"`
+	For         string            `yaml:"for,omitempty"`
+	Labels      map[string]string `yaml:"labels,omitempty"`
+	Annotations map[string]string `yaml:"annotations,omitempty"`
+}
+
+// Generate builds alerting rules for the metrics exported with the given configuration
+func Generate(cfg *config.Config) *RuleFile {
+	ns := cfg.MetricsOptions.Namespace
+	if ns == "" {
+		ns = "github"
+	}
+
+	// Alert when less than half of the headroom left by the rate limit buffer remains
+	threshold := math.Round((1-cfg.GitHub.RateLimitBuffer)/2*1000) / 1000
+	if threshold <= 0 {
+		threshold = 0.05
+	}
+
+	rules := []Rule{
+		{
+			Alert: "GitHubRateLimitNearExhaustion",
+			Expr:  fmt.Sprintf("%[1]s_rate_limit_remaining / %[1]s_rate_limit_total < %g", ns, threshold),
+			For:   "5m",
+			Labels: map[string]string{
+				"severity": "warning",
+			},
+			Annotations: map[string]string{
+				"summary":     "GitHub API rate limit nearly exhausted",
+				"description": fmt.Sprintf("Less than %g%% of the GitHub API rate limit remains.", threshold*100),
+			},
+		},
+		{
+			Alert: "GitHubTokenExpiringSoon",
+			Expr:  fmt.Sprintf("%s_token_expiration_timestamp - time() < 7 * 86400", ns),
+			Labels: map[string]string{
+				"severity": "warning",
+			},
+			Annotations: map[string]string{
+				"summary":     "GitHub token expires soon",
+				"description": "The GitHub token used by the exporter expires in {{ $value | humanizeDuration }}.",
+			},
+		},
+		{
+			Alert: "GitHubCollectionFailing",
+			Expr:  fmt.Sprintf(`sum by (endpoint) (increase(%s_api_errors_total{error_type="collection_error"}[15m])) > 0`, ns),
+			For:   "30m",
+			Labels: map[string]string{
+				"severity": "warning",
+			},
+			Annotations: map[string]string{
+				"summary":     "GitHub metric collection is failing",
+				"description": "Collection of {{ $labels.endpoint }} metrics has been failing for 30 minutes.",
+			},
+		},
+	}
+
+	if len(cfg.GitHub.Branches) > 0 && cfg.Collectors.BuildStatusEnabled() {
+		rules = append(rules, Rule{
+			Alert: "GitHubBranchBuildFailing",
+			Expr:  fmt.Sprintf(`%s_branch_build_status{branch=~"%s"} == 0`, ns, branchMatcher(cfg.GitHub.Branches)),
+			For:   "30m",
+			Labels: map[string]string{
+				"severity": "warning",
+			},
+			Annotations: map[string]string{
+				"summary":     "Build failing on {{ $labels.org }}/{{ $labels.repo }}",
+				"description": "The build on branch {{ $labels.branch }} of {{ $labels.org }}/{{ $labels.repo }} has been failing for more than 30 minutes.",
+			},
+		})
+	}
+
+	return &RuleFile{
+		Groups: []RuleGroup{{
+			Name:  "github-exporter",
+			Rules: rules,
+		}},
+	}
+}
+
+// YAML renders the rule file
+func (r *RuleFile) YAML() ([]byte, error) {
+	var buf bytes.Buffer
+
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+
+	if err := encoder.Encode(r); err != nil {
+		return nil, err
+	}
+
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// branchMatcher builds a regex matcher for the configured branches
+func branchMatcher(branches []string) string {
+	escaped := make([]string, 0, len(branches))
+
+	for _, branch := range branches {
+		escaped = append(escaped, regexpQuote(branch))
+	}
+
+	return strings.Join(escaped, "|")
+}
+
+// regexpQuote escapes regex metacharacters for use inside a PromQL string
+func regexpQuote(s string) string {
+	var b strings.Builder
+
+	for _, r := range s {
+		if strings.ContainsRune(`\.+*?()|[]{}^$`, r) {
+			b.WriteString(`\\`)
+		}
+
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
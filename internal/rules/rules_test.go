@@ -0,0 +1,61 @@
+package rules
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/d0ugal/github-exporter/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+// TestGenerate tests rule generation from configuration
+func TestGenerate(t *testing.T) {
+	cfg := &config.Config{
+		GitHub: config.GitHubConfig{
+			Branches:        []string{"main", "release/1.0"},
+			RateLimitBuffer: 0.8,
+		},
+	}
+	cfg.MetricsOptions.Namespace = "ghe"
+
+	ruleFile := Generate(cfg)
+
+	alerts := make(map[string]Rule)
+	for _, rule := range ruleFile.Groups[0].Rules {
+		alerts[rule.Alert] = rule
+	}
+
+	if got := alerts["GitHubRateLimitNearExhaustion"].Expr; got != "ghe_rate_limit_remaining / ghe_rate_limit_total < 0.1" {
+		t.Errorf("Unexpected rate limit expression: %s", got)
+	}
+
+	build, ok := alerts["GitHubBranchBuildFailing"]
+	if !ok {
+		t.Fatal("Expected build failure alert when branches are configured")
+	}
+
+	if !strings.Contains(build.Expr, `branch=~"main|release/1\\.0"`) {
+		t.Errorf("Unexpected build expression: %s", build.Expr)
+	}
+
+	data, err := ruleFile.YAML()
+	if err != nil {
+		t.Fatalf("Failed to render rules: %v", err)
+	}
+
+	var decoded RuleFile
+	if err := yaml.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Generated rules are not valid YAML: %v", err)
+	}
+}
+
+// TestGenerateWithoutBranches tests that build alerts are omitted without branches
+func TestGenerateWithoutBranches(t *testing.T) {
+	ruleFile := Generate(&config.Config{GitHub: config.GitHubConfig{RateLimitBuffer: 0.8}})
+
+	for _, rule := range ruleFile.Groups[0].Rules {
+		if rule.Alert == "GitHubBranchBuildFailing" {
+			t.Error("Expected no build failure alert without branches")
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package server
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+)
+
+// Pauser stops and restarts API calls of the collector
+type Pauser interface {
+	Pause()
+	Resume()
+}
+
+// WithPauseControl enables the endpoints pausing and resuming collection, e.g.
+// during GitHub incidents when every call fails. Requests must carry the token
+// as a bearer token; without a token the endpoints are not served.
+func (s *Server) WithPauseControl(pauser Pauser, token string) *Server {
+	if token == "" {
+		return s
+	}
+
+	s.adminMux.Handle("POST /-/pause", s.allow(requireBearerToken(token, func(w http.ResponseWriter, r *http.Request) {
+		pauser.Pause()
+		writeJSON(w, http.StatusOK, map[string]string{"status": "paused"})
+	})))
+
+	s.adminMux.Handle("POST /-/resume", s.allow(requireBearerToken(token, func(w http.ResponseWriter, r *http.Request) {
+		pauser.Resume()
+		writeJSON(w, http.StatusOK, map[string]string{"status": "resumed"})
+	})))
+
+	return s
+}
+
+// requireBearerToken rejects requests that do not carry token as a bearer token
+func requireBearerToken(token string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !hasBearerToken(r, token) {
+			writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid or missing bearer token"})
+			return
+		}
+
+		next(w, r)
+	}
+}
+
+// hasBearerToken reports whether the request is authenticated with token
+func hasBearerToken(r *http.Request, token string) bool {
+	got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+
+	return ok && subtle.ConstantTimeCompare([]byte(got), []byte(token)) == 1
+}
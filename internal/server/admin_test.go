@@ -0,0 +1,100 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/d0ugal/github-exporter/internal/config"
+)
+
+// fakePauser records whether collection is paused
+type fakePauser struct {
+	paused bool
+}
+
+func (f *fakePauser) Pause()  { f.paused = true }
+func (f *fakePauser) Resume() { f.paused = false }
+
+// TestPauseControl tests that pausing and resuming requires the bearer token
+func TestPauseControl(t *testing.T) {
+	pauser := &fakePauser{}
+	s, _ := createTestServer(&config.Config{})
+	s.WithPauseControl(pauser, "s3cret")
+
+	post := func(path, token string) int {
+		req := httptest.NewRequest(http.MethodPost, path, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+
+		rec := httptest.NewRecorder()
+		s.mux.ServeHTTP(rec, req)
+
+		return rec.Code
+	}
+
+	if code := post("/-/pause", "wrong"); code != http.StatusUnauthorized || pauser.paused {
+		t.Errorf("Expected an unauthorized request not to pause, got %d", code)
+	}
+
+	if code := post("/-/pause", "s3cret"); code != http.StatusOK || !pauser.paused {
+		t.Errorf("Expected collection to be paused, got %d", code)
+	}
+
+	if code := post("/-/resume", ""); code != http.StatusUnauthorized || !pauser.paused {
+		t.Errorf("Expected an unauthenticated request not to resume, got %d", code)
+	}
+
+	if code := post("/-/resume", "s3cret"); code != http.StatusOK || pauser.paused {
+		t.Errorf("Expected collection to be resumed, got %d", code)
+	}
+}
+
+// TestPauseControlWithoutToken tests that the endpoints are not served without a token
+func TestPauseControlWithoutToken(t *testing.T) {
+	s, _ := createTestServer(&config.Config{})
+	s.WithPauseControl(&fakePauser{}, "")
+
+	rec := httptest.NewRecorder()
+	s.mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/-/pause", nil))
+
+	if rec.Code == http.StatusOK {
+		t.Error("Expected the pause endpoint to be disabled without a token")
+	}
+}
+
+// TestPauseControlSeparateListener tests that the admin endpoints are only served
+// by the admin listener when it is configured
+func TestPauseControlSeparateListener(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Admin.Port = 9090
+	s, _ := createTestServer(cfg)
+	s.WithPauseControl(&fakePauser{}, "s3cret").WithResync(&fakeResyncer{}, "s3cret")
+
+	for _, path := range []string{"/-/pause", "/api/v1/resync/d0ugal/github-exporter"} {
+		req := httptest.NewRequest(http.MethodPost, path, nil)
+		req.Header.Set("Authorization", "Bearer s3cret")
+
+		rec := httptest.NewRecorder()
+		s.mux.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusNotFound && rec.Code != http.StatusMethodNotAllowed {
+			t.Errorf("Expected %s not to be served with the metrics, got %d", path, rec.Code)
+		}
+
+		rec = httptest.NewRecorder()
+		s.adminMux.ServeHTTP(rec, req)
+
+		if rec.Code >= 400 {
+			t.Errorf("Expected %s to be served by the admin listener, got %d", path, rec.Code)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	s.adminMux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Expected metrics not to be served by the admin listener, got %d", rec.Code)
+	}
+}
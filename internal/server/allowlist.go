@@ -0,0 +1,45 @@
+package server
+
+import (
+	"log/slog"
+	"net/http"
+	"net/netip"
+)
+
+// allow rejects requests from clients outside server.allowed_networks. The
+// address of the connection is used, so a reverse proxy in front of the
+// exporter has to be allowed itself. Without networks next is returned unchanged.
+func (s *Server) allow(next http.Handler) http.Handler {
+	if len(s.networks) == 0 {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.allowed(r.RemoteAddr) {
+			slog.Debug("Rejected request from a client outside the allowed networks", "remote_addr", r.RemoteAddr, "path", r.URL.Path)
+			http.Error(w, "Forbidden", http.StatusForbidden)
+
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// allowed reports whether the client at remoteAddr is in one of the allowed networks
+func (s *Server) allowed(remoteAddr string) bool {
+	addrPort, err := netip.ParseAddrPort(remoteAddr)
+	if err != nil {
+		return false
+	}
+
+	addr := addrPort.Addr().Unmap()
+
+	for _, network := range s.networks {
+		if network.Contains(addr) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,48 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/d0ugal/github-exporter/internal/config"
+)
+
+// TestAllowlist tests that protected and admin endpoints are restricted to the
+// allowed networks while /health stays open
+func TestAllowlist(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.ServerOptions.AllowedNetworks = []string{"10.0.0.0/8", "192.168.1.10", "fd00::/8"}
+	s, _ := createTestServer(cfg)
+	s.WithPauseControl(&fakePauser{}, "s3cret")
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		remoteAddr string
+		expected   int
+	}{
+		{"allowed range", http.MethodGet, "/metrics", "10.1.2.3:4567", http.StatusOK},
+		{"allowed address", http.MethodGet, "/metrics", "192.168.1.10:4567", http.StatusOK},
+		{"allowed IPv6", http.MethodGet, "/metrics", "[fd12::1]:4567", http.StatusOK},
+		{"IPv4-mapped IPv6", http.MethodGet, "/metrics", "[::ffff:10.1.2.3]:4567", http.StatusOK},
+		{"other client", http.MethodGet, "/metrics", "192.168.1.11:4567", http.StatusForbidden},
+		{"other client on admin", http.MethodPost, "/-/pause", "172.16.0.1:4567", http.StatusForbidden},
+		{"health", http.MethodGet, "/health", "172.16.0.1:4567", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			req.RemoteAddr = tt.remoteAddr
+
+			rec := httptest.NewRecorder()
+			s.mux.ServeHTTP(rec, req)
+
+			if rec.Code != tt.expected {
+				t.Errorf("Expected %d, got %d", tt.expected, rec.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package server
+
+import (
+	"crypto/subtle"
+	"net/http"
+)
+
+// protect restricts next to allowed clients that present the credentials of
+// server.auth, as far as either is configured
+func (s *Server) protect(next http.Handler) http.Handler {
+	auth := s.config.ServerOptions.Auth
+	if !auth.Enabled() {
+		return s.allow(next)
+	}
+
+	return s.allow(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth.BearerToken != "" && hasBearerToken(r, auth.BearerToken) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if username, password, ok := r.BasicAuth(); ok && auth.Username != "" &&
+			subtle.ConstantTimeCompare([]byte(username), []byte(auth.Username)) == 1 &&
+			subtle.ConstantTimeCompare([]byte(password), []byte(auth.Password)) == 1 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if auth.Username != "" {
+			w.Header().Set("WWW-Authenticate", `Basic realm="`+s.name+`"`)
+		}
+
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+	}))
+}
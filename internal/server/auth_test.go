@@ -0,0 +1,50 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/d0ugal/github-exporter/internal/config"
+)
+
+// TestProtect tests that protected endpoints accept basic auth or the bearer
+// token and that the health endpoint stays open
+func TestProtect(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.ServerOptions.Auth = config.AuthConfig{Username: "prometheus", Password: "hunter22", BearerToken: "s3cret"}
+	s, _ := createTestServer(cfg)
+
+	tests := []struct {
+		name     string
+		path     string
+		setup    func(r *http.Request)
+		expected int
+	}{
+		{"no credentials", "/metrics", func(r *http.Request) {}, http.StatusUnauthorized},
+		{"wrong password", "/metrics", func(r *http.Request) { r.SetBasicAuth("prometheus", "wrong") }, http.StatusUnauthorized},
+		{"basic auth", "/metrics", func(r *http.Request) { r.SetBasicAuth("prometheus", "hunter22") }, http.StatusOK},
+		{"wrong token", "/api/v1/snapshot", func(r *http.Request) { r.Header.Set("Authorization", "Bearer wrong") }, http.StatusUnauthorized},
+		{"bearer token", "/api/v1/snapshot", func(r *http.Request) { r.Header.Set("Authorization", "Bearer s3cret") }, http.StatusOK},
+		{"index", "/", func(r *http.Request) {}, http.StatusUnauthorized},
+		{"health", "/health", func(r *http.Request) {}, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			tt.setup(req)
+
+			rec := httptest.NewRecorder()
+			s.mux.ServeHTTP(rec, req)
+
+			if rec.Code != tt.expected {
+				t.Errorf("Expected %d, got %d", tt.expected, rec.Code)
+			}
+
+			if rec.Code == http.StatusUnauthorized && rec.Header().Get("WWW-Authenticate") == "" {
+				t.Error("Expected a basic auth challenge")
+			}
+		})
+	}
+}
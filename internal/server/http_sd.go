@@ -0,0 +1,58 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/d0ugal/github-exporter/internal/collectors"
+)
+
+// RepositoryProvider lists the repositories known to the collector
+type RepositoryProvider interface {
+	Repositories() []collectors.RepositoryInfo
+}
+
+// targetGroup is a Prometheus HTTP service discovery target group
+type targetGroup struct {
+	Targets []string          `json:"targets"`
+	Labels  map[string]string `json:"labels"`
+}
+
+// WithServiceDiscovery enables the Prometheus HTTP service discovery endpoint,
+// listing the repositories known to the given provider
+func (s *Server) WithServiceDiscovery(provider RepositoryProvider) *Server {
+	s.repositories = provider
+	s.mux.Handle("GET /http_sd", s.protect(http.HandlerFunc(s.handleHTTPSD)))
+
+	return s
+}
+
+// handleHTTPSD serves one target group per repository, with the repository
+// full name as the target. Labels use the __meta_ prefix so they are only kept
+// when relabeling rules copy them.
+func (s *Server) handleHTTPSD(w http.ResponseWriter, r *http.Request) {
+	repos := s.repositories.Repositories()
+	groups := make([]targetGroup, 0, len(repos))
+
+	for _, repo := range repos {
+		labels := map[string]string{
+			"__meta_github_org":        repo.Org,
+			"__meta_github_repo":       repo.Repo,
+			"__meta_github_visibility": repo.Visibility,
+			"__meta_github_language":   repo.Language,
+		}
+
+		// Wrap topics in commas, like Prometheus' own SD mechanisms, so a single
+		// topic can be matched with a regex such as .*,monitoring,.*
+		if len(repo.Topics) > 0 {
+			labels["__meta_github_topics"] = "," + strings.Join(repo.Topics, ",") + ","
+		}
+
+		groups = append(groups, targetGroup{
+			Targets: []string{repo.FullName()},
+			Labels:  labels,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, groups)
+}
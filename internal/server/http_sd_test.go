@@ -0,0 +1,61 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/d0ugal/github-exporter/internal/collectors"
+	"github.com/d0ugal/github-exporter/internal/config"
+)
+
+// fakeRepositoryProvider returns a fixed list of repositories
+type fakeRepositoryProvider []collectors.RepositoryInfo
+
+func (f fakeRepositoryProvider) Repositories() []collectors.RepositoryInfo {
+	return f
+}
+
+// TestHTTPSD tests that repositories are listed as HTTP SD target groups
+func TestHTTPSD(t *testing.T) {
+	s, _ := createTestServer(&config.Config{})
+	s.WithServiceDiscovery(fakeRepositoryProvider{
+		{Org: "d0ugal", Repo: "github-exporter", Visibility: "public", Language: "Go", Topics: []string{"prometheus", "exporter"}},
+		{Org: "d0ugal", Repo: "dotfiles", Visibility: "private"},
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/http_sd", nil)
+	rec := httptest.NewRecorder()
+	s.mux.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", rec.Code)
+	}
+
+	var groups []targetGroup
+	if err := json.Unmarshal(rec.Body.Bytes(), &groups); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	if len(groups) != 2 {
+		t.Fatalf("Expected 2 target groups, got %d", len(groups))
+	}
+
+	first := groups[0]
+	if len(first.Targets) != 1 || first.Targets[0] != "d0ugal/github-exporter" {
+		t.Errorf("Unexpected targets %v", first.Targets)
+	}
+
+	if first.Labels["__meta_github_language"] != "Go" {
+		t.Errorf("Expected language label Go, got %q", first.Labels["__meta_github_language"])
+	}
+
+	if first.Labels["__meta_github_topics"] != ",prometheus,exporter," {
+		t.Errorf("Unexpected topics label %q", first.Labels["__meta_github_topics"])
+	}
+
+	if _, ok := groups[1].Labels["__meta_github_topics"]; ok {
+		t.Error("Expected no topics label for repository without topics")
+	}
+}
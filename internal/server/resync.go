@@ -0,0 +1,58 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/d0ugal/github-exporter/internal/collectors"
+	"github.com/d0ugal/github-exporter/internal/config"
+)
+
+// Resyncer queues a poll of a single repository
+type Resyncer interface {
+	RequestResync(owner, repo string) error
+}
+
+// WithResync enables the endpoint forcing a poll of a repository whose webhook
+// data is suspected to be stale. Requests must carry the admin token as a bearer
+// token; without a token the endpoint is not served.
+func (s *Server) WithResync(resyncer Resyncer, token string) *Server {
+	if token == "" {
+		return s
+	}
+
+	s.adminMux.Handle("POST "+config.ResyncPathPrefix+"{org}/{repo}", s.allow(&resyncHandler{
+		resyncer: resyncer,
+		token:    token,
+	}))
+
+	return s
+}
+
+// resyncHandler serves the resync endpoint
+type resyncHandler struct {
+	resyncer Resyncer
+	token    string
+}
+
+func (h *resyncHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !hasBearerToken(r, h.token) {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid or missing bearer token"})
+		return
+	}
+
+	org, repo := r.PathValue("org"), r.PathValue("repo")
+
+	err := h.resyncer.RequestResync(org, repo)
+	switch {
+	case errors.Is(err, collectors.ErrUnknownRepository):
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": err.Error()})
+	case errors.Is(err, collectors.ErrResyncQueueFull):
+		w.Header().Set("Retry-After", "60")
+		writeJSON(w, http.StatusServiceUnavailable, map[string]string{"error": err.Error()})
+	case err != nil:
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	default:
+		writeJSON(w, http.StatusAccepted, map[string]string{"status": "queued", "repository": org + "/" + repo})
+	}
+}
@@ -0,0 +1,79 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/d0ugal/github-exporter/internal/collectors"
+	"github.com/d0ugal/github-exporter/internal/config"
+)
+
+// fakeResyncer records resync requests, failing for unknown repositories
+type fakeResyncer struct {
+	requested []string
+}
+
+func (f *fakeResyncer) RequestResync(owner, repo string) error {
+	if repo == "unknown" {
+		return collectors.ErrUnknownRepository
+	}
+
+	f.requested = append(f.requested, owner+"/"+repo)
+
+	return nil
+}
+
+// TestResync tests that resync requests require the bearer token and are queued
+func TestResync(t *testing.T) {
+	resyncer := &fakeResyncer{}
+	s, _ := createTestServer(&config.Config{})
+	s.WithResync(resyncer, "s3cret")
+
+	tests := []struct {
+		name     string
+		path     string
+		token    string
+		expected int
+	}{
+		{"missing token", "/api/v1/resync/d0ugal/github-exporter", "", http.StatusUnauthorized},
+		{"wrong token", "/api/v1/resync/d0ugal/github-exporter", "wrong", http.StatusUnauthorized},
+		{"unknown repository", "/api/v1/resync/d0ugal/unknown", "s3cret", http.StatusNotFound},
+		{"queued", "/api/v1/resync/d0ugal/github-exporter", "s3cret", http.StatusAccepted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tt.path, nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+
+			rec := httptest.NewRecorder()
+			s.mux.ServeHTTP(rec, req)
+
+			if rec.Code != tt.expected {
+				t.Errorf("Expected %d, got %d: %s", tt.expected, rec.Code, rec.Body.String())
+			}
+		})
+	}
+
+	if len(resyncer.requested) != 1 || resyncer.requested[0] != "d0ugal/github-exporter" {
+		t.Errorf("Expected a single resync of d0ugal/github-exporter, got %v", resyncer.requested)
+	}
+}
+
+// TestResyncWithoutToken tests that the resync endpoint is not served without an admin token
+func TestResyncWithoutToken(t *testing.T) {
+	resyncer := &fakeResyncer{}
+	s, _ := createTestServer(&config.Config{})
+	s.WithResync(resyncer, "")
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/resync/d0ugal/github-exporter", nil)
+	rec := httptest.NewRecorder()
+	s.mux.ServeHTTP(rec, req)
+
+	if rec.Code == http.StatusAccepted || len(resyncer.requested) != 0 {
+		t.Errorf("Expected the resync endpoint not to be served, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,277 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"log/slog"
+	"net/http"
+	"net/netip"
+	"time"
+
+	"github.com/d0ugal/github-exporter/internal/collectors"
+	"github.com/d0ugal/github-exporter/internal/config"
+	"github.com/d0ugal/github-exporter/internal/metrics"
+	promexporter_metrics "github.com/d0ugal/promexporter/metrics"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+//go:embed templates/*.html
+var templateFS embed.FS
+
+var (
+	indexTemplate  = template.Must(template.ParseFS(templateFS, "templates/index.html"))
+	statusTemplate = template.Must(template.New("status.html").Funcs(template.FuncMap{
+		"timestamp": formatTimestamp,
+	}).ParseFS(templateFS, "templates/status.html"))
+)
+
+// StatusProvider provides a snapshot of the collector state for the status page
+type StatusProvider interface {
+	Status() collectors.Status
+}
+
+// VersionInfo holds the version information shown by the server
+type VersionInfo struct {
+	Version   string
+	Commit    string
+	BuildDate string
+}
+
+// indexData holds the data passed to the index template
+type indexData struct {
+	Name      string
+	Version   VersionInfo
+	Metrics   []promexporter_metrics.MetricInfo
+	HasStatus bool
+}
+
+// statusData holds the data passed to the status template
+type statusData struct {
+	Name    string
+	Version VersionInfo
+	Status  collectors.Status
+}
+
+// Server serves the exporter's HTTP endpoints
+type Server struct {
+	config       *config.Config
+	metrics      *metrics.GitHubRegistry
+	name         string
+	versionInfo  VersionInfo
+	status       StatusProvider
+	repositories RepositoryProvider
+	mux          *http.ServeMux
+	adminMux     *http.ServeMux // Same as mux unless admin.port is set
+	networks     []netip.Prefix // Clients allowed to reach protected endpoints
+	servers      []*http.Server
+}
+
+// New creates a new HTTP server for the exporter
+func New(cfg *config.Config, metricsRegistry *metrics.GitHubRegistry, name string, versionInfo VersionInfo) *Server {
+	s := &Server{
+		config:      cfg,
+		metrics:     metricsRegistry,
+		name:        name,
+		versionInfo: versionInfo,
+		mux:         http.NewServeMux(),
+	}
+
+	// Validated when the configuration is loaded
+	s.networks, _ = cfg.ServerOptions.Networks()
+
+	s.adminMux = s.mux
+	if cfg.Admin.SeparateListener() {
+		s.adminMux = http.NewServeMux()
+	}
+
+	s.setupRoutes()
+
+	return s
+}
+
+// WithStatusProvider enables the status page, backed by the given provider.
+// The status page is part of the web UI and is not served when it is disabled.
+func (s *Server) WithStatusProvider(provider StatusProvider) *Server {
+	s.status = provider
+
+	if s.config.Server.IsWebUIEnabled() {
+		s.mux.Handle("GET /status", s.protect(http.HandlerFunc(s.handleStatus)))
+	}
+
+	return s
+}
+
+// WithWebhookReceiver accepts GitHub webhook deliveries at path
+func (s *Server) WithWebhookReceiver(path string, receiver http.Handler) *Server {
+	s.mux.Handle("POST "+path, receiver)
+
+	return s
+}
+
+func (s *Server) setupRoutes() {
+	// Root endpoint with HTML index (optional)
+	if s.config.Server.IsWebUIEnabled() {
+		s.mux.Handle("GET /{$}", s.protect(http.HandlerFunc(s.handleRoot)))
+	}
+
+	// Metrics endpoint
+	s.mux.Handle("GET /metrics", s.protect(promhttp.HandlerFor(s.metrics.Gatherer(), promhttp.HandlerOpts{
+		EnableOpenMetrics:                   s.config.MetricsOptions.IsOpenMetricsEnabled(),
+		EnableOpenMetricsTextCreatedSamples: s.config.MetricsOptions.CreatedTimestamps,
+	})))
+
+	// Current metrics as JSON, for debugging
+	s.mux.Handle("GET /api/v1/snapshot", s.protect(http.HandlerFunc(s.handleSnapshot)))
+
+	// Health endpoint (optional)
+	if s.config.Server.IsHealthEnabled() {
+		s.mux.HandleFunc("GET /health", s.handleHealth)
+	}
+}
+
+// Start starts the HTTP server, and the admin listener if configured, and
+// blocks until they are shut down. If either fails, both are stopped.
+func (s *Server) Start() error {
+	s.servers = []*http.Server{s.newHTTPServer(fmt.Sprintf("%s:%d", s.config.Server.Host, s.config.Server.Port), s.mux)}
+	if s.config.Admin.SeparateListener() {
+		s.servers = append(s.servers, s.newHTTPServer(fmt.Sprintf("%s:%d", s.config.Admin.Host, s.config.Admin.Port), s.adminMux))
+	}
+
+	tlsConfig := s.config.ServerOptions.TLS
+
+	slog.Info("Starting exporter server",
+		"name", s.name,
+		"address", s.servers[0].Addr,
+		"tls", tlsConfig.Enabled(),
+		"auth", s.config.ServerOptions.Auth.Enabled(),
+	)
+
+	if s.config.Admin.SeparateListener() {
+		slog.Info("Starting admin listener", "address", s.servers[1].Addr)
+	}
+
+	errs := make(chan error, len(s.servers))
+
+	for _, server := range s.servers {
+		go func() {
+			var err error
+			if tlsConfig.Enabled() {
+				err = server.ListenAndServeTLS(tlsConfig.CertFile, tlsConfig.KeyFile)
+			} else {
+				err = server.ListenAndServe()
+			}
+
+			if errors.Is(err, http.ErrServerClosed) {
+				err = nil
+			}
+
+			errs <- err
+		}()
+	}
+
+	var firstErr error
+
+	for range s.servers {
+		if err := <-errs; err != nil && firstErr == nil {
+			firstErr = err
+			_ = s.Shutdown()
+		}
+	}
+
+	return firstErr
+}
+
+// newHTTPServer creates a server for handler listening on addr
+func (s *Server) newHTTPServer(addr string, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadHeaderTimeout: 30 * time.Second,
+		TLSConfig:         &tls.Config{MinVersion: tls.VersionTLS12},
+	}
+}
+
+// Shutdown gracefully shuts down the server and the admin listener
+func (s *Server) Shutdown() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	var errs []error
+
+	for _, server := range s.servers {
+		if err := server.Shutdown(ctx); err != nil {
+			slog.Error("Server shutdown error", "address", server.Addr, "error", err)
+			errs = append(errs, err)
+		}
+	}
+
+	if len(s.servers) > 0 && len(errs) == 0 {
+		slog.Info("Server shutdown gracefully")
+	}
+
+	return errors.Join(errs...)
+}
+
+func (s *Server) handleRoot(w http.ResponseWriter, r *http.Request) {
+	data := indexData{
+		Name:      s.name,
+		Version:   s.versionInfo,
+		Metrics:   s.metrics.GetMetricsInfo(),
+		HasStatus: s.status != nil,
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+	if err := indexTemplate.Execute(w, data); err != nil {
+		http.Error(w, fmt.Sprintf("Error rendering template: %v", err), http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
+	data := statusData{
+		Name:    s.name,
+		Version: s.versionInfo,
+		Status:  s.status.Status(),
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+	if err := statusTemplate.Execute(w, data); err != nil {
+		http.Error(w, fmt.Sprintf("Error rendering template: %v", err), http.StatusInternalServerError)
+	}
+}
+
+// formatTimestamp formats a time for display, returning "never" for the zero time
+func formatTimestamp(t time.Time) string {
+	if t.IsZero() {
+		return "never"
+	}
+
+	return t.UTC().Format(time.RFC3339)
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"status":     "healthy",
+		"timestamp":  time.Now().Unix(),
+		"service":    s.name,
+		"version":    s.versionInfo.Version,
+		"commit":     s.versionInfo.Commit,
+		"build_date": s.versionInfo.BuildDate,
+	})
+}
+
+// writeJSON writes a JSON response with the given status code
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		slog.Error("Failed to write JSON response", "error", err)
+	}
+}
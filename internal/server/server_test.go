@@ -0,0 +1,147 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/d0ugal/github-exporter/internal/collectors"
+	"github.com/d0ugal/github-exporter/internal/config"
+	"github.com/d0ugal/github-exporter/internal/metrics"
+	promexporter_metrics "github.com/d0ugal/promexporter/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// createTestServer creates a server with a GitHub registry for testing
+func createTestServer(cfg *config.Config) (*Server, *metrics.GitHubRegistry) {
+	baseRegistry := promexporter_metrics.NewRegistry("github_exporter_info")
+	githubRegistry := metrics.NewGitHubRegistry(baseRegistry)
+
+	return New(cfg, githubRegistry, "github-exporter", VersionInfo{Version: "test"}), githubRegistry
+}
+
+// scrape performs a metrics request with the given Accept header
+func scrape(t *testing.T, s *Server, accept string) (string, string) {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	req.Header.Set("Accept", accept)
+
+	rec := httptest.NewRecorder()
+	s.mux.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", rec.Code)
+	}
+
+	return rec.Header().Get("Content-Type"), rec.Body.String()
+}
+
+const openMetricsAccept = "application/openmetrics-text;version=1.0.0"
+
+// TestMetricsOpenMetricsCreatedSamples tests that counters emit _created series when enabled
+func TestMetricsOpenMetricsCreatedSamples(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.MetricsOptions.CreatedTimestamps = true
+
+	s, registry := createTestServer(cfg)
+	registry.GitHubAPICallsTotal.With(prometheus.Labels{"endpoint": "repos", "status": "200"}).Inc()
+
+	contentType, body := scrape(t, s, openMetricsAccept)
+
+	if !strings.HasPrefix(contentType, "application/openmetrics-text") {
+		t.Errorf("Expected OpenMetrics content type, got %s", contentType)
+	}
+
+	if !strings.Contains(body, "github_api_calls_created") {
+		t.Error("Expected _created series for counters")
+	}
+}
+
+// TestMetricsOpenMetricsDisabled tests that the text format is served when OpenMetrics is disabled
+func TestMetricsOpenMetricsDisabled(t *testing.T) {
+	disabled := false
+	cfg := &config.Config{}
+	cfg.MetricsOptions.OpenMetrics = &disabled
+
+	s, _ := createTestServer(cfg)
+
+	contentType, _ := scrape(t, s, openMetricsAccept)
+	if !strings.HasPrefix(contentType, "text/plain") {
+		t.Errorf("Expected text format, got %s", contentType)
+	}
+}
+
+// TestHealthAndRoot tests the health and index endpoints
+func TestHealthAndRoot(t *testing.T) {
+	s, _ := createTestServer(&config.Config{})
+
+	for _, path := range []string{"/health", "/"} {
+		rec := httptest.NewRecorder()
+		s.mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+
+		if rec.Code != http.StatusOK {
+			t.Errorf("Expected 200 for %s, got %d", path, rec.Code)
+		}
+	}
+}
+
+// fakeStatusProvider returns a fixed collector status
+type fakeStatusProvider struct {
+	status collectors.Status
+}
+
+func (f fakeStatusProvider) Status() collectors.Status {
+	return f.status
+}
+
+// TestStatusPage tests that the status page renders targets and rate limit state
+func TestStatusPage(t *testing.T) {
+	s, _ := createTestServer(&config.Config{})
+	s.WithStatusProvider(fakeStatusProvider{status: collectors.Status{
+		Orgs:               []string{"d0ugal"},
+		Branches:           []string{"main"},
+		RefreshInterval:    5 * time.Minute,
+		RateLimitTotal:     5000,
+		RateLimitRemaining: 4200,
+		Targets: []collectors.TargetStatus{
+			{Type: "org", Target: "d0ugal", LastCollected: time.Now(), LastSuccess: time.Now()},
+			{Type: "repo", Target: "d0ugal/broken", LastCollected: time.Now(), LastError: "404 Not Found"},
+		},
+	}})
+
+	req := httptest.NewRequest(http.MethodGet, "/status", nil)
+	rec := httptest.NewRecorder()
+	s.mux.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", rec.Code)
+	}
+
+	body := rec.Body.String()
+	for _, want := range []string{"5m0s", "4200 / 5000", "d0ugal/broken", "404 Not Found", "<code>main</code>"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected status page to contain %q", want)
+		}
+	}
+}
+
+// TestStatusPageDisabledWithWebUI tests that the status page is not served when the web UI is disabled
+func TestStatusPageDisabledWithWebUI(t *testing.T) {
+	disabled := false
+	cfg := &config.Config{}
+	cfg.Server.EnableWebUI = &disabled
+
+	s, _ := createTestServer(cfg)
+	s.WithStatusProvider(fakeStatusProvider{})
+
+	req := httptest.NewRequest(http.MethodGet, "/status", nil)
+	rec := httptest.NewRecorder()
+	s.mux.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Expected 404, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,162 @@
+package server
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+)
+
+// snapshotResponse is the body of /api/v1/snapshot
+type snapshotResponse struct {
+	Timestamp time.Time        `json:"timestamp"`
+	Samples   []snapshotSample `json:"samples"`
+}
+
+// snapshotSample is a single sample as it would appear on /metrics. Histograms
+// and summaries are flattened into their _bucket, _sum and _count (or quantile)
+// samples like in the text exposition format.
+type snapshotSample struct {
+	Name      string            `json:"name"`
+	Labels    map[string]string `json:"labels"`
+	Value     sampleValue       `json:"value"`
+	Timestamp int64             `json:"timestamp"` // Unix milliseconds
+}
+
+// sampleValue encodes as a JSON number, or as a string for NaN and infinities
+// which JSON numbers can't represent
+type sampleValue float64
+
+// MarshalJSON implements json.Marshaler
+func (v sampleValue) MarshalJSON() ([]byte, error) {
+	f := float64(v)
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return []byte(strconv.Quote(strconv.FormatFloat(f, 'g', -1, 64))), nil
+	}
+
+	return []byte(strconv.FormatFloat(f, 'g', -1, 64)), nil
+}
+
+// handleSnapshot serves the samples currently exported on /metrics, including
+// those restored from the state store during warm-up. The name query parameter
+// selects a metric family and every other parameter must match a label, e.g.
+// /api/v1/snapshot?org=d0ugal&repo=github-exporter.
+func (s *Server) handleSnapshot(w http.ResponseWriter, r *http.Request) {
+	families, err := s.metrics.Gatherer().Gather()
+	if err != nil && len(families) == 0 {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return
+	}
+
+	query := r.URL.Query()
+	name := query.Get("name")
+	query.Del("name")
+
+	now := time.Now()
+	response := snapshotResponse{Timestamp: now, Samples: []snapshotSample{}}
+
+	for _, family := range families {
+		if name != "" && family.GetName() != name {
+			continue
+		}
+
+		for _, metric := range family.GetMetric() {
+			labels := make(map[string]string, len(metric.GetLabel()))
+			for _, pair := range metric.GetLabel() {
+				labels[pair.GetName()] = pair.GetValue()
+			}
+
+			if !matchesQuery(labels, query) {
+				continue
+			}
+
+			timestamp := now.UnixMilli()
+			if metric.TimestampMs != nil {
+				timestamp = metric.GetTimestampMs()
+			}
+
+			for _, sample := range flattenMetric(family.GetName(), family.GetType(), metric, labels) {
+				sample.Timestamp = timestamp
+				response.Samples = append(response.Samples, sample)
+			}
+		}
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// matchesQuery reports whether labels has every label given in query
+func matchesQuery(labels map[string]string, query map[string][]string) bool {
+	for label, values := range query {
+		if len(values) == 0 || labels[label] != values[0] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// flattenMetric returns the samples of a metric
+func flattenMetric(name string, metricType dto.MetricType, metric *dto.Metric, labels map[string]string) []snapshotSample {
+	switch metricType {
+	case dto.MetricType_COUNTER:
+		return []snapshotSample{{Name: name, Labels: labels, Value: sampleValue(metric.GetCounter().GetValue())}}
+	case dto.MetricType_GAUGE:
+		return []snapshotSample{{Name: name, Labels: labels, Value: sampleValue(metric.GetGauge().GetValue())}}
+	case dto.MetricType_HISTOGRAM:
+		histogram := metric.GetHistogram()
+		samples := make([]snapshotSample, 0, len(histogram.GetBucket())+3)
+		infinite := false
+
+		for _, bucket := range histogram.GetBucket() {
+			infinite = math.IsInf(bucket.GetUpperBound(), 1)
+			samples = append(samples, snapshotSample{
+				Name:   name + "_bucket",
+				Labels: withLabel(labels, "le", strconv.FormatFloat(bucket.GetUpperBound(), 'g', -1, 64)),
+				Value:  sampleValue(bucket.GetCumulativeCount()),
+			})
+		}
+
+		// The +Inf bucket is implicit unless it was defined explicitly
+		if !infinite {
+			samples = append(samples, snapshotSample{Name: name + "_bucket", Labels: withLabel(labels, "le", "+Inf"), Value: sampleValue(histogram.GetSampleCount())})
+		}
+
+		return append(samples,
+			snapshotSample{Name: name + "_sum", Labels: labels, Value: sampleValue(histogram.GetSampleSum())},
+			snapshotSample{Name: name + "_count", Labels: labels, Value: sampleValue(histogram.GetSampleCount())},
+		)
+	case dto.MetricType_SUMMARY:
+		summary := metric.GetSummary()
+		samples := make([]snapshotSample, 0, len(summary.GetQuantile())+2)
+
+		for _, quantile := range summary.GetQuantile() {
+			samples = append(samples, snapshotSample{
+				Name:   name,
+				Labels: withLabel(labels, "quantile", strconv.FormatFloat(quantile.GetQuantile(), 'g', -1, 64)),
+				Value:  sampleValue(quantile.GetValue()),
+			})
+		}
+
+		return append(samples,
+			snapshotSample{Name: name + "_sum", Labels: labels, Value: sampleValue(summary.GetSampleSum())},
+			snapshotSample{Name: name + "_count", Labels: labels, Value: sampleValue(summary.GetSampleCount())},
+		)
+	default:
+		return []snapshotSample{{Name: name, Labels: labels, Value: sampleValue(metric.GetUntyped().GetValue())}}
+	}
+}
+
+// withLabel returns a copy of labels with one more label
+func withLabel(labels map[string]string, name, value string) map[string]string {
+	copied := make(map[string]string, len(labels)+1)
+	for k, v := range labels {
+		copied[k] = v
+	}
+
+	copied[name] = value
+
+	return copied
+}
@@ -0,0 +1,95 @@
+package server
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/d0ugal/github-exporter/internal/config"
+)
+
+// decodedSample is a sample of a snapshot with a finite value
+type decodedSample struct {
+	Name      string            `json:"name"`
+	Labels    map[string]string `json:"labels"`
+	Value     float64           `json:"value"`
+	Timestamp int64             `json:"timestamp"`
+}
+
+// getSnapshot requests /api/v1/snapshot with the given query and decodes the samples
+func getSnapshot(t *testing.T, s *Server, query string) []decodedSample {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/snapshot"+query, nil)
+	rec := httptest.NewRecorder()
+	s.mux.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", rec.Code)
+	}
+
+	var response struct {
+		Samples []decodedSample `json:"samples"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	return response.Samples
+}
+
+// TestSnapshot tests that samples can be selected by metric name and labels
+func TestSnapshot(t *testing.T) {
+	s, registry := createTestServer(&config.Config{})
+
+	registry.GitHubReposStars.WithLabelValues("d0ugal", "github-exporter", "public").Set(42)
+	registry.GitHubReposStars.WithLabelValues("d0ugal", "dotfiles", "private").Set(1)
+	registry.GitHubAPIRequestDuration.WithLabelValues("repos").Observe(0.2)
+
+	samples := getSnapshot(t, s, "?name=github_repo_stars&repo=github-exporter")
+
+	if len(samples) != 1 {
+		t.Fatalf("Expected 1 sample, got %d", len(samples))
+	}
+
+	sample := samples[0]
+	if sample.Labels["org"] != "d0ugal" || sample.Value != 42 || sample.Timestamp == 0 {
+		t.Errorf("Unexpected sample %+v", sample)
+	}
+
+	// Histograms are flattened into buckets, sum and count
+	found := map[string]bool{}
+	for _, sample := range getSnapshot(t, s, "?name=github_api_request_duration_seconds") {
+		found[sample.Name] = true
+
+		if sample.Name == "github_api_request_duration_seconds_bucket" && sample.Labels["le"] == "+Inf" && sample.Value != 1 {
+			t.Errorf("Expected 1 observation in the +Inf bucket, got %v", sample.Value)
+		}
+	}
+
+	for _, name := range []string{"github_api_request_duration_seconds_bucket", "github_api_request_duration_seconds_sum", "github_api_request_duration_seconds_count"} {
+		if !found[name] {
+			t.Errorf("Expected %s samples", name)
+		}
+	}
+}
+
+// TestSampleValueJSON tests that non-finite values are encoded as strings
+func TestSampleValueJSON(t *testing.T) {
+	for value, expected := range map[float64]string{1.5: `1.5`, math.Inf(1): `"+Inf"`} {
+		data, err := json.Marshal(sampleValue(value))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if string(data) != expected {
+			t.Errorf("Expected %s, got %s", expected, data)
+		}
+	}
+
+	if data, _ := json.Marshal(sampleValue(math.NaN())); string(data) != `"NaN"` {
+		t.Errorf("Expected \"NaN\", got %s", data)
+	}
+}
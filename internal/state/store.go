@@ -0,0 +1,178 @@
+// Package state persists exporter state across restarts in a single JSON file
+package state
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"github.com/prometheus/common/model"
+)
+
+// State is the exporter state persisted between restarts
+type State struct {
+	Snapshot *Snapshot `json:"snapshot,omitempty"`
+
+	// WorkflowRuns are the recently completed workflow runs that windowed
+	// metrics, such as success ratios, are computed from
+	WorkflowRuns []WorkflowRun `json:"workflow_runs,omitempty"`
+
+	// Followers are the follower counts last seen for each account and the
+	// gains and losses counted so far
+	Followers []Followers `json:"followers,omitempty"`
+
+	// OrgRepos are the repositories last discovered in each organization and
+	// the creations and archivals counted so far
+	OrgRepos []OrgRepos `json:"org_repos,omitempty"`
+}
+
+// OrgRepos tracks the repositories of an organization between discoveries
+type OrgRepos struct {
+	Org      string    `json:"org"`
+	Newest   time.Time `json:"newest"` // Creation time of the newest repository seen
+	Active   []string  `json:"active"` // Names of the repositories not archived
+	Created  int       `json:"created"`
+	Archived int       `json:"archived"`
+}
+
+// Followers is the follower count of an account and its changes since first seen
+type Followers struct {
+	Account string `json:"account"`
+	Kind    string `json:"kind,omitempty"` // "user", or empty for organizations
+	Count   int    `json:"count"`
+	Gained  int    `json:"gained"`
+	Lost    int    `json:"lost"`
+}
+
+// WorkflowRun is a completed workflow run on a monitored branch
+type WorkflowRun struct {
+	Org         string    `json:"org"`
+	Repo        string    `json:"repo"`
+	Workflow    string    `json:"workflow"`
+	Branch      string    `json:"branch"`
+	ID          int64     `json:"id"`
+	CompletedAt time.Time `json:"completed_at"`
+	Success     bool      `json:"success"`
+}
+
+// Snapshot is the set of metrics exported at the end of a collection cycle
+type Snapshot struct {
+	SavedAt time.Time `json:"saved_at"`
+	Metrics string    `json:"metrics"` // Prometheus text exposition format
+}
+
+// Store reads and writes the state file
+type Store struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewStore creates a store backed by the file at path
+func NewStore(path string) *Store {
+	return &Store{path: path}
+}
+
+// Path returns the location of the state file
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Load reads the state file, returning an empty state if it does not exist yet
+func (s *Store) Load() (*State, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &State{}, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state file: %w", err)
+	}
+
+	var state State
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse state file %s: %w", s.path, err)
+	}
+
+	return &state, nil
+}
+
+// Save writes the state file. The file is replaced atomically so a crash while
+// saving never leaves a truncated state behind.
+func (s *Store) Save(state *State) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to encode state: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("failed to create state file: %w", err)
+	}
+
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to replace state file: %w", err)
+	}
+
+	return nil
+}
+
+// NewSnapshot encodes metric families into a snapshot taken at savedAt
+func NewSnapshot(families []*dto.MetricFamily, savedAt time.Time) (*Snapshot, error) {
+	var buf bytes.Buffer
+
+	for _, family := range families {
+		if _, err := expfmt.MetricFamilyToText(&buf, family); err != nil {
+			return nil, fmt.Errorf("failed to encode metric family %s: %w", family.GetName(), err)
+		}
+	}
+
+	return &Snapshot{SavedAt: savedAt, Metrics: buf.String()}, nil
+}
+
+// Families decodes the snapshot's metric families, sorted by name
+func (s *Snapshot) Families() ([]*dto.MetricFamily, error) {
+	parser := expfmt.NewTextParser(model.UTF8Validation)
+
+	parsed, err := parser.TextToMetricFamilies(strings.NewReader(s.Metrics))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot metrics: %w", err)
+	}
+
+	families := make([]*dto.MetricFamily, 0, len(parsed))
+	for _, family := range parsed {
+		families = append(families, family)
+	}
+
+	sort.Slice(families, func(i, j int) bool {
+		return families[i].GetName() < families[j].GetName()
+	})
+
+	return families, nil
+}
@@ -0,0 +1,70 @@
+package state
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// TestStoreLoadMissing tests that a missing state file loads as an empty state
+func TestStoreLoadMissing(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), "state.json"))
+
+	state, err := store.Load()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if state.Snapshot != nil {
+		t.Errorf("Expected no snapshot, got %+v", state.Snapshot)
+	}
+}
+
+// TestStoreSnapshotRoundTrip tests saving and loading a metric snapshot
+func TestStoreSnapshotRoundTrip(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	stars := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "github_repo_stars", Help: "Stars"}, []string{"org", "repo"})
+	registry.MustRegister(stars)
+	stars.WithLabelValues("d0ugal", "github-exporter").Set(42)
+
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	savedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	snapshot, err := NewSnapshot(families, savedAt)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	store := NewStore(filepath.Join(t.TempDir(), "state.json"))
+	if err := store.Save(&State{Snapshot: snapshot}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	state, err := store.Load()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !state.Snapshot.SavedAt.Equal(savedAt) {
+		t.Errorf("Expected snapshot saved at %s, got %s", savedAt, state.Snapshot.SavedAt)
+	}
+
+	loaded, err := state.Snapshot.Families()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(loaded) != 1 || loaded[0].GetName() != "github_repo_stars" {
+		t.Fatalf("Expected github_repo_stars family, got %v", loaded)
+	}
+
+	if got := loaded[0].GetMetric()[0].GetGauge().GetValue(); got != 42 {
+		t.Errorf("Expected 42 stars, got %v", got)
+	}
+}
@@ -0,0 +1,189 @@
+// Package tokenpool authenticates GitHub API requests with a pool of tokens,
+// moving on to another token whenever the one in use approaches its rate limit.
+package tokenpool
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/d0ugal/github-exporter/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+)
+
+// token holds the rate limit state and rate limiter of a token in the pool
+type token struct {
+	index   int
+	value   string
+	limiter *rate.Limiter
+
+	// The core rate limit reported with the token's latest response, unknown
+	// until the token is first used
+	known     bool
+	limit     int
+	remaining int
+	reset     time.Time
+}
+
+// Transport authenticates each request with one of a pool of tokens. Requests
+// keep using the same token until its remaining requests fall below the share
+// of its rate limit kept in reserve, then move on to the token with the most
+// requests left. Requests are only paced once every token has reached its
+// reserve, and then by the rate limit of the token in use.
+type Transport struct {
+	base    http.RoundTripper
+	metrics *metrics.GitHubRegistry
+	buffer  float64 // Fraction of each token's rate limit to use
+
+	mu      sync.Mutex
+	tokens  []*token
+	current *token
+}
+
+// NewTransport creates a transport rotating between tokens, sending requests
+// with base, or http.DefaultTransport if base is nil
+func NewTransport(tokens []string, base http.RoundTripper, metricsRegistry *metrics.GitHubRegistry, rateLimitBuffer float64) *Transport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	t := &Transport{
+		base:    base,
+		metrics: metricsRegistry,
+		buffer:  rateLimitBuffer,
+	}
+
+	for i, value := range tokens {
+		t.tokens = append(t.tokens, &token{index: i, value: value, limiter: rate.NewLimiter(rate.Inf, 1)})
+	}
+
+	if len(t.tokens) > 0 {
+		t.current = t.tokens[0]
+	}
+
+	return t
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	tok := t.next(time.Now())
+
+	if err := tok.limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+tok.value)
+
+	resp, err := t.base.RoundTrip(req)
+	if resp != nil {
+		t.observeRateLimit(tok, resp)
+	}
+
+	return resp, err
+}
+
+// next returns the token the next request is made with, rotating to the token
+// with the most requests left when the current one has reached its reserve
+func (t *Transport) next(now time.Time) *token {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	// Tokens paced until their rate limit reset are no longer paced, as their
+	// next response may only arrive once requests are allowed again
+	for _, tok := range t.tokens {
+		if tok.known && !now.Before(tok.reset) {
+			tok.limiter.SetLimit(rate.Inf)
+		}
+	}
+
+	if t.available(t.current, now) > 0 {
+		return t.current
+	}
+
+	best := t.current
+	for _, tok := range t.tokens {
+		if t.available(tok, now) > t.available(best, now) {
+			best = tok
+		}
+	}
+
+	if best != t.current {
+		t.current = best
+		t.metrics.GitHubTokenPoolRotations.With(prometheus.Labels{}).Inc()
+	}
+
+	return best
+}
+
+// available returns the number of requests a token can make before reaching
+// its reserve. Tokens not used yet, or whose rate limit has reset since, have
+// their whole rate limit available.
+func (t *Transport) available(tok *token, now time.Time) int {
+	if !tok.known {
+		return math.MaxInt
+	}
+
+	if !now.Before(tok.reset) {
+		return tok.limit
+	}
+
+	return tok.remaining - (tok.limit - int(float64(tok.limit)*t.buffer))
+}
+
+// observeRateLimit records the rate limit reported with a response to a
+// request made with tok. Once the token has reached its reserve its requests
+// are paced so the remaining ones last until it resets.
+func (t *Transport) observeRateLimit(tok *token, resp *http.Response) {
+	limit, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Limit"))
+	if err != nil {
+		return
+	}
+
+	remaining, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return
+	}
+
+	reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil {
+		return
+	}
+
+	// GraphQL points and search requests have rate limits of their own
+	if resource := resp.Header.Get("X-RateLimit-Resource"); resource != "" && resource != "core" {
+		return
+	}
+
+	labels := prometheus.Labels{"token_index": strconv.Itoa(tok.index)}
+	t.metrics.GitHubTokenPoolRateLimitTotal.With(labels).Set(float64(limit))
+	t.metrics.GitHubTokenPoolRateLimitRemaining.With(labels).Set(float64(remaining))
+	t.metrics.GitHubTokenPoolRateLimitReset.With(labels).Set(float64(reset))
+
+	t.mu.Lock()
+	tok.known = true
+	tok.limit, tok.remaining, tok.reset = limit, remaining, time.Unix(reset, 0)
+	available := t.available(tok, time.Now())
+	t.mu.Unlock()
+
+	if available > 0 {
+		tok.limiter.SetLimit(rate.Inf)
+		return
+	}
+
+	// A limit of 0 would fail every request until restarted, keep the pace and
+	// let the reset lift it instead
+	if remaining <= 0 {
+		return
+	}
+
+	timeUntilReset := time.Until(tok.reset)
+	if timeUntilReset <= 0 {
+		timeUntilReset = time.Hour
+	}
+
+	tok.limiter.SetLimit(rate.Limit(float64(remaining) * t.buffer / timeUntilReset.Seconds()))
+}
@@ -0,0 +1,166 @@
+package tokenpool
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/d0ugal/github-exporter/internal/metrics"
+	promexporter_metrics "github.com/d0ugal/promexporter/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// newTestTransport creates a transport for tokens sending requests to a server
+// that reports the remaining requests of each token from remaining
+func newTestTransport(t *testing.T, remaining map[string]int) (*Transport, *metrics.GitHubRegistry, string, *[]string) {
+	t.Helper()
+
+	var used []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		used = append(used, token)
+		remaining[token]--
+
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Remaining", fmt.Sprint(remaining[token]))
+		w.Header().Set("X-RateLimit-Reset", fmt.Sprint(time.Now().Add(time.Hour).Unix()))
+		w.Header().Set("X-RateLimit-Resource", "core")
+	}))
+	t.Cleanup(server.Close)
+
+	registry := metrics.NewGitHubRegistry(promexporter_metrics.NewRegistry("github-exporter-test"))
+
+	return NewTransport([]string{"one", "two", "three"}, nil, registry, 0.8), registry, server.URL, &used
+}
+
+// TestTransportRotation tests that requests stay on a token until it reaches
+// its reserve and then move on to the token with the most requests left
+func TestTransportRotation(t *testing.T) {
+	transport, registry, url, used := newTestTransport(t, map[string]int{"one": 22, "two": 50, "three": 90})
+	client := &http.Client{Transport: transport}
+
+	// Every token is used once so its rate limit is known
+	for _, tok := range transport.tokens {
+		transport.current = tok
+
+		resp, err := client.Get(url)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		_ = resp.Body.Close()
+	}
+
+	transport.current = transport.tokens[0]
+
+	for range 3 {
+		resp, err := client.Get(url)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		_ = resp.Body.Close()
+	}
+
+	// "one" has 21 left, one more request above its reserve of 20
+	expected := []string{"one", "two", "three", "one", "three", "three"}
+	if strings.Join(*used, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected tokens %v, got %v", expected, *used)
+	}
+
+	if got := testutil.ToFloat64(registry.GitHubTokenPoolRotations.WithLabelValues()); got != 1 {
+		t.Errorf("Expected 1 rotation, got %v", got)
+	}
+
+	if got := testutil.ToFloat64(registry.GitHubTokenPoolRateLimitRemaining.WithLabelValues("0")); got != 20 {
+		t.Errorf("Expected 20 requests remaining for token 0, got %v", got)
+	}
+
+	if got := testutil.ToFloat64(registry.GitHubTokenPoolRateLimitRemaining.WithLabelValues("2")); got != 87 {
+		t.Errorf("Expected 87 requests remaining for token 2, got %v", got)
+	}
+}
+
+// TestTransportExhausted tests that requests are paced once every token has reached its reserve
+func TestTransportExhausted(t *testing.T) {
+	transport, _, url, _ := newTestTransport(t, map[string]int{"one": 10, "two": 10, "three": 10})
+	client := &http.Client{Transport: transport}
+
+	for _, tok := range transport.tokens {
+		transport.current = tok
+
+		resp, err := client.Get(url)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		_ = resp.Body.Close()
+
+		if limit := tok.limiter.Limit(); limit <= 0 || limit > 1 {
+			t.Errorf("Expected token %d to be paced, got a limit of %v", tok.index, limit)
+		}
+	}
+}
+
+// TestTransportReset tests that tokens whose rate limit has reset are available again
+func TestTransportReset(t *testing.T) {
+	transport, _, _, _ := newTestTransport(t, map[string]int{})
+	now := time.Now()
+
+	exhausted, reset := transport.tokens[0], transport.tokens[1]
+	exhausted.known, exhausted.limit, exhausted.remaining, exhausted.reset = true, 100, 0, now.Add(time.Hour)
+	reset.known, reset.limit, reset.remaining, reset.reset = true, 100, 0, now.Add(-time.Minute)
+
+	transport.tokens = transport.tokens[:2]
+
+	if got := transport.next(now); got != reset {
+		t.Errorf("Expected the token whose rate limit reset, got token %d", got.index)
+	}
+}
+
+// TestTransportRecovery tests that a token that ran out of requests is never
+// stopped entirely and is no longer paced once its rate limit has reset
+func TestTransportRecovery(t *testing.T) {
+	remaining := map[string]int{"one": 12}
+	transport, _, url, _ := newTestTransport(t, remaining)
+	transport.tokens = transport.tokens[:1]
+	tok := transport.tokens[0]
+
+	request := func() {
+		t.Helper()
+
+		ctx, cancel := context.WithTimeout(t.Context(), time.Second)
+		defer cancel()
+
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+
+		resp, err := transport.RoundTrip(req)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		_ = resp.Body.Close()
+	}
+
+	// Paced below the reserve until the reset an hour away
+	request()
+
+	if limit := tok.limiter.Limit(); limit <= 0 || limit > 1 {
+		t.Fatalf("Expected the token to be paced, got a limit of %v", limit)
+	}
+
+	tok.reset = time.Now().Add(-time.Minute)
+	remaining["one"] = 1
+
+	// The reset lifts the pace, and running out of requests does not stop the token
+	request()
+
+	if limit := tok.limiter.Limit(); limit <= 0 {
+		t.Fatalf("Expected the token to keep a positive limit, got %v", limit)
+	}
+
+	tok.reset = time.Now().Add(-time.Minute)
+
+	request()
+}
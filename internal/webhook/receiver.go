@@ -0,0 +1,206 @@
+// Package webhook receives GitHub webhook deliveries and turns events into metrics
+package webhook
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/d0ugal/github-exporter/internal/config"
+	"github.com/d0ugal/github-exporter/internal/metrics"
+	"github.com/google/go-github/v76/github"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// maxPayloadBytes is the largest payload GitHub delivers; larger bodies are rejected
+const maxPayloadBytes = 25 << 20
+
+// Updater applies events to the metrics collected by polling, so they change as
+// soon as a delivery arrives instead of at the next collection cycle. Polling
+// still sets them from the API, correcting any deliveries that were missed.
+type Updater interface {
+	ApplyWorkflowRun(event *github.WorkflowRunEvent)
+	ApplyCheckRun(event *github.CheckRunEvent)
+	ApplyIssues(event *github.IssuesEvent)
+	ApplyPullRequest(event *github.PullRequestEvent)
+}
+
+// Receiver validates the signature of GitHub webhook deliveries and updates
+// metrics from the events they carry
+type Receiver struct {
+	secret  []byte
+	metrics *metrics.GitHubRegistry
+	updater Updater
+}
+
+// NewReceiver creates a receiver validating deliveries with the configured secret
+func NewReceiver(cfg config.WebhookConfig, metricsRegistry *metrics.GitHubRegistry) *Receiver {
+	return &Receiver{
+		secret:  []byte(cfg.Secret),
+		metrics: metricsRegistry,
+	}
+}
+
+// WithUpdater applies deliveries to the metrics collected by polling
+func (r *Receiver) WithUpdater(updater Updater) *Receiver {
+	r.updater = updater
+
+	return r
+}
+
+// ServeHTTP handles a single webhook delivery. Deliveries with an invalid
+// signature are rejected; events that are not turned into metrics are accepted
+// and ignored so GitHub does not report them as failed.
+func (r *Receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	start := time.Now()
+	eventType := github.WebHookType(req)
+
+	req.Body = http.MaxBytesReader(w, req.Body, maxPayloadBytes)
+
+	payload, err := github.ValidatePayload(req, r.secret)
+	if err != nil {
+		slog.Warn("Rejected webhook delivery", "delivery", github.DeliveryID(req), "error", err)
+		http.Error(w, "invalid webhook delivery", http.StatusUnauthorized)
+
+		// The event header of an unsigned delivery can't be trusted as a label value
+		r.observe("unknown", "invalid_signature", start)
+
+		return
+	}
+
+	if !handledEvents[eventType] {
+		w.WriteHeader(http.StatusNoContent)
+		r.observe(eventType, "ignored", start)
+
+		return
+	}
+
+	event, err := github.ParseWebHook(eventType, payload)
+	if err != nil {
+		slog.Warn("Failed to parse webhook payload", "delivery", github.DeliveryID(req), "event", eventType, "error", err)
+		http.Error(w, "invalid webhook payload", http.StatusBadRequest)
+		r.observe(eventType, "invalid_payload", start)
+
+		return
+	}
+
+	r.handle(event)
+
+	w.WriteHeader(http.StatusNoContent)
+	r.observe(eventType, "processed", start)
+
+	if occurred := eventTime(event); !occurred.IsZero() {
+		r.metrics.GitHubWebhookEventLag.With(prometheus.Labels{"event": eventType}).Observe(max(time.Since(occurred).Seconds(), 0))
+	}
+}
+
+// observe records the result and handler duration of a delivery
+func (r *Receiver) observe(eventType, result string, start time.Time) {
+	r.metrics.GitHubWebhookDeliveries.With(prometheus.Labels{"event": eventType, "result": result}).Inc()
+	r.metrics.GitHubWebhookHandlerDuration.With(prometheus.Labels{"event": eventType}).Observe(time.Since(start).Seconds())
+}
+
+// handledEvents lists the event types turned into metrics
+var handledEvents = map[string]bool{
+	"check_run":    true,
+	"issues":       true,
+	"pull_request": true,
+	"push":         true,
+	"repository":   true,
+	"workflow_run": true,
+}
+
+// eventTime returns when the event described by a delivery happened, or the zero
+// time if the payload doesn't say. Deliveries carry no timestamp of their own.
+func eventTime(event interface{}) time.Time {
+	switch e := event.(type) {
+	case *github.RepositoryEvent:
+		return e.GetRepo().GetUpdatedAt().Time
+	case *github.PushEvent:
+		return e.GetRepo().GetPushedAt().Time
+	case *github.PullRequestEvent:
+		return e.GetPullRequest().GetUpdatedAt().Time
+	case *github.IssuesEvent:
+		return e.GetIssue().GetUpdatedAt().Time
+	case *github.WorkflowRunEvent:
+		return e.GetWorkflowRun().GetUpdatedAt().Time
+	case *github.CheckRunEvent:
+		if completed := e.GetCheckRun().GetCompletedAt(); !completed.IsZero() {
+			return completed.Time
+		}
+
+		return e.GetCheckRun().GetStartedAt().Time
+	}
+
+	return time.Time{}
+}
+
+// handle updates metrics from a parsed event
+func (r *Receiver) handle(event interface{}) {
+	switch e := event.(type) {
+	case *github.RepositoryEvent:
+		r.handleRepositoryEvent(e)
+	case *github.PushEvent:
+		r.handlePushEvent(e)
+	case *github.PullRequestEvent:
+		r.handlePullRequestEvent(e)
+
+		if r.updater != nil {
+			r.updater.ApplyPullRequest(e)
+		}
+	case *github.IssuesEvent:
+		if r.updater != nil {
+			r.updater.ApplyIssues(e)
+		}
+	case *github.WorkflowRunEvent:
+		if r.updater != nil {
+			r.updater.ApplyWorkflowRun(e)
+		}
+	case *github.CheckRunEvent:
+		if r.updater != nil {
+			r.updater.ApplyCheckRun(e)
+		}
+	}
+}
+
+// handleRepositoryEvent counts repository lifecycle events, such as repositories
+// being created, deleted, archived or made public, per organization
+func (r *Receiver) handleRepositoryEvent(event *github.RepositoryEvent) {
+	org := event.GetOrg().GetLogin()
+	if org == "" {
+		org = event.GetRepo().GetOwner().GetLogin()
+	}
+
+	r.metrics.GitHubWebhookRepositoryEvents.With(prometheus.Labels{
+		"org":    org,
+		"action": event.GetAction(),
+	}).Inc()
+}
+
+// handlePushEvent counts pushes per branch. Tag pushes are not counted.
+func (r *Receiver) handlePushEvent(event *github.PushEvent) {
+	branch, ok := strings.CutPrefix(event.GetRef(), "refs/heads/")
+	if !ok {
+		return
+	}
+
+	repo := event.GetRepo()
+
+	r.metrics.GitHubPushEvents.With(prometheus.Labels{
+		"org":    repo.GetOwner().GetLogin(),
+		"repo":   repo.GetName(),
+		"branch": branch,
+	}).Inc()
+}
+
+// handlePullRequestEvent counts pull request events per repository and action
+func (r *Receiver) handlePullRequestEvent(event *github.PullRequestEvent) {
+	repo := event.GetRepo()
+
+	r.metrics.GitHubPullRequestEvents.With(prometheus.Labels{
+		"org":    repo.GetOwner().GetLogin(),
+		"repo":   repo.GetName(),
+		"action": event.GetAction(),
+	}).Inc()
+}
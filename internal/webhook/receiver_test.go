@@ -0,0 +1,242 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/d0ugal/github-exporter/internal/config"
+	"github.com/d0ugal/github-exporter/internal/metrics"
+	promexporter_metrics "github.com/d0ugal/promexporter/metrics"
+	"github.com/google/go-github/v76/github"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+const testSecret = "s3cret"
+
+// newTestReceiver creates a receiver with a fresh metrics registry
+func newTestReceiver() (*Receiver, *metrics.GitHubRegistry) {
+	registry := metrics.NewGitHubRegistry(promexporter_metrics.NewRegistry("github_exporter_info"))
+
+	return NewReceiver(config.WebhookConfig{Secret: testSecret}, registry), registry
+}
+
+// deliver posts a webhook delivery signed with secret and returns the response code
+func deliver(t *testing.T, receiver *Receiver, event, payload, secret string) int {
+	t.Helper()
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+
+	req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-GitHub-Event", event)
+	req.Header.Set("X-GitHub-Delivery", "72d3162e-cc78-11e3-81ab-4c9367dc0958")
+	req.Header.Set("X-Hub-Signature-256", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+
+	rec := httptest.NewRecorder()
+	receiver.ServeHTTP(rec, req)
+
+	return rec.Code
+}
+
+// TestRepositoryEvents tests counting repository events per organization and action
+func TestRepositoryEvents(t *testing.T) {
+	receiver, registry := newTestReceiver()
+
+	created := `{"action": "created", "repository": {"name": "new", "owner": {"login": "d0ugal"}}, "organization": {"login": "d0ugal"}}`
+	publicized := `{"action": "publicized", "repository": {"name": "secret", "owner": {"login": "d0ugal"}}}`
+
+	for _, payload := range []string{created, created, publicized} {
+		if code := deliver(t, receiver, "repository", payload, testSecret); code != http.StatusNoContent {
+			t.Fatalf("Expected 204, got %d", code)
+		}
+	}
+
+	if got := testutil.ToFloat64(registry.GitHubWebhookRepositoryEvents.WithLabelValues("d0ugal", "created")); got != 2 {
+		t.Errorf("Expected 2 created events, got %v", got)
+	}
+
+	// Without an organization, the repository owner is used
+	if got := testutil.ToFloat64(registry.GitHubWebhookRepositoryEvents.WithLabelValues("d0ugal", "publicized")); got != 1 {
+		t.Errorf("Expected 1 publicized event, got %v", got)
+	}
+}
+
+// TestInvalidSignature tests that deliveries signed with another secret are rejected
+func TestInvalidSignature(t *testing.T) {
+	receiver, registry := newTestReceiver()
+
+	payload := `{"action": "deleted", "repository": {"name": "gone"}, "organization": {"login": "d0ugal"}}`
+
+	if code := deliver(t, receiver, "repository", payload, "wrong"); code != http.StatusUnauthorized {
+		t.Errorf("Expected 401, got %d", code)
+	}
+
+	if got := testutil.CollectAndCount(registry.GitHubWebhookRepositoryEvents); got != 0 {
+		t.Errorf("Expected no events to be counted, got %d", got)
+	}
+}
+
+// TestUnhandledEvents tests that events not turned into metrics are accepted
+func TestUnhandledEvents(t *testing.T) {
+	receiver, _ := newTestReceiver()
+
+	if code := deliver(t, receiver, "ping", `{"zen": "Keep it logically awesome."}`, testSecret); code != http.StatusNoContent {
+		t.Errorf("Expected 204 for ping, got %d", code)
+	}
+}
+
+// TestPushEvents tests counting pushes per branch and ignoring tag pushes
+func TestPushEvents(t *testing.T) {
+	receiver, registry := newTestReceiver()
+
+	branch := `{"ref": "refs/heads/main", "repository": {"name": "github-exporter", "owner": {"name": "d0ugal", "login": "d0ugal"}}}`
+	tag := `{"ref": "refs/tags/v1.0.0", "repository": {"name": "github-exporter", "owner": {"name": "d0ugal", "login": "d0ugal"}}}`
+
+	for _, payload := range []string{branch, branch, tag} {
+		if code := deliver(t, receiver, "push", payload, testSecret); code != http.StatusNoContent {
+			t.Fatalf("Expected 204, got %d", code)
+		}
+	}
+
+	if got := testutil.ToFloat64(registry.GitHubPushEvents.WithLabelValues("d0ugal", "github-exporter", "main")); got != 2 {
+		t.Errorf("Expected 2 pushes to main, got %v", got)
+	}
+
+	if got := testutil.CollectAndCount(registry.GitHubPushEvents); got != 1 {
+		t.Errorf("Expected tag pushes not to be counted, got %d series", got)
+	}
+}
+
+// TestPullRequestEvents tests counting pull request events per action
+func TestPullRequestEvents(t *testing.T) {
+	receiver, registry := newTestReceiver()
+
+	for _, action := range []string{"opened", "synchronize", "synchronize", "closed"} {
+		payload := `{"action": "` + action + `", "number": 1, "repository": {"name": "github-exporter", "owner": {"login": "d0ugal"}}}`
+
+		if code := deliver(t, receiver, "pull_request", payload, testSecret); code != http.StatusNoContent {
+			t.Fatalf("Expected 204, got %d", code)
+		}
+	}
+
+	if got := testutil.ToFloat64(registry.GitHubPullRequestEvents.WithLabelValues("d0ugal", "github-exporter", "synchronize")); got != 2 {
+		t.Errorf("Expected 2 synchronize events, got %v", got)
+	}
+
+	if got := testutil.CollectAndCount(registry.GitHubPullRequestEvents); got != 3 {
+		t.Errorf("Expected 3 actions, got %d", got)
+	}
+}
+
+// TestDeliveryMetrics tests counting deliveries by result and observing handler
+// duration and event lag
+func TestDeliveryMetrics(t *testing.T) {
+	receiver, registry := newTestReceiver()
+
+	updatedAt := time.Now().Add(-30 * time.Second).UTC().Format(time.RFC3339)
+	payload := `{"action": "opened", "pull_request": {"updated_at": "` + updatedAt + `"}, "repository": {"name": "github-exporter", "owner": {"login": "d0ugal"}}}`
+
+	deliver(t, receiver, "pull_request", payload, testSecret)
+	deliver(t, receiver, "pull_request", payload, "wrong")
+	deliver(t, receiver, "pull_request", `{"action": `, testSecret)
+	deliver(t, receiver, "star", `{"action": "created"}`, testSecret)
+
+	for _, tt := range []struct {
+		event, result string
+	}{
+		{"pull_request", "processed"},
+		{"unknown", "invalid_signature"},
+		{"pull_request", "invalid_payload"},
+		{"star", "ignored"},
+	} {
+		if got := testutil.ToFloat64(registry.GitHubWebhookDeliveries.WithLabelValues(tt.event, tt.result)); got != 1 {
+			t.Errorf("Expected 1 %s %s delivery, got %v", tt.event, tt.result, got)
+		}
+	}
+
+	expected := `
+		# HELP github_webhook_event_lag_seconds Time from the event a webhook delivery describes happening to the delivery being processed
+		# TYPE github_webhook_event_lag_seconds histogram
+		github_webhook_event_lag_seconds_bucket{event="pull_request",le="1"} 0
+		github_webhook_event_lag_seconds_bucket{event="pull_request",le="2"} 0
+		github_webhook_event_lag_seconds_bucket{event="pull_request",le="5"} 0
+		github_webhook_event_lag_seconds_bucket{event="pull_request",le="10"} 0
+		github_webhook_event_lag_seconds_bucket{event="pull_request",le="30"} 0
+		github_webhook_event_lag_seconds_bucket{event="pull_request",le="60"} 1
+		github_webhook_event_lag_seconds_bucket{event="pull_request",le="120"} 1
+		github_webhook_event_lag_seconds_bucket{event="pull_request",le="300"} 1
+		github_webhook_event_lag_seconds_bucket{event="pull_request",le="900"} 1
+		github_webhook_event_lag_seconds_bucket{event="pull_request",le="3600"} 1
+		github_webhook_event_lag_seconds_bucket{event="pull_request",le="+Inf"} 1
+		github_webhook_event_lag_seconds_count{event="pull_request"} 1
+	`
+
+	// The sum depends on timing, so only buckets and count are compared
+	if err := testutil.CollectAndCompare(registry.GitHubWebhookEventLag, strings.NewReader(expected), "github_webhook_event_lag_seconds_bucket", "github_webhook_event_lag_seconds_count"); err != nil {
+		t.Errorf("Unexpected event lag: %v", err)
+	}
+
+	if got := testutil.CollectAndCount(registry.GitHubWebhookHandlerDuration); got != 3 {
+		t.Errorf("Expected handler durations for 3 event types, got %d", got)
+	}
+}
+
+// recordingUpdater records the events applied to it
+type recordingUpdater struct {
+	applied []string
+}
+
+func (u *recordingUpdater) ApplyWorkflowRun(event *github.WorkflowRunEvent) {
+	u.applied = append(u.applied, "workflow_run:"+event.GetWorkflowRun().GetConclusion())
+}
+
+func (u *recordingUpdater) ApplyCheckRun(event *github.CheckRunEvent) {
+	u.applied = append(u.applied, "check_run:"+event.GetCheckRun().GetName())
+}
+
+func (u *recordingUpdater) ApplyIssues(event *github.IssuesEvent) {
+	u.applied = append(u.applied, "issues:"+event.GetAction())
+}
+
+func (u *recordingUpdater) ApplyPullRequest(event *github.PullRequestEvent) {
+	u.applied = append(u.applied, "pull_request:"+event.GetAction())
+}
+
+// TestUpdater tests that deliveries are applied to the polled metrics
+func TestUpdater(t *testing.T) {
+	receiver, registry := newTestReceiver()
+	updater := &recordingUpdater{}
+	receiver.WithUpdater(updater)
+
+	repo := `"repository": {"name": "github-exporter", "owner": {"login": "d0ugal"}}`
+
+	for _, delivery := range []struct {
+		event, payload string
+	}{
+		{"workflow_run", `{"action": "completed", "workflow_run": {"conclusion": "success"}, ` + repo + `}`},
+		{"check_run", `{"action": "completed", "check_run": {"name": "lint"}, ` + repo + `}`},
+		{"issues", `{"action": "opened", ` + repo + `}`},
+		{"pull_request", `{"action": "closed", ` + repo + `}`},
+		{"push", `{"ref": "refs/heads/main", ` + repo + `}`},
+	} {
+		if code := deliver(t, receiver, delivery.event, delivery.payload, testSecret); code != http.StatusNoContent {
+			t.Fatalf("Expected 204 for %s, got %d", delivery.event, code)
+		}
+	}
+
+	expected := []string{"workflow_run:success", "check_run:lint", "issues:opened", "pull_request:closed"}
+	if strings.Join(updater.applied, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected %v to be applied, got %v", expected, updater.applied)
+	}
+
+	if got := testutil.ToFloat64(registry.GitHubPullRequestEvents.WithLabelValues("d0ugal", "github-exporter", "closed")); got != 1 {
+		t.Errorf("Expected the pull request event to still be counted, got %v", got)
+	}
+}